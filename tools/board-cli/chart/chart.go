@@ -0,0 +1,90 @@
+// Package chart renders burndown and burnup charts shared by the CLI
+// (`chart burndown`, `chart burnup`) and the TUI (`/burndown`). Series are
+// built by the CLI; this package only draws them, as SVG or as Unicode
+// text, without Graphviz or other external tools.
+package chart
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Kind selects what a chart shows.
+type Kind string
+
+const (
+	// Burndown plots the work remaining, with completed work alongside.
+	Burndown Kind = "burndown"
+	// Burnup plots completed work against the total scope.
+	Burnup Kind = "burnup"
+)
+
+// ParseKind parses "burndown" or "burnup".
+func ParseKind(s string) (Kind, error) {
+	switch strings.ToLower(s) {
+	case "burndown", "down":
+		return Burndown, nil
+	case "burnup", "up":
+		return Burnup, nil
+	}
+	return "", fmt.Errorf("unknown chart %q (valid: burndown, burnup)", s)
+}
+
+// Point is the state of the scope at the end of one day.
+type Point struct {
+	Date      string `json:"date"` // 2006-01-02
+	Total     int    `json:"total"`
+	Done      int    `json:"done"`
+	Remaining int    `json:"remaining"`
+}
+
+// Series is one chart's data.
+type Series struct {
+	Kind   Kind    `json:"kind"`
+	Scope  string  `json:"scope"` // element ID, or "" for the whole board
+	Title  string  `json:"title"`
+	Unit   string  `json:"unit"` // "elements" or "checklist items"
+	Points []Point `json:"points"`
+}
+
+// Last returns the latest point, or a zero point for an empty series.
+func (s *Series) Last() Point {
+	if len(s.Points) == 0 {
+		return Point{}
+	}
+	return s.Points[len(s.Points)-1]
+}
+
+// max returns the largest value the chart has to fit.
+func (s *Series) max() int {
+	m := 0
+	for _, p := range s.Points {
+		if p.Total > m {
+			m = p.Total
+		}
+	}
+	return m
+}
+
+// primary and secondary return the plotted values: remaining and done for
+// a burndown, done and total for a burnup.
+func (s *Series) primary(p Point) int {
+	if s.Kind == Burnup {
+		return p.Done
+	}
+	return p.Remaining
+}
+
+func (s *Series) secondary(p Point) int {
+	if s.Kind == Burnup {
+		return p.Total
+	}
+	return p.Done
+}
+
+func (s *Series) labels() (string, string) {
+	if s.Kind == Burnup {
+		return "done", "scope"
+	}
+	return "remaining", "done"
+}
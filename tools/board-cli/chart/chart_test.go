@@ -0,0 +1,89 @@
+package chart
+
+import (
+	"strings"
+	"testing"
+)
+
+func sampleSeries(kind Kind) *Series {
+	return &Series{Kind: kind, Title: "Burndown — EPIC-1", Unit: "elements", Points: []Point{
+		{Date: "2026-03-01", Total: 4, Done: 0, Remaining: 4},
+		{Date: "2026-03-02", Total: 4, Done: 1, Remaining: 3},
+		{Date: "2026-03-03", Total: 5, Done: 3, Remaining: 2},
+		{Date: "2026-03-04", Total: 5, Done: 5, Remaining: 0},
+	}}
+}
+
+func TestSparkline(t *testing.T) {
+	if got := Sparkline([]int{0, 1, 4, 8}, 8); got != " ▁▄█" {
+		t.Errorf("Sparkline = %q", got)
+	}
+	if got := Sparkline([]int{3}, 0); got != " " {
+		t.Errorf("Sparkline with no max = %q", got)
+	}
+}
+
+func TestText(t *testing.T) {
+	out := Text(sampleSeries(Burndown), 60, 5)
+	lines := strings.Split(strings.TrimRight(out, "\n"), "\n")
+	if lines[0] != "Burndown — EPIC-1 — 0 remaining, 5 done of 5 elements" {
+		t.Errorf("header = %q", lines[0])
+	}
+	if lines[1] != "5 ┤    " {
+		t.Errorf("top row = %q, want nothing reaching 5", lines[1])
+	}
+	if lines[5] != "  ┤███ " {
+		t.Errorf("bottom row = %q", lines[5])
+	}
+	if !strings.Contains(out, "remaining ") || !strings.Contains(out, "4 → 0") {
+		t.Errorf("missing remaining sparkline:\n%s", out)
+	}
+
+	up := Text(sampleSeries(Burnup), 60, 5)
+	if !strings.Contains(up, "scope") || !strings.Contains(up, "4 → 5") {
+		t.Errorf("burnup should show the scope:\n%s", up)
+	}
+}
+
+func TestTextSamples(t *testing.T) {
+	s := &Series{Kind: Burndown, Title: "t", Unit: "elements"}
+	for i := 0; i < 100; i++ {
+		s.Points = append(s.Points, Point{Date: "d", Total: 100, Remaining: 100 - i})
+	}
+	for _, line := range strings.Split(Text(s, 20, 3), "\n")[1:4] {
+		if n := len([]rune(line)); n != len("100 ┤")-2+20 {
+			t.Errorf("row %q is %d runes wide, want 20 columns", line, n)
+		}
+	}
+}
+
+func TestSVG(t *testing.T) {
+	svg := SVG(sampleSeries(Burndown))
+	if !strings.HasPrefix(svg, "<svg ") || !strings.HasSuffix(svg, "</svg>\n") {
+		t.Fatalf("not an SVG document:\n%s", svg)
+	}
+	if strings.Count(svg, "<polyline") != 2 || !strings.Contains(svg, "stroke-dasharray") {
+		t.Errorf("burndown should have two series and an ideal line:\n%s", svg)
+	}
+	if !strings.Contains(svg, "remaining (0)") || !strings.Contains(svg, "2026-03-04") {
+		t.Errorf("missing legend or dates:\n%s", svg)
+	}
+	if strings.Contains(SVG(sampleSeries(Burnup)), "stroke-dasharray") {
+		t.Error("burnup has no ideal line")
+	}
+}
+
+func TestGridValues(t *testing.T) {
+	tests := []struct {
+		max  int
+		want int // step
+	}{
+		{1, 1}, {5, 1}, {6, 2}, {12, 5}, {30, 10}, {100, 20}, {260, 50}, {600, 200},
+	}
+	for _, tt := range tests {
+		values := gridValues(tt.max)
+		if len(values) < 2 || values[1] != tt.want || len(values) > 6 {
+			t.Errorf("gridValues(%d) = %v, want step %d", tt.max, values, tt.want)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package chart
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// SVG geometry
+const (
+	svgWidth   = 720
+	svgHeight  = 360
+	svgLeft    = 48
+	svgRight   = 16
+	svgTop     = 40
+	svgBottom  = 48
+	svgPrimary = "#e67e22"
+	svgDone    = "#27ae60"
+	svgScope   = "#7f8c8d"
+)
+
+// SVG draws the series as a standalone SVG line chart: remaining and done
+// for a burndown, done and total scope for a burnup, with a dashed ideal
+// line from the first point's remaining work down to zero.
+func SVG(s *Series) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="12">`+"\n",
+		svgWidth, svgHeight, svgWidth, svgHeight)
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="#ffffff"/>`+"\n", svgWidth, svgHeight)
+	fmt.Fprintf(&b, `<text x="%d" y="24" font-size="16" font-weight="bold">%s</text>`+"\n", svgLeft, html.EscapeString(s.Title))
+
+	primary, secondary := svgPrimary, svgDone
+	if s.Kind == Burnup {
+		primary, secondary = svgDone, svgScope
+	}
+
+	plotW := float64(svgWidth - svgLeft - svgRight)
+	plotH := float64(svgHeight - svgTop - svgBottom)
+	max := s.max()
+	if max == 0 {
+		max = 1
+	}
+	x := func(i int) float64 {
+		if len(s.Points) < 2 {
+			return float64(svgLeft) + plotW/2
+		}
+		return float64(svgLeft) + plotW*float64(i)/float64(len(s.Points)-1)
+	}
+	y := func(v int) float64 {
+		return float64(svgTop) + plotH - plotH*float64(v)/float64(max)
+	}
+
+	// Axes and horizontal grid lines with value labels
+	for _, v := range gridValues(max) {
+		fmt.Fprintf(&b, `<line x1="%d" y1="%.1f" x2="%d" y2="%.1f" stroke="#eeeeee"/>`+"\n", svgLeft, y(v), svgWidth-svgRight, y(v))
+		fmt.Fprintf(&b, `<text x="%d" y="%.1f" text-anchor="end" fill="#555555">%d</text>`+"\n", svgLeft-6, y(v)+4, v)
+	}
+	fmt.Fprintf(&b, `<line x1="%d" y1="%d" x2="%d" y2="%.1f" stroke="#333333"/>`+"\n", svgLeft, svgTop, svgLeft, y(0))
+	fmt.Fprintf(&b, `<line x1="%d" y1="%.1f" x2="%d" y2="%.1f" stroke="#333333"/>`+"\n", svgLeft, y(0), svgWidth-svgRight, y(0))
+
+	if len(s.Points) > 0 {
+		first, last := s.Points[0], s.Points[len(s.Points)-1]
+		fmt.Fprintf(&b, `<text x="%.1f" y="%d" text-anchor="start" fill="#555555">%s</text>`+"\n", x(0), svgHeight-svgBottom+18, first.Date)
+		if len(s.Points) > 1 {
+			fmt.Fprintf(&b, `<text x="%.1f" y="%d" text-anchor="end" fill="#555555">%s</text>`+"\n", x(len(s.Points)-1), svgHeight-svgBottom+18, last.Date)
+		}
+
+		if s.Kind == Burndown {
+			fmt.Fprintf(&b, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="#bbbbbb" stroke-dasharray="6 4"/>`+"\n",
+				x(0), y(first.Remaining), x(len(s.Points)-1), y(0))
+		}
+		writePolyline(&b, s, x, y, s.secondary, secondary)
+		writePolyline(&b, s, x, y, s.primary, primary)
+	}
+
+	// Legend
+	first, second := s.labels()
+	last := s.Last()
+	legendY := svgHeight - 14
+	fmt.Fprintf(&b, `<rect x="%d" y="%d" width="12" height="4" fill="%s"/>`+"\n", svgLeft, legendY-4, primary)
+	fmt.Fprintf(&b, `<text x="%d" y="%d">%s (%d)</text>`+"\n", svgLeft+18, legendY, first, s.primary(last))
+	fmt.Fprintf(&b, `<rect x="%d" y="%d" width="12" height="4" fill="%s"/>`+"\n", svgLeft+160, legendY-4, secondary)
+	fmt.Fprintf(&b, `<text x="%d" y="%d">%s (%d)</text>`+"\n", svgLeft+178, legendY, second, s.secondary(last))
+	fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="end" fill="#555555">%s</text>`+"\n", svgWidth-svgRight, legendY, html.EscapeString(s.Unit))
+
+	b.WriteString("</svg>\n")
+	return b.String()
+}
+
+func writePolyline(b *strings.Builder, s *Series, x func(int) float64, y func(int) float64, value func(Point) int, color string) {
+	coords := make([]string, len(s.Points))
+	for i, p := range s.Points {
+		coords[i] = fmt.Sprintf("%.1f,%.1f", x(i), y(value(p)))
+	}
+	if len(coords) == 1 {
+		fmt.Fprintf(b, `<circle cx="%.1f" cy="%.1f" r="3" fill="%s"/>`+"\n", x(0), y(value(s.Points[0])), color)
+		return
+	}
+	fmt.Fprintf(b, `<polyline points="%s" fill="none" stroke="%s" stroke-width="2"/>`+"\n", strings.Join(coords, " "), color)
+}
+
+// gridValues returns evenly spaced values from 0 to max, at most six, on
+// steps of 1, 2 or 5 times a power of ten.
+func gridValues(max int) []int {
+	step := 1
+steps:
+	for base := 1; ; base *= 10 {
+		for _, f := range []int{1, 2, 5} {
+			step = base * f
+			if max/step <= 5 {
+				break steps
+			}
+		}
+	}
+	var values []int
+	for v := 0; v <= max; v += step {
+		values = append(values, v)
+	}
+	return values
+}
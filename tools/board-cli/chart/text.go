@@ -0,0 +1,113 @@
+package chart
+
+import (
+	"fmt"
+	"strings"
+)
+
+var sparks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline draws values as one line of block characters scaled to max.
+func Sparkline(values []int, max int) string {
+	var b strings.Builder
+	for _, v := range values {
+		if max <= 0 || v <= 0 {
+			b.WriteRune(' ')
+			continue
+		}
+		i := (v*len(sparks) - 1) / max
+		if i >= len(sparks) {
+			i = len(sparks) - 1
+		}
+		b.WriteRune(sparks[i])
+	}
+	return b.String()
+}
+
+// sample picks at most width points, keeping the first and the last.
+func sample(points []Point, width int) []Point {
+	if width <= 0 || len(points) <= width {
+		return points
+	}
+	result := make([]Point, width)
+	for i := range result {
+		result[i] = points[i*(len(points)-1)/(width-1)]
+	}
+	return result
+}
+
+// Text draws the series as a bar chart of the primary value, height rows
+// tall and at most width columns wide (one column per day, sampled when
+// the series is longer), followed by sparklines of both values. Colours
+// are left to the caller; the output is plain text.
+func Text(s *Series, width, height int) string {
+	var b strings.Builder
+	first, second := s.labels()
+	last := s.Last()
+	fmt.Fprintf(&b, "%s — %d remaining, %d done of %d %s\n", s.Title, last.Remaining, last.Done, last.Total, s.Unit)
+	if len(s.Points) == 0 {
+		b.WriteString("no data\n")
+		return b.String()
+	}
+
+	points := sample(s.Points, width)
+	max := s.max()
+	if height < 1 {
+		height = 1
+	}
+	label := len(fmt.Sprint(max))
+
+	// Each row covers 1/height of max; partial cells use eighth blocks
+	for row := height; row >= 1; row-- {
+		axis := strings.Repeat(" ", label)
+		if row == height {
+			axis = fmt.Sprintf("%*d", label, max)
+		}
+		b.WriteString(axis + " ┤")
+		for _, p := range points {
+			v := s.primary(p)
+			// Eighths of a row this column fills above the rows below
+			eighths := 0
+			if max > 0 {
+				eighths = v*height*8/max - (row-1)*8
+			}
+			switch {
+			case eighths >= 8:
+				b.WriteRune('█')
+			case eighths > 0:
+				b.WriteRune(sparks[eighths-1])
+			default:
+				b.WriteRune(' ')
+			}
+		}
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(&b, "%*d ┼%s\n", label, 0, strings.Repeat("─", len(points)))
+	dates := points[0].Date
+	if len(points) > 1 {
+		end := points[len(points)-1].Date
+		gap := len(points) - len(dates) - len(end)
+		if gap < 1 {
+			gap = 1
+		}
+		dates += strings.Repeat(" ", gap) + end
+	}
+	fmt.Fprintf(&b, "%s  %s\n", strings.Repeat(" ", label), dates)
+
+	values := func(f func(Point) int) []int {
+		v := make([]int, len(points))
+		for i, p := range points {
+			v[i] = f(p)
+		}
+		return v
+	}
+	name := len(first)
+	if len(second) > name {
+		name = len(second)
+	}
+	fmt.Fprintf(&b, "%-*s %s  %d → %d\n", name, first, Sparkline(values(s.primary), max),
+		s.primary(points[0]), s.primary(points[len(points)-1]))
+	fmt.Fprintf(&b, "%-*s %s  %d → %d\n", name, second, Sparkline(values(s.secondary), max),
+		s.secondary(points[0]), s.secondary(points[len(points)-1]))
+	return b.String()
+}
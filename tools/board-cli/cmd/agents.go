@@ -3,10 +3,12 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/aagrigore/task-board/internal/board"
 	"github.com/aagrigore/task-board/internal/output"
+	"github.com/aagrigore/task-board/internal/wip"
 	"github.com/spf13/cobra"
 )
 
@@ -23,6 +25,9 @@ type AgentInfo struct {
 	AssignedElements []AgentAssignedElement `json:"assignedElements"`
 	TotalAssigned    int                    `json:"totalAssigned"`
 	StaleCount       int                    `json:"staleCount"`
+	// Load counts the agent's unfinished elements; Limit is its WIP limit (0: none)
+	Load  int `json:"load"`
+	Limit int `json:"limit"`
 }
 
 // AgentAssignedElement represents an element assigned to an agent
@@ -90,7 +95,7 @@ func childProgress(b *board.Board, e *board.Element) string {
 		if c.Status == board.StatusToReview {
 			review++
 		}
-		if c.Status == board.StatusDone || c.Status == board.StatusClosed {
+		if b.Workflow.Finished(c) {
 			done++
 		}
 	}
@@ -108,7 +113,7 @@ func childProgress(b *board.Board, e *board.Element) string {
 }
 
 func runAgents(cmd *cobra.Command, args []string) error {
-	b, err := board.Load(boardDir)
+	b, err := loadViewBoard()
 	if err != nil {
 		if JSONEnabled() {
 			output.PrintError(os.Stderr, output.InternalError, fmt.Sprintf("loading board: %v", err), nil)
@@ -127,7 +132,7 @@ func runAgents(cmd *cobra.Command, args []string) error {
 		}
 		if !agentsAll {
 			// Filter: show if not done/closed, OR if updated within freshness window
-			isDone := e.Status == board.StatusDone || e.Status == board.StatusClosed
+			isDone := b.Workflow.Finished(e)
 			isFresh := !e.LastUpdate.IsZero() && now.Sub(e.LastUpdate) <= freshness
 			if isDone && !isFresh {
 				continue
@@ -138,7 +143,7 @@ func runAgents(cmd *cobra.Command, args []string) error {
 
 	// JSON output
 	if JSONEnabled() {
-		return printAgentsJSON(assigned, now, freshness)
+		return printAgentsJSON(b, assigned, now, freshness)
 	}
 
 	if len(assigned) == 0 {
@@ -166,7 +171,7 @@ func runAgents(cmd *cobra.Command, args []string) error {
 	active := 0
 	done := 0
 	for _, e := range assigned {
-		if e.Status == board.StatusDone || e.Status == board.StatusClosed {
+		if b.Workflow.Finished(e) {
 			done++
 		} else {
 			active++
@@ -174,10 +179,29 @@ func runAgents(cmd *cobra.Command, args []string) error {
 	}
 	fmt.Printf("\nTotal: %d agents, %d active, %d done\n", len(assigned), active, done)
 
+	// Load against WIP limits, for agents that have one
+	var loads []string
+	seen := map[string]bool{}
+	for _, e := range assigned {
+		limit := b.Workflow.Limits().AssigneeLimit(e.AssignedTo)
+		if seen[e.AssignedTo] || limit == 0 {
+			continue
+		}
+		seen[e.AssignedTo] = true
+		load := fmt.Sprintf("@%s %d/%d", e.AssignedTo, wip.AssigneeLoad(b, e.AssignedTo), limit)
+		if wip.AssigneeLoad(b, e.AssignedTo) > limit {
+			load = output.Red + load + output.Reset
+		}
+		loads = append(loads, load)
+	}
+	if len(loads) > 0 {
+		fmt.Printf("WIP: %s\n", strings.Join(loads, ", "))
+	}
+
 	return nil
 }
 
-func printAgentsJSON(assigned []*board.Element, now time.Time, freshness time.Duration) error {
+func printAgentsJSON(b *board.Board, assigned []*board.Element, now time.Time, freshness time.Duration) error {
 	// Group elements by agent name
 	agentMap := make(map[string][]*board.Element)
 	for _, e := range assigned {
@@ -199,7 +223,7 @@ func printAgentsJSON(assigned []*board.Element, now time.Time, freshness time.Du
 
 			// Determine staleSince - element is stale if not done/closed and updated longer than freshness ago
 			var staleSince *string
-			isDone := e.Status == board.StatusDone || e.Status == board.StatusClosed
+			isDone := b.Workflow.Finished(e)
 			if !isDone && !e.LastUpdate.IsZero() && now.Sub(e.LastUpdate) > freshness {
 				staleTime := e.LastUpdate.Add(freshness).Format("2006-01-02T15:04:05Z")
 				staleSince = &staleTime
@@ -221,6 +245,8 @@ func printAgentsJSON(assigned []*board.Element, now time.Time, freshness time.Du
 			AssignedElements: assignedElements,
 			TotalAssigned:    len(elements),
 			StaleCount:       staleCount,
+			Load:             wip.AssigneeLoad(b, agentName),
+			Limit:            b.Workflow.Limits().AssigneeLimit(agentName),
 		})
 	}
 
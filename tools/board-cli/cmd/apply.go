@@ -0,0 +1,604 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/aagrigore/task-board/internal/board"
+	"github.com/aagrigore/task-board/internal/output"
+	"github.com/aagrigore/task-board/internal/plan"
+	"github.com/aagrigore/task-board/internal/txn"
+	"github.com/aagrigore/task-board/internal/wip"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// Operation is one entry of an apply file. Fields used depend on Op.
+type Operation struct {
+	Op string `yaml:"op"`
+	// create
+	As          string  `yaml:"as"`
+	Type        string  `yaml:"type"`
+	Parent      string  `yaml:"parent"`
+	Name        string  `yaml:"name"`
+	Description *string `yaml:"description"`
+	// update (description above is shared)
+	ID    string  `yaml:"id"`
+	Title *string `yaml:"title"`
+	Scope *string `yaml:"scope"`
+	AC    *string `yaml:"ac"`
+	// link, unlink
+	BlockedBy string `yaml:"blocked-by"`
+	// add-item, notes
+	Text string `yaml:"text"`
+	Set  bool   `yaml:"set"`
+	// assign
+	Agent string `yaml:"agent"`
+}
+
+// ApplyResponse is the JSON response for apply
+type ApplyResponse struct {
+	Applied int               `json:"applied"`
+	Symbols map[string]string `json:"symbols"`
+	Results []ApplyResult     `json:"results"`
+	DryRun  bool              `json:"dryRun,omitempty"`
+	Message string            `json:"message"`
+}
+
+// ApplyResult describes one executed operation
+type ApplyResult struct {
+	Index   int    `json:"index"`
+	Op      string `json:"op"`
+	ID      string `json:"id"`
+	Message string `json:"message"`
+}
+
+// ApplyIssue is a validation problem with one operation
+type ApplyIssue struct {
+	Index   int    `json:"index"`
+	Op      string `json:"op"`
+	Message string `json:"message"`
+}
+
+var applyCmd = &cobra.Command{
+	Use:   "apply <file|->",
+	Short: "Apply a batch of operations atomically",
+	Long: `Apply a YAML or JSON list of operations as one transaction.
+
+The board lock is taken first and every operation is validated before
+anything is written, including links that would close a dependency cycle;
+--dry-run stops there. The batch is rolled back entirely if any operation
+fails. apply works on one board and refuses --workspace.
+
+Operations:
+  create    type, name, parent (not for epics), description, as
+  update    id, title, description, scope, ac
+  link      id, blocked-by
+  unlink    id, blocked-by
+  add-item  id, text
+  notes     id, text, set
+  assign    id, agent (refused past a WIP limit, like assign)
+
+'as: story1' on a create names the new element; later operations refer
+to it as '$story1' wherever an ID is expected.`,
+	Example: `  task-board apply plan.yaml --json
+  cat ops.json | task-board apply -
+
+  # plan.yaml
+  - op: create
+    type: story
+    parent: EPIC-260101-aaaaaa
+    name: Audio capture
+    as: capture
+  - op: create
+    type: task
+    parent: $capture
+    name: Recorder interface
+    as: iface
+  - op: create
+    type: task
+    parent: $capture
+    name: Recorder implementation
+    as: impl
+  - op: link
+    id: $impl
+    blocked-by: $iface
+  - op: add-item
+    id: $impl
+    text: Write tests`,
+	Args: cobra.ExactArgs(1),
+	RunE: recorded(runApply),
+}
+
+var applyDryRun bool
+
+func init() {
+	rootCmd.AddCommand(applyCmd)
+	applyCmd.Flags().BoolVar(&applyDryRun, "dry-run", false, "Validate the batch, including link cycles, without writing")
+}
+
+// applyOps lists the supported operations.
+var applyOps = map[string]bool{
+	"create": true, "update": true, "link": true, "unlink": true,
+	"add-item": true, "notes": true, "assign": true,
+}
+
+var symbolPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+func runApply(cmd *cobra.Command, args []string) error {
+	if WorkspaceEnabled() {
+		err := fmt.Errorf("apply works on one board; run it with --board-dir instead of --workspace")
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.ValidationError, err.Error(), nil)
+			return nil
+		}
+		return err
+	}
+
+	var data []byte
+	var err error
+	if args[0] == "-" {
+		data, err = io.ReadAll(cmd.InOrStdin())
+	} else {
+		data, err = os.ReadFile(args[0])
+	}
+	if err != nil {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.InternalError, fmt.Sprintf("reading operations: %v", err), nil)
+			return nil
+		}
+		return fmt.Errorf("reading operations: %w", err)
+	}
+
+	ops, err := parseOperations(data)
+	if err != nil {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.ValidationError, err.Error(), nil)
+			return nil
+		}
+		return err
+	}
+
+	// recorded holds the board lock from here on, so no other command can
+	// change the board between loading, validating and writing it
+	b, err := board.Load(boardDir)
+	if err != nil {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.InternalError, fmt.Sprintf("loading board: %v", err), nil)
+			return nil
+		}
+		return fmt.Errorf("loading board: %w", err)
+	}
+
+	if issues := validateOperations(b, ops); len(issues) > 0 {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.ValidationError,
+				fmt.Sprintf("%d invalid operation(s); nothing applied", len(issues)),
+				map[string]interface{}{
+					"issues": issues,
+				})
+			return nil
+		}
+		var lines []string
+		for _, issue := range issues {
+			lines = append(lines, fmt.Sprintf("#%d %s: %s", issue.Index, issue.Op, issue.Message))
+		}
+		return fmt.Errorf("%d invalid operation(s); nothing applied:\n  %s", len(issues), strings.Join(lines, "\n  "))
+	}
+	if applyDryRun {
+		response := ApplyResponse{
+			Symbols: map[string]string{},
+			Results: []ApplyResult{},
+			DryRun:  true,
+			Message: fmt.Sprintf("%d operation(s) valid; nothing applied", len(ops)),
+		}
+		if JSONEnabled() {
+			return output.PrintJSON(os.Stdout, response)
+		}
+		fmt.Println(response.Message)
+		return nil
+	}
+
+	tx, err := txn.Journal(boardDir)
+	if err != nil {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.InternalError, err.Error(), nil)
+			return nil
+		}
+		return err
+	}
+
+	response := ApplyResponse{Symbols: make(map[string]string), Results: []ApplyResult{}}
+	for i, op := range ops {
+		result, err := executeOperation(b, op, response.Symbols)
+		if err != nil {
+			rollbackErr := tx.Rollback()
+			return applyFailed(i+1, op, err, rollbackErr)
+		}
+		result.Index = i + 1
+		response.Results = append(response.Results, result)
+	}
+	tx.Commit()
+
+	response.Applied = len(response.Results)
+	response.Message = fmt.Sprintf("Applied %d operation(s)", response.Applied)
+
+	if JSONEnabled() {
+		return output.PrintJSON(os.Stdout, response)
+	}
+	fmt.Println(response.Message)
+	names := make([]string, 0, len(response.Symbols))
+	for name := range response.Symbols {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Printf("  $%s = %s\n", name, response.Symbols[name])
+	}
+	return nil
+}
+
+// scratchBoard copies the elements of b with blocked-by lists of their own,
+// for validation to try links on.
+func scratchBoard(b *board.Board) *board.Board {
+	scratch := &board.Board{Dir: b.Dir, Workflow: b.Workflow}
+	for _, e := range b.Elements {
+		c := *e
+		c.BlockedBy = append([]string(nil), e.BlockedBy...)
+		scratch.Elements = append(scratch.Elements, &c)
+	}
+	return scratch
+}
+
+// unlinkScratch removes a link from a scratch board the way unlink does,
+// dropping the escalated link between the parents once no child needs it.
+func unlinkScratch(scratch *board.Board, elem, blocker *board.Element) {
+	elem.BlockedBy = removeRef(elem.BlockedBy, blocker.ID())
+	elemParent, blockerParent := scratch.ParentOf(elem), scratch.ParentOf(blocker)
+	if elemParent == nil || blockerParent == nil || elemParent.ID() == blockerParent.ID() {
+		return
+	}
+	if !scratch.HasCrossChildDependency(elemParent, blockerParent) {
+		elemParent.BlockedBy = removeRef(elemParent.BlockedBy, blockerParent.ID())
+	}
+}
+
+// applyFailed reports an operation that failed mid-batch.
+func applyFailed(index int, op Operation, err, rollbackErr error) error {
+	msg := fmt.Sprintf("operation #%d (%s) failed: %v; all changes rolled back", index, op.Op, err)
+	if rollbackErr != nil {
+		msg = fmt.Sprintf("operation #%d (%s) failed: %v; rollback failed: %v", index, op.Op, err, rollbackErr)
+	}
+	if JSONEnabled() {
+		code := output.InternalError
+		details := map[string]interface{}{
+			"index":      index,
+			"op":         op.Op,
+			"rolledBack": rollbackErr == nil,
+		}
+		var cycleErr *errLinkCycle
+		if errors.As(err, &cycleErr) {
+			code = output.CycleDetected
+			details["cycle"] = cycleErr.cycle
+		}
+		var wipErr *errWIPLimit
+		if errors.As(err, &wipErr) {
+			code = output.WIPLimit
+			details["limits"] = wipErr.violations
+		}
+		output.PrintError(os.Stderr, code, msg, details)
+		return nil
+	}
+	return fmt.Errorf("%s", msg)
+}
+
+// parseOperations decodes a YAML or JSON list of operations, rejecting unknown keys.
+func parseOperations(data []byte) ([]Operation, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	var ops []Operation
+	if err := dec.Decode(&ops); err != nil {
+		if err == io.EOF {
+			return nil, fmt.Errorf("no operations given")
+		}
+		return nil, fmt.Errorf("parsing operations: %w", err)
+	}
+	if len(ops) == 0 {
+		return nil, fmt.Errorf("no operations given")
+	}
+	return ops, nil
+}
+
+// validateOperations checks every operation against the board and the
+// symbols defined by earlier creates, without writing anything. Links and
+// unlinks are tried on a scratch copy of the dependency graph, so a batch
+// that would close a cycle is refused before it starts.
+func validateOperations(b *board.Board, ops []Operation) []ApplyIssue {
+	var issues []ApplyIssue
+	symbols := make(map[string]board.ElementType)
+	scratch := scratchBoard(b)
+
+	for i, op := range ops {
+		fail := func(format string, args ...interface{}) {
+			issues = append(issues, ApplyIssue{Index: i + 1, Op: op.Op, Message: fmt.Sprintf(format, args...)})
+		}
+		// resolve returns the type of a referenced element, or "" after reporting why not
+		resolve := func(field, ref string) board.ElementType {
+			if ref == "" {
+				fail("%s is required", field)
+				return ""
+			}
+			if strings.HasPrefix(ref, "$") {
+				t, ok := symbols[ref[1:]]
+				if !ok {
+					fail("%s: %s is not defined by an earlier create", field, ref)
+				}
+				return t
+			}
+			e := b.FindByID(ref)
+			if e == nil {
+				fail("%s: element %s not found", field, ref)
+				return ""
+			}
+			return e.Type
+		}
+
+		if !applyOps[op.Op] {
+			fail("unknown op %q", op.Op)
+			continue
+		}
+		if op.As != "" && op.Op != "create" {
+			fail("'as' is only allowed on create")
+		}
+
+		switch op.Op {
+		case "create":
+			elemType, err := board.ParseElementType(op.Type)
+			if err != nil {
+				fail("%v", err)
+				continue
+			}
+			if strings.TrimSpace(op.Name) == "" {
+				fail("name is required")
+			}
+			if elemType == board.EpicType {
+				if op.Parent != "" {
+					fail("epics have no parent")
+				}
+			} else if parentType := resolve("parent", op.Parent); parentType != "" {
+				want := board.StoryType
+				if elemType == board.StoryType {
+					want = board.EpicType
+				}
+				if parentType != want {
+					fail("a %s's parent must be a %s, not a %s", elemType, want, parentType)
+				}
+			}
+			if op.As != "" {
+				if !symbolPattern.MatchString(op.As) {
+					fail("invalid symbol %q: use letters, digits, '-' or '_'", op.As)
+				} else if _, dup := symbols[op.As]; dup {
+					fail("symbol %q is already defined", op.As)
+				}
+				symbols[op.As] = elemType
+				// Later links may name it, and escalate through its parent
+				scratch.Elements = append(scratch.Elements, &board.Element{Type: elemType, RawID: "$" + op.As, ParentID: op.Parent})
+			}
+		case "update":
+			resolve("id", op.ID)
+			if op.Title == nil && op.Description == nil && op.Scope == nil && op.AC == nil {
+				fail("nothing to update: give title, description, scope or ac")
+			}
+		case "link", "unlink":
+			idType := resolve("id", op.ID)
+			blockerType := resolve("blocked-by", op.BlockedBy)
+			if op.ID != "" && strings.EqualFold(op.ID, op.BlockedBy) {
+				fail("an element cannot block itself")
+				continue
+			}
+			e, blocker := scratch.FindByID(op.ID), scratch.FindByID(op.BlockedBy)
+			if idType == "" || blockerType == "" || e == nil || blocker == nil {
+				continue
+			}
+			if op.Op == "unlink" {
+				unlinkScratch(scratch, e, blocker)
+				continue
+			}
+			edges := plan.LinkEdges(scratch, e, blocker)
+			if cycle := plan.FindLinkCycle(scratch, edges); cycle != nil {
+				fail("linking %s to %s would create a dependency cycle: %s", e.ID(), blocker.ID(), strings.Join(cycle, " -> "))
+				continue
+			}
+			for _, edge := range edges {
+				if from := scratch.FindByID(edge.From); !edge.Exists && from != nil {
+					from.BlockedBy = append(from.BlockedBy, edge.To)
+				}
+			}
+		case "add-item", "notes":
+			resolve("id", op.ID)
+			if strings.TrimSpace(op.Text) == "" {
+				fail("text is required")
+			}
+		case "assign":
+			resolve("id", op.ID)
+			if strings.TrimSpace(op.Agent) == "" {
+				fail("agent is required")
+			}
+		}
+	}
+	return issues
+}
+
+// executeOperation performs one validated operation, recording new symbols.
+func executeOperation(b *board.Board, op Operation, symbols map[string]string) (ApplyResult, error) {
+	result := ApplyResult{Op: op.Op}
+	find := func(ref string) (*board.Element, error) {
+		id := ref
+		if strings.HasPrefix(ref, "$") {
+			id = symbols[ref[1:]]
+		}
+		e := b.FindByID(id)
+		if e == nil {
+			return nil, fmt.Errorf("element %s not found", ref)
+		}
+		return e, nil
+	}
+
+	switch op.Op {
+	case "create":
+		elemType, _ := board.ParseElementType(op.Type)
+		parentDir, parentID := b.Dir, ""
+		if op.Parent != "" {
+			parent, err := find(op.Parent)
+			if err != nil {
+				return result, err
+			}
+			parentDir, parentID = parent.Path, parent.ID()
+		}
+		description := ""
+		if op.Description != nil {
+			description = *op.Description
+		}
+		status := b.Flow(elemType).Initial
+		id, path, err := writeNewElement(elemType, status, op.Name, description, parentDir)
+		if err != nil {
+			return result, err
+		}
+		b.Elements = append(b.Elements, &board.Element{
+			Type:        elemType,
+			RawID:       id,
+			Name:        board.SanitizeName(op.Name),
+			Path:        path,
+			ParentID:    parentID,
+			Status:      status,
+			Title:       fmt.Sprintf("%s: %s", id, op.Name),
+			Description: description,
+		})
+		if op.As != "" {
+			symbols[op.As] = id
+		}
+		result.ID = id
+		result.Message = fmt.Sprintf("Created %s: %s", id, op.Name)
+
+	case "update":
+		e, err := find(op.ID)
+		if err != nil {
+			return result, err
+		}
+		rd, err := board.ParseReadmeFile(e.ReadmePath())
+		if err != nil {
+			return result, fmt.Errorf("reading README.md of %s: %w", e.ID(), err)
+		}
+		if op.Title != nil {
+			rd.Title = fmt.Sprintf("%s: %s", e.ID(), *op.Title)
+		}
+		if op.Description != nil {
+			rd.Description = *op.Description
+		}
+		if op.Scope != nil {
+			rd.Scope = *op.Scope
+		}
+		if op.AC != nil {
+			rd.AC = *op.AC
+		}
+		if err := board.WriteReadmeFile(e.ReadmePath(), rd); err != nil {
+			return result, fmt.Errorf("writing README.md of %s: %w", e.ID(), err)
+		}
+		result.ID = e.ID()
+		result.Message = fmt.Sprintf("Updated %s", e.ID())
+
+	case "link":
+		e, err := find(op.ID)
+		if err != nil {
+			return result, err
+		}
+		blocker, err := find(op.BlockedBy)
+		if err != nil {
+			return result, err
+		}
+		edges := plan.LinkEdges(b, e, blocker)
+		if cycle := plan.FindLinkCycle(b, edges); cycle != nil {
+			return result, &errLinkCycle{cycle: cycle}
+		}
+		if err := writeLinkEdges(b, edges); err != nil {
+			return result, err
+		}
+		result.ID = e.ID()
+		result.Message = fmt.Sprintf("%s now blocked by %s", e.ID(), blocker.ID())
+
+	case "unlink":
+		e, err := find(op.ID)
+		if err != nil {
+			return result, err
+		}
+		blocker, err := find(op.BlockedBy)
+		if err != nil {
+			return result, err
+		}
+		if !containsRef(e.BlockedBy, blocker.ID()) {
+			return result, fmt.Errorf("%s is not blocked by %s", e.ID(), blocker.ID())
+		}
+		if err := replaceRef(e, blocker.ID(), "", true); err != nil {
+			return result, err
+		}
+		if err := replaceRef(blocker, e.ID(), "", false); err != nil {
+			return result, err
+		}
+		if err := deescalateDependency(b, e, blocker); err != nil {
+			return result, fmt.Errorf("de-escalating dependency: %w", err)
+		}
+		result.ID = e.ID()
+		result.Message = fmt.Sprintf("%s no longer blocked by %s", e.ID(), blocker.ID())
+
+	case "add-item", "notes", "assign":
+		e, err := find(op.ID)
+		if err != nil {
+			return result, err
+		}
+		pd, err := board.ParseProgressFile(e.ProgressPath())
+		if err != nil {
+			return result, fmt.Errorf("reading progress for %s: %w", e.ID(), err)
+		}
+		switch op.Op {
+		case "add-item":
+			pd.Checklist = append(pd.Checklist, board.ChecklistItem{Text: op.Text})
+			result.Message = fmt.Sprintf("Added checklist item to %s", e.ID())
+		case "notes":
+			if op.Set || pd.Notes == "" {
+				pd.Notes = op.Text
+			} else {
+				pd.Notes += "\n" + op.Text
+			}
+			result.Message = fmt.Sprintf("Updated notes of %s", e.ID())
+		case "assign":
+			if violations := wip.Check(b, e, e.Status, op.Agent); len(violations) > 0 {
+				return result, &errWIPLimit{id: e.ID(), agent: op.Agent, violations: violations}
+			}
+			pd.AssignedTo = op.Agent
+			e.AssignedTo = op.Agent
+			result.Message = fmt.Sprintf("Assigned %s to %s", e.ID(), op.Agent)
+		}
+		if err := board.WriteProgressFile(e.ProgressPath(), pd); err != nil {
+			return result, fmt.Errorf("writing progress for %s: %w", e.ID(), err)
+		}
+		result.ID = e.ID()
+	}
+	return result, nil
+}
+
+// errWIPLimit marks an assign operation refused because it would exceed a
+// WIP limit.
+type errWIPLimit struct {
+	id, agent  string
+	violations []wip.Usage
+}
+
+func (e *errWIPLimit) Error() string {
+	return fmt.Sprintf("cannot assign %s to %s — %s", e.id, e.agent, describeWIP(e.violations))
+}
@@ -0,0 +1,315 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aagrigore/task-board/internal/board"
+	"github.com/aagrigore/task-board/internal/output"
+	"github.com/aagrigore/task-board/internal/txn"
+)
+
+func writeOps(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "ops.yaml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestApply(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	jsonOutput = true
+	defer func() { jsonOutput = false }()
+
+	ops := writeOps(t, `
+- op: create
+  type: story
+  parent: `+testEpic1ID+`
+  name: Capture
+  as: capture
+- op: create
+  type: task
+  parent: $capture
+  name: Recorder interface
+  as: iface
+- op: create
+  type: task
+  parent: $capture
+  name: Recorder impl
+  description: Writes samples
+  as: impl
+- op: link
+  id: $impl
+  blocked-by: $iface
+- op: add-item
+  id: $impl
+  text: Write tests
+- op: update
+  id: $impl
+  scope: Only WAV
+- op: assign
+  id: $iface
+  agent: agent-1
+`)
+	out := captureOutput(t, func() {
+		if err := runApply(applyCmd, []string{ops}); err != nil {
+			t.Fatalf("runApply: %v", err)
+		}
+	})
+	var resp ApplyResponse
+	if err := json.Unmarshal([]byte(out), &resp); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if resp.Applied != 7 || len(resp.Symbols) != 3 {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+
+	b, _ := board.Load(bd)
+	story := b.FindByID(resp.Symbols["capture"])
+	impl := b.FindByID(resp.Symbols["impl"])
+	iface := b.FindByID(resp.Symbols["iface"])
+	if story == nil || impl == nil || iface == nil {
+		t.Fatalf("created elements missing: %v", resp.Symbols)
+	}
+	if impl.ParentID != story.ID() || story.ParentID != testEpic1ID {
+		t.Errorf("wrong parents: impl under %s, story under %s", impl.ParentID, story.ParentID)
+	}
+	if len(impl.BlockedBy) != 1 || impl.BlockedBy[0] != iface.ID() {
+		t.Errorf("impl blocked by = %v", impl.BlockedBy)
+	}
+	if len(impl.Checklist) != 1 || impl.Checklist[0].Text != "Write tests" {
+		t.Errorf("impl checklist = %+v", impl.Checklist)
+	}
+	if impl.Scope != "Only WAV" || impl.Description != "Writes samples" {
+		t.Errorf("impl README = %q / %q", impl.Scope, impl.Description)
+	}
+	if iface.AssignedTo != "agent-1" {
+		t.Errorf("iface assigned to %q", iface.AssignedTo)
+	}
+	if _, err := os.Stat(filepath.Join(bd, txn.LockFile)); !os.IsNotExist(err) {
+		t.Error("lock should be released")
+	}
+}
+
+func TestApplyValidationWritesNothing(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	jsonOutput = true
+	defer func() { jsonOutput = false }()
+
+	ops := writeOps(t, `
+- op: create
+  type: task
+  parent: `+testStory1ID+`
+  name: Fine
+  as: fine
+- op: create
+  type: task
+  parent: `+testEpic1ID+`
+  name: Wrong parent
+- op: link
+  id: $fine
+  blocked-by: $missing
+- op: frobnicate
+`)
+	var stdout string
+	stderr := captureStderr(t, func() {
+		stdout = captureOutput(t, func() {
+			runApply(applyCmd, []string{ops})
+		})
+	})
+	if stdout != "" {
+		t.Errorf("nothing should be printed on stdout, got %q", stdout)
+	}
+	var resp output.JSONError
+	if err := json.Unmarshal([]byte(stderr), &resp); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, stderr)
+	}
+	if resp.Error.Code != output.ValidationError {
+		t.Errorf("code = %s", resp.Error.Code)
+	}
+	issues, _ := resp.Error.Details["issues"].([]interface{})
+	if len(issues) != 3 {
+		t.Errorf("expected 3 issues, got %v", resp.Error.Details)
+	}
+
+	b, _ := board.Load(bd)
+	if n := len(b.Children(b.FindByID(testStory1ID))); n != 4 {
+		t.Errorf("invalid batch should not create elements, story has %d children", n)
+	}
+}
+
+func TestApplyRollsBackOnFailure(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+
+	// TASK-3 is not blocked by TASK-1, which only shows while executing
+	ops := writeOps(t, `
+- op: create
+  type: task
+  parent: `+testStory1ID+`
+  name: Temporary
+- op: notes
+  id: `+testTask1ID+`
+  text: Replaced
+  set: true
+- op: unlink
+  id: `+testTask3ID+`
+  blocked-by: `+testTask1ID+`
+`)
+	err := runApply(applyCmd, []string{ops})
+	if err == nil || !strings.Contains(err.Error(), "rolled back") {
+		t.Fatalf("expected rollback error, got %v", err)
+	}
+
+	b, _ := board.Load(bd)
+	if n := len(b.Children(b.FindByID(testStory1ID))); n != 4 {
+		t.Errorf("create should be rolled back, story has %d children", n)
+	}
+	pd, _ := board.ParseProgressFile(b.FindByID(testTask1ID).ProgressPath())
+	if pd.Notes != "Started work" {
+		t.Errorf("notes should be restored, got %q", pd.Notes)
+	}
+	if _, err := os.Stat(filepath.Join(bd, txn.LockFile)); !os.IsNotExist(err) {
+		t.Error("lock should be released after rollback")
+	}
+}
+
+func TestApplyAssignRespectsWIP(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	os.WriteFile(filepath.Join(bd, board.WorkflowFile), []byte(testWIPWorkflow), 0644)
+
+	ops := writeOps(t, `
+- op: assign
+  id: `+testTask1ID+`
+  agent: alice
+- op: assign
+  id: `+testTask3ID+`
+  agent: alice
+`)
+	err := runApply(applyCmd, []string{ops})
+	if err == nil || !strings.Contains(err.Error(), "@alice WIP limit 1 (would be 2)") || !strings.Contains(err.Error(), "rolled back") {
+		t.Fatalf("the second assignment should exceed alice's limit, got %v", err)
+	}
+	b, _ := board.Load(bd)
+	if a := b.FindByID(testTask1ID).AssignedTo; a != "" {
+		t.Errorf("the first assignment should be rolled back, got %q", a)
+	}
+
+	jsonOutput = true
+	defer func() { jsonOutput = false }()
+	stderr := captureStderr(t, func() { runApply(applyCmd, []string{ops}) })
+	if !strings.Contains(stderr, `"code": "WIP_LIMIT"`) || !strings.Contains(stderr, `"limits"`) {
+		t.Errorf("expected a WIP_LIMIT error, got %s", stderr)
+	}
+}
+
+func TestApplyStdinAndLock(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+
+	applyCmd.SetIn(strings.NewReader(`[{"op": "notes", "id": "` + testTask2ID + `", "text": "from json"}]`))
+	defer applyCmd.SetIn(nil)
+	captureOutput(t, func() {
+		if err := runApply(applyCmd, []string{"-"}); err != nil {
+			t.Fatalf("runApply: %v", err)
+		}
+	})
+	b, _ := board.Load(bd)
+	pd, _ := board.ParseProgressFile(b.FindByID(testTask2ID).ProgressPath())
+	if !strings.Contains(pd.Notes, "from json") {
+		t.Errorf("notes = %q", pd.Notes)
+	}
+
+	tx, err := txn.Begin(bd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Commit()
+	lockWait = 0
+	defer func() { lockWait = 10 * time.Second }()
+	applyCmd.SetIn(strings.NewReader(`[{"op": "notes", "id": "` + testTask2ID + `", "text": "again"}]`))
+	if err := applyCmd.RunE(applyCmd, []string{"-"}); err == nil || !strings.Contains(err.Error(), "locked") {
+		t.Errorf("apply should refuse while locked, got %v", err)
+	}
+	// Every board-changing command takes the same lock
+	if err := progressStatusCmd.RunE(progressStatusCmd, []string{testTask2ID, "development"}); err == nil || !strings.Contains(err.Error(), "locked") {
+		t.Errorf("progress status should refuse while locked, got %v", err)
+	}
+}
+
+func TestApplyValidationFindsCycles(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	applyDryRun = true
+	defer func() { applyDryRun = false }()
+
+	// TASK-1 blocks TASK-2 on the board; the new tasks block each other
+	ops := writeOps(t, `
+- op: create
+  type: task
+  parent: `+testStory1ID+`
+  name: First
+  as: first
+- op: create
+  type: task
+  parent: `+testStory1ID+`
+  name: Second
+  as: second
+- op: link
+  id: $first
+  blocked-by: $second
+- op: link
+  id: $second
+  blocked-by: $first
+- op: link
+  id: `+testTask1ID+`
+  blocked-by: `+testTask2ID+`
+`)
+	err := runApply(applyCmd, []string{ops})
+	if err == nil || !strings.Contains(err.Error(), "2 invalid operation(s)") ||
+		!strings.Contains(err.Error(), "#4 link: linking $second to $first would create a dependency cycle") ||
+		!strings.Contains(err.Error(), "#5 link: linking "+testTask1ID+" to "+testTask2ID+" would create a dependency cycle") {
+		t.Fatalf("expected both cycles, got %v", err)
+	}
+
+	// Unlinking first frees the way
+	ops = writeOps(t, `
+- op: unlink
+  id: `+testTask2ID+`
+  blocked-by: `+testTask1ID+`
+- op: link
+  id: `+testTask1ID+`
+  blocked-by: `+testTask2ID+`
+`)
+	out := captureOutput(t, func() {
+		if err := runApply(applyCmd, []string{ops}); err != nil {
+			t.Fatalf("runApply: %v", err)
+		}
+	})
+	if !strings.Contains(out, "2 operation(s) valid; nothing applied") {
+		t.Errorf("dry run output = %q", out)
+	}
+	b, _ := board.Load(bd)
+	if !containsRef(b.FindByID(testTask2ID).BlockedBy, testTask1ID) {
+		t.Error("a dry run should write nothing")
+	}
+}
+
+func TestApplyRefusesWorkspace(t *testing.T) {
+	workspaceFile = setupTestWorkspace(t)
+	defer func() { workspaceFile = "" }()
+
+	ops := writeOps(t, "- op: notes\n  id: api:"+testTask1ID+"\n  text: hi\n")
+	if err := runApply(applyCmd, []string{ops}); err == nil || !strings.Contains(err.Error(), "--workspace") {
+		t.Errorf("expected apply to refuse --workspace, got %v", err)
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/aagrigore/task-board/internal/board"
 	"github.com/aagrigore/task-board/internal/output"
+	"github.com/aagrigore/task-board/internal/wip"
 	"github.com/spf13/cobra"
 )
 
@@ -19,15 +20,19 @@ var assignCmd = &cobra.Command{
 	Use:   "assign <ID>",
 	Short: "Assign element to an agent",
 	Args:  cobra.ExactArgs(1),
-	RunE:  runAssign,
+	RunE:  recorded(runAssign),
 }
 
-var assignAgent string
+var (
+	assignAgent string
+	assignForce bool
+)
 
 func init() {
 	rootCmd.AddCommand(assignCmd)
 	assignCmd.Flags().StringVar(&assignAgent, "agent", "", "Agent name (required)")
 	assignCmd.MarkFlagRequired("agent")
+	assignCmd.Flags().BoolVar(&assignForce, "force", false, "Exceed the agent's WIP limit")
 }
 
 func runAssign(cmd *cobra.Command, args []string) error {
@@ -62,6 +67,19 @@ func runAssign(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("reading progress for %s: %w", id, err)
 	}
 
+	if !assignForce {
+		if violations := wip.Check(b, elem, elem.Status, assignAgent); len(violations) > 0 {
+			msg := fmt.Sprintf("Cannot assign %s to %s — %s", id, assignAgent, describeWIP(violations))
+			if JSONEnabled() {
+				output.PrintError(os.Stderr, output.WIPLimit, msg, map[string]interface{}{
+					"limits": violations,
+				})
+				return nil
+			}
+			return fmt.Errorf("cannot assign %s to %s — %s (use --force to exceed)", id, assignAgent, describeWIP(violations))
+		}
+	}
+
 	pd.AssignedTo = assignAgent
 
 	if err := board.WriteProgressFile(elem.ProgressPath(), pd); err != nil {
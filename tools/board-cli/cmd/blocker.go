@@ -0,0 +1,405 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aagrigore/task-board/internal/board"
+	"github.com/aagrigore/task-board/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// BlockerJSON is an external blocker in JSON output
+type BlockerJSON struct {
+	ID       string               `json:"id"`
+	Reason   string               `json:"reason"`
+	Owner    string               `json:"owner,omitempty"`
+	Expect   string               `json:"expect,omitempty"`
+	Overdue  bool                 `json:"overdue"`
+	Created  string               `json:"created"`
+	Resolved string               `json:"resolved,omitempty"`
+	Elements []BlockedElementJSON `json:"elements"`
+}
+
+// BlockedElementJSON is an element held by an external blocker
+type BlockedElementJSON struct {
+	ID string `json:"id"`
+}
+
+// BlockerResponse is the JSON response for blocker add
+type BlockerResponse struct {
+	Blocker BlockerJSON `json:"blocker"`
+	Message string      `json:"message"`
+}
+
+// BlockerListResponse is the JSON response for blocker list
+type BlockerListResponse struct {
+	Blockers []BlockerJSON `json:"blockers"`
+	Count    int           `json:"count"`
+}
+
+// BlockerResolveResponse is the JSON response for blocker resolve
+type BlockerResolveResponse struct {
+	Blocker  BlockerJSON       `json:"blocker"`
+	Restored []BlockerRestored `json:"restored"`
+	// Kept lists the elements left as they are, with why
+	Kept    []ElementProblem `json:"kept"`
+	Message string           `json:"message"`
+}
+
+// BlockerRestored is an element returned to its previous status
+type BlockerRestored struct {
+	ID   string `json:"id"`
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+var blockerCmd = &cobra.Command{
+	Use:   "blocker",
+	Short: "Track external blockers (other teams, vendors, access)",
+	Long: `Track what outside the board holds elements up, stored in
+.task-board/blockers.yaml.
+
+'blocker add' sets its elements to the blocked status and remembers the
+status each had; 'blocker resolve' puts them back, unless another open
+blocker still holds them. Open blockers show in summary and, as extra
+nodes, in plan.`,
+}
+
+var blockerAddCmd = &cobra.Command{
+	Use:   "add <ID>...",
+	Short: "Record an external blocker holding up elements",
+	Example: `  task-board blocker add TASK-260101-aaaaaa --reason "waiting on API keys" --owner ops --expect 2026-11-01
+  task-board blocker add TASK-260101-aaaaaa BUG-260102-bbbbbb --reason "vendor outage" --expect friday`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: recorded(runBlockerAdd),
+}
+
+var blockerListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List external blockers",
+	Args:  cobra.NoArgs,
+	RunE:  runBlockerList,
+}
+
+var blockerResolveCmd = &cobra.Command{
+	Use:   "resolve <EXT-ID>",
+	Short: "Resolve an external blocker and restore its elements",
+	Args:  cobra.ExactArgs(1),
+	RunE:  recorded(runBlockerResolve),
+}
+
+var (
+	blockerReason string
+	blockerOwner  string
+	blockerExpect string
+	blockerForce  bool
+	blockerAll    bool
+)
+
+func init() {
+	rootCmd.AddCommand(blockerCmd)
+	blockerCmd.AddCommand(blockerAddCmd)
+	blockerCmd.AddCommand(blockerListCmd)
+	blockerCmd.AddCommand(blockerResolveCmd)
+	blockerAddCmd.Flags().StringVar(&blockerReason, "reason", "", "What the elements are waiting on")
+	blockerAddCmd.Flags().StringVar(&blockerOwner, "owner", "", "Who outside the board owns it")
+	blockerAddCmd.Flags().StringVar(&blockerExpect, "expect", "", "When it should be resolved: a date, an age (3d, 2w) or a weekday")
+	for _, c := range []*cobra.Command{blockerAddCmd, blockerResolveCmd} {
+		c.Flags().BoolVar(&blockerForce, "force", false, "Change statuses the workflow would refuse")
+	}
+	blockerListCmd.Flags().BoolVar(&blockerAll, "all", false, "Include resolved blockers")
+}
+
+func runBlockerAdd(cmd *cobra.Command, args []string) error {
+	fail := func(code output.ErrorCode, err error) error {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, code, err.Error(), nil)
+			return nil
+		}
+		return err
+	}
+
+	if strings.TrimSpace(blockerReason) == "" {
+		return fail(output.ValidationError, fmt.Errorf("--reason is required"))
+	}
+	now := time.Now()
+	var expect time.Time
+	if blockerExpect != "" {
+		t, err := parseUntil("expect", blockerExpect, now)
+		if err != nil {
+			return fail(output.ValidationError, err)
+		}
+		expect = t.UTC()
+	}
+
+	b, err := board.Load(boardDir)
+	if err != nil {
+		return fail(output.InternalError, fmt.Errorf("loading board: %w", err))
+	}
+	blockers, err := board.LoadBlockers(boardDir)
+	if err != nil {
+		return fail(output.InternalError, err)
+	}
+
+	bl := &board.Blocker{
+		ID:      board.NextBlockerID(blockers),
+		Reason:  strings.TrimSpace(blockerReason),
+		Owner:   blockerOwner,
+		Expect:  expect,
+		Created: now.UTC().Truncate(time.Second),
+	}
+
+	// Check every element before changing any
+	var elems []*board.Element
+	for _, id := range args {
+		elem := b.FindByID(id)
+		if elem == nil {
+			return fail(output.NotFound, fmt.Errorf("element %s not found", id))
+		}
+		if bl.Holds(elem.ID()) != nil {
+			continue
+		}
+		if b.Workflow.Finished(elem) {
+			return fail(output.ValidationError, fmt.Errorf("%s is already %s", elem.ID(), elem.Status))
+		}
+		blocked, ok := b.Flow(elem.Type).First(board.CategoryBlocked)
+		if !ok {
+			return fail(output.ValidationError, fmt.Errorf("the %s workflow has no blocked status", elem.Type))
+		}
+		// An element already on hold keeps its prior status from before
+		if b.Workflow.Category(elem) != board.CategoryBlocked {
+			if refusal := transitionRefusal(b, elem, blocked, blockerForce); refusal != nil {
+				return fail(refusal.code, fmt.Errorf("%s: %s", elem.ID(), refusal.message))
+			}
+		}
+		bl.Elements = append(bl.Elements, board.BlockedElement{ID: elem.ID()})
+		elems = append(elems, elem)
+	}
+
+	if err := board.SaveBlockers(boardDir, append(blockers, bl)); err != nil {
+		return fail(output.InternalError, err)
+	}
+	infof("%s: %s\n", bl.ID, bl.Reason)
+	for _, elem := range elems {
+		blocked, _ := b.Flow(elem.Type).First(board.CategoryBlocked)
+		if elem.Status == blocked {
+			continue
+		}
+		if err := setStatus(b, elem, nil, blocked, fmt.Sprintf("  %s: %s → %s", elem.ID(), elem.Status, blocked)); err != nil {
+			return fail(output.InternalError, err)
+		}
+	}
+
+	message := fmt.Sprintf("Added %s holding %d element(s)", bl.ID, len(bl.Elements))
+	if JSONEnabled() {
+		return output.PrintJSON(os.Stdout, BlockerResponse{Blocker: blockerJSON(bl, now), Message: message})
+	}
+	fmt.Println(message)
+	return nil
+}
+
+func runBlockerList(cmd *cobra.Command, args []string) error {
+	blockers, err := board.LoadBlockers(boardDir)
+	if err != nil {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.InternalError, err.Error(), nil)
+			return nil
+		}
+		return err
+	}
+
+	now := time.Now()
+	list := []BlockerJSON{}
+	table := output.NewTable("ID", "OWNER", "EXPECT", "ELEMENTS", "REASON")
+	for _, bl := range blockers {
+		if !bl.Open() && !blockerAll {
+			continue
+		}
+		j := blockerJSON(bl, now)
+		list = append(list, j)
+		ids := make([]string, len(bl.Elements))
+		for i, held := range bl.Elements {
+			ids[i] = held.ID
+		}
+		table.AddRow(bl.ID, bl.Owner, describeExpect(bl, now), strings.Join(ids, ", "), bl.Reason)
+	}
+
+	if JSONEnabled() {
+		return output.PrintJSON(os.Stdout, BlockerListResponse{Blockers: list, Count: len(list)})
+	}
+	if len(list) == 0 {
+		fmt.Println("No external blockers.")
+		return nil
+	}
+	fmt.Print(table.String())
+	return nil
+}
+
+func runBlockerResolve(cmd *cobra.Command, args []string) error {
+	fail := func(code output.ErrorCode, err error) error {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, code, err.Error(), nil)
+			return nil
+		}
+		return err
+	}
+
+	b, err := board.Load(boardDir)
+	if err != nil {
+		return fail(output.InternalError, fmt.Errorf("loading board: %w", err))
+	}
+	blockers, err := board.LoadBlockers(boardDir)
+	if err != nil {
+		return fail(output.InternalError, err)
+	}
+	bl := board.FindBlocker(blockers, args[0])
+	if bl == nil {
+		return fail(output.NotFound, fmt.Errorf("blocker %s not found", args[0]))
+	}
+	if !bl.Open() {
+		return fail(output.ValidationError, fmt.Errorf("%s is already resolved", bl.ID))
+	}
+
+	now := time.Now()
+	bl.Resolved = now.UTC().Truncate(time.Second)
+	if err := board.SaveBlockers(boardDir, blockers); err != nil {
+		return fail(output.InternalError, err)
+	}
+	infof("%s resolved: %s\n", bl.ID, bl.Reason)
+
+	response := BlockerResolveResponse{Restored: []BlockerRestored{}, Kept: []ElementProblem{}}
+	keep := func(id, why string) {
+		response.Kept = append(response.Kept, ElementProblem{ID: id, Error: why})
+		infof("  %s stays as it is: %s\n", id, why)
+	}
+	for _, held := range bl.Elements {
+		elem := b.FindByID(held.ID)
+		if elem == nil {
+			keep(held.ID, "no longer on the board")
+			continue
+		}
+		if other := otherBlocker(blockers, bl, elem.ID()); other != nil {
+			keep(elem.ID(), fmt.Sprintf("still held by %s", other.ID))
+			continue
+		}
+		if b.Workflow.Category(elem) != board.CategoryBlocked {
+			keep(elem.ID(), fmt.Sprintf("no longer blocked (%s)", elem.Status))
+			continue
+		}
+		pd, err := board.ParseProgressFile(elem.ProgressPath())
+		if err != nil {
+			return fail(output.InternalError, fmt.Errorf("reading progress for %s: %w", elem.ID(), err))
+		}
+		target, ok := priorStatus(b.Flow(elem.Type), pd, func(c board.Category) bool { return c != board.CategoryBlocked })
+		if !ok {
+			keep(elem.ID(), "no earlier status to return to")
+			continue
+		}
+		if refusal := transitionRefusal(b, elem, target, blockerForce); refusal != nil {
+			keep(elem.ID(), refusal.message)
+			continue
+		}
+		from := elem.Status
+		if err := setStatus(b, elem, pd, target, fmt.Sprintf("  %s: %s → %s", elem.ID(), from, target)); err != nil {
+			return fail(output.InternalError, err)
+		}
+		response.Restored = append(response.Restored, BlockerRestored{ID: elem.ID(), From: string(from), To: string(target)})
+	}
+
+	response.Blocker = blockerJSON(bl, now)
+	response.Message = fmt.Sprintf("Resolved %s, restored %d element(s)", bl.ID, len(response.Restored))
+	if JSONEnabled() {
+		return output.PrintJSON(os.Stdout, response)
+	}
+	fmt.Println(response.Message)
+	return nil
+}
+
+// setStatus moves an element to a status the caller has already checked:
+// history, prior status and timer in progress.md, then note, then the
+// parent cascade. Every status change goes through it. pd is the
+// element's progress, with any other changes the caller made, or nil to
+// read it.
+func setStatus(b *board.Board, elem *board.Element, pd *board.ProgressData, status board.Status, note string) error {
+	if pd == nil {
+		var err error
+		if pd, err = board.ParseProgressFile(elem.ProgressPath()); err != nil {
+			return fmt.Errorf("reading progress for %s: %w", elem.ID(), err)
+		}
+	}
+	oldStatus := pd.Status
+	pd.SetStatus(status, b.Flow(elem.Type))
+	autoTimer(b.Workflow, pd, oldStatus, status)
+	if err := board.WriteProgressFile(elem.ProgressPath(), pd); err != nil {
+		return fmt.Errorf("writing progress for %s: %w", elem.ID(), err)
+	}
+	infof("%s\n", note)
+	elem.Status = status
+	if oldStatus != status {
+		cascadeStatus(b, elem)
+	}
+	return nil
+}
+
+// otherBlocker returns an open blocker other than bl that holds id, or nil.
+func otherBlocker(blockers []*board.Blocker, bl *board.Blocker, id string) *board.Blocker {
+	for _, other := range blockers {
+		if other != bl && other.Open() && other.Holds(id) != nil {
+			return other
+		}
+	}
+	return nil
+}
+
+func blockerJSON(bl *board.Blocker, now time.Time) BlockerJSON {
+	j := BlockerJSON{
+		ID:       bl.ID,
+		Reason:   bl.Reason,
+		Owner:    bl.Owner,
+		Overdue:  bl.Overdue(now),
+		Created:  bl.Created.UTC().Format(time.RFC3339),
+		Elements: make([]BlockedElementJSON, len(bl.Elements)),
+	}
+	if !bl.Expect.IsZero() {
+		j.Expect = bl.Expect.Local().Format("2006-01-02")
+	}
+	if !bl.Resolved.IsZero() {
+		j.Resolved = bl.Resolved.UTC().Format(time.RFC3339)
+	}
+	for i, held := range bl.Elements {
+		j.Elements[i] = BlockedElementJSON{ID: held.ID}
+	}
+	return j
+}
+
+// describeExpect shows when a blocker should go away, flagging overdue ones.
+func describeExpect(bl *board.Blocker, now time.Time) string {
+	switch {
+	case !bl.Open():
+		return "resolved " + bl.Resolved.Local().Format("2006-01-02")
+	case bl.Expect.IsZero():
+		return ""
+	case bl.Overdue(now):
+		return bl.Expect.Local().Format("2006-01-02") + " (overdue)"
+	default:
+		return bl.Expect.Local().Format("2006-01-02")
+	}
+}
+
+// describeBlockerOwner is the " — @owner, expected …" tail of a blocker line.
+func describeBlockerOwner(bl *board.Blocker, now time.Time) string {
+	var parts []string
+	if bl.Owner != "" {
+		parts = append(parts, "@"+bl.Owner)
+	}
+	if expect := describeExpect(bl, now); expect != "" {
+		parts = append(parts, "expected "+expect)
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return " — " + strings.Join(parts, ", ")
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/aagrigore/task-board/internal/board"
+)
+
+func setStatusForTest(t *testing.T, bd, id string, s board.Status) {
+	t.Helper()
+	b, _ := board.Load(bd)
+	elem := b.FindByID(id)
+	pd, err := board.ParseProgressFile(elem.ProgressPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	pd.Status = s
+	if err := board.WriteProgressFile(elem.ProgressPath(), pd); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBlockerAddAndResolve(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	defer func() { blockerReason, blockerOwner, blockerExpect = "", "", "" }()
+	setStatusForTest(t, bd, testTask3ID, board.StatusDevelopment)
+
+	blockerReason, blockerOwner, blockerExpect = "waiting on API keys", "ops", "2026-11-01"
+	captureOutput(t, func() {
+		if err := runBlockerAdd(blockerAddCmd, []string{testTask1ID, testTask3ID}); err != nil {
+			t.Fatalf("blocker add: %v", err)
+		}
+	})
+	blockerReason, blockerOwner, blockerExpect = "legal review", "", ""
+	captureOutput(t, func() {
+		if err := runBlockerAdd(blockerAddCmd, []string{testTask3ID}); err != nil {
+			t.Fatalf("blocker add: %v", err)
+		}
+	})
+
+	b, _ := board.Load(bd)
+	task1, task3 := b.FindByID(testTask1ID), b.FindByID(testTask3ID)
+	if task1.Status != board.StatusBlocked || task3.Status != board.StatusBlocked {
+		t.Fatalf("statuses = %s, %s, want blocked", task1.Status, task3.Status)
+	}
+	if len(task3.HeldBy()) != 2 {
+		t.Errorf("task 3 should be held twice, got %d", len(task3.HeldBy()))
+	}
+	if pd, _ := board.ParseProgressFile(task3.ProgressPath()); pd.PriorStatus != board.StatusDevelopment {
+		t.Errorf("a second blocker should keep the status from before the first, got %q", pd.PriorStatus)
+	}
+
+	jsonOutput = true
+	stdout := captureOutput(t, func() {
+		if err := runSummary(summaryCmd, nil); err != nil {
+			t.Fatal(err)
+		}
+	})
+	jsonOutput = false
+	var summary SummaryResponse
+	if err := json.Unmarshal([]byte(stdout), &summary); err != nil {
+		t.Fatalf("parse: %v\n%s", err, stdout)
+	}
+	if len(summary.Summary.External) != 2 || summary.Summary.External[0].Expect != "2026-11-01" {
+		t.Errorf("summary external = %+v", summary.Summary.External)
+	}
+
+	captureOutput(t, func() {
+		if err := runBlockerResolve(blockerResolveCmd, []string{"ext-1"}); err != nil {
+			t.Fatalf("blocker resolve: %v", err)
+		}
+	})
+	b, _ = board.Load(bd)
+	if s := b.FindByID(testTask1ID).Status; s != board.StatusBacklog {
+		t.Errorf("task 1 should be back to backlog, got %s", s)
+	}
+	if s := b.FindByID(testTask3ID).Status; s != board.StatusBlocked {
+		t.Errorf("task 3 is still held by EXT-2, got %s", s)
+	}
+
+	captureOutput(t, func() {
+		if err := runBlockerResolve(blockerResolveCmd, []string{"EXT-2"}); err != nil {
+			t.Fatalf("blocker resolve: %v", err)
+		}
+	})
+	b, _ = board.Load(bd)
+	if s := b.FindByID(testTask3ID).Status; s != board.StatusDevelopment {
+		t.Errorf("task 3 should be back to development, got %s", s)
+	}
+	if err := runBlockerResolve(blockerResolveCmd, []string{"EXT-2"}); err == nil {
+		t.Error("resolving twice should fail")
+	}
+}
+
+func TestBlockerAddRefusesFinished(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	defer func() { blockerReason = "" }()
+	setStatusForTest(t, bd, testTask1ID, board.StatusDone)
+
+	blockerReason = "too late"
+	if err := runBlockerAdd(blockerAddCmd, []string{testTask1ID}); err == nil {
+		t.Error("a done element cannot be blocked")
+	}
+	if blockers, _ := board.LoadBlockers(bd); len(blockers) != 0 {
+		t.Errorf("nothing should be recorded, got %d blockers", len(blockers))
+	}
+}
@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/aagrigore/task-board/chart"
+	"github.com/aagrigore/task-board/internal/board"
+	"github.com/aagrigore/task-board/internal/burn"
+	"github.com/aagrigore/task-board/internal/output"
+	"github.com/aagrigore/task-board/internal/plan"
+	"github.com/spf13/cobra"
+)
+
+// ChartResponse is the JSON response for chart burndown and chart burnup
+type ChartResponse struct {
+	Chart *chart.Series `json:"chart"`
+	SVG   string        `json:"svg,omitempty"` // path of the rendered SVG with --svg
+}
+
+var chartCmd = &cobra.Command{
+	Use:   "chart",
+	Short: "Draw burndown and burnup charts",
+	Long: `Charts of remaining and completed work over time, drawn in the terminal or
+rendered as SVG to .temp/ next to the plan renders (no Graphviz needed).
+
+The history comes from each element's creation time, the status history in
+progress.md, earlier versions of progress.md in git (when the board is in a
+repository; --no-git skips it) and its last update. Closed elements drop out
+of the scope.
+
+By default tasks and bugs are counted; --checklist counts checklist items of
+every element in scope instead.`,
+}
+
+var chartBurndownCmd = &cobra.Command{
+	Use:   "burndown [ID]",
+	Short: "Chart the remaining work of an epic, a story or the whole board",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runChart,
+}
+
+var chartBurnupCmd = &cobra.Command{
+	Use:   "burnup [ID]",
+	Short: "Chart completed work against the total scope",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runChart,
+}
+
+var (
+	chartChecklist bool
+	chartTypes     []string
+	chartSince     string
+	chartSVG       bool
+	chartNoGit     bool
+	chartWidth     int
+	chartHeight    int
+)
+
+func init() {
+	rootCmd.AddCommand(chartCmd)
+	for _, c := range []*cobra.Command{chartBurndownCmd, chartBurnupCmd} {
+		chartCmd.AddCommand(c)
+		c.Flags().BoolVar(&chartChecklist, "checklist", false, "Count checklist items instead of elements")
+		c.Flags().StringSliceVar(&chartTypes, "type", []string{"task", "bug"}, "Element types to count")
+		c.Flags().StringVar(&chartSince, "since", "", "Start the chart at this date (2006-01-02) or age (14d, 2w)")
+		c.Flags().BoolVar(&chartSVG, "svg", false, "Render an SVG to .temp/ in the scope's directory")
+		c.Flags().BoolVar(&chartNoGit, "no-git", false, "Don't read earlier versions of progress.md from git")
+		c.Flags().IntVar(&chartWidth, "width", 60, "Terminal chart width in columns")
+		c.Flags().IntVar(&chartHeight, "height", 10, "Terminal chart height in rows")
+	}
+}
+
+func runChart(cmd *cobra.Command, args []string) error {
+	fail := func(code output.ErrorCode, err error) error {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, code, err.Error(), nil)
+			return nil
+		}
+		return err
+	}
+
+	kind, err := chart.ParseKind(cmd.Name())
+	if err != nil {
+		return fail(output.ValidationError, err)
+	}
+	since, err := parseSince(chartSince)
+	if err != nil {
+		return fail(output.ValidationError, err)
+	}
+	// Checklists live on every type, so --checklist counts all of them
+	// unless --type narrows it
+	var types map[board.ElementType]bool
+	if !chartChecklist || cmd.Flags().Changed("type") {
+		types = map[board.ElementType]bool{}
+		for _, name := range chartTypes {
+			t, err := board.ParseElementType(name)
+			if err != nil {
+				return fail(output.ValidationError, err)
+			}
+			types[t] = true
+		}
+	}
+
+	b, err := loadViewBoard()
+	if err != nil {
+		return fail(output.InternalError, fmt.Errorf("loading board: %w", err))
+	}
+	scopeID := ""
+	if len(args) == 1 {
+		scopeID = args[0]
+	}
+	scope, err := plan.AllDescendants(b, scopeID)
+	if err != nil {
+		return fail(output.NotFound, err)
+	}
+	var elements []*board.Element
+	for _, e := range scope {
+		if types == nil || types[e.Type] {
+			elements = append(elements, e)
+		}
+	}
+
+	unit := "elements"
+	if chartChecklist {
+		unit = "checklist items"
+	}
+	title := "Burndown"
+	if kind == chart.Burnup {
+		title = "Burnup"
+	}
+	series := &chart.Series{
+		Kind:  kind,
+		Scope: scopeID,
+		Title: fmt.Sprintf("%s — %s", title, scopeLabel(b, scopeID)),
+		Unit:  unit,
+		Points: burn.Build(b.Workflow, elements, burn.Options{
+			Checklist: chartChecklist,
+			Git:       !chartNoGit,
+			Since:     since,
+		}),
+	}
+
+	response := ChartResponse{Chart: series}
+	if chartSVG {
+		path, err := plan.RenderOutputPath(b, scopeID, string(kind), "svg")
+		if err == nil {
+			err = os.WriteFile(path, []byte(chart.SVG(series)), 0644)
+		}
+		if err != nil {
+			return fail(output.InternalError, fmt.Errorf("writing chart: %w", err))
+		}
+		response.SVG = path
+	}
+
+	if JSONEnabled() {
+		return output.PrintJSON(os.Stdout, response)
+	}
+	if response.SVG != "" {
+		fmt.Printf("Chart rendered to %s\n", response.SVG)
+		return nil
+	}
+	fmt.Print(chart.Text(series, chartWidth, chartHeight))
+	return nil
+}
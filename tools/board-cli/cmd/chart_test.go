@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestChartBurndown(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	chartNoGit = true
+	defer func() { chartNoGit = false }()
+	passReviewForTest(t, bd, testTask4ID)
+	captureOutput(t, func() {
+		runProgressStatus(progressStatusCmd, []string{testTask4ID, "development"})
+		runProgressStatus(progressStatusCmd, []string{testTask4ID, "done"})
+	})
+
+	jsonOutput = true
+	defer func() { jsonOutput = false }()
+	out := captureOutput(t, func() {
+		if err := runChart(chartBurndownCmd, []string{testStory3ID}); err != nil {
+			t.Fatal(err)
+		}
+	})
+	var resp ChartResponse
+	if err := json.Unmarshal([]byte(out), &resp); err != nil {
+		t.Fatalf("parse: %v\n%s", err, out)
+	}
+	last := resp.Chart.Last()
+	if resp.Chart.Kind != "burndown" || last.Total != 1 || last.Done != 1 || last.Remaining != 0 {
+		t.Errorf("chart = %+v", resp.Chart)
+	}
+
+	chartSVG = true
+	defer func() { chartSVG = false }()
+	out = captureOutput(t, func() {
+		if err := runChart(chartBurnupCmd, []string{testStory3ID}); err != nil {
+			t.Fatal(err)
+		}
+	})
+	resp = ChartResponse{}
+	json.Unmarshal([]byte(out), &resp)
+	if filepath.Base(resp.SVG) != "burnup.svg" || !strings.Contains(resp.SVG, ".temp") {
+		t.Fatalf("svg path = %q", resp.SVG)
+	}
+	data, err := os.ReadFile(resp.SVG)
+	if err != nil || !strings.Contains(string(data), "Burnup — "+testStory3ID) {
+		t.Errorf("svg not written: %v\n%s", err, data)
+	}
+}
+
+func TestChartUnknownScope(t *testing.T) {
+	boardDir = setupTestBoard(t)
+	if err := runChart(chartBurndownCmd, []string{"EPIC-999999-zzzzzz"}); err == nil {
+		t.Error("an unknown scope should be rejected")
+	}
+}
@@ -2,12 +2,14 @@ package cmd
 
 import (
 	"fmt"
+	"math"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/aagrigore/task-board/internal/board"
+	"github.com/aagrigore/task-board/internal/dedupe"
 	"github.com/aagrigore/task-board/internal/output"
 	"github.com/aagrigore/task-board/templates"
 	"github.com/spf13/cobra"
@@ -15,7 +17,15 @@ import (
 
 // CreateResponse is the JSON response for create commands
 type CreateResponse struct {
-	Created CreatedElement `json:"created"`
+	Created            CreatedElement       `json:"created"`
+	PossibleDuplicates []DuplicateCandidate `json:"possibleDuplicates,omitempty"`
+}
+
+// DuplicateCandidate is an existing element similar to one being created
+type DuplicateCandidate struct {
+	ID    string  `json:"id"`
+	Name  string  `json:"name"`
+	Score float64 `json:"score,omitempty"`
 }
 
 // CreatedElement represents a newly created element
@@ -36,25 +46,25 @@ var createCmd = &cobra.Command{
 var createEpicCmd = &cobra.Command{
 	Use:   "epic",
 	Short: "Create a new epic",
-	RunE:  runCreateEpic,
+	RunE:  recorded(runCreateEpic),
 }
 
 var createStoryCmd = &cobra.Command{
 	Use:   "story",
 	Short: "Create a new story",
-	RunE:  runCreateStory,
+	RunE:  recorded(runCreateStory),
 }
 
 var createTaskCmd = &cobra.Command{
 	Use:   "task",
 	Short: "Create a new task",
-	RunE:  runCreateTask,
+	RunE:  recorded(runCreateTask),
 }
 
 var createBugCmd = &cobra.Command{
 	Use:   "bug",
 	Short: "Create a new bug",
-	RunE:  runCreateBug,
+	RunE:  recorded(runCreateBug),
 }
 
 var (
@@ -62,6 +72,7 @@ var (
 	createDescription string
 	createEpicFlag    string
 	createStoryFlag   string
+	createNoDuplicate bool
 )
 
 func init() {
@@ -93,6 +104,10 @@ func init() {
 	createBugCmd.Flags().StringVar(&createStoryFlag, "story", "", "Parent story ID (required)")
 	createBugCmd.MarkFlagRequired("name")
 	createBugCmd.MarkFlagRequired("story")
+
+	for _, c := range []*cobra.Command{createEpicCmd, createStoryCmd, createTaskCmd, createBugCmd} {
+		c.Flags().BoolVar(&createNoDuplicate, "no-duplicates", false, "Refuse to create when a similar element already exists")
+	}
 }
 
 func runCreateEpic(cmd *cobra.Command, args []string) error {
@@ -120,17 +135,18 @@ func createElement(elemType board.ElementType, name, description, parentID strin
 		return err
 	}
 
+	b, err := board.Load(boardDir)
+	if err != nil {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.InternalError, fmt.Sprintf("loading board: %s", err.Error()), nil)
+			return nil
+		}
+		return fmt.Errorf("loading board: %w", err)
+	}
+
 	// Find parent directory
 	parentDir := boardDir
 	if parentID != "" {
-		b, err := board.Load(boardDir)
-		if err != nil {
-			if JSONEnabled() {
-				output.PrintError(os.Stderr, output.InternalError, fmt.Sprintf("loading board: %s", err.Error()), nil)
-				return nil
-			}
-			return fmt.Errorf("loading board: %w", err)
-		}
 		parent := b.FindByID(parentID)
 		if parent == nil {
 			if JSONEnabled() {
@@ -144,6 +160,59 @@ func createElement(elemType board.ElementType, name, description, parentID strin
 		parentDir = parent.Path
 	}
 
+	// Look for existing elements that say the same thing
+	duplicates := findDuplicates(b, elemType, name, description)
+	if len(duplicates) > 0 && createNoDuplicate {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.Duplicate,
+				fmt.Sprintf("%s %q looks like a duplicate of %s", elemType, name, duplicates[0].ID),
+				map[string]interface{}{
+					"candidates": duplicates,
+				})
+			return nil
+		}
+		return fmt.Errorf("%s %q looks like a duplicate of:\n  %s", elemType, name, formatDuplicates(duplicates, "\n  "))
+	}
+
+	status := b.Flow(elemType).Initial
+	id, elemPath, err := writeNewElement(elemType, status, name, description, parentDir)
+	if err != nil {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.InternalError, err.Error(), nil)
+			return nil
+		}
+		return err
+	}
+
+	if JSONEnabled() {
+		// Compute relative path from board root
+		relPath := computeRelativePath(boardDir, elemPath)
+
+		resp := CreateResponse{
+			Created: CreatedElement{
+				ID:     id,
+				Type:   string(elemType),
+				Name:   name,
+				Status: string(status),
+				Parent: parentID,
+				Path:   relPath,
+			},
+			PossibleDuplicates: duplicates,
+		}
+		output.PrintJSON(os.Stdout, resp)
+	} else {
+		fmt.Printf("Created %s: %s\n", id, name)
+		fmt.Printf("  Path: %s\n", elemPath)
+		if len(duplicates) > 0 {
+			fmt.Fprintf(os.Stderr, "warning: possible duplicate of:\n  %s\n", formatDuplicates(duplicates, "\n  "))
+		}
+	}
+	return nil
+}
+
+// writeNewElement creates the directory, README.md and progress.md of a new
+// element under parentDir with the given status and returns its ID and path.
+func writeNewElement(elemType board.ElementType, status board.Status, name, description, parentDir string) (string, string, error) {
 	// Generate distributed ID (YYMMDD-xxxxxx format)
 	id := board.GenerateID(elemType)
 
@@ -153,11 +222,7 @@ func createElement(elemType board.ElementType, name, description, parentID strin
 	elemPath := filepath.Join(parentDir, dirName)
 
 	if err := os.MkdirAll(elemPath, 0755); err != nil {
-		if JSONEnabled() {
-			output.PrintError(os.Stderr, output.InternalError, fmt.Sprintf("creating directory: %s", err.Error()), nil)
-			return nil
-		}
-		return fmt.Errorf("creating directory: %w", err)
+		return "", "", fmt.Errorf("creating directory: %w", err)
 	}
 
 	// Render and write README.md
@@ -167,65 +232,64 @@ func createElement(elemType board.ElementType, name, description, parentID strin
 		Description: description,
 	})
 	if err != nil {
-		if JSONEnabled() {
-			output.PrintError(os.Stderr, output.InternalError, fmt.Sprintf("rendering readme template: %s", err.Error()), nil)
-			return nil
-		}
-		return fmt.Errorf("rendering readme template: %w", err)
+		return "", "", fmt.Errorf("rendering readme template: %w", err)
 	}
 	if err := os.WriteFile(filepath.Join(elemPath, "README.md"), []byte(readmeContent), 0644); err != nil {
-		if JSONEnabled() {
-			output.PrintError(os.Stderr, output.InternalError, fmt.Sprintf("writing README.md: %s", err.Error()), nil)
-			return nil
-		}
-		return fmt.Errorf("writing README.md: %w", err)
+		return "", "", fmt.Errorf("writing README.md: %w", err)
 	}
 
 	// Render and write progress.md
 	progressContent, err := templates.RenderProgress(string(elemType))
 	if err != nil {
-		if JSONEnabled() {
-			output.PrintError(os.Stderr, output.InternalError, fmt.Sprintf("rendering progress template: %s", err.Error()), nil)
-			return nil
-		}
-		return fmt.Errorf("rendering progress template: %w", err)
+		return "", "", fmt.Errorf("rendering progress template: %w", err)
 	}
 	if err := os.WriteFile(filepath.Join(elemPath, "progress.md"), []byte(progressContent), 0644); err != nil {
-		if JSONEnabled() {
-			output.PrintError(os.Stderr, output.InternalError, fmt.Sprintf("writing progress.md: %s", err.Error()), nil)
-			return nil
-		}
-		return fmt.Errorf("writing progress.md: %w", err)
+		return "", "", fmt.Errorf("writing progress.md: %w", err)
 	}
 
 	// Set CreatedAt timestamp
 	progressPath := filepath.Join(elemPath, "progress.md")
 	pd, err := board.ParseProgressFile(progressPath)
 	if err == nil {
+		pd.Status = status
 		pd.CreatedAt = time.Now().UTC()
 		board.WriteProgressFile(progressPath, pd)
 	}
+	return id, elemPath, nil
+}
 
-	if JSONEnabled() {
-		// Compute relative path from board root
-		relPath := computeRelativePath(boardDir, elemPath)
-
-		resp := CreateResponse{
-			Created: CreatedElement{
-				ID:     id,
-				Type:   string(elemType),
-				Name:   name,
-				Status: string(board.StatusBacklog),
-				Parent: parentID,
-				Path:   relPath,
-			},
+// findDuplicates returns open elements of the same type whose name and
+// description resemble the ones given. Closed elements are ignored.
+func findDuplicates(b *board.Board, elemType board.ElementType, name, description string) []DuplicateCandidate {
+	var pool []*board.Element
+	for _, e := range b.FindByType(elemType) {
+		if b.Workflow.Category(e) != board.CategoryClosed {
+			pool = append(pool, e)
 		}
-		output.PrintJSON(os.Stdout, resp)
-	} else {
-		fmt.Printf("Created %s: %s\n", id, name)
-		fmt.Printf("  Path: %s\n", elemPath)
 	}
-	return nil
+	matches := dedupe.FindSimilar(pool, dedupe.Text{Name: name, Description: description}, dedupe.DefaultThreshold)
+	var candidates []DuplicateCandidate
+	for _, m := range matches {
+		candidates = append(candidates, DuplicateCandidate{
+			ID:    m.Element.ID(),
+			Name:  dedupe.ElementText(m.Element).Name,
+			Score: roundScore(m.Score),
+		})
+	}
+	return candidates
+}
+
+func formatDuplicates(candidates []DuplicateCandidate, sep string) string {
+	lines := make([]string, len(candidates))
+	for i, c := range candidates {
+		lines[i] = fmt.Sprintf("%s %s (%.0f%% similar)", c.ID, c.Name, c.Score*100)
+	}
+	return strings.Join(lines, sep)
+}
+
+// roundScore keeps similarity scores readable in JSON output.
+func roundScore(score float64) float64 {
+	return math.Round(score*100) / 100
 }
 
 // computeRelativePath computes a hierarchical path like "EPIC-X/STORY-Y/TASK-Z"
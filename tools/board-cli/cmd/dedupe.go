@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/aagrigore/task-board/internal/board"
+	"github.com/aagrigore/task-board/internal/dedupe"
+	"github.com/aagrigore/task-board/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// DedupeResponse is the JSON response for the dedupe report
+type DedupeResponse struct {
+	Clusters  []DuplicateCluster `json:"clusters"`
+	Count     int                `json:"count"`
+	Threshold float64            `json:"threshold"`
+}
+
+// DuplicateCluster is a group of likely duplicate elements
+type DuplicateCluster struct {
+	Elements []DuplicateCandidate `json:"elements"`
+	Pairs    []DuplicatePair      `json:"pairs"`
+}
+
+// DuplicatePair is two similar elements within a cluster
+type DuplicatePair struct {
+	A     string  `json:"a"`
+	B     string  `json:"b"`
+	Score float64 `json:"score"`
+}
+
+var dedupeCmd = &cobra.Command{
+	Use:   "dedupe",
+	Short: "Report likely duplicate elements",
+	Long: `List clusters of elements of the same type whose names and descriptions
+are similar enough to be likely duplicates. Closed elements are skipped
+unless --include-closed is given.
+
+Fold a duplicate into the element to keep with 'task-board dedupe merge'.`,
+	Args: cobra.NoArgs,
+	RunE: runDedupe,
+}
+
+var dedupeMergeCmd = &cobra.Command{
+	Use:   "merge <SOURCE-ID> <TARGET-ID>",
+	Short: "Fold one element into another and close it",
+	Long: `Same as 'task-board merge': fold a duplicate reported by dedupe into
+the element to keep.`,
+	Example: `  task-board dedupe merge TASK-260101-bbbbbb TASK-260101-aaaaaa`,
+	Args:    cobra.ExactArgs(2),
+	RunE:    recorded(runMerge),
+}
+
+var (
+	dedupeType          string
+	dedupeThreshold     float64
+	dedupeIncludeClosed bool
+)
+
+func init() {
+	rootCmd.AddCommand(dedupeCmd)
+	dedupeCmd.AddCommand(dedupeMergeCmd)
+	dedupeCmd.Flags().StringVar(&dedupeType, "type", "", "Only compare elements of this type (epic, story, task, bug)")
+	dedupeCmd.Flags().Float64Var(&dedupeThreshold, "threshold", dedupe.DefaultThreshold, "Minimum similarity (0-1) to report")
+	dedupeCmd.Flags().BoolVar(&dedupeIncludeClosed, "include-closed", false, "Also compare closed elements")
+}
+
+func runDedupe(cmd *cobra.Command, args []string) error {
+	if dedupeThreshold <= 0 || dedupeThreshold > 1 {
+		msg := fmt.Sprintf("invalid threshold %v: must be in (0, 1]", dedupeThreshold)
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.ValidationError, msg, map[string]interface{}{"threshold": dedupeThreshold})
+			return nil
+		}
+		return fmt.Errorf("%s", msg)
+	}
+
+	b, err := board.Load(boardDir)
+	if err != nil {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.InternalError, fmt.Sprintf("loading board: %v", err), nil)
+			return nil
+		}
+		return fmt.Errorf("loading board: %w", err)
+	}
+
+	elements := b.Elements
+	if dedupeType != "" {
+		elemType, err := board.ParseElementType(dedupeType)
+		if err != nil {
+			if JSONEnabled() {
+				output.PrintError(os.Stderr, output.ValidationError, err.Error(), nil)
+				return nil
+			}
+			return err
+		}
+		elements = b.FindByType(elemType)
+	}
+	if !dedupeIncludeClosed {
+		var open []*board.Element
+		for _, e := range elements {
+			if b.Workflow.Category(e) != board.CategoryClosed {
+				open = append(open, e)
+			}
+		}
+		elements = open
+	}
+
+	clusters := dedupe.Clusters(elements, dedupeThreshold)
+
+	if JSONEnabled() {
+		response := DedupeResponse{
+			Clusters:  make([]DuplicateCluster, 0, len(clusters)),
+			Count:     len(clusters),
+			Threshold: dedupeThreshold,
+		}
+		for _, c := range clusters {
+			dc := DuplicateCluster{}
+			for _, e := range c.Elements {
+				dc.Elements = append(dc.Elements, DuplicateCandidate{ID: e.ID(), Name: dedupe.ElementText(e).Name})
+			}
+			for _, p := range c.Pairs {
+				dc.Pairs = append(dc.Pairs, DuplicatePair{A: p.A.ID(), B: p.B.ID(), Score: roundScore(p.Score)})
+			}
+			response.Clusters = append(response.Clusters, dc)
+		}
+		return output.PrintJSON(os.Stdout, response)
+	}
+
+	if len(clusters) == 0 {
+		fmt.Println("No likely duplicates found.")
+		return nil
+	}
+
+	for i, c := range clusters {
+		fmt.Printf("Cluster %d (best match %.0f%%):\n", i+1, c.Pairs[0].Score*100)
+		for _, e := range c.Elements {
+			fmt.Printf("  %s %s %s%s%s\n", e.ID(), dedupe.ElementText(e).Name,
+				output.Gray, b.Ancestry(e), output.Reset)
+		}
+	}
+	fmt.Printf("\n%d cluster(s). Fold a duplicate with: task-board dedupe merge <SOURCE-ID> <TARGET-ID>\n", len(clusters))
+	return nil
+}
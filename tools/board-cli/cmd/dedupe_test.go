@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aagrigore/task-board/internal/board"
+)
+
+func TestCreateReportsPossibleDuplicates(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	jsonOutput = true
+	defer func() { jsonOutput = false }()
+
+	createName = "Interface"
+	createDescription = "Define interface"
+	createStoryFlag = testStory2ID
+	defer func() { createDescription = "" }()
+
+	out := captureOutput(t, func() {
+		if err := runCreateTask(createTaskCmd, nil); err != nil {
+			t.Fatalf("runCreateTask: %v", err)
+		}
+	})
+
+	var resp CreateResponse
+	if err := json.Unmarshal([]byte(out), &resp); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if resp.Created.ID == "" {
+		t.Fatal("element should still be created")
+	}
+	if len(resp.PossibleDuplicates) != 1 || resp.PossibleDuplicates[0].ID != testTask1ID {
+		t.Errorf("expected %s as possible duplicate, got %+v", testTask1ID, resp.PossibleDuplicates)
+	}
+}
+
+func TestCreateNoDuplicatesRefuses(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+
+	createName = "Interface"
+	createDescription = ""
+	createStoryFlag = testStory2ID
+	createNoDuplicate = true
+	defer func() { createNoDuplicate = false }()
+
+	err := runCreateTask(createTaskCmd, nil)
+	if err == nil || !strings.Contains(err.Error(), testTask1ID) {
+		t.Fatalf("expected duplicate error naming %s, got %v", testTask1ID, err)
+	}
+
+	story2Dir := filepath.Join(bd, testEpic1ID+"_recording", testStory2ID+"_amplitude")
+	if dir := findCreatedDir(story2Dir, "TASK"); dir != "" {
+		t.Errorf("no task should be created, found %s", dir)
+	}
+
+	// Distinct names are still allowed
+	createName = "Spectrum analyzer"
+	captureOutput(t, func() {
+		if err := runCreateTask(createTaskCmd, nil); err != nil {
+			t.Fatalf("distinct task should be created: %v", err)
+		}
+	})
+}
+
+func TestDedupeReport(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+
+	createName = "Interface"
+	createDescription = "Define the interface"
+	defer func() { createDescription = "" }()
+	createStoryFlag = testStory2ID
+	var dupID string
+	captureOutput(t, func() {
+		runCreateTask(createTaskCmd, nil)
+	})
+	b, _ := board.Load(bd)
+	for _, e := range b.Children(b.FindByID(testStory2ID)) {
+		dupID = e.ID()
+	}
+
+	jsonOutput = true
+	defer func() { jsonOutput = false }()
+	out := captureOutput(t, func() {
+		if err := runDedupe(dedupeCmd, nil); err != nil {
+			t.Fatalf("runDedupe: %v", err)
+		}
+	})
+
+	var resp DedupeResponse
+	if err := json.Unmarshal([]byte(out), &resp); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if resp.Count != 1 {
+		t.Fatalf("expected 1 cluster, got %d: %s", resp.Count, out)
+	}
+	ids := []string{resp.Clusters[0].Elements[0].ID, resp.Clusters[0].Elements[1].ID}
+	if !strings.Contains(strings.Join(ids, " "), testTask1ID) || !strings.Contains(strings.Join(ids, " "), dupID) {
+		t.Errorf("cluster should hold %s and %s, got %v", testTask1ID, dupID, ids)
+	}
+}
@@ -6,12 +6,14 @@ import (
 
 	"github.com/aagrigore/task-board/internal/board"
 	"github.com/aagrigore/task-board/internal/output"
+	"github.com/aagrigore/task-board/internal/trash"
 	"github.com/spf13/cobra"
 )
 
 // DeleteResponse represents the JSON output for delete command
 type DeleteResponse struct {
 	Deleted DeletedElement `json:"deleted"`
+	Trash   string         `json:"trash"`
 	Message string         `json:"message"`
 }
 
@@ -24,9 +26,9 @@ type DeletedElement struct {
 
 var deleteCmd = &cobra.Command{
 	Use:   "delete <ID>",
-	Short: "Delete a board element",
+	Short: "Delete a board element (moves it to the trash)",
 	Args:  cobra.ExactArgs(1),
-	RunE:  runDelete,
+	RunE:  recorded(runDelete),
 }
 
 var deleteForce bool
@@ -83,9 +85,24 @@ func runDelete(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Clean up dependency references in other elements
+	// The element and its descendants go to the trash together
+	removed := map[string]bool{elem.ID(): true}
+	contains := []string{elem.ID()}
+	for _, e := range b.Elements {
+		for p := b.ParentOf(e); p != nil; p = b.ParentOf(p) {
+			if p.ID() == elem.ID() {
+				removed[e.ID()] = true
+				contains = append(contains, e.ID())
+				break
+			}
+		}
+	}
+
+	// Clean up dependency references in other elements, remembering them
+	// so trash restore can put them back
+	var stripped []trash.Ref
 	for _, other := range b.Elements {
-		if other.ID() == elem.ID() {
+		if removed[other.ID()] {
 			continue
 		}
 
@@ -99,8 +116,9 @@ func runDelete(cmd *cobra.Command, args []string) error {
 		// Remove from BlockedBy
 		var newBlockedBy []string
 		for _, bid := range pd.BlockedBy {
-			if bid == elem.ID() {
+			if removed[bid] {
 				changed = true
+				stripped = append(stripped, trash.Ref{Element: other.ID(), Target: bid, BlockedBy: true})
 				continue
 			}
 			newBlockedBy = append(newBlockedBy, bid)
@@ -110,21 +128,41 @@ func runDelete(cmd *cobra.Command, args []string) error {
 		// Remove from Blocks
 		var newBlocks []string
 		for _, bid := range pd.Blocks {
-			if bid == elem.ID() {
+			if removed[bid] {
 				changed = true
+				stripped = append(stripped, trash.Ref{Element: other.ID(), Target: bid})
 				continue
 			}
 			newBlocks = append(newBlocks, bid)
 		}
 		pd.Blocks = newBlocks
 
+		// Remove typed links
+		var newLinks []board.Link
+		for _, l := range pd.Links {
+			if removed[l.Target] {
+				changed = true
+				stripped = append(stripped, trash.Ref{Element: other.ID(), Target: l.Target, LinkType: string(l.Type)})
+				continue
+			}
+			newLinks = append(newLinks, l)
+		}
+		pd.Links = newLinks
+
 		if changed {
 			board.WriteProgressFile(other.ProgressPath(), pd)
 		}
 	}
 
-	// Delete the directory
-	if err := os.RemoveAll(elem.Path); err != nil {
+	// Move the directory to the trash
+	item := &trash.Item{
+		ID:       elem.ID(),
+		Type:     string(elem.Type),
+		Title:    elem.Title,
+		Contains: contains,
+		Stripped: stripped,
+	}
+	if err := trash.Put(boardDir, elem.Path, item); err != nil {
 		if JSONEnabled() {
 			output.PrintError(os.Stderr, output.InternalError, fmt.Sprintf("deleting %s: %v", id, err), nil)
 			return nil
@@ -140,11 +178,12 @@ func runDelete(cmd *cobra.Command, args []string) error {
 				Type: string(elem.Type),
 				Name: elemName,
 			},
-			Message: "Element deleted successfully",
+			Trash:   item.Key,
+			Message: "Element moved to trash",
 		}
 		return output.PrintJSON(os.Stdout, response)
 	}
 
-	fmt.Printf("Deleted %s\n", id)
+	fmt.Printf("Deleted %s (restore with: task-board trash restore %s)\n", id, elem.ID())
 	return nil
 }
@@ -3,9 +3,11 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/aagrigore/task-board/internal/board"
 	"github.com/aagrigore/task-board/internal/output"
+	"github.com/aagrigore/task-board/internal/plan"
 	"github.com/spf13/cobra"
 )
 
@@ -22,24 +24,116 @@ type LinkUpdate struct {
 	Relation string `json:"relation"`
 }
 
+// LinkDryRunResponse is the JSON response for link --dry-run
+type LinkDryRunResponse struct {
+	Source string         `json:"source"`
+	Target string         `json:"target"`
+	DryRun bool           `json:"dryRun"`
+	Edges  []LinkEdgeJSON `json:"edges"`
+}
+
+// LinkEdgeJSON is one blocked-by edge link would record
+type LinkEdgeJSON struct {
+	Blocked   string `json:"blocked"`
+	Blocker   string `json:"blocker"`
+	Escalated bool   `json:"escalated"`
+	Exists    bool   `json:"exists"`
+}
+
 var linkCmd = &cobra.Command{
 	Use:   "link <ID>",
 	Short: "Link element dependencies",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runLink,
+	Long: `Record that an element is blocked by another. When the two have different
+parents, the dependency is escalated to the parents (story, then epic).
+
+The link is refused with the offending path if it, or any edge it would
+escalate, closes a dependency cycle. Use --dry-run to preview the edges.
+
+--type records another kind of link with --to, on both elements:
+
+  relates-to    related work, both ways
+  duplicates    the element repeats the target (target: duplicated-by)
+  caused-by     e.g. a bug caused by a task (target: causes)
+  follows-up    work that continues the target (target: followed-up-by)
+  parent-of     a parent across the hierarchy (target: child-of)
+
+Only blocked-by links affect the plan; the others are shown by show, the
+TUI and plan graphs.
+
+  task-board link BUG-260105-abc123 --type caused-by --to TASK-260101-def456`,
+	Args: cobra.ExactArgs(1),
+	RunE: recorded(runLink),
 }
 
-var linkBlockedBy string
+var (
+	linkBlockedBy string
+	linkDryRun    bool
+	linkType      string
+	linkTo        string
+)
 
 func init() {
 	rootCmd.AddCommand(linkCmd)
-	linkCmd.Flags().StringVar(&linkBlockedBy, "blocked-by", "", "ID of blocking element (required)")
-	linkCmd.MarkFlagRequired("blocked-by")
+	linkCmd.Flags().StringVar(&linkBlockedBy, "blocked-by", "", "ID of blocking element")
+	linkCmd.Flags().StringVar(&linkType, "type", "blocked-by", "Link type: blocked-by, relates-to, duplicates, caused-by, follows-up, parent-of")
+	linkCmd.Flags().StringVar(&linkTo, "to", "", "ID of the element to link to")
+	linkCmd.Flags().BoolVar(&linkDryRun, "dry-run", false, "Show the edges that would be written, including escalated ones, without writing")
+}
+
+// linkFlags resolves --blocked-by, --type and --to into a link type (empty
+// for blocked-by) and the target's ID.
+func linkFlags(blockedBy, typ, to string) (board.LinkType, string, error) {
+	if typ == "" || strings.EqualFold(typ, "blocked-by") {
+		switch {
+		case blockedBy != "" && to != "" && blockedBy != to:
+			return "", "", fmt.Errorf("give the blocker once, with --blocked-by or --to")
+		case blockedBy != "":
+			return "", blockedBy, nil
+		case to != "":
+			return "", to, nil
+		}
+		return "", "", fmt.Errorf("--blocked-by (or --type with --to) is required")
+	}
+	t, err := board.ParseLinkType(typ)
+	if err != nil {
+		return "", "", err
+	}
+	if blockedBy != "" {
+		return "", "", fmt.Errorf("--blocked-by records blocked-by links; use --to with --type %s", t)
+	}
+	if to == "" {
+		return "", "", fmt.Errorf("--to is required with --type %s", t)
+	}
+	return t, to, nil
 }
 
 func runLink(cmd *cobra.Command, args []string) error {
 	id := args[0]
 
+	t, targetID, err := linkFlags(linkBlockedBy, linkType, linkTo)
+	if err != nil {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.ValidationError, err.Error(), nil)
+			return nil
+		}
+		return err
+	}
+	if t != "" {
+		if linkDryRun {
+			err := fmt.Errorf("--dry-run previews blocked-by links only")
+			if JSONEnabled() {
+				output.PrintError(os.Stderr, output.ValidationError, err.Error(), nil)
+				return nil
+			}
+			return err
+		}
+		return runTypedLink(id, targetID, t, true)
+	}
+
+	if WorkspaceEnabled() {
+		return runWorkspaceLink(id, targetID)
+	}
+
 	b, err := board.Load(boardDir)
 	if err != nil {
 		if JSONEnabled() {
@@ -49,6 +143,12 @@ func runLink(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("loading board: %w", err)
 	}
 
+	return linkInBoard(b, id, targetID)
+}
+
+// linkInBoard records `id` blocked by `blockedByID` within a single board
+// and escalates the dependency up the hierarchy.
+func linkInBoard(b *board.Board, id, blockedByID string) error {
 	elem := b.FindByID(id)
 	if elem == nil {
 		if JSONEnabled() {
@@ -60,17 +160,34 @@ func runLink(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("element %s not found", id)
 	}
 
-	blocker := b.FindByID(linkBlockedBy)
+	blocker := b.FindByID(blockedByID)
 	if blocker == nil {
 		if JSONEnabled() {
-			output.PrintError(os.Stderr, output.NotFound, fmt.Sprintf("blocker %s not found", linkBlockedBy), map[string]interface{}{
-				"id": linkBlockedBy,
+			output.PrintError(os.Stderr, output.NotFound, fmt.Sprintf("blocker %s not found", blockedByID), map[string]interface{}{
+				"id": blockedByID,
 			})
 			return nil
 		}
-		return fmt.Errorf("blocker %s not found", linkBlockedBy)
+		return fmt.Errorf("blocker %s not found", blockedByID)
 	}
 
+	// --- Refuse cycles at any level before writing ---
+	edges := plan.LinkEdges(b, elem, blocker)
+	if cycle := plan.FindLinkCycle(b, edges); cycle != nil {
+		return linkCycleError(id, blocker.ID(), cycle)
+	}
+	if linkDryRun {
+		return printLinkDryRun(elem.ID(), blocker.ID(), edges)
+	}
+	return writeLink(b, elem, blocker)
+}
+
+// writeLink records that elem is blocked by blocker, both ways, and
+// escalates the dependency to their parents. The caller has already
+// refused cycles.
+func writeLink(b *board.Board, elem, blocker *board.Element) error {
+	id := elem.ID()
+
 	// --- Update blocked element: add blockedBy ---
 	pd, err := board.ParseProgressFile(elem.ProgressPath())
 	if err != nil {
@@ -246,8 +363,361 @@ func escalateDependency(b *board.Board, elem, blocker *board.Element) error {
 		}
 	}
 
-	fmt.Printf("  ↳ escalated: %s → blocked by %s\n", elemParent.ID(), blockerParent.ID())
+	infof("  ↳ escalated: %s → blocked by %s\n", elemParent.ID(), blockerParent.ID())
 
 	// Recurse up
 	return escalateDependency(b, elemParent, blockerParent)
 }
+
+// runWorkspaceLink resolves both IDs through the workspace and refuses links
+// that would close a cycle anywhere in it. Otherwise links inside one board
+// behave exactly like a plain link; links across boards store qualified IDs
+// on both sides and are not escalated, since each board keeps its own hierarchy.
+func runWorkspaceLink(id, blockedByID string) error {
+	ws, err := board.LoadWorkspace(workspaceFile)
+	if err != nil {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.InternalError, fmt.Sprintf("loading workspace: %v", err), nil)
+			return nil
+		}
+		return fmt.Errorf("loading workspace: %w", err)
+	}
+
+	elem, elemBoard := ws.FindByID(id)
+	if elem == nil {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.NotFound, fmt.Sprintf("element %s not found", id), map[string]interface{}{
+				"id": id,
+			})
+			return nil
+		}
+		return fmt.Errorf("element %s not found", id)
+	}
+
+	blocker, blockerBoard := ws.FindByID(blockedByID)
+	if blocker == nil {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.NotFound, fmt.Sprintf("blocker %s not found", blockedByID), map[string]interface{}{
+				"id": blockedByID,
+			})
+			return nil
+		}
+		return fmt.Errorf("blocker %s not found", blockedByID)
+	}
+
+	source := board.QualifyID(elemBoard.Name, elem.ID())
+	target := board.QualifyID(blockerBoard.Name, blocker.ID())
+
+	// Cycles are refused across the whole workspace: a link inside one board
+	// can close one through another board
+	merged := ws.Merged()
+	var edges []plan.Edge
+	if elemBoard == blockerBoard {
+		for _, e := range plan.LinkEdges(elemBoard.Board, elem, blocker) {
+			e.From = board.QualifyID(elemBoard.Name, e.From)
+			e.To = board.QualifyID(elemBoard.Name, e.To)
+			edges = append(edges, e)
+		}
+	} else {
+		// Cross-board links are not escalated, so only the direct edge is checked
+		edges = []plan.Edge{{
+			From:   source,
+			To:     target,
+			Exists: containsRef(merged.FindByID(source).BlockedBy, target),
+		}}
+	}
+	if cycle := plan.FindLinkCycle(merged, edges); cycle != nil {
+		return linkCycleError(source, target, cycle)
+	}
+	if linkDryRun {
+		return printLinkDryRun(source, target, edges)
+	}
+	if elemBoard == blockerBoard {
+		return writeLink(elemBoard.Board, elem, blocker)
+	}
+
+	if err := addProgressRef(elem, ws.Ref(elemBoard, blockerBoard, blocker), true); err != nil {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.InternalError, err.Error(), nil)
+			return nil
+		}
+		return err
+	}
+	if err := addProgressRef(blocker, ws.Ref(blockerBoard, elemBoard, elem), false); err != nil {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.InternalError, err.Error(), nil)
+			return nil
+		}
+		return err
+	}
+
+	if JSONEnabled() {
+		response := LinkResponse{
+			Updated: LinkUpdate{
+				Source:   source,
+				Target:   target,
+				Relation: "blocked-by",
+			},
+			Message: fmt.Sprintf("%s now blocked by %s", source, target),
+		}
+		return output.PrintJSON(os.Stdout, response)
+	}
+
+	fmt.Printf("%s → blocked by %s\n", source, target)
+	fmt.Printf("%s → blocks %s\n", target, source)
+	return nil
+}
+
+// addProgressRef appends ref to the element's Blocked By (blockedBy=true)
+// or Blocks list, skipping duplicates.
+func addProgressRef(e *board.Element, ref string, blockedBy bool) error {
+	pd, err := board.ParseProgressFile(e.ProgressPath())
+	if err != nil {
+		return fmt.Errorf("reading progress for %s: %w", e.ID(), err)
+	}
+	list := &pd.Blocks
+	if blockedBy {
+		list = &pd.BlockedBy
+	}
+	for _, existing := range *list {
+		if existing == ref {
+			return nil
+		}
+	}
+	*list = append(*list, ref)
+	if err := board.WriteProgressFile(e.ProgressPath(), pd); err != nil {
+		return fmt.Errorf("writing progress for %s: %w", e.ID(), err)
+	}
+	return nil
+}
+
+// linkCycleError reports a link refused because it would close a cycle.
+func linkCycleError(id, blockedByID string, cycle []string) error {
+	path := strings.Join(cycle, " -> ")
+	if JSONEnabled() {
+		output.PrintError(os.Stderr, output.CycleDetected,
+			fmt.Sprintf("Linking %s to %s would create a dependency cycle: %s", id, blockedByID, path),
+			map[string]interface{}{
+				"source": id,
+				"target": blockedByID,
+				"cycle":  cycle,
+			})
+		return nil
+	}
+	return fmt.Errorf("linking %s to %s would create a dependency cycle: %s", id, blockedByID, path)
+}
+
+// printLinkDryRun lists the edges a link would write without writing them.
+func printLinkDryRun(source, target string, edges []plan.Edge) error {
+	if JSONEnabled() {
+		response := LinkDryRunResponse{Source: source, Target: target, DryRun: true}
+		for _, e := range edges {
+			response.Edges = append(response.Edges, LinkEdgeJSON{
+				Blocked:   e.From,
+				Blocker:   e.To,
+				Escalated: e.Escalated,
+				Exists:    e.Exists,
+			})
+		}
+		return output.PrintJSON(os.Stdout, response)
+	}
+
+	fmt.Println("Dry run — nothing written:")
+	for _, e := range edges {
+		prefix := "  "
+		if e.Escalated {
+			prefix = "  ↳ escalated: "
+		}
+		suffix := ""
+		if e.Exists {
+			suffix = " (already linked)"
+		}
+		fmt.Printf("%s%s → blocked by %s%s\n", prefix, e.From, e.To, suffix)
+	}
+	return nil
+}
+
+func containsRef(refs []string, ref string) bool {
+	for _, existing := range refs {
+		if existing == ref {
+			return true
+		}
+	}
+	return false
+}
+
+// errLinkCycle marks a link refused because it would close a cycle.
+type errLinkCycle struct {
+	cycle []string
+}
+
+func (e *errLinkCycle) Error() string {
+	return "dependency cycle: " + strings.Join(e.cycle, " -> ")
+}
+
+// writeLinkEdges records every edge from plan.LinkEdges that is not on the
+// board yet, on both ends, keeping the in-memory elements in step so later
+// cycle checks see them. Unlike linkInBoard it prints nothing.
+func writeLinkEdges(b *board.Board, edges []plan.Edge) error {
+	for _, edge := range edges {
+		if edge.Exists {
+			continue
+		}
+		from, to := b.FindByID(edge.From), b.FindByID(edge.To)
+		if from == nil || to == nil {
+			return fmt.Errorf("linking %s to %s: element not found", edge.From, edge.To)
+		}
+		if err := addProgressRef(from, to.ID(), true); err != nil {
+			return err
+		}
+		if err := addProgressRef(to, from.ID(), false); err != nil {
+			return err
+		}
+		from.BlockedBy = appendUnique(from.BlockedBy, to.ID())
+		to.Blocks = appendUnique(to.Blocks, from.ID())
+	}
+	return nil
+}
+
+// typedLinkEnds are the two elements of a typed link and how each names
+// the other: plain IDs within a board, qualified ones across boards.
+type typedLinkEnds struct {
+	elem, target        *board.Element
+	elemRef, targetRef  string // stored on target and on elem
+	source, destination string // for messages
+}
+
+// resolveTypedLink finds both ends, through the workspace when one is set.
+func resolveTypedLink(id, targetID string) (*typedLinkEnds, output.ErrorCode, error) {
+	if WorkspaceEnabled() {
+		ws, err := board.LoadWorkspace(workspaceFile)
+		if err != nil {
+			return nil, output.InternalError, fmt.Errorf("loading workspace: %w", err)
+		}
+		elem, elemBoard := ws.FindByID(id)
+		if elem == nil {
+			return nil, output.NotFound, fmt.Errorf("element %s not found", id)
+		}
+		target, targetBoard := ws.FindByID(targetID)
+		if target == nil {
+			return nil, output.NotFound, fmt.Errorf("element %s not found", targetID)
+		}
+		ends := &typedLinkEnds{
+			elem:        elem,
+			target:      target,
+			elemRef:     ws.Ref(targetBoard, elemBoard, elem),
+			targetRef:   ws.Ref(elemBoard, targetBoard, target),
+			source:      elem.ID(),
+			destination: target.ID(),
+		}
+		if elemBoard != targetBoard {
+			ends.source = board.QualifyID(elemBoard.Name, elem.ID())
+			ends.destination = board.QualifyID(targetBoard.Name, target.ID())
+		}
+		return ends, "", nil
+	}
+
+	b, err := board.Load(boardDir)
+	if err != nil {
+		return nil, output.InternalError, fmt.Errorf("loading board: %w", err)
+	}
+	elem := b.FindByID(id)
+	if elem == nil {
+		return nil, output.NotFound, fmt.Errorf("element %s not found", id)
+	}
+	target := b.FindByID(targetID)
+	if target == nil {
+		return nil, output.NotFound, fmt.Errorf("element %s not found", targetID)
+	}
+	return &typedLinkEnds{
+		elem:        elem,
+		target:      target,
+		elemRef:     elem.ID(),
+		targetRef:   target.ID(),
+		source:      elem.ID(),
+		destination: target.ID(),
+	}, "", nil
+}
+
+// runTypedLink adds (add=true) or removes a typed link on both ends.
+func runTypedLink(id, targetID string, t board.LinkType, add bool) error {
+	fail := func(code output.ErrorCode, err error) error {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, code, err.Error(), nil)
+			return nil
+		}
+		return err
+	}
+
+	ends, code, err := resolveTypedLink(id, targetID)
+	if err != nil {
+		return fail(code, err)
+	}
+	if ends.source == ends.destination {
+		return fail(output.ValidationError, fmt.Errorf("cannot link %s to itself", ends.source))
+	}
+
+	forward := board.Link{Type: t, Target: ends.targetRef}
+	backward := board.Link{Type: t.Inverse(), Target: ends.elemRef}
+	changed, err := updateLink(ends.elem, forward, add)
+	if err != nil {
+		return fail(output.InternalError, err)
+	}
+	if _, err := updateLink(ends.target, backward, add); err != nil {
+		return fail(output.InternalError, err)
+	}
+
+	var message string
+	switch {
+	case add && changed:
+		message = fmt.Sprintf("%s now %s %s", ends.source, t, ends.destination)
+	case add:
+		message = fmt.Sprintf("%s is already %s %s", ends.source, t, ends.destination)
+	case changed:
+		message = fmt.Sprintf("%s no longer %s %s", ends.source, t, ends.destination)
+	default:
+		return fail(output.ValidationError, fmt.Errorf("%s is not %s %s", ends.source, t, ends.destination))
+	}
+
+	if JSONEnabled() {
+		update := LinkUpdate{Source: ends.source, Target: ends.destination, Relation: string(t)}
+		if add {
+			return output.PrintJSON(os.Stdout, LinkResponse{Updated: update, Message: message})
+		}
+		return output.PrintJSON(os.Stdout, UnlinkResponse{Updated: UnlinkUpdate(update), Message: message})
+	}
+	if !changed {
+		fmt.Println(message)
+		return nil
+	}
+	verb := ""
+	if !add {
+		verb = "removed "
+	}
+	fmt.Printf("%s → %s%s %s\n", ends.source, verb, t, ends.destination)
+	fmt.Printf("%s → %s%s %s\n", ends.destination, verb, t.Inverse(), ends.source)
+	return nil
+}
+
+// updateLink adds or removes a link in the element's progress.md and
+// reports whether anything changed.
+func updateLink(e *board.Element, l board.Link, add bool) (bool, error) {
+	pd, err := board.ParseProgressFile(e.ProgressPath())
+	if err != nil {
+		return false, fmt.Errorf("reading progress for %s: %w", e.ID(), err)
+	}
+	var changed bool
+	if add {
+		changed = pd.AddLink(l)
+	} else {
+		changed = pd.RemoveLink(l)
+	}
+	if !changed {
+		return false, nil
+	}
+	if err := board.WriteProgressFile(e.ProgressPath(), pd); err != nil {
+		return false, fmt.Errorf("writing progress for %s: %w", e.ID(), err)
+	}
+	e.Links = pd.Links
+	return true, nil
+}
@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/aagrigore/task-board/internal/board"
@@ -158,3 +160,169 @@ func TestLinkSameParentNoEscalation(t *testing.T) {
 		}
 	}
 }
+
+func TestLinkRejectsCycle(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	linkBlockedBy = testTask2ID
+
+	// TASK-02 is already blocked by TASK-01
+	err := runLink(linkCmd, []string{testTask1ID})
+	if err == nil || !strings.Contains(err.Error(), testTask1ID+" -> "+testTask2ID+" -> "+testTask1ID) {
+		t.Fatalf("expected cycle error with path, got %v", err)
+	}
+
+	b, _ := board.Load(bd)
+	if task1 := b.FindByID(testTask1ID); len(task1.BlockedBy) != 0 {
+		t.Errorf("nothing should be written, TASK-01 blockedBy = %v", task1.BlockedBy)
+	}
+}
+
+func TestLinkRejectsEscalatedCycle(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+
+	// TASK-04 (STORY-03/EPIC-02) waits for TASK-01 (STORY-01/EPIC-01):
+	// escalates STORY-03 → STORY-01 and EPIC-02 → EPIC-01
+	linkBlockedBy = testTask1ID
+	captureOutput(t, func() {
+		if err := runLink(linkCmd, []string{testTask4ID}); err != nil {
+			t.Fatalf("runLink: %v", err)
+		}
+	})
+
+	// TASK-03 waiting for TASK-04 has no task-level cycle, but would
+	// escalate STORY-01 → STORY-03, closing a loop at story level
+	linkBlockedBy = testTask4ID
+	err := runLink(linkCmd, []string{testTask3ID})
+	if err == nil || !strings.Contains(err.Error(), "cycle") || !strings.Contains(err.Error(), testStory3ID) {
+		t.Fatalf("expected escalated cycle error, got %v", err)
+	}
+}
+
+func TestLinkCycleJSON(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	linkBlockedBy = testTask2ID
+	jsonOutput = true
+	defer func() { jsonOutput = false }()
+
+	stderr := captureStderr(t, func() {
+		if err := runLink(linkCmd, []string{testTask1ID}); err != nil {
+			t.Fatalf("runLink: %v", err)
+		}
+	})
+	var resp struct {
+		Error struct {
+			Code    string                 `json:"code"`
+			Details map[string]interface{} `json:"details"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal([]byte(stderr), &resp); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, stderr)
+	}
+	if resp.Error.Code != "CYCLE_DETECTED" {
+		t.Errorf("code = %s, want CYCLE_DETECTED", resp.Error.Code)
+	}
+	if cycle, ok := resp.Error.Details["cycle"].([]interface{}); !ok || len(cycle) != 3 {
+		t.Errorf("details.cycle = %v", resp.Error.Details["cycle"])
+	}
+}
+
+func TestLinkDryRun(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	linkBlockedBy = testTask1ID
+	linkDryRun = true
+	defer func() { linkDryRun = false }()
+	jsonOutput = true
+	defer func() { jsonOutput = false }()
+
+	out := captureOutput(t, func() {
+		if err := runLink(linkCmd, []string{testTask4ID}); err != nil {
+			t.Fatalf("runLink: %v", err)
+		}
+	})
+	var resp LinkDryRunResponse
+	if err := json.Unmarshal([]byte(out), &resp); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if !resp.DryRun || len(resp.Edges) != 3 {
+		t.Fatalf("expected direct + 2 escalated edges, got %+v", resp.Edges)
+	}
+	if resp.Edges[1].Blocked != testStory3ID || resp.Edges[1].Blocker != testStory1ID || !resp.Edges[1].Escalated {
+		t.Errorf("unexpected story edge %+v", resp.Edges[1])
+	}
+	if resp.Edges[2].Blocked != testEpic2ID || resp.Edges[2].Blocker != testEpic1ID {
+		t.Errorf("unexpected epic edge %+v", resp.Edges[2])
+	}
+
+	b, _ := board.Load(bd)
+	for _, id := range []string{testTask4ID, testStory3ID, testEpic2ID} {
+		if e := b.FindByID(id); len(e.BlockedBy) != 0 {
+			t.Errorf("dry run wrote %s blockedBy = %v", id, e.BlockedBy)
+		}
+	}
+}
+
+func TestTypedLinkAndUnlink(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	linkBlockedBy, unlinkBlockedBy = "", ""
+	linkType, linkTo = "caused-by", testTask1ID
+	unlinkType, unlinkTo = "caused-by", testTask1ID
+	t.Cleanup(func() {
+		linkType, linkTo = "blocked-by", ""
+		unlinkType, unlinkTo = "blocked-by", ""
+	})
+
+	out := captureOutput(t, func() {
+		if err := runLink(linkCmd, []string{testBug1ID}); err != nil {
+			t.Fatalf("runLink: %v", err)
+		}
+	})
+	if !strings.Contains(out, testTask1ID+" → causes "+testBug1ID) {
+		t.Errorf("output should show both ends, got %q", out)
+	}
+
+	b, _ := board.Load(bd)
+	bug, task1 := b.FindByID(testBug1ID), b.FindByID(testTask1ID)
+	if len(bug.Links) != 1 || bug.Links[0] != (board.Link{Type: board.LinkCausedBy, Target: testTask1ID}) {
+		t.Errorf("bug links = %+v", bug.Links)
+	}
+	if len(task1.Links) != 1 || task1.Links[0] != (board.Link{Type: board.LinkCauses, Target: testBug1ID}) {
+		t.Errorf("task links = %+v", task1.Links)
+	}
+	if len(bug.BlockedBy) != 0 {
+		t.Errorf("a typed link should not block, got %v", bug.BlockedBy)
+	}
+
+	captureOutput(t, func() {
+		if err := runUnlink(unlinkCmd, []string{testBug1ID}); err != nil {
+			t.Fatalf("runUnlink: %v", err)
+		}
+	})
+	b, _ = board.Load(bd)
+	if len(b.FindByID(testBug1ID).Links) != 0 || len(b.FindByID(testTask1ID).Links) != 0 {
+		t.Error("unlink should remove both ends")
+	}
+	if err := runUnlink(unlinkCmd, []string{testBug1ID}); err == nil {
+		t.Error("removing a missing link should fail")
+	}
+}
+
+func TestTypedLinkRejectsSelfAndBadType(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	linkBlockedBy = ""
+	t.Cleanup(func() { linkType, linkTo = "blocked-by", "" })
+
+	linkType, linkTo = "relates-to", testTask3ID
+	if err := runLink(linkCmd, []string{testTask3ID}); err == nil {
+		t.Error("self-link should fail")
+	}
+	linkType = "inspired-by"
+	if err := runLink(linkCmd, []string{testTask1ID}); err == nil {
+		t.Error("unknown link type should fail")
+	}
+}
@@ -4,23 +4,38 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/aagrigore/task-board/internal/board"
 	"github.com/aagrigore/task-board/internal/output"
+	"github.com/aagrigore/task-board/query"
 	"github.com/spf13/cobra"
 )
 
 var listCmd = &cobra.Command{
-	Use:   "list <epics|stories|tasks|bugs>",
+	Use:   "list [epics|stories|tasks|bugs]",
 	Short: "List board elements",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runList,
+	Long: `List board elements, optionally filtered by type, flags, a query or a saved view.
+
+Without a type, elements of every type are listed.
+
+Query examples:
+  task-board list tasks --query "status:development assignee:alice"
+  task-board list --query "(type:task OR type:bug) -status:done,closed"
+  task-board list --query "updated:>7d blocked:true"
+  task-board list --view mine
+
+See 'task-board view --help' for saving named queries.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runList,
 }
 
 var (
 	listStatus string
 	listEpic   string
 	listStory  string
+	listView   string
+	listQuery  string
 )
 
 func init() {
@@ -28,6 +43,8 @@ func init() {
 	listCmd.Flags().StringVar(&listStatus, "status", "", "Filter by status")
 	listCmd.Flags().StringVar(&listEpic, "epic", "", "Filter by epic ID")
 	listCmd.Flags().StringVar(&listStory, "story", "", "Filter by story ID")
+	listCmd.Flags().StringVar(&listView, "view", "", "Filter by a saved view (see 'view list')")
+	listCmd.Flags().StringVar(&listQuery, "query", "", "Filter by a query, e.g. \"status:development assignee:alice\"")
 }
 
 // ListResponse is the JSON response structure for list command
@@ -58,18 +75,28 @@ type ListFilters struct {
 	Story  string `json:"story,omitempty"`
 	Epic   string `json:"epic,omitempty"`
 	Status string `json:"status,omitempty"`
+	View   string `json:"view,omitempty"`
+	Query  string `json:"query,omitempty"`
+	// IncludeSnoozed is set by --include-snoozed
+	IncludeSnoozed bool `json:"includeSnoozed,omitempty"`
 }
 
 func runList(cmd *cobra.Command, args []string) error {
-	elemType, err := board.ParseElementType(args[0])
-	if err != nil {
-		if JSONEnabled() {
-			output.PrintError(os.Stderr, output.ValidationError, err.Error(), nil)
+	typeName := "all"
+	var elemType board.ElementType
+	if len(args) == 1 {
+		var err error
+		elemType, err = board.ParseElementType(args[0])
+		if err != nil {
+			if JSONEnabled() {
+				output.PrintError(os.Stderr, output.ValidationError, err.Error(), nil)
+			}
+			return err
 		}
-		return err
+		typeName = string(elemType)
 	}
 
-	b, err := board.Load(boardDir)
+	b, err := loadViewBoard()
 	if err != nil {
 		if JSONEnabled() {
 			output.PrintError(os.Stderr, output.InternalError, fmt.Sprintf("loading board: %v", err), nil)
@@ -77,11 +104,14 @@ func runList(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("loading board: %w", err)
 	}
 
-	elements := b.FindByType(elemType)
+	elements := b.Elements
+	if elemType != "" {
+		elements = b.FindByType(elemType)
+	}
 
 	// Apply filters
 	if listStatus != "" {
-		status, err := board.ParseStatus(listStatus)
+		status, err := b.Workflow.ParseAny(listStatus)
 		if err != nil {
 			if JSONEnabled() {
 				output.PrintError(os.Stderr, output.InvalidStatus, err.Error(), nil)
@@ -99,14 +129,25 @@ func runList(cmd *cobra.Command, args []string) error {
 		elements = board.FilterByParent(elements, listStory)
 	}
 
+	if listView != "" || listQuery != "" {
+		q, err := resolveListQuery()
+		if err != nil {
+			return err
+		}
+		elements = filterByQuery(b, elements, q)
+	}
+
+	elements, hidden := hideSnoozed(b, elements, time.Now())
+
 	// JSON output
 	if JSONEnabled() {
-		return printListJSON(b, elements, string(elemType))
+		return printListJSON(b, elements, typeName)
 	}
 
 	// Table output
 	if len(elements) == 0 {
 		fmt.Println("No elements found.")
+		printSnoozedHint(hidden)
 		return nil
 	}
 
@@ -133,6 +174,7 @@ func runList(cmd *cobra.Command, args []string) error {
 	}
 
 	fmt.Print(table.String())
+	printSnoozedHint(hidden)
 	return nil
 }
 
@@ -142,7 +184,10 @@ func printListJSON(b *board.Board, elements []*board.Element, elemType string) e
 	for _, e := range elements {
 		// Build relative path from element's absolute path
 		relPath := ""
-		if e.Path != "" {
+		if WorkspaceEnabled() {
+			// Merged workspace elements carry qualified IDs, so walk the real path instead
+			relPath = computeRelativePath(b.Dir, e.Path)
+		} else if e.Path != "" {
 			// Extract just the path within the board (after .task-board/)
 			if idx := filepath.Base(filepath.Dir(e.Path)); idx != "." {
 				relPath = buildElementPath(e, b)
@@ -188,10 +233,13 @@ func printListJSON(b *board.Board, elements []*board.Element, elemType string) e
 		Elements: listElements,
 		Count:    len(listElements),
 		Filters: ListFilters{
-			Type:   elemType,
-			Story:  listStory,
-			Epic:   listEpic,
-			Status: listStatus,
+			Type:           elemType,
+			Story:          listStory,
+			Epic:           listEpic,
+			Status:         listStatus,
+			View:           listView,
+			Query:          listQuery,
+			IncludeSnoozed: includeSnoozed,
 		},
 	}
 
@@ -215,3 +263,71 @@ func buildElementPath(e *board.Element, b *board.Board) string {
 
 	return filepath.Join(parts...)
 }
+
+// resolveListQuery combines --view and --query into one query (AND).
+func resolveListQuery() (*query.Query, error) {
+	text := listQuery
+	if listView != "" {
+		views, err := query.LoadViews(boardDir)
+		if err != nil {
+			if JSONEnabled() {
+				output.PrintError(os.Stderr, output.InternalError, err.Error(), nil)
+			}
+			return nil, err
+		}
+		v := query.FindView(views, listView)
+		if v == nil {
+			if JSONEnabled() {
+				output.PrintError(os.Stderr, output.NotFound, fmt.Sprintf("view %s not found", listView), map[string]interface{}{
+					"view": listView,
+				})
+			}
+			return nil, fmt.Errorf("view %s not found", listView)
+		}
+		text = v.Query
+		if listQuery != "" {
+			text = "(" + v.Query + ") (" + listQuery + ")"
+		}
+	}
+
+	q, err := query.Parse(text)
+	if err != nil {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.ValidationError, fmt.Sprintf("invalid query: %v", err), map[string]interface{}{
+				"query": text,
+			})
+		}
+		return nil, fmt.Errorf("invalid query: %w", err)
+	}
+	return q, nil
+}
+
+// filterByQuery keeps the elements matching q.
+func filterByQuery(b *board.Board, elements []*board.Element, q *query.Query) []*board.Element {
+	var result []*board.Element
+	for _, e := range elements {
+		if q.Match(queryFields(b, e)) {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// queryFields describes an element for the query language.
+func queryFields(b *board.Board, e *board.Element) query.Fields {
+	var ancestors []string
+	for p := b.ParentOf(e); p != nil; p = b.ParentOf(p) {
+		ancestors = append(ancestors, p.ID())
+	}
+	return query.Fields{
+		ID:        e.ID(),
+		Type:      string(e.Type),
+		Name:      e.Name,
+		Status:    string(e.Status),
+		Assignee:  e.AssignedTo,
+		Parent:    e.ParentID,
+		Ancestors: ancestors,
+		UpdatedAt: e.LastUpdate,
+		Blocked:   len(b.ActiveBlockers(e)) > 0,
+	}
+}
@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"encoding/json"
+	"strings"
 	"testing"
 )
 
@@ -92,3 +94,95 @@ func TestListEmpty(t *testing.T) {
 		t.Fatalf("runList: %v", err)
 	}
 }
+
+func resetListFlags() {
+	listStatus, listEpic, listStory, listView, listQuery = "", "", "", "", ""
+}
+
+func TestListQuery(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	resetListFlags()
+	defer resetListFlags()
+
+	listQuery = "blocked:true"
+	jsonOutput = true
+	defer func() { jsonOutput = false }()
+	out := captureOutput(t, func() {
+		if err := runList(listCmd, []string{"tasks"}); err != nil {
+			t.Fatalf("runList --query: %v", err)
+		}
+	})
+	var resp ListResponse
+	if err := json.Unmarshal([]byte(out), &resp); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if resp.Count != 1 || resp.Elements[0].ID != testTask2ID {
+		t.Errorf("blocked:true should list only TASK-02, got %+v", resp.Elements)
+	}
+	if resp.Filters.Query != "blocked:true" {
+		t.Errorf("filters.query = %q", resp.Filters.Query)
+	}
+}
+
+func TestListQueryWithoutType(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	resetListFlags()
+	defer resetListFlags()
+
+	listQuery = "parent:" + testEpic2ID
+	out := captureOutput(t, func() {
+		if err := runList(listCmd, nil); err != nil {
+			t.Fatalf("runList --query: %v", err)
+		}
+	})
+	for _, want := range []string{testStory3ID, testTask4ID} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %s under %s:\n%s", want, testEpic2ID, out)
+		}
+	}
+	if strings.Contains(out, testTask1ID) {
+		t.Errorf("elements of other epics must not match:\n%s", out)
+	}
+}
+
+func TestListInvalidQuery(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	resetListFlags()
+	defer resetListFlags()
+
+	listQuery = "(status:done"
+	if err := runList(listCmd, []string{"tasks"}); err == nil {
+		t.Fatal("expected error for invalid query")
+	}
+}
+
+func TestListView(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	resetListFlags()
+	defer resetListFlags()
+
+	captureOutput(t, func() {
+		if err := runViewSave(viewSaveCmd, []string{"storage", "parent:" + testStory3ID}); err != nil {
+			t.Fatalf("runViewSave: %v", err)
+		}
+	})
+
+	listView = "storage"
+	out := captureOutput(t, func() {
+		if err := runList(listCmd, []string{"tasks"}); err != nil {
+			t.Fatalf("runList --view: %v", err)
+		}
+	})
+	if !strings.Contains(out, testTask4ID) || strings.Contains(out, testTask1ID) {
+		t.Errorf("view should list only TASK-04:\n%s", out)
+	}
+
+	listView = "missing"
+	if err := runList(listCmd, []string{"tasks"}); err == nil {
+		t.Fatal("expected error for unknown view")
+	}
+}
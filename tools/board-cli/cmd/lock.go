@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aagrigore/task-board/internal/board"
+	"github.com/aagrigore/task-board/internal/history"
+	"github.com/aagrigore/task-board/internal/output"
+	"github.com/aagrigore/task-board/internal/txn"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// lockWait is how long a board-changing command waits for another one to
+// release the board lock.
+var lockWait = 10 * time.Second
+
+// locked wraps the RunE of a board-changing command that is not recorded:
+// it holds the board lock, or the lock of every workspace board, while the
+// command runs, so concurrent commands never interleave.
+func locked(run func(*cobra.Command, []string) error) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		dirs := []string{boardDir}
+		if WorkspaceEnabled() {
+			cfg, err := board.ReadWorkspaceConfig(workspaceFile)
+			if err != nil {
+				return run(cmd, args) // the command reports the bad workspace
+			}
+			dirs = dirs[:0]
+			for _, bc := range cfg.Boards {
+				dirs = append(dirs, bc.Dir(workspaceFile))
+			}
+		}
+		var locks []*txn.Lock
+		defer func() {
+			for _, l := range locks {
+				l.Release()
+			}
+		}()
+		for _, dir := range dirs {
+			lock, err := txn.Acquire(dir, lockWait)
+			if err != nil {
+				if JSONEnabled() {
+					output.PrintError(os.Stderr, output.InternalError, err.Error(), nil)
+					return nil
+				}
+				return err
+			}
+			locks = append(locks, lock)
+		}
+		return run(cmd, args)
+	}
+}
+
+// recorded is locked, and also keeps whatever the command writes in the
+// undo history. Workspace-mode runs are not recorded.
+func recorded(run func(*cobra.Command, []string) error) func(*cobra.Command, []string) error {
+	return locked(func(cmd *cobra.Command, args []string) error {
+		if WorkspaceEnabled() {
+			return run(cmd, args)
+		}
+		snap, err := txn.TakeSnapshot(boardDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "warning: not recorded for undo: %v\n", err)
+			return run(cmd, args)
+		}
+		runErr := run(cmd, args)
+		changes, err := snap.Changes()
+		if err == nil && !changes.Empty() {
+			_, err = history.Record(boardDir, commandLine(cmd, args), changes)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "warning: recording undo history: %v\n", err)
+		}
+		return runErr
+	})
+}
+
+// commandLine reconstructs the invocation for history listings.
+func commandLine(cmd *cobra.Command, args []string) string {
+	parts := []string{cmd.CommandPath()}
+	parts = append(parts, args...)
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		if f.Name == "json" || f.Name == "board-dir" {
+			return
+		}
+		if f.Value.Type() == "bool" {
+			parts = append(parts, "--"+f.Name)
+		} else {
+			parts = append(parts, fmt.Sprintf("--%s=%q", f.Name, f.Value.String()))
+		}
+	})
+	return strings.Join(parts, " ")
+}
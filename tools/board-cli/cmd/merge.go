@@ -0,0 +1,404 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aagrigore/task-board/internal/board"
+	"github.com/aagrigore/task-board/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// MergeResponse is the JSON response for merge
+type MergeResponse struct {
+	Source         string   `json:"source"`
+	Target         string   `json:"target"`
+	ChildrenMoved  []string `json:"childrenMoved"`
+	ChecklistAdded int      `json:"checklistAdded"`
+	LinksMoved     []string `json:"linksMoved"`
+	LinksSkipped   []string `json:"linksSkipped"`
+	Message        string   `json:"message"`
+}
+
+var mergeCmd = &cobra.Command{
+	Use:   "merge <SOURCE-ID> <TARGET-ID>",
+	Short: "Fold one element into another and close it",
+	Long: `Fold SOURCE into TARGET, which must have the same type:
+
+  - SOURCE's children move under TARGET
+  - checklist items missing from TARGET are added
+  - SOURCE's notes are appended to TARGET's notes
+  - every blocked-by/blocks link pointing at SOURCE is rewired to TARGET
+  - so are typed links (relates-to, caused-by, ...)
+
+SOURCE is then closed with a "Merged into TARGET" note and a duplicates
+link to TARGET, as progress status would close it; a merge the workflow
+would not let SOURCE close is refused up front. Links that would make TARGET depend on itself are dropped
+and reported.`,
+	Example: `  task-board merge TASK-260101-bbbbbb TASK-260101-aaaaaa
+  task-board merge STORY-260101-dddddd STORY-260101-cccccc --json`,
+	Args: cobra.ExactArgs(2),
+	RunE: recorded(runMerge),
+}
+
+func init() {
+	rootCmd.AddCommand(mergeCmd)
+}
+
+func runMerge(cmd *cobra.Command, args []string) error {
+	b, err := board.Load(boardDir)
+	if err != nil {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.InternalError, fmt.Sprintf("loading board: %v", err), nil)
+			return nil
+		}
+		return fmt.Errorf("loading board: %w", err)
+	}
+
+	src := b.FindByID(args[0])
+	dst := b.FindByID(args[1])
+	for i, e := range []*board.Element{src, dst} {
+		if e == nil {
+			if JSONEnabled() {
+				output.PrintError(os.Stderr, output.NotFound, fmt.Sprintf("element %s not found", args[i]), map[string]interface{}{
+					"id": args[i],
+				})
+				return nil
+			}
+			return fmt.Errorf("element %s not found", args[i])
+		}
+	}
+
+	if err := validateMerge(b, src, dst); err != nil {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.ValidationError, err.Error(), map[string]interface{}{
+				"source": src.ID(),
+				"target": dst.ID(),
+			})
+			return nil
+		}
+		return err
+	}
+
+	result, err := mergeElements(b, src, dst)
+	if err != nil {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.InternalError, err.Error(), nil)
+			return nil
+		}
+		return err
+	}
+
+	message := fmt.Sprintf("Merged %s into %s", src.ID(), dst.ID())
+	if JSONEnabled() {
+		result.Message = message
+		return output.PrintJSON(os.Stdout, result)
+	}
+
+	fmt.Println(message)
+	for _, id := range result.ChildrenMoved {
+		fmt.Printf("  moved child: %s\n", id)
+	}
+	if result.ChecklistAdded > 0 {
+		fmt.Printf("  checklist: %d item(s) added\n", result.ChecklistAdded)
+	}
+	for _, l := range result.LinksMoved {
+		fmt.Printf("  moved link: %s\n", l)
+	}
+	for _, l := range result.LinksSkipped {
+		fmt.Printf("  dropped link: %s\n", l)
+	}
+	return nil
+}
+
+// validateMerge checks that src can be folded into dst.
+func validateMerge(b *board.Board, src, dst *board.Element) error {
+	if src.ID() == dst.ID() {
+		return fmt.Errorf("cannot merge %s into itself", src.ID())
+	}
+	if src.Type != dst.Type {
+		return fmt.Errorf("cannot merge %s %s into %s %s: types differ", src.Type, src.ID(), dst.Type, dst.ID())
+	}
+	if b.Workflow.Category(src) == board.CategoryClosed {
+		return fmt.Errorf("%s is already closed", src.ID())
+	}
+	if b.Workflow.Category(dst) == board.CategoryClosed {
+		return fmt.Errorf("cannot merge into closed element %s", dst.ID())
+	}
+	if refusal := transitionRefusal(b, src, b.Flow(src.Type).ClosedStatus(), false); refusal != nil {
+		return fmt.Errorf("cannot close %s: %s", src.ID(), refusal.message)
+	}
+	if src.Type == board.EpicType || src.Type == board.StoryType {
+		for _, child := range b.Children(src) {
+			if _, err := os.Stat(filepath.Join(dst.Path, filepath.Base(child.Path))); err == nil {
+				return fmt.Errorf("%s already contains %s", dst.ID(), filepath.Base(child.Path))
+			}
+		}
+	}
+	return nil
+}
+
+// mergeElements folds src into dst: children, checklist, notes and
+// dependency links move to dst, and src is closed. Links that would make dst
+// block itself, directly or through a cycle, are dropped and reported as skipped.
+func mergeElements(b *board.Board, src, dst *board.Element) (*MergeResponse, error) {
+	result := &MergeResponse{
+		Source:        src.ID(),
+		Target:        dst.ID(),
+		ChildrenMoved: []string{},
+		LinksMoved:    []string{},
+		LinksSkipped:  []string{},
+	}
+
+	// Children first: the board is reloaded afterwards so paths stay valid
+	if children := b.Children(src); len(children) > 0 {
+		for _, child := range children {
+			newPath := filepath.Join(dst.Path, filepath.Base(child.Path))
+			if err := os.Rename(child.Path, newPath); err != nil {
+				return nil, fmt.Errorf("moving %s: %w", child.ID(), err)
+			}
+			result.ChildrenMoved = append(result.ChildrenMoved, child.ID())
+		}
+		reloaded, err := board.Load(b.Dir)
+		if err != nil {
+			return nil, fmt.Errorf("reloading board: %w", err)
+		}
+		b = reloaded
+		src, dst = b.FindByID(src.ID()), b.FindByID(dst.ID())
+		for _, id := range result.ChildrenMoved {
+			child := b.FindByID(id)
+			if c := b.Workflow.Category(child); c == board.CategoryTodo || c == board.CategoryActive {
+				reopenParentIfNeeded(b, child)
+			}
+		}
+	}
+
+	srcPd, err := board.ParseProgressFile(src.ProgressPath())
+	if err != nil {
+		return nil, fmt.Errorf("reading progress for %s: %w", src.ID(), err)
+	}
+	dstPd, err := board.ParseProgressFile(dst.ProgressPath())
+	if err != nil {
+		return nil, fmt.Errorf("reading progress for %s: %w", dst.ID(), err)
+	}
+
+	// Checklist: add items dst does not have yet
+	have := make(map[string]bool, len(dstPd.Checklist))
+	for _, item := range dstPd.Checklist {
+		have[strings.ToLower(item.Text)] = true
+	}
+	for _, item := range srcPd.Checklist {
+		if have[strings.ToLower(item.Text)] {
+			continue
+		}
+		dstPd.Checklist = append(dstPd.Checklist, item)
+		have[strings.ToLower(item.Text)] = true
+		result.ChecklistAdded++
+	}
+
+	// Notes
+	if srcPd.Notes != "" {
+		merged := fmt.Sprintf("Merged from %s:\n%s", src.ID(), srcPd.Notes)
+		if dstPd.Notes != "" {
+			dstPd.Notes += "\n\n" + merged
+		} else {
+			dstPd.Notes = merged
+		}
+	}
+
+	// Links. Each pair is (blocked, blocker).
+	var removed, added [][2]*board.Element
+	for _, ref := range srcPd.BlockedBy {
+		if board.IsQualifiedID(ref) {
+			// Cross-board reference: keep it on dst; the other board still names src
+			dstPd.BlockedBy = appendUnique(dstPd.BlockedBy, ref)
+			result.LinksMoved = append(result.LinksMoved, fmt.Sprintf("%s blocked by %s", dst.ID(), ref))
+			continue
+		}
+		blocker := b.FindByID(ref)
+		if blocker == nil {
+			continue
+		}
+		if err := replaceRef(blocker, src.ID(), "", false); err != nil {
+			return nil, err
+		}
+		removed = append(removed, [2]*board.Element{src, blocker})
+		link := fmt.Sprintf("%s blocked by %s", dst.ID(), blocker.ID())
+		if blocker.ID() == dst.ID() || dependsOn(b, blocker, dst) {
+			result.LinksSkipped = append(result.LinksSkipped, link)
+			continue
+		}
+		if err := replaceRef(blocker, "", dst.ID(), false); err != nil {
+			return nil, err
+		}
+		dstPd.BlockedBy = appendUnique(dstPd.BlockedBy, blocker.ID())
+		dst.BlockedBy = appendUnique(dst.BlockedBy, blocker.ID())
+		added = append(added, [2]*board.Element{dst, blocker})
+		result.LinksMoved = append(result.LinksMoved, link)
+	}
+	for _, ref := range srcPd.Blocks {
+		if board.IsQualifiedID(ref) {
+			dstPd.Blocks = appendUnique(dstPd.Blocks, ref)
+			result.LinksMoved = append(result.LinksMoved, fmt.Sprintf("%s blocks %s", dst.ID(), ref))
+			continue
+		}
+		blocked := b.FindByID(ref)
+		if blocked == nil {
+			continue
+		}
+		if err := replaceRef(blocked, src.ID(), "", true); err != nil {
+			return nil, err
+		}
+		removed = append(removed, [2]*board.Element{blocked, src})
+		link := fmt.Sprintf("%s blocks %s", dst.ID(), blocked.ID())
+		if blocked.ID() == dst.ID() || dependsOn(b, dst, blocked) {
+			result.LinksSkipped = append(result.LinksSkipped, link)
+			continue
+		}
+		if err := replaceRef(blocked, "", dst.ID(), true); err != nil {
+			return nil, err
+		}
+		dstPd.Blocks = appendUnique(dstPd.Blocks, blocked.ID())
+		dst.Blocks = appendUnique(dst.Blocks, blocked.ID())
+		added = append(added, [2]*board.Element{blocked, dst})
+		result.LinksMoved = append(result.LinksMoved, link)
+	}
+
+	// Typed links move the same way, without the cycle checks
+	for _, l := range srcPd.Links {
+		if l.Target == dst.ID() {
+			continue
+		}
+		if other := b.FindByID(l.Target); other != nil {
+			if _, err := updateLink(other, board.Link{Type: l.Type.Inverse(), Target: src.ID()}, false); err != nil {
+				return nil, err
+			}
+			if _, err := updateLink(other, board.Link{Type: l.Type.Inverse(), Target: dst.ID()}, true); err != nil {
+				return nil, err
+			}
+		} else if !board.IsQualifiedID(l.Target) {
+			continue
+		}
+		if dstPd.AddLink(l) {
+			result.LinksMoved = append(result.LinksMoved, fmt.Sprintf("%s %s %s", dst.ID(), l.Type, l.Target))
+		}
+	}
+
+	// dst itself may have named src; those references are now meaningless
+	dstPd.BlockedBy = removeRef(dstPd.BlockedBy, src.ID())
+	dstPd.Blocks = removeRef(dstPd.Blocks, src.ID())
+	var dstLinks []board.Link
+	for _, l := range dstPd.Links {
+		if l.Target != src.ID() {
+			dstLinks = append(dstLinks, l)
+		}
+	}
+	dstPd.Links = dstLinks
+	// What remains of src is the record that it duplicates dst
+	dstPd.AddLink(board.Link{Type: board.LinkDuplicateOf, Target: src.ID()})
+
+	if err := board.WriteProgressFile(dst.ProgressPath(), dstPd); err != nil {
+		return nil, fmt.Errorf("writing progress for %s: %w", dst.ID(), err)
+	}
+
+	srcPd.BlockedBy = nil
+	srcPd.Blocks = nil
+	srcPd.Links = []board.Link{{Type: board.LinkDuplicates, Target: dst.ID()}}
+	closing := fmt.Sprintf("Merged into %s", dst.ID())
+	if srcPd.Notes != "" {
+		srcPd.Notes += "\n" + closing
+	} else {
+		srcPd.Notes = closing
+	}
+	src.BlockedBy = nil
+	src.Blocks = nil
+	src.Links = srcPd.Links
+	closed := b.Flow(src.Type).ClosedStatus()
+	if err := setStatus(b, src, srcPd, closed, fmt.Sprintf("  %s: %s → %s", src.ID(), src.Status, closed)); err != nil {
+		return nil, err
+	}
+
+	// Keep parent-level dependencies in step with the moved links
+	for _, pair := range removed {
+		if err := deescalateDependency(b, pair[0], pair[1]); err != nil {
+			return nil, fmt.Errorf("de-escalating dependency: %w", err)
+		}
+	}
+	for _, pair := range added {
+		if err := escalateDependency(b, pair[0], pair[1]); err != nil {
+			return nil, fmt.Errorf("escalating dependency: %w", err)
+		}
+	}
+
+	promoteParentIfAllChildrenDone(b, src)
+	return result, nil
+}
+
+// replaceRef removes old from, and adds add to, the element's Blocked By
+// (blockedBy=true) or Blocks list. Either may be empty.
+func replaceRef(e *board.Element, old, add string, blockedBy bool) error {
+	pd, err := board.ParseProgressFile(e.ProgressPath())
+	if err != nil {
+		return fmt.Errorf("reading progress for %s: %w", e.ID(), err)
+	}
+	list, mem := &pd.Blocks, &e.Blocks
+	if blockedBy {
+		list, mem = &pd.BlockedBy, &e.BlockedBy
+	}
+	if old != "" {
+		*list = removeRef(*list, old)
+		*mem = removeRef(*mem, old)
+	}
+	if add != "" {
+		*list = appendUnique(*list, add)
+		*mem = appendUnique(*mem, add)
+	}
+	if err := board.WriteProgressFile(e.ProgressPath(), pd); err != nil {
+		return fmt.Errorf("writing progress for %s: %w", e.ID(), err)
+	}
+	return nil
+}
+
+// dependsOn reports whether elem is blocked by target, directly or transitively.
+func dependsOn(b *board.Board, elem, target *board.Element) bool {
+	seen := make(map[string]bool)
+	stack := []*board.Element{elem}
+	for len(stack) > 0 {
+		e := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, id := range e.BlockedBy {
+			if strings.EqualFold(id, target.ID()) {
+				return true
+			}
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			if next := b.FindByID(id); next != nil {
+				stack = append(stack, next)
+			}
+		}
+	}
+	return false
+}
+
+func appendUnique(list []string, id string) []string {
+	for _, existing := range list {
+		if existing == id {
+			return list
+		}
+	}
+	return append(list, id)
+}
+
+func removeRef(list []string, id string) []string {
+	var out []string
+	for _, existing := range list {
+		if existing != id {
+			out = append(out, existing)
+		}
+	}
+	return out
+}
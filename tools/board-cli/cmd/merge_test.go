@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aagrigore/task-board/internal/board"
+)
+
+func TestMerge(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+
+	// TASK-1 has a checklist, notes and blocks TASK-2; fold it into TASK-3
+	captureOutput(t, func() {
+		if err := runMerge(mergeCmd, []string{testTask1ID, testTask3ID}); err != nil {
+			t.Fatalf("runDedupeMerge: %v", err)
+		}
+	})
+
+	b, _ := board.Load(bd)
+	src := b.FindByID(testTask1ID)
+	dst := b.FindByID(testTask3ID)
+	task2 := b.FindByID(testTask2ID)
+
+	if src.Status != board.StatusClosed {
+		t.Errorf("source should be closed, got %s", src.Status)
+	}
+	if len(src.Blocks) != 0 || len(src.BlockedBy) != 0 {
+		t.Errorf("source links should be cleared, got blocks=%v blockedBy=%v", src.Blocks, src.BlockedBy)
+	}
+	if len(dst.Checklist) != 2 {
+		t.Errorf("checklist should be merged, got %+v", dst.Checklist)
+	}
+	if len(dst.Blocks) != 1 || dst.Blocks[0] != testTask2ID {
+		t.Errorf("target should block %s, got %v", testTask2ID, dst.Blocks)
+	}
+	if len(task2.BlockedBy) != 1 || task2.BlockedBy[0] != testTask3ID {
+		t.Errorf("%s should be blocked by %s, got %v", testTask2ID, testTask3ID, task2.BlockedBy)
+	}
+
+	dstPd, _ := board.ParseProgressFile(dst.ProgressPath())
+	if !strings.Contains(dstPd.Notes, "Merged from "+testTask1ID) || !strings.Contains(dstPd.Notes, "Started work") {
+		t.Errorf("target notes should carry source notes, got %q", dstPd.Notes)
+	}
+	srcPd, _ := board.ParseProgressFile(src.ProgressPath())
+	if !strings.Contains(srcPd.Notes, "Merged into "+testTask3ID) {
+		t.Errorf("source notes should point at target, got %q", srcPd.Notes)
+	}
+}
+
+func TestMergeDropsSelfLink(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+
+	// TASK-2 is blocked by TASK-1: folding TASK-2 into TASK-1 must not make TASK-1 block itself
+	captureOutput(t, func() {
+		if err := runMerge(mergeCmd, []string{testTask2ID, testTask1ID}); err != nil {
+			t.Fatalf("runDedupeMerge: %v", err)
+		}
+	})
+
+	b, _ := board.Load(bd)
+	task1 := b.FindByID(testTask1ID)
+	if len(task1.Blocks) != 0 || len(task1.BlockedBy) != 0 {
+		t.Errorf("target should have no links left, got blocks=%v blockedBy=%v", task1.Blocks, task1.BlockedBy)
+	}
+}
+
+func TestMergeValidation(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+
+	cases := [][2]string{
+		{testTask1ID, testTask1ID}, // itself
+		{testTask1ID, testBug1ID},  // different type
+		{testTask1ID, "TASK-260101-zzzzzz"},
+	}
+	for _, c := range cases {
+		if err := runMerge(mergeCmd, c[:]); err == nil {
+			t.Errorf("merge %s into %s should fail", c[0], c[1])
+		}
+	}
+
+	// Nothing was changed by the failed merges
+	data, _ := os.ReadFile(filepath.Join(bd, testEpic1ID+"_recording", testStory1ID+"_audio-capture", testTask1ID+"_interface", "progress.md"))
+	if !strings.Contains(string(data), "backlog") {
+		t.Error("source should be untouched after failed merges")
+	}
+}
+
+func TestMergeMovesChildren(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+
+	// STORY-1 holds four open elements; STORY-2 is empty
+	captureOutput(t, func() {
+		if err := runMerge(mergeCmd, []string{testStory1ID, testStory2ID}); err != nil {
+			t.Fatalf("runMerge: %v", err)
+		}
+	})
+
+	b, _ := board.Load(bd)
+	story1 := b.FindByID(testStory1ID)
+	story2 := b.FindByID(testStory2ID)
+	if story1.Status != board.StatusClosed {
+		t.Errorf("source story should be closed, got %s", story1.Status)
+	}
+	if n := len(b.Children(story1)); n != 0 {
+		t.Errorf("source story should have no children, got %d", n)
+	}
+	if n := len(b.Children(story2)); n != 4 {
+		t.Errorf("target story should hold 4 children, got %d", n)
+	}
+	// Links between the moved children survive the move
+	task2 := b.FindByID(testTask2ID)
+	if len(task2.BlockedBy) != 1 || task2.BlockedBy[0] != testTask1ID {
+		t.Errorf("child links should be preserved, got %v", task2.BlockedBy)
+	}
+}
+
+func TestMergeJSON(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	jsonOutput = true
+	defer func() { jsonOutput = false }()
+
+	out := captureOutput(t, func() {
+		runMerge(mergeCmd, []string{testTask3ID, testTask1ID})
+	})
+	var resp MergeResponse
+	if err := json.Unmarshal([]byte(out), &resp); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if resp.Source != testTask3ID || resp.Target != testTask1ID || resp.Message == "" {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+}
+
+func TestMergeRecordsDuplicate(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+
+	captureOutput(t, func() {
+		if err := runMerge(mergeCmd, []string{testTask1ID, testTask3ID}); err != nil {
+			t.Fatalf("runMerge: %v", err)
+		}
+	})
+
+	b, _ := board.Load(bd)
+	src, dst := b.FindByID(testTask1ID), b.FindByID(testTask3ID)
+	if len(src.Links) != 1 || src.Links[0] != (board.Link{Type: board.LinkDuplicates, Target: testTask3ID}) {
+		t.Errorf("source links = %+v, want duplicates %s", src.Links, testTask3ID)
+	}
+	if len(dst.Links) != 1 || dst.Links[0] != (board.Link{Type: board.LinkDuplicateOf, Target: testTask1ID}) {
+		t.Errorf("target links = %+v, want duplicated-by %s", dst.Links, testTask1ID)
+	}
+}
+
+func TestMergeClosesThroughWorkflow(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	workflow := filepath.Join(bd, board.WorkflowFile)
+	os.WriteFile(workflow, []byte("transitions:\n  development: [to-review]\ntime-tracking:\n  auto: [development]\n"), 0644)
+
+	captureOutput(t, func() {
+		runProgressStatus(progressStatusCmd, []string{testTask3ID, "development"})
+	})
+	err := runMerge(mergeCmd, []string{testTask3ID, testTask1ID})
+	if err == nil || !strings.Contains(err.Error(), "allowed: to-review") {
+		t.Fatalf("merge should refuse a transition the workflow forbids, got %v", err)
+	}
+
+	// Closing the source stops its timer like any other status change
+	os.WriteFile(workflow, []byte("time-tracking:\n  auto: [development]\n"), 0644)
+	captureOutput(t, func() {
+		if err := runMerge(mergeCmd, []string{testTask3ID, testTask1ID}); err != nil {
+			t.Fatalf("merge: %v", err)
+		}
+	})
+	b, _ := board.Load(bd)
+	src := b.FindByID(testTask3ID)
+	if src.Status != board.StatusClosed || src.Running() {
+		t.Errorf("source should be closed with its timer stopped: %s %+v", src.Status, src.TimeLog)
+	}
+}
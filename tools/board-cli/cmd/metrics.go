@@ -0,0 +1,292 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aagrigore/task-board/internal/board"
+	"github.com/aagrigore/task-board/internal/metrics"
+	"github.com/aagrigore/task-board/internal/output"
+	"github.com/aagrigore/task-board/internal/plan"
+	"github.com/aagrigore/task-board/query"
+	"github.com/spf13/cobra"
+)
+
+// MetricsResponse is the JSON response for metrics
+type MetricsResponse struct {
+	Metrics *metrics.Report `json:"metrics"`
+	Filters MetricsFilters  `json:"filters"`
+}
+
+// MetricsFilters shows which filters were applied
+type MetricsFilters struct {
+	Epic     string   `json:"epic,omitempty"`
+	Assignee string   `json:"assignee,omitempty"`
+	Types    []string `json:"types"`
+	Since    string   `json:"since,omitempty"`
+	Period   string   `json:"period"`
+	Start    string   `json:"start"`
+}
+
+var metricsCmd = &cobra.Command{
+	Use:   "metrics",
+	Short: "Show cycle time, lead time, throughput and cumulative flow",
+	Long: `Flow metrics built from the status history 'progress status' records in
+each progress.md:
+
+  cycle time       start status (development) → done
+  lead time        created → done
+  throughput       elements finished per day or week
+  time in status   total and mean time spent in each status
+  cumulative flow  elements in each status at the end of every day
+
+Tasks and bugs are measured unless --type says otherwise. Elements finished
+before history was recorded count as done at their last update.
+
+Examples:
+  task-board metrics --since 14d
+  task-board metrics --epic EPIC-260101-aaaaaa --period week
+  task-board metrics --assignee alice --json
+  task-board metrics --csv flow > cfd.csv`,
+	Args: cobra.NoArgs,
+	RunE: runMetrics,
+}
+
+var (
+	metricsEpic     string
+	metricsAssignee string
+	metricsTypes    []string
+	metricsSince    string
+	metricsPeriod   string
+	metricsStart    string
+	metricsCSV      string
+)
+
+func init() {
+	rootCmd.AddCommand(metricsCmd)
+	metricsCmd.Flags().StringVar(&metricsEpic, "epic", "", "Only elements under this epic (or story)")
+	metricsCmd.Flags().StringVar(&metricsAssignee, "assignee", "", "Only elements assigned to this agent")
+	metricsCmd.Flags().StringSliceVar(&metricsTypes, "type", []string{"task", "bug"}, "Element types to measure")
+	metricsCmd.Flags().StringVar(&metricsSince, "since", "", "Only completions and flow from this date (2006-01-02) or age (14d, 2w)")
+	metricsCmd.Flags().StringVar(&metricsPeriod, "period", "day", "Throughput period: day or week")
+	metricsCmd.Flags().StringVar(&metricsStart, "start", "development", "Status that starts the cycle time")
+	metricsCmd.Flags().StringVar(&metricsCSV, "csv", "", "Write one table as CSV: elements, throughput, status or flow")
+}
+
+func runMetrics(cmd *cobra.Command, args []string) error {
+	fail := func(code output.ErrorCode, err error) error {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, code, err.Error(), nil)
+			return nil
+		}
+		return err
+	}
+
+	period, err := metrics.ParsePeriod(metricsPeriod)
+	if err != nil {
+		return fail(output.ValidationError, err)
+	}
+	since, err := parseSince(metricsSince)
+	if err != nil {
+		return fail(output.ValidationError, err)
+	}
+	switch metricsCSV {
+	case "", "elements", "throughput", "status", "flow":
+	default:
+		return fail(output.ValidationError, fmt.Errorf("unknown CSV table %q (valid: elements, throughput, status, flow)", metricsCSV))
+	}
+	types := map[board.ElementType]bool{}
+	var typeNames []string
+	for _, name := range metricsTypes {
+		t, err := board.ParseElementType(name)
+		if err != nil {
+			return fail(output.ValidationError, err)
+		}
+		types[t] = true
+		typeNames = append(typeNames, string(t))
+	}
+
+	b, err := loadViewBoard()
+	if err != nil {
+		return fail(output.InternalError, fmt.Errorf("loading board: %w", err))
+	}
+	start, err := b.Workflow.ParseAny(metricsStart)
+	if err != nil {
+		return fail(output.InvalidStatus, err)
+	}
+
+	scope, err := plan.AllDescendants(b, metricsEpic)
+	if err != nil {
+		return fail(output.NotFound, err)
+	}
+	var elements []*board.Element
+	for _, e := range scope {
+		if !types[e.Type] {
+			continue
+		}
+		if metricsAssignee != "" && e.AssignedTo != metricsAssignee {
+			continue
+		}
+		elements = append(elements, e)
+	}
+
+	report := metrics.Compute(b.Workflow, elements, metrics.Options{Start: start, Period: period, Since: since})
+
+	if metricsCSV != "" {
+		return writeMetricsCSV(report, metricsCSV)
+	}
+
+	if JSONEnabled() {
+		filters := MetricsFilters{Epic: metricsEpic, Assignee: metricsAssignee, Types: typeNames,
+			Period: string(period), Start: string(start)}
+		if !since.IsZero() {
+			filters.Since = since.Format(time.RFC3339)
+		}
+		return output.PrintJSON(os.Stdout, MetricsResponse{Metrics: report, Filters: filters})
+	}
+
+	if len(elements) == 0 {
+		fmt.Println("No elements to measure.")
+		return nil
+	}
+
+	fmt.Println(output.Bold + "Cycle & lead time" + output.Reset)
+	fmt.Printf("%scycle: %s → done, lead: created → done%s\n", output.Gray, start, output.Reset)
+	table := output.NewTable("", "COUNT", "MEAN", "MEDIAN", "85TH", "MAX")
+	for _, row := range []struct {
+		name  string
+		stats metrics.Stats
+	}{
+		{"cycle time", report.CycleTime},
+		{"lead time", report.LeadTime},
+	} {
+		s := row.stats
+		if s.Count == 0 {
+			table.AddRow(row.name, "0", "-", "-", "-", "-")
+			continue
+		}
+		table.AddRow(row.name, strconv.Itoa(s.Count), formatHours(s.Mean), formatHours(s.Median), formatHours(s.P85), formatHours(s.Max))
+	}
+	fmt.Print(table.String())
+
+	fmt.Println()
+	fmt.Printf("%sThroughput per %s%s\n", output.Bold, period, output.Reset)
+	if len(report.Throughput) == 0 {
+		fmt.Println("  nothing finished yet")
+	}
+	for _, p := range report.Throughput {
+		fmt.Printf("  %-10s %3d %s\n", p.Period, p.Count, output.Green+strings.Repeat("█", p.Count)+output.Reset)
+	}
+
+	fmt.Println()
+	fmt.Println(output.Bold + "Time in status" + output.Reset)
+	table = output.NewTable("STATUS", "CATEGORY", "VISITS", "TOTAL", "MEAN")
+	for _, s := range report.TimeInStatus {
+		table.AddRow(string(s.Status), string(s.Category), strconv.Itoa(s.Visits), formatHours(s.TotalHours), formatHours(s.MeanHours))
+	}
+	fmt.Print(table.String())
+
+	// The cumulative flow is long; show the last two weeks in text
+	fmt.Println()
+	fmt.Println(output.Bold + "Cumulative flow" + output.Reset + output.Gray + " (last 14 days; --json or --csv flow for all)" + output.Reset)
+	headers := []string{"DATE"}
+	for _, s := range report.Statuses {
+		headers = append(headers, strings.ToUpper(string(s)))
+	}
+	table = output.NewTable(headers...)
+	points := report.CumulativeFlow
+	if len(points) > 14 {
+		points = points[len(points)-14:]
+	}
+	for _, p := range points {
+		row := []string{p.Date}
+		for _, s := range report.Statuses {
+			row = append(row, strconv.Itoa(p.Counts[s]))
+		}
+		table.AddRow(row...)
+	}
+	fmt.Print(table.String())
+	return nil
+}
+
+// parseSince accepts a date (2006-01-02), an RFC 3339 time or an age like
+// "14d" counted back from now.
+func parseSince(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t.UTC(), nil
+	}
+	age, err := query.ParseAge(s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("--since: cannot parse %q as a date (2006-01-02) or an age (14d, 2w)", s)
+	}
+	return time.Now().UTC().Add(-age), nil
+}
+
+// formatHours shows short durations in hours and long ones in days.
+func formatHours(h float64) string {
+	if h < 48 {
+		return fmt.Sprintf("%.1fh", h)
+	}
+	return fmt.Sprintf("%.1fd", h/24)
+}
+
+func writeMetricsCSV(report *metrics.Report, table string) error {
+	w := csv.NewWriter(os.Stdout)
+	hours := func(h *float64) string {
+		if h == nil {
+			return ""
+		}
+		return strconv.FormatFloat(*h, 'f', 2, 64)
+	}
+	stamp := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.UTC().Format(time.RFC3339)
+	}
+
+	switch table {
+	case "elements":
+		w.Write([]string{"id", "type", "status", "assignee", "created_at", "started_at", "done_at", "cycle_hours", "lead_hours"})
+		for _, e := range report.Elements {
+			w.Write([]string{e.ID, e.Type, e.Status, e.Assignee, stamp(e.CreatedAt), stamp(e.StartedAt), stamp(e.DoneAt),
+				hours(e.CycleHours), hours(e.LeadHours)})
+		}
+	case "throughput":
+		w.Write([]string{"period", "count", "ids"})
+		for _, p := range report.Throughput {
+			w.Write([]string{p.Period, strconv.Itoa(p.Count), strings.Join(p.IDs, " ")})
+		}
+	case "status":
+		w.Write([]string{"status", "category", "visits", "total_hours", "mean_hours"})
+		for _, s := range report.TimeInStatus {
+			w.Write([]string{string(s.Status), string(s.Category), strconv.Itoa(s.Visits),
+				hours(&s.TotalHours), hours(&s.MeanHours)})
+		}
+	case "flow":
+		header := []string{"date"}
+		for _, s := range report.Statuses {
+			header = append(header, string(s))
+		}
+		w.Write(header)
+		for _, p := range report.CumulativeFlow {
+			row := []string{p.Date}
+			for _, s := range report.Statuses {
+				row = append(row, strconv.Itoa(p.Counts[s]))
+			}
+			w.Write(row)
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
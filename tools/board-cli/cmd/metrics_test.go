@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/aagrigore/task-board/internal/board"
+)
+
+func TestMetricsFromHistory(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	passReviewForTest(t, bd, testTask4ID)
+	captureOutput(t, func() {
+		for _, status := range []string{"development", "to-review", "done"} {
+			if err := runProgressStatus(progressStatusCmd, []string{testTask4ID, status}); err != nil {
+				t.Fatal(err)
+			}
+		}
+	})
+	b, _ := board.Load(bd)
+	if h := b.FindByID(testTask4ID).History; len(h) != 3 || h[2].From != board.StatusToReview {
+		t.Fatalf("progress status should record history, got %+v", h)
+	}
+
+	metricsEpic = testEpic2ID
+	jsonOutput = true
+	defer func() { metricsEpic, jsonOutput = "", false }()
+	out := captureOutput(t, func() {
+		if err := runMetrics(metricsCmd, nil); err != nil {
+			t.Fatal(err)
+		}
+	})
+	var resp MetricsResponse
+	if err := json.Unmarshal([]byte(out), &resp); err != nil {
+		t.Fatalf("parse: %v\n%s", err, out)
+	}
+	m := resp.Metrics
+	if len(m.Elements) != 1 || m.Elements[0].ID != testTask4ID {
+		t.Errorf("--epic should keep only the epic's tasks, got %+v", m.Elements)
+	}
+	if m.CycleTime.Count != 1 || len(m.Throughput) != 1 || m.Throughput[0].Count != 1 {
+		t.Errorf("cycle %+v, throughput %+v", m.CycleTime, m.Throughput)
+	}
+
+	metricsEpic = testEpic1ID
+	metricsTypes = []string{"bug"}
+	defer func() { metricsTypes = []string{"task", "bug"} }()
+	out = captureOutput(t, func() { runMetrics(metricsCmd, nil) })
+	resp = MetricsResponse{}
+	json.Unmarshal([]byte(out), &resp)
+	if len(resp.Metrics.Elements) != 1 || resp.Metrics.Elements[0].ID != testBug1ID {
+		t.Errorf("--type bug should keep only bugs, got %+v", resp.Metrics.Elements)
+	}
+}
+
+func TestMetricsCSV(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	captureOutput(t, func() {
+		runProgressStatus(progressStatusCmd, []string{testTask4ID, "development"})
+	})
+
+	metricsCSV = "flow"
+	defer func() { metricsCSV = "" }()
+	out := captureOutput(t, func() {
+		if err := runMetrics(metricsCmd, nil); err != nil {
+			t.Fatal(err)
+		}
+	})
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	// Only the task with history has a timeline; the others have no created time
+	if lines[0] != "date,development" || !strings.HasSuffix(lines[len(lines)-1], ",1") {
+		t.Errorf("unexpected flow CSV:\n%s", out)
+	}
+
+	metricsCSV = "chart"
+	if err := runMetrics(metricsCmd, nil); err == nil {
+		t.Error("unknown CSV tables should be rejected")
+	}
+}
@@ -13,7 +13,7 @@ var moveCmd = &cobra.Command{
 	Use:   "move <ID>",
 	Short: "Move element to a different parent",
 	Args:  cobra.ExactArgs(1),
-	RunE:  runMove,
+	RunE:  recorded(runMove),
 }
 
 var moveToFlag string
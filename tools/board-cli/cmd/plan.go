@@ -25,6 +25,15 @@ type PlanOutput struct {
 	Phases             []PhaseOutput `json:"phases"`
 	CriticalPath       []string      `json:"criticalPath"`
 	CriticalPathLength int           `json:"criticalPathLength"`
+	// External lists the open external blockers holding up elements in scope
+	External []PlanExternal `json:"external"`
+}
+
+// PlanExternal is an external blocker with the elements in scope it holds
+// up: the elements themselves or their ancestors in scope
+type PlanExternal struct {
+	BlockerJSON
+	Holds []string `json:"holds"`
 }
 
 // PhaseOutput represents a phase in JSON format
@@ -51,6 +60,7 @@ var (
 	planLayout       string
 	planActive       bool
 	planEngine       string
+	planMermaid      bool
 )
 
 var planCmd = &cobra.Command{
@@ -70,11 +80,12 @@ func init() {
 	planCmd.Flags().BoolVar(&planSave, "save", false, "Save plan as plan.md")
 	planCmd.Flags().BoolVar(&planCriticalPath, "critical-path", false, "Show only critical path")
 	planCmd.Flags().IntVar(&planPhase, "phase", 0, "Show only specific phase number")
-	planCmd.Flags().BoolVar(&planRender, "render", false, "Render dependency graph via Graphviz")
-	planCmd.Flags().StringVar(&planFormat, "format", "svg", "Render output format: svg, png, pdf")
+	planCmd.Flags().BoolVar(&planRender, "render", false, "Render dependency graph via Graphviz or the built-in SVG layout")
+	planCmd.Flags().StringVar(&planFormat, "format", "svg", "Render output format: svg, png, pdf (Graphviz), mermaid or plantuml (diagram source)")
 	planCmd.Flags().StringVar(&planLayout, "layout", "hierarchy", "Graph layout: hierarchy (epic/story clusters) or phases (phase clusters)")
 	planCmd.Flags().BoolVar(&planActive, "active", false, "Show only active elements (exclude done/closed)")
-	planCmd.Flags().StringVar(&planEngine, "engine", "", "Graphviz engine: dot, neato, fdp, circo, twopi (default: fdp for project, dot for epic/story)")
+	planCmd.Flags().BoolVar(&planMermaid, "mermaid", false, "With --save, embed a Mermaid graph of the --layout in plan.md")
+	planCmd.Flags().StringVar(&planEngine, "engine", "", "Graphviz engine: dot, neato, fdp, circo, twopi, or builtin for the built-in SVG layout (default: fdp for project, dot for epic/story; builtin when Graphviz is missing)")
 }
 
 func runPlan(cmd *cobra.Command, args []string) error {
@@ -119,14 +130,14 @@ func runPlan(cmd *cobra.Command, args []string) error {
 	}
 
 	if planSave {
-		return savePlanMD(b, scopeID, scopeName, p)
+		return savePlanMD(b, scopeID, scopeName, p, elements)
 	}
 
 	if JSONEnabled() {
-		return printPlanJSON(b, scopeID, p)
+		return printPlanJSON(b, scopeID, p, elements)
 	}
 
-	printPlan(scopeName, p)
+	printPlan(scopeName, p, elements)
 	return nil
 }
 
@@ -141,7 +152,7 @@ func scopeLabel(b *board.Board, scopeID string) string {
 	return fmt.Sprintf("%s: %s", elem.ID(), elem.Title)
 }
 
-func printPlan(scopeName string, p *plan.Plan) {
+func printPlan(scopeName string, p *plan.Plan, elements []*board.Element) {
 	// Critical path only mode
 	if planCriticalPath {
 		printCriticalPath(p)
@@ -180,9 +191,39 @@ func printPlan(scopeName string, p *plan.Plan) {
 
 	if planPhase == 0 {
 		printCriticalPath(p)
+		printPlanExternal(elements)
 	}
 }
 
+// printPlanExternal lists the external blockers holding up the plan.
+func printPlanExternal(elements []*board.Element) {
+	external := planExternal(elements)
+	if len(external) == 0 {
+		return
+	}
+	fmt.Printf("\n%sExternal Blockers%s\n", output.Bold, output.Reset)
+	for _, x := range external {
+		fmt.Printf("  %s → %s: %s\n", x.ID, strings.Join(x.Holds, ", "), x.Reason)
+	}
+}
+
+// planExternal groups the external blocker edges of the elements by blocker.
+func planExternal(elements []*board.Element) []PlanExternal {
+	now := time.Now()
+	external := []PlanExternal{}
+	index := map[*board.Blocker]int{}
+	for _, edge := range board.ExternalEdges(elements) {
+		i, ok := index[edge.Blocker]
+		if !ok {
+			i = len(external)
+			index[edge.Blocker] = i
+			external = append(external, PlanExternal{BlockerJSON: blockerJSON(edge.Blocker, now), Holds: []string{}})
+		}
+		external[i].Holds = append(external[i].Holds, edge.To)
+	}
+	return external
+}
+
 func printCriticalPath(p *plan.Plan) {
 	if len(p.CriticalPath) == 0 {
 		fmt.Println("No critical path (no dependencies).")
@@ -197,7 +238,7 @@ func printCriticalPath(p *plan.Plan) {
 	fmt.Printf("  %s (%d phases)\n", strings.Join(ids, " -> "), len(p.Phases))
 }
 
-func printPlanJSON(b *board.Board, scopeID string, p *plan.Plan) error {
+func printPlanJSON(b *board.Board, scopeID string, p *plan.Plan, elements []*board.Element) error {
 	// Get epic info
 	epicID := ""
 	epicName := "Project"
@@ -252,13 +293,14 @@ func printPlanJSON(b *board.Board, scopeID string, p *plan.Plan) error {
 			Phases:             phases,
 			CriticalPath:       criticalPath,
 			CriticalPathLength: len(p.CriticalPath),
+			External:           planExternal(elements),
 		},
 	}
 
 	return output.PrintJSON(os.Stdout, response)
 }
 
-func savePlanMD(b *board.Board, scopeID, scopeName string, p *plan.Plan) error {
+func savePlanMD(b *board.Board, scopeID, scopeName string, p *plan.Plan, elements []*board.Element) error {
 	mdPath, err := planMDPath(b, scopeID)
 	if err != nil {
 		return err
@@ -296,9 +338,25 @@ func savePlanMD(b *board.Board, scopeID, scopeName string, p *plan.Plan) error {
 		sb.WriteString(fmt.Sprintf("%s (%d phases)\n\n", strings.Join(ids, " -> "), len(p.Phases)))
 	}
 
+	if external := planExternal(elements); len(external) > 0 {
+		sb.WriteString("## External Blockers\n")
+		for _, x := range external {
+			sb.WriteString(fmt.Sprintf("- %s (%s): %s\n", x.ID, strings.Join(x.Holds, ", "), x.Reason))
+		}
+		sb.WriteString("\n")
+	}
+
 	sb.WriteString("## Warnings\n")
 	sb.WriteString("- No issues found\n")
 
+	if planMermaid {
+		graph, err := graphText(b, scopeID, "mermaid")
+		if err != nil {
+			return err
+		}
+		sb.WriteString("\n## Graph\n\n```mermaid\n" + graph + "```\n")
+	}
+
 	if err := os.MkdirAll(filepath.Dir(mdPath), 0755); err != nil {
 		return fmt.Errorf("creating directory for plan.md: %w", err)
 	}
@@ -311,54 +369,119 @@ func savePlanMD(b *board.Board, scopeID, scopeName string, p *plan.Plan) error {
 	return nil
 }
 
-func filterActiveElements(elements []*board.Element) []*board.Element {
+func filterActiveElements(wf *board.Workflow, elements []*board.Element) []*board.Element {
 	var result []*board.Element
 	for _, e := range elements {
-		if e.Status != board.StatusDone && e.Status != board.StatusClosed {
+		if !wf.Finished(e) {
 			result = append(result, e)
 		}
 	}
 	return result
 }
 
-func renderGraph(b *board.Board, scopeID string, elements []*board.Element, p *plan.Plan) error {
-	var dot string
-
-	switch planLayout {
-	case "phases":
-		// Collect all descendants, build plan from them, render with phase clusters.
-		allElements, err := plan.AllDescendants(b, scopeID)
-		if err != nil {
-			return err
+// graphElements returns the elements drawn by --render and --mermaid in a
+// layout, with their phase plan for the phases layout. Active leaves out
+// finished elements.
+func graphElements(b *board.Board, scopeID, layout string, active bool) ([]*board.Element, *plan.Plan, error) {
+	allElements, err := plan.AllDescendants(b, scopeID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if layout != "phases" {
+		// Hierarchy layout: full tree with epic/story clusters.
+		if active {
+			allElements = filterActiveElements(b.Workflow, allElements)
 		}
-		// Exclude the root element itself (we want its children in the graph).
-		if scopeID != "" {
-			var filtered []*board.Element
-			for _, e := range allElements {
-				if e.ID() != strings.ToUpper(scopeID) {
-					filtered = append(filtered, e)
-				}
+		return allElements, nil, nil
+	}
+
+	// Exclude the root element itself (we want its children in the graph).
+	if scopeID != "" {
+		var filtered []*board.Element
+		for _, e := range allElements {
+			if e.ID() != strings.ToUpper(scopeID) {
+				filtered = append(filtered, e)
 			}
-			allElements = filtered
-		}
-		if planActive {
-			allElements = filterActiveElements(allElements)
 		}
-		fullPlan := plan.BuildPlan(allElements)
-		if fullPlan.HasCycle {
-			return fmt.Errorf("dependency cycle detected involving: %s", strings.Join(fullPlan.CycleNodes, ", "))
-		}
-		dot = plan.GenerateDOT(fullPlan, allElements)
+		allElements = filtered
+	}
+	if active {
+		allElements = filterActiveElements(b.Workflow, allElements)
+	}
+	fullPlan := plan.BuildPlan(allElements)
+	if fullPlan.HasCycle {
+		return nil, nil, fmt.Errorf("dependency cycle detected involving: %s", strings.Join(fullPlan.CycleNodes, ", "))
+	}
+	return allElements, fullPlan, nil
+}
+
+// graphText generates the graph for format in the current --layout and
+// --active: DOT source, Mermaid or PlantUML source, or "svg" from the
+// built-in layout.
+func graphText(b *board.Board, scopeID, format string) (string, error) {
+	elements, p, err := graphElements(b, scopeID, planLayout, planActive)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case format == "mermaid" && p != nil:
+		return plan.GenerateMermaid(p, elements, b.Workflow), nil
+	case format == "mermaid":
+		return plan.GenerateFullMermaid(b, elements), nil
+	case format == "plantuml" && p != nil:
+		return plan.GeneratePlantUML(p, elements, b.Workflow), nil
+	case format == "plantuml":
+		return plan.GenerateFullPlantUML(b, elements), nil
+	case format == "svg" && p != nil:
+		return plan.GenerateSVG(p, elements, b.Workflow), nil
+	case format == "svg":
+		return plan.GenerateFullSVG(b, elements), nil
+	case p != nil:
+		return plan.GenerateDOT(p, elements, b.Workflow), nil
 	default:
-		// Hierarchy layout: full tree with epic/story clusters.
-		allElements, err := plan.AllDescendants(b, scopeID)
-		if err != nil {
-			return err
-		}
-		if planActive {
-			allElements = filterActiveElements(allElements)
+		return plan.GenerateFullDOT(b, elements), nil
+	}
+}
+
+// textFormats are the --format values written as diagram source instead of
+// being rendered by Graphviz, with their file extensions.
+var textFormats = map[string]string{
+	"mermaid":  "mmd",
+	"plantuml": "puml",
+}
+
+func renderGraph(b *board.Board, scopeID string, elements []*board.Element, p *plan.Plan) error {
+	// Determine engine: use flag if set, otherwise smart default
+	engine := planEngine
+	if engine == "" {
+		if scopeID == "" {
+			engine = "fdp" // project level: force-directed for overview
+		} else {
+			engine = "dot" // epic/story level: hierarchical
 		}
-		dot = plan.GenerateFullDOT(b, allElements)
+	}
+
+	// Mermaid and PlantUML are written as source; SVG falls back to the
+	// built-in layout when Graphviz isn't installed.
+	ext, isText := textFormats[planFormat]
+	if !isText {
+		ext = planFormat
+	}
+	source := "dot"
+	switch {
+	case isText:
+		source = planFormat
+	case engine == "builtin" && planFormat != "svg":
+		return fmt.Errorf("the builtin engine renders svg only, not %s", planFormat)
+	case engine == "builtin":
+		source = "svg"
+	case planFormat == "svg" && !plan.HasEngine(engine):
+		fmt.Fprintf(os.Stderr, "graphviz '%s' not found, using the built-in layout\n", engine)
+		source = "svg"
+	}
+	text, err := graphText(b, scopeID, source)
+	if err != nil {
+		return err
 	}
 
 	suffix := "plan"
@@ -368,22 +491,24 @@ func renderGraph(b *board.Board, scopeID string, elements []*board.Element, p *p
 	if planActive {
 		suffix += "-active"
 	}
-	outputPath, err := plan.RenderOutputPath(b, scopeID, suffix, planFormat)
+	outputPath, err := plan.RenderOutputPath(b, scopeID, suffix, ext)
 	if err != nil {
 		return err
 	}
 
-	// Determine engine: use flag if set, otherwise smart default
-	engine := planEngine
-	if engine == "" {
-		if scopeID == "" {
-			engine = "fdp" // project level: force-directed for overview
+	if source != "dot" {
+		if err := os.WriteFile(outputPath, []byte(text), 0644); err != nil {
+			return fmt.Errorf("writing %s: %w", outputPath, err)
+		}
+		if isText {
+			fmt.Printf("Graph written to %s\n", outputPath)
 		} else {
-			engine = "dot" // epic/story level: hierarchical
+			fmt.Printf("Graph rendered to %s\n", outputPath)
 		}
+		return nil
 	}
 
-	if err := plan.RenderDOT(dot, outputPath, planFormat, engine); err != nil {
+	if err := plan.RenderDOT(text, outputPath, planFormat, engine); err != nil {
 		return err
 	}
 
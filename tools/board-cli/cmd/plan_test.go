@@ -28,6 +28,22 @@ func captureOutput(t *testing.T, fn func()) string {
 	return buf.String()
 }
 
+// captureStderr runs fn and returns what it wrote to os.Stderr.
+func captureStderr(t *testing.T, fn func()) string {
+	t.Helper()
+	old := os.Stderr
+	r, w, _ := os.Pipe()
+	os.Stderr = w
+
+	fn()
+
+	w.Close()
+	os.Stderr = old
+	var buf bytes.Buffer
+	io.Copy(&buf, r)
+	return buf.String()
+}
+
 func resetPlanFlags() {
 	planSave = false
 	planCriticalPath = false
@@ -36,6 +52,8 @@ func resetPlanFlags() {
 	planActive = false
 	planLayout = "hierarchy"
 	planFormat = "svg"
+	planMermaid = false
+	planEngine = ""
 }
 
 func TestPlanNoArgs(t *testing.T) {
@@ -159,6 +177,114 @@ func TestPlanSave(t *testing.T) {
 	}
 }
 
+func TestPlanSaveMermaid(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	resetPlanFlags()
+	planSave = true
+	planMermaid = true
+	defer resetPlanFlags()
+
+	captureOutput(t, func() {
+		if err := runPlan(planCmd, []string{testStory1ID}); err != nil {
+			t.Fatalf("runPlan --save --mermaid: %v", err)
+		}
+	})
+
+	b, err := board.Load(bd)
+	if err != nil {
+		t.Fatalf("loading board: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(b.FindByID(testStory1ID).Path, "plan.md"))
+	if err != nil {
+		t.Fatalf("reading plan.md: %v", err)
+	}
+	s := string(content)
+	if !strings.Contains(s, "## Graph\n\n```mermaid\nflowchart TB\n") {
+		t.Errorf("plan.md missing mermaid block, got:\n%s", s)
+	}
+	if !strings.HasSuffix(s, "```\n") {
+		t.Errorf("mermaid block not closed, got:\n%s", s)
+	}
+}
+
+func TestPlanRenderTextFormats(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	defer resetPlanFlags()
+
+	tests := []struct {
+		format, layout, file, want string
+	}{
+		{"mermaid", "hierarchy", "plan.mmd", "flowchart TB"},
+		{"mermaid", "phases", "plan-phases.mmd", "flowchart LR"},
+		{"plantuml", "hierarchy", "plan.puml", "@startuml"},
+		{"plantuml", "phases", "plan-phases.puml", "left to right direction"},
+	}
+	for _, tt := range tests {
+		resetPlanFlags()
+		planRender = true
+		planFormat = tt.format
+		planLayout = tt.layout
+
+		out := captureOutput(t, func() {
+			if err := runPlan(planCmd, []string{testEpic1ID}); err != nil {
+				t.Fatalf("runPlan --format %s: %v", tt.format, err)
+			}
+		})
+		path := filepath.Join(bd, testEpic1ID+"_recording", ".temp", tt.file)
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("%s/%s: %v (output: %s)", tt.format, tt.layout, err, out)
+		}
+		if !strings.Contains(string(content), tt.want) {
+			t.Errorf("%s/%s: missing %q, got:\n%s", tt.format, tt.layout, tt.want, content)
+		}
+		if !strings.Contains(out, "Graph written to "+path) {
+			t.Errorf("%s/%s: output = %q", tt.format, tt.layout, out)
+		}
+	}
+}
+
+func TestPlanRenderBuiltinEngine(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	defer resetPlanFlags()
+
+	for _, layout := range []string{"hierarchy", "phases"} {
+		resetPlanFlags()
+		planRender = true
+		planEngine = "builtin"
+		planLayout = layout
+
+		out := captureOutput(t, func() {
+			if err := runPlan(planCmd, []string{testEpic1ID}); err != nil {
+				t.Fatalf("runPlan --engine builtin --layout %s: %v", layout, err)
+			}
+		})
+		file := "plan.svg"
+		if layout == "phases" {
+			file = "plan-phases.svg"
+		}
+		content, err := os.ReadFile(filepath.Join(bd, testEpic1ID+"_recording", ".temp", file))
+		if err != nil {
+			t.Fatalf("%s: %v (output: %s)", layout, err, out)
+		}
+		if !strings.HasPrefix(string(content), "<svg ") || !strings.Contains(string(content), testStory1ID) {
+			t.Errorf("%s: unexpected SVG:\n%s", layout, content)
+		}
+	}
+
+	resetPlanFlags()
+	planRender = true
+	planEngine = "builtin"
+	planFormat = "png"
+	err := runPlan(planCmd, []string{testEpic1ID})
+	if err == nil || !strings.Contains(err.Error(), "svg only") {
+		t.Errorf("builtin png error = %v", err)
+	}
+}
+
 func TestPlanSaveEpic(t *testing.T) {
 	bd := setupTestBoard(t)
 	boardDir = bd
@@ -421,7 +547,7 @@ func TestActiveFilterRemovesDoneAndClosed(t *testing.T) {
 		{Title: "Blocked Task", Status: board.StatusBlocked},
 	}
 
-	result := filterActiveElements(elements)
+	result := filterActiveElements(nil, elements)
 
 	if len(result) != 3 {
 		t.Fatalf("expected 3 active elements, got %d", len(result))
@@ -446,12 +572,12 @@ func TestActiveFilterRemovesDoneAndClosed(t *testing.T) {
 }
 
 func TestActiveFilterEmptyInput(t *testing.T) {
-	result := filterActiveElements(nil)
+	result := filterActiveElements(nil, nil)
 	if result != nil {
 		t.Errorf("expected nil for nil input, got %v", result)
 	}
 
-	result = filterActiveElements([]*board.Element{})
+	result = filterActiveElements(nil, []*board.Element{})
 	if result != nil {
 		t.Errorf("expected nil for empty input, got %v", result)
 	}
@@ -464,7 +590,7 @@ func TestActiveFilterAllDone(t *testing.T) {
 		{Title: "Closed 1", Status: board.StatusClosed},
 	}
 
-	result := filterActiveElements(elements)
+	result := filterActiveElements(nil, elements)
 	if result != nil {
 		t.Errorf("expected nil when all elements are done/closed, got %d elements", len(result))
 	}
@@ -476,7 +602,7 @@ func TestActiveFilterNoneDone(t *testing.T) {
 		{Title: "Progress 1", Status: board.StatusDevelopment},
 	}
 
-	result := filterActiveElements(elements)
+	result := filterActiveElements(nil, elements)
 	if len(result) != 2 {
 		t.Fatalf("expected 2 elements when none are done/closed, got %d", len(result))
 	}
@@ -499,7 +625,7 @@ func TestActiveRenderHierarchyExcludesDone(t *testing.T) {
 		t.Fatalf("AllDescendants: %v", err)
 	}
 
-	filtered := filterActiveElements(allElements)
+	filtered := filterActiveElements(nil, allElements)
 
 	// Verify done/closed elements are excluded
 	for _, e := range filtered {
@@ -569,14 +695,14 @@ func TestActiveRenderPhasesExcludesDone(t *testing.T) {
 		}
 	}
 
-	filtered := filterActiveElements(withoutRoot)
+	filtered := filterActiveElements(nil, withoutRoot)
 
 	fullPlan := plan.BuildPlan(filtered)
 	if fullPlan.HasCycle {
 		t.Fatalf("unexpected cycle: %v", fullPlan.CycleNodes)
 	}
 
-	dot := plan.GenerateDOT(fullPlan, filtered)
+	dot := plan.GenerateDOT(fullPlan, filtered, b.Workflow)
 	if strings.Contains(strings.ToLower(dot), strings.ToLower(mixedTask1ID)) {
 		t.Errorf("DOT output should not contain TASK-01 (done), got:\n%s", dot)
 	}
@@ -608,7 +734,7 @@ func TestActiveProjectLevelExcludesDoneEpic(t *testing.T) {
 		t.Fatalf("AllDescendants: %v", err)
 	}
 
-	filtered := filterActiveElements(allElements)
+	filtered := filterActiveElements(nil, allElements)
 
 	// EPIC-02 is done, should be excluded
 	for _, e := range filtered {
@@ -8,13 +8,16 @@ import (
 
 	"github.com/aagrigore/task-board/internal/board"
 	"github.com/aagrigore/task-board/internal/output"
+	"github.com/aagrigore/task-board/internal/policy"
+	"github.com/aagrigore/task-board/internal/wip"
 	"github.com/spf13/cobra"
 )
 
 // ProgressResponse represents the JSON response for progress commands
 type ProgressResponse struct {
-	Updated UpdatedElement `json:"updated"`
-	Message string         `json:"message"`
+	Updated  UpdatedElement     `json:"updated"`
+	Message  string             `json:"message"`
+	Warnings []policy.Violation `json:"warnings,omitempty"`
 }
 
 // ChecklistResponse represents the JSON response for checklist commands
@@ -36,7 +39,9 @@ var progressStatusCmd = &cobra.Command{
 	Short: "Set element status",
 	Long: `Set element status.
 
-Valid statuses: backlog, analysis, to-dev, development, to-review, reviewing, done, closed, blocked
+Valid statuses (default workflow): backlog, analysis, to-dev, development,
+to-review, reviewing, done, closed, blocked. .task-board/workflow.yaml can
+change them per type; see 'task-board workflow show'.
 
 Aliases: dev (development), review (reviewing), todev (to-dev), toreview (to-review)
 
@@ -44,11 +49,29 @@ Auto-promotion: When all children of a story/epic become done/closed,
 the parent is automatically promoted to done. Cascades up the hierarchy.
 
 Auto-reopen: When a child becomes active and the parent is done/closed,
-the parent is automatically reopened to development.
+the parent is automatically reopened to the active status it had before it
+finished, or to development when there is none.
 
-Dependency blocking: Cannot start development if blocked by unfinished tasks.`,
+Entering a blocked status remembers the one left under "## Prior Status"
+in progress.md; 'task-board unblock' uses it to undo the block.
+
+Dependency blocking: Cannot start development if blocked by unfinished tasks.
+
+WIP limits: Refused with WIP_LIMIT when the change would exceed a limit
+from the workflow's wip-limits, unless --force is given.
+
+Review gating: Entering a done status is refused with REVIEW_REQUIRED
+until every acceptance criterion has passed 'task-board review', unless
+--force is given.
+
+Policies: Rules of the board's policy.yaml for the target status are
+checked; a broken error rule refuses the change with POLICY_VIOLATION
+unless --force is given, a broken warn rule is reported.
+
+Time tracking: Entering a status listed under time-tracking.auto in the
+workflow starts a timer; leaving those statuses stops it.`,
 	Args: cobra.ExactArgs(2),
-	RunE: runProgressStatus,
+	RunE: recorded(runProgressStatus),
 }
 
 var progressChecklistCmd = &cobra.Command{
@@ -62,31 +85,34 @@ var progressCheckCmd = &cobra.Command{
 	Use:   "check <ID> <item-number>",
 	Short: "Check a checklist item",
 	Args:  cobra.ExactArgs(2),
-	RunE:  runProgressCheck,
+	RunE:  recorded(runProgressCheck),
 }
 
 var progressUncheckCmd = &cobra.Command{
 	Use:   "uncheck <ID> <item-number>",
 	Short: "Uncheck a checklist item",
 	Args:  cobra.ExactArgs(2),
-	RunE:  runProgressUncheck,
+	RunE:  recorded(runProgressUncheck),
 }
 
 var progressAddItemCmd = &cobra.Command{
 	Use:   "add-item <ID> <text>",
 	Short: "Add a checklist item",
 	Args:  cobra.ExactArgs(2),
-	RunE:  runProgressAddItem,
+	RunE:  recorded(runProgressAddItem),
 }
 
 var progressNotesCmd = &cobra.Command{
 	Use:   "notes <ID> <text>",
 	Short: "Add or set notes on an element",
 	Args:  cobra.ExactArgs(2),
-	RunE:  runProgressNotes,
+	RunE:  recorded(runProgressNotes),
 }
 
-var progressNotesSet bool
+var (
+	progressNotesSet    bool
+	progressStatusForce bool
+)
 
 func init() {
 	rootCmd.AddCommand(progressCmd)
@@ -98,18 +124,11 @@ func init() {
 	progressCmd.AddCommand(progressNotesCmd)
 
 	progressNotesCmd.Flags().BoolVar(&progressNotesSet, "set", false, "Replace all notes (default: append)")
+	progressStatusCmd.Flags().BoolVar(&progressStatusForce, "force", false, "Exceed WIP limits and skip the acceptance review and policy rules")
 }
 
 func runProgressStatus(cmd *cobra.Command, args []string) error {
 	id := args[0]
-	newStatus, err := board.ParseStatus(args[1])
-	if err != nil {
-		if JSONEnabled() {
-			output.PrintError(os.Stderr, output.InvalidStatus, err.Error(), nil)
-			return nil
-		}
-		return err
-	}
 
 	b, err := board.Load(boardDir)
 	if err != nil {
@@ -140,41 +159,46 @@ func runProgressStatus(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("reading progress: %w", err)
 	}
 
-	// Check blockedBy before allowing development or later
-	if newStatus == board.StatusDevelopment || newStatus == board.StatusToReview ||
-		newStatus == board.StatusReviewing || newStatus == board.StatusDone {
-		activeBlockers := b.ActiveBlockers(elem)
-		if len(activeBlockers) > 0 {
-			var blockerDescs []string
-			var blockerIDs []string
-			for _, blocker := range activeBlockers {
-				blockerDescs = append(blockerDescs, fmt.Sprintf("%s (status: %s)", blocker.ID(), blocker.Status))
-				blockerIDs = append(blockerIDs, blocker.ID())
-			}
-			if JSONEnabled() {
-				output.PrintError(os.Stderr, output.ValidationError,
-					fmt.Sprintf("Cannot set %s to %s — blocked by unfinished tasks", id, newStatus),
-					map[string]interface{}{
-						"blockedBy": blockerIDs,
-					})
-				return nil
-			}
-			return fmt.Errorf("cannot set %s to %s — blocked by:\n  %s",
-				id, newStatus, strings.Join(blockerDescs, "\n  "))
+	flow := b.Flow(elem.Type)
+	newStatus, err := flow.Parse(args[1])
+	if err != nil {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.InvalidStatus, fmt.Sprintf("%s (%s workflow)", err.Error(), elem.Type), map[string]interface{}{
+				"valid": flow.Names(),
+			})
+			return nil
 		}
+		return fmt.Errorf("%w (%s workflow)", err, elem.Type)
 	}
 
-	pd.Status = newStatus
-	if err := board.WriteProgressFile(elem.ProgressPath(), pd); err != nil {
+	if refusal := transitionRefusal(b, elem, newStatus, progressStatusForce); refusal != nil {
 		if JSONEnabled() {
-			output.PrintError(os.Stderr, output.InternalError, fmt.Sprintf("writing progress: %v", err), nil)
+			output.PrintError(os.Stderr, refusal.code, fmt.Sprintf("%s: %s", id, refusal.message), refusal.details)
 			return nil
 		}
-		return fmt.Errorf("writing progress: %w", err)
+		if refusal.hint != "" {
+			return fmt.Errorf("%s: %s (%s)", id, refusal.message, refusal.hint)
+		}
+		return fmt.Errorf("%s: %s", id, refusal.message)
+	}
+	// Warning rules are reported even when --force skipped the error ones
+	p, err := policy.Load(b)
+	if err != nil {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.ValidationError, err.Error(), nil)
+			return nil
+		}
+		return err
 	}
+	ruled := p.Check(b, elem, newStatus)
 
-	// Update in-memory status for auto-promotion check
-	elem.Status = newStatus
+	if err := setStatus(b, elem, pd, newStatus, fmt.Sprintf("%s → %s", id, newStatus)); err != nil {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.InternalError, err.Error(), nil)
+			return nil
+		}
+		return err
+	}
 
 	if JSONEnabled() {
 		// Get name from README
@@ -192,28 +216,159 @@ func runProgressStatus(cmd *cobra.Command, args []string) error {
 				Status:   string(newStatus),
 				Assignee: pd.AssignedTo,
 			},
-			Message: fmt.Sprintf("Status changed to %s", newStatus),
+			Message:  fmt.Sprintf("Status changed to %s", newStatus),
+			Warnings: policy.Warnings(ruled),
 		}
 		output.PrintJSON(os.Stdout, response)
 	} else {
-		fmt.Printf("%s → %s\n", id, newStatus)
+		for _, v := range policy.Warnings(ruled) {
+			fmt.Fprintf(os.Stderr, "warning: %s [%s]\n", v.Message, v.Rule)
+		}
 	}
+	return nil
+}
 
+// cascadeStatus updates the parents of an element whose status just changed.
+func cascadeStatus(b *board.Board, elem *board.Element) {
 	// Auto-promote parent if all children are done
-	if newStatus == board.StatusDone {
+	category := b.Workflow.Category(elem)
+	if category == board.CategoryDone {
 		promoteParentIfAllChildrenDone(b, elem)
 	}
 
 	// Auto-reopen parent if child becomes active (not done/closed/blocked)
-	if newStatus != board.StatusDone && newStatus != board.StatusClosed && newStatus != board.StatusBlocked {
+	if category == board.CategoryTodo || category == board.CategoryActive {
 		reopenParentIfNeeded(b, elem)
 	}
+}
+
+// refusal is why a status change is refused. message reads after the
+// element ID, e.g. "cannot set to done — …"; code and details go into JSON
+// errors and hint into text ones.
+type refusal struct {
+	code    output.ErrorCode
+	message string
+	hint    string
+	details map[string]interface{}
+}
+
+// transitionRefusal explains why progress status would refuse to move elem
+// to status, or returns nil when it may. WIP limits, the acceptance review
+// and policy rules are skipped when force is set.
+func transitionRefusal(b *board.Board, elem *board.Element, status board.Status, force bool) *refusal {
+	flow := b.Flow(elem.Type)
+	if !flow.Allows(elem.Status, status) {
+		allowed := flow.Transitions[elem.Status]
+		return &refusal{
+			code:    output.InvalidStatus,
+			message: fmt.Sprintf("cannot change from %s to %s (allowed: %s)", elem.Status, status, joinStatuses(allowed)),
+			details: map[string]interface{}{"from": elem.Status, "to": status, "allowed": allowed},
+		}
+	}
+	// Check blockedBy before statuses the workflow keeps for unblocked work
+	if def := flow.Def(status); def != nil && def.NeedsUnblocked {
+		if blockers := b.ActiveBlockers(elem); len(blockers) > 0 {
+			ids := make([]string, len(blockers))
+			descs := make([]string, len(blockers))
+			for i, blocker := range blockers {
+				ids[i] = blocker.ID()
+				descs[i] = fmt.Sprintf("%s (%s)", blocker.ID(), blocker.Status)
+			}
+			return &refusal{
+				code:    output.ValidationError,
+				message: fmt.Sprintf("cannot set to %s — blocked by %s", status, strings.Join(descs, ", ")),
+				details: map[string]interface{}{"blockedBy": ids},
+			}
+		}
+	}
+	if !force {
+		if violations := wip.Check(b, elem, status, elem.AssignedTo); len(violations) > 0 {
+			return &refusal{
+				code:    output.WIPLimit,
+				message: fmt.Sprintf("cannot set to %s — %s", status, describeWIP(violations)),
+				hint:    "use --force to exceed",
+				details: map[string]interface{}{"limits": violations},
+			}
+		}
+		return gateRefusal(b, elem, status)
+	}
+	return nil
+}
 
+// gateRefusal explains why elem may not enter status before its acceptance
+// review passes or while it breaks an error rule of the policy, or returns
+// nil. Auto-promotion runs it too.
+func gateRefusal(b *board.Board, elem *board.Element, status board.Status) *refusal {
+	if b.Flow(elem.Type).Category(status) == board.CategoryDone {
+		if why := reviewRefusal(elem); why != "" {
+			return &refusal{
+				code:    output.ReviewRequired,
+				message: fmt.Sprintf("cannot set to %s — %s", status, why),
+				hint:    "record them with 'task-board review' or use --force",
+				details: map[string]interface{}{"criteria": criteriaJSON(board.Unpassed(elem.Criteria()))},
+			}
+		}
+	}
+	p, err := policy.Load(b)
+	if err != nil {
+		return &refusal{code: output.ValidationError, message: err.Error()}
+	}
+	if broken := policy.Errors(p.Check(b, elem, status)); len(broken) > 0 {
+		return &refusal{
+			code:    output.PolicyViolation,
+			message: fmt.Sprintf("cannot set to %s — %s", status, describePolicy(broken)),
+			hint:    "use --force to override",
+			details: map[string]interface{}{"violations": broken},
+		}
+	}
 	return nil
 }
 
-// promoteParentIfAllChildrenDone checks if all children of the parent are done/closed,
-// and if so, automatically promotes the parent to done. Recurses up the hierarchy.
+// describePolicy summarises broken rules, e.g. "1 unchecked checklist
+// item(s) (checklist-before-review)".
+func describePolicy(violations []policy.Violation) string {
+	parts := make([]string, len(violations))
+	for i, v := range violations {
+		parts[i] = fmt.Sprintf("%s (%s)", strings.TrimPrefix(v.Message, v.ElementID+": "), v.Rule)
+	}
+	return strings.Join(parts, "; ")
+}
+
+// describeWIP summarises exceeded limits, e.g. "development WIP limit 3 (would be 4)".
+func describeWIP(violations []wip.Usage) string {
+	parts := make([]string, len(violations))
+	for i, v := range violations {
+		parts[i] = fmt.Sprintf("%s WIP limit %d (would be %d)", wipLabel(v), v.Limit, v.Count)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// wipLabel names the bucket of a WIP usage for text output.
+func wipLabel(u wip.Usage) string {
+	switch u.Scope {
+	case wip.ScopeAssignee:
+		return "@" + u.Key
+	case wip.ScopeStory:
+		return "story " + u.Key
+	default:
+		return u.Key
+	}
+}
+
+func joinStatuses(statuses []board.Status) string {
+	if len(statuses) == 0 {
+		return "none"
+	}
+	names := make([]string, len(statuses))
+	for i, s := range statuses {
+		names[i] = string(s)
+	}
+	return strings.Join(names, ", ")
+}
+
+// promoteParentIfAllChildrenDone checks if all children of the parent are finished,
+// and if so, automatically promotes the parent to its workflow's promote-to
+// status. Recurses up the hierarchy.
 func promoteParentIfAllChildrenDone(b *board.Board, elem *board.Element) {
 	parent := b.ParentOf(elem)
 	if parent == nil {
@@ -223,7 +378,7 @@ func promoteParentIfAllChildrenDone(b *board.Board, elem *board.Element) {
 	// Check if all children of parent are done or closed
 	children := b.Children(parent)
 	for _, child := range children {
-		if child.Status != board.StatusDone && child.Status != board.StatusClosed {
+		if !b.Workflow.Finished(child) {
 			return // not all children done, don't promote
 		}
 	}
@@ -234,19 +389,26 @@ func promoteParentIfAllChildrenDone(b *board.Board, elem *board.Element) {
 		return // silently skip on error
 	}
 
-	if parentPd.Status == board.StatusDone || parentPd.Status == board.StatusClosed {
+	if b.Workflow.Finished(parent) {
 		return // already done/closed
 	}
 
-	parentPd.Status = board.StatusDone
+	target := b.Flow(parent.Type).PromoteTo
+	// Promotion skips the workflow's transitions, as parents jump straight
+	// to done, but not the review and policy rules
+	if refusal := gateRefusal(b, parent, target); refusal != nil {
+		infof("%s not promoted: %s\n", parent.ID(), refusal.message)
+		return
+	}
+	parentPd.SetStatus(target, b.Flow(parent.Type))
 	if err := board.WriteProgressFile(parent.ProgressPath(), parentPd); err != nil {
 		return // silently skip on error
 	}
 
 	// Update in-memory status for recursive check
-	parent.Status = board.StatusDone
+	parent.Status = target
 
-	fmt.Printf("%s → %s (auto-promoted: all children done)\n", parent.ID(), board.StatusDone)
+	infof("%s → %s (auto-promoted: all children done)\n", parent.ID(), target)
 
 	// Recursively check grandparent
 	promoteParentIfAllChildrenDone(b, parent)
@@ -261,23 +423,31 @@ func reopenParentIfNeeded(b *board.Board, elem *board.Element) {
 	}
 
 	// Only reopen if parent is currently done or closed
-	if parent.Status != board.StatusDone && parent.Status != board.StatusClosed {
-		return // parent is already open/progress/blocked
+	target := b.Flow(parent.Type).ReopenTo
+	if !b.Workflow.Finished(parent) || target == "" {
+		return // parent is already open/progress/blocked, or never reopens
 	}
 
-	// Reopen parent to development (since it has work in progress)
 	parentPd, err := board.ParseProgressFile(parent.ProgressPath())
 	if err != nil {
 		return
 	}
 
-	parentPd.Status = board.StatusDevelopment
+	// Reopen parent to the active status it had before it finished, else
+	// to its workflow's reopen-to status (development by default). A todo
+	// status is no use: promoted parents mostly come from backlog
+	if prior, ok := priorStatus(b.Flow(parent.Type), parentPd, func(c board.Category) bool {
+		return c == board.CategoryActive
+	}); ok {
+		target = prior
+	}
+	parentPd.SetStatus(target, b.Flow(parent.Type))
 	if err := board.WriteProgressFile(parent.ProgressPath(), parentPd); err != nil {
 		return
 	}
 
-	parent.Status = board.StatusDevelopment
-	fmt.Printf("%s → %s (auto-reopened: child active)\n", parent.ID(), board.StatusDevelopment)
+	parent.Status = target
+	infof("%s → %s (auto-reopened: child active)\n", parent.ID(), target)
 
 	// Recursively check grandparent
 	reopenParentIfNeeded(b, parent)
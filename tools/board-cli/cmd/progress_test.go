@@ -1,10 +1,13 @@
 package cmd
 
 import (
+	"os"
 	"strings"
 	"testing"
 
 	"github.com/aagrigore/task-board/internal/board"
+	"github.com/aagrigore/task-board/internal/output"
+	"github.com/aagrigore/task-board/internal/policy"
 )
 
 func TestProgressStatusSet(t *testing.T) {
@@ -107,3 +110,30 @@ func TestProgressAddItem(t *testing.T) {
 		t.Errorf("item text = %q, want 'New step'", task1.Checklist[2].Text)
 	}
 }
+
+func TestProgressStatusRefusesLikeOtherCommands(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	if err := os.WriteFile(policy.Path(bd), []byte("bogus: [\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	jsonOutput = true
+	stderr := captureStderr(t, func() {
+		runProgressStatus(progressStatusCmd, []string{testTask3ID, "development"})
+	})
+	jsonOutput = false
+	if !strings.Contains(stderr, `"code": "VALIDATION_ERROR"`) {
+		t.Errorf("a broken policy should be a validation error, got %s", stderr)
+	}
+
+	b, _ := board.Load(bd)
+	refusal := transitionRefusal(b, b.FindByID(testTask3ID), board.StatusDevelopment, false)
+	if refusal == nil || refusal.code != output.ValidationError {
+		t.Fatalf("refusal = %+v, want a validation error", refusal)
+	}
+	err := runProgressStatus(progressStatusCmd, []string{testTask3ID, "development"})
+	if err == nil || err.Error() != testTask3ID+": "+refusal.message {
+		t.Errorf("error = %v, want %q", err, refusal.message)
+	}
+}
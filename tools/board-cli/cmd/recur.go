@@ -0,0 +1,350 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aagrigore/task-board/internal/board"
+	"github.com/aagrigore/task-board/internal/output"
+	"github.com/aagrigore/task-board/internal/recur"
+	"github.com/spf13/cobra"
+)
+
+// RecurResponse is the JSON response for recur
+type RecurResponse struct {
+	ID         string         `json:"id"`
+	Recurrence RecurrenceJSON `json:"recurrence"`
+	Message    string         `json:"message"`
+}
+
+// RecurrenceJSON is the recurrence of a template or an occurrence
+type RecurrenceJSON struct {
+	Rule     string `json:"rule,omitempty"`
+	Next     string `json:"next,omitempty"`
+	Last     string `json:"last,omitempty"`
+	Template string `json:"template,omitempty"`
+	Previous string `json:"previous,omitempty"`
+}
+
+// TickResponse is the JSON response for tick
+type TickResponse struct {
+	Created []TickInstance `json:"created"`
+	// Problems lists templates whose rule can't be read
+	Problems []ElementProblem `json:"problems"`
+	DryRun   bool             `json:"dryRun"`
+}
+
+// TickInstance is an occurrence made from a template
+type TickInstance struct {
+	ID       string `json:"id"` // empty in a dry run
+	Name     string `json:"name"`
+	Template string `json:"template"`
+	Previous string `json:"previous"`
+	Due      string `json:"due"`
+	Next     string `json:"next"` // when the template is due again
+	Path     string `json:"path"`
+}
+
+// ElementProblem is an element a command skipped, and why
+type ElementProblem struct {
+	ID    string `json:"id"`
+	Error string `json:"error"`
+}
+
+var recurCmd = &cobra.Command{
+	Use:   "recur <ID>",
+	Short: "Make a task or bug recur, or show its recurrence",
+	Long: `Make a task or bug a template that 'task-board tick' copies into a new
+occurrence under the same story whenever it is due.
+
+Rules:
+  daily, weekly, monthly, yearly
+  every 3d, every 2w, every 2 months
+  cron fields "minute hour day-of-month month day-of-week", e.g.
+  "0 9 * * 1" (Mondays at 09:00, local time)
+
+The first occurrence is due at --from (default now) for interval rules, at
+the first match from then on for cron rules. --every none stops the
+recurrence; closing the template pauses it.`,
+	Args: cobra.ExactArgs(1),
+	RunE: recorded(runRecur),
+}
+
+var tickCmd = &cobra.Command{
+	Use:   "tick",
+	Short: "Create the occurrences of recurring elements that are due",
+	Long: `Copy every recurring template that is due into a new occurrence under the
+same story: README, assignee, estimate and checklist (unchecked), in the
+initial status. The occurrence records its template and the occurrence
+before it. A template missed several times gets a single occurrence, so
+tick is safe to run as often as you like, e.g. from cron:
+
+  */15 * * * *  cd /path/to/project && task-board tick`,
+	Args: cobra.NoArgs,
+	RunE: recorded(runTick),
+}
+
+var (
+	recurEvery string
+	recurFrom  string
+	tickDryRun bool
+	recurOff   = map[string]bool{"none": true, "never": true, "off": true}
+)
+
+func init() {
+	rootCmd.AddCommand(recurCmd)
+	rootCmd.AddCommand(tickCmd)
+	recurCmd.Flags().StringVar(&recurEvery, "every", "", `Recurrence rule (weekly, "every 2w", "0 9 * * 1"; none to stop)`)
+	recurCmd.Flags().StringVar(&recurFrom, "from", "", "First occurrence (2006-01-02 or 2006-01-02 15:04, local time; default now)")
+	tickCmd.Flags().BoolVar(&tickDryRun, "dry-run", false, "List what would be created without writing")
+}
+
+// parseDate reads a local date or time for a flag: 2006-01-02,
+// "2006-01-02 15:04" or RFC3339.
+func parseDate(flag, s string) (time.Time, error) {
+	for _, layout := range []string{"2006-01-02", "2006-01-02 15:04", "2006-01-02T15:04"} {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("--%s: cannot parse %q as a date (2006-01-02 or 2006-01-02 15:04)", flag, s)
+}
+
+func runRecur(cmd *cobra.Command, args []string) error {
+	fail := func(code output.ErrorCode, err error) error {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, code, err.Error(), nil)
+			return nil
+		}
+		return err
+	}
+
+	b, err := board.Load(boardDir)
+	if err != nil {
+		return fail(output.InternalError, fmt.Errorf("loading board: %w", err))
+	}
+	elem := b.FindByID(args[0])
+	if elem == nil {
+		return fail(output.NotFound, fmt.Errorf("element %s not found", args[0]))
+	}
+	pd, err := board.ParseProgressFile(elem.ProgressPath())
+	if err != nil {
+		return fail(output.InternalError, fmt.Errorf("reading progress: %w", err))
+	}
+
+	message := describeRecurrence(pd.Recurrence)
+	if cmd.Flags().Changed("every") {
+		switch {
+		case elem.Type != board.TaskType && elem.Type != board.BugType:
+			return fail(output.ValidationError, fmt.Errorf("%s is a %s; only tasks and bugs recur", elem.ID(), elem.Type))
+		case pd.Recurrence.Template != "":
+			return fail(output.ValidationError, fmt.Errorf("%s is an occurrence of %s; set the rule on the template", elem.ID(), pd.Recurrence.Template))
+		}
+
+		if recurOff[strings.ToLower(recurEvery)] {
+			pd.Recurrence.Rule, pd.Recurrence.Next = "", time.Time{}
+			message = "recurrence stopped"
+		} else {
+			rule, err := recur.Parse(recurEvery)
+			if err != nil {
+				return fail(output.ValidationError, err)
+			}
+			from := time.Now()
+			if recurFrom != "" {
+				if from, err = parseDate("from", recurFrom); err != nil {
+					return fail(output.ValidationError, err)
+				}
+			}
+			pd.Recurrence.Rule = rule.String()
+			pd.Recurrence.Next = rule.First(from).UTC().Truncate(time.Second)
+			message = describeRecurrence(pd.Recurrence)
+		}
+		if err := board.WriteProgressFile(elem.ProgressPath(), pd); err != nil {
+			return fail(output.InternalError, fmt.Errorf("writing progress: %w", err))
+		}
+	}
+
+	if JSONEnabled() {
+		return output.PrintJSON(os.Stdout, RecurResponse{ID: elem.ID(), Recurrence: recurrenceJSON(pd.Recurrence), Message: message})
+	}
+	fmt.Printf("%s %s\n", elem.ID(), message)
+	return nil
+}
+
+// describeRecurrence reads like "recurs weekly, next 2026-10-19 09:00".
+func describeRecurrence(r board.Recurrence) string {
+	var parts []string
+	if r.Rule != "" {
+		parts = append(parts, fmt.Sprintf("recurs %s, next %s", r.Rule, r.Next.Local().Format("2006-01-02 15:04")))
+		if r.Last != "" {
+			parts = append(parts, "last "+r.Last)
+		}
+	}
+	if r.Template != "" {
+		occurrence := "occurrence of " + r.Template
+		if r.Previous != "" {
+			occurrence += " after " + r.Previous
+		}
+		parts = append(parts, occurrence)
+	}
+	if len(parts) == 0 {
+		return "does not recur"
+	}
+	return strings.Join(parts, "; ")
+}
+
+func recurrenceJSON(r board.Recurrence) RecurrenceJSON {
+	j := RecurrenceJSON{Rule: r.Rule, Last: r.Last, Template: r.Template, Previous: r.Previous}
+	if !r.Next.IsZero() {
+		j.Next = r.Next.UTC().Format(time.RFC3339)
+	}
+	return j
+}
+
+func runTick(cmd *cobra.Command, args []string) error {
+	fail := func(code output.ErrorCode, err error) error {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, code, err.Error(), nil)
+			return nil
+		}
+		return err
+	}
+
+	b, err := board.Load(boardDir)
+	if err != nil {
+		return fail(output.InternalError, fmt.Errorf("loading board: %w", err))
+	}
+
+	now := time.Now()
+	response := TickResponse{Created: []TickInstance{}, Problems: []ElementProblem{}, DryRun: tickDryRun}
+	for _, tmpl := range b.Elements {
+		r := tmpl.Recurrence
+		if r.Rule == "" || r.Next.After(now) || b.Workflow.Category(tmpl) == board.CategoryClosed {
+			continue
+		}
+		if r.Next.IsZero() {
+			// A rule written by hand without a due time is due now
+			r.Next = now
+		}
+		rule, err := recur.Parse(r.Rule)
+		if err != nil {
+			response.Problems = append(response.Problems, ElementProblem{ID: tmpl.ID(), Error: err.Error()})
+			continue
+		}
+
+		// One occurrence however many were missed; the next is the first
+		// one still ahead
+		due := r.Next.Local()
+		next := due
+		for !next.After(now) {
+			if next = rule.Next(next); next.IsZero() {
+				break
+			}
+		}
+
+		instance, err := tickInstance(b, tmpl, due, next)
+		if err != nil {
+			return fail(output.InternalError, fmt.Errorf("%s: %w", tmpl.ID(), err))
+		}
+		response.Created = append(response.Created, instance)
+	}
+
+	if JSONEnabled() {
+		return output.PrintJSON(os.Stdout, response)
+	}
+	for _, p := range response.Problems {
+		fmt.Fprintf(os.Stderr, "warning: %s: %s\n", p.ID, p.Error)
+	}
+	if len(response.Created) == 0 {
+		fmt.Println("Nothing due.")
+		return nil
+	}
+	verb := "Created"
+	if tickDryRun {
+		verb = "Would create"
+	}
+	for _, c := range response.Created {
+		id := c.ID
+		if id == "" {
+			id = "occurrence"
+		}
+		fmt.Printf("%s %s: %s (from %s, due %s)\n", verb, id, c.Name, c.Template, c.Due)
+	}
+	return nil
+}
+
+// tickInstance copies a template into a new occurrence due at due, then
+// moves the template on to next (zero when the rule has run out).
+func tickInstance(b *board.Board, tmpl *board.Element, due, next time.Time) (TickInstance, error) {
+	rd, err := board.ParseReadmeFile(tmpl.ReadmePath())
+	if err != nil {
+		return TickInstance{}, fmt.Errorf("reading README: %w", err)
+	}
+	name := fmt.Sprintf("%s (%s)", elementTitle(tmpl), due.Format("2006-01-02"))
+	instance := TickInstance{
+		Name:     name,
+		Template: tmpl.ID(),
+		Due:      due.Format("2006-01-02 15:04"),
+		Path:     computeRelativePath(b.Dir, filepath.Dir(tmpl.Path)),
+	}
+	// The previous occurrence is the latest one still on the board
+	if last := tmpl.Recurrence.Last; last != "" && b.FindByID(last) != nil {
+		instance.Previous = last
+	}
+	if !next.IsZero() {
+		instance.Next = next.Format("2006-01-02 15:04")
+	}
+	if tickDryRun {
+		return instance, nil
+	}
+
+	status := b.Flow(tmpl.Type).Initial
+	id, path, err := writeNewElement(tmpl.Type, status, name, rd.Description, filepath.Dir(tmpl.Path))
+	if err != nil {
+		return TickInstance{}, err
+	}
+	instanceRd := *rd
+	instanceRd.Title = fmt.Sprintf("%s: %s", id, name)
+	if err := board.WriteReadmeFile(filepath.Join(path, "README.md"), &instanceRd); err != nil {
+		return TickInstance{}, fmt.Errorf("writing README.md: %w", err)
+	}
+
+	progressPath := filepath.Join(path, "progress.md")
+	pd, err := board.ParseProgressFile(progressPath)
+	if err != nil {
+		return TickInstance{}, fmt.Errorf("reading progress for %s: %w", id, err)
+	}
+	pd.AssignedTo = tmpl.AssignedTo
+	pd.Estimate = tmpl.Estimate
+	for _, item := range tmpl.Checklist {
+		pd.Checklist = append(pd.Checklist, board.ChecklistItem{Text: item.Text})
+	}
+	pd.Recurrence = board.Recurrence{Template: tmpl.ID(), Previous: instance.Previous}
+	if err := board.WriteProgressFile(progressPath, pd); err != nil {
+		return TickInstance{}, fmt.Errorf("writing progress for %s: %w", id, err)
+	}
+
+	tmplPd, err := board.ParseProgressFile(tmpl.ProgressPath())
+	if err != nil {
+		return TickInstance{}, fmt.Errorf("reading progress: %w", err)
+	}
+	tmplPd.Recurrence.Last = id
+	tmplPd.Recurrence.Next = next.UTC()
+	if next.IsZero() {
+		// The rule doesn't match again
+		tmplPd.Recurrence.Rule = ""
+	}
+	if err := board.WriteProgressFile(tmpl.ProgressPath(), tmplPd); err != nil {
+		return TickInstance{}, fmt.Errorf("writing progress: %w", err)
+	}
+
+	instance.ID = id
+	instance.Path = computeRelativePath(b.Dir, path)
+	return instance, nil
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aagrigore/task-board/internal/board"
+)
+
+func TestRecurAndTick(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	defer func() { recurEvery, recurFrom, tickDryRun = "", "", false }()
+
+	from := time.Now().AddDate(0, 0, -10).Format("2006-01-02")
+	recurEvery, recurFrom = "weekly", from
+	recurCmd.Flags().Set("every", "weekly")
+	out := captureOutput(t, func() {
+		if err := runRecur(recurCmd, []string{testTask1ID}); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if !strings.Contains(out, testTask1ID+" recurs weekly, next "+from+" 00:00") {
+		t.Errorf("recur output = %q", out)
+	}
+
+	tickDryRun = true
+	out = captureOutput(t, func() {
+		if err := runTick(tickCmd, nil); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if !strings.Contains(out, "Would create occurrence: Interface ("+from+")") {
+		t.Errorf("dry run output = %q", out)
+	}
+
+	tickDryRun = false
+	jsonOutput = true
+	defer func() { jsonOutput = false }()
+	stdout := captureOutput(t, func() {
+		if err := runTick(tickCmd, nil); err != nil {
+			t.Fatal(err)
+		}
+	})
+	var resp TickResponse
+	if err := json.Unmarshal([]byte(stdout), &resp); err != nil {
+		t.Fatalf("parse: %v\n%s", err, stdout)
+	}
+	// Ten days late: one occurrence, and the template is due again in four days
+	if len(resp.Created) != 1 || resp.Created[0].Template != testTask1ID || resp.Created[0].Previous != "" {
+		t.Fatalf("created = %+v", resp.Created)
+	}
+	wantNext := time.Now().AddDate(0, 0, 4).Format("2006-01-02") + " 00:00"
+	if resp.Created[0].Next != wantNext {
+		t.Errorf("next = %q, want %q", resp.Created[0].Next, wantNext)
+	}
+	firstID := resp.Created[0].ID
+
+	b, _ := board.Load(bd)
+	first := b.FindByID(firstID)
+	if first == nil || first.ParentID != testStory1ID || first.Status != board.StatusBacklog {
+		t.Fatalf("occurrence = %+v", first)
+	}
+	if first.Title != firstID+": Interface ("+from+")" || first.AC != "- Interface defined" {
+		t.Errorf("occurrence README: title %q, AC %q", first.Title, first.AC)
+	}
+	if len(first.Checklist) != 2 || first.Checklist[1].Checked || first.Recurrence.Template != testTask1ID {
+		t.Errorf("occurrence progress: %+v, %+v", first.Checklist, first.Recurrence)
+	}
+	if tmpl := b.FindByID(testTask1ID); tmpl.Recurrence.Last != firstID {
+		t.Errorf("template last = %q", tmpl.Recurrence.Last)
+	}
+
+	// Idempotent until the template is due again
+	stdout = captureOutput(t, func() { runTick(tickCmd, nil) })
+	resp = TickResponse{}
+	json.Unmarshal([]byte(stdout), &resp)
+	if len(resp.Created) != 0 {
+		t.Errorf("second tick created %+v", resp.Created)
+	}
+
+	tmpl := b.FindByID(testTask1ID)
+	pd, _ := board.ParseProgressFile(tmpl.ProgressPath())
+	pd.Recurrence.Next = time.Now().Add(-time.Hour)
+	board.WriteProgressFile(tmpl.ProgressPath(), pd)
+	stdout = captureOutput(t, func() { runTick(tickCmd, nil) })
+	resp = TickResponse{}
+	json.Unmarshal([]byte(stdout), &resp)
+	if len(resp.Created) != 1 || resp.Created[0].Previous != firstID {
+		t.Errorf("next occurrence = %+v, want it after %s", resp.Created, firstID)
+	}
+}
+
+func TestRecurErrors(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	defer func() { recurEvery = "" }()
+	recurCmd.Flags().Set("every", "weekly")
+
+	recurEvery = "weekly"
+	if err := runRecur(recurCmd, []string{testStory1ID}); err == nil || !strings.Contains(err.Error(), "only tasks and bugs recur") {
+		t.Errorf("story: err = %v", err)
+	}
+	recurEvery = "fortnightly"
+	if err := runRecur(recurCmd, []string{testTask1ID}); err == nil || !strings.Contains(err.Error(), "invalid rule") {
+		t.Errorf("bad rule: err = %v", err)
+	}
+
+	recurEvery = "0 9 * * 1"
+	captureOutput(t, func() { runRecur(recurCmd, []string{testTask1ID}) })
+	recurEvery = "none"
+	out := captureOutput(t, func() { runRecur(recurCmd, []string{testTask1ID}) })
+	if !strings.Contains(out, "recurrence stopped") {
+		t.Errorf("stop output = %q", out)
+	}
+	b, _ := board.Load(bd)
+	if r := b.FindByID(testTask1ID).Recurrence; !r.IsZero() {
+		t.Errorf("recurrence = %+v, want none", r)
+	}
+}
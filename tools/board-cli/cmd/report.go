@@ -0,0 +1,273 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aagrigore/task-board/internal/board"
+	"github.com/aagrigore/task-board/internal/output"
+	"github.com/aagrigore/task-board/internal/plan"
+	"github.com/aagrigore/task-board/internal/site"
+	"github.com/spf13/cobra"
+)
+
+// ReportHTMLResponse is the JSON response for report html
+type ReportHTMLResponse struct {
+	Report ReportHTMLOutput `json:"report"`
+}
+
+// ReportHTMLOutput describes the generated site
+type ReportHTMLOutput struct {
+	Dir   string `json:"dir"`
+	Index string `json:"index"`
+	Pages int    `json:"pages"`
+}
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Generate reports from the board",
+}
+
+var reportHTMLCmd = &cobra.Command{
+	Use:   "html",
+	Short: "Generate a static HTML site of the board",
+	Long: `Generate a static site that opens offline in any browser: an index of epics
+with progress bars, and a page per element with its README and notes
+rendered from markdown, checklist, dependencies, children, status history,
+breadcrumbs and plan graph (built-in SVG layout, no Graphviz needed).
+
+Every page has a search box over index.js, the same data as index.json.
+tree.json and data/<ID>.json hold the tree --json and show --json output.
+
+The site goes to .temp/report/ in the board directory unless --out is set.`,
+	Args: cobra.NoArgs,
+	RunE: runReportHTML,
+}
+
+var (
+	reportOut   string
+	reportTitle string
+)
+
+func init() {
+	rootCmd.AddCommand(reportCmd)
+	reportCmd.AddCommand(reportHTMLCmd)
+	reportHTMLCmd.Flags().StringVar(&reportOut, "out", "", "Output directory (default: .temp/report in the board directory)")
+	reportHTMLCmd.Flags().StringVar(&reportTitle, "title", "", "Site title (default: the project directory name)")
+}
+
+func runReportHTML(cmd *cobra.Command, args []string) error {
+	fail := func(code output.ErrorCode, err error) error {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, code, err.Error(), nil)
+			return nil
+		}
+		return err
+	}
+
+	b, err := loadViewBoard()
+	if err != nil {
+		return fail(output.InternalError, fmt.Errorf("loading board: %w", err))
+	}
+
+	title := reportTitle
+	if title == "" {
+		title = "Task Board"
+		if abs, err := filepath.Abs(boardDir); err == nil {
+			title = filepath.Base(filepath.Dir(abs))
+		}
+	}
+	dir := reportOut
+	if dir == "" {
+		dir = filepath.Join(boardDir, ".temp", "report")
+	}
+
+	s, err := buildSite(b, title)
+	if err != nil {
+		return fail(output.InternalError, err)
+	}
+	if err := site.Write(dir, s); err != nil {
+		return fail(output.InternalError, err)
+	}
+
+	index := filepath.Join(dir, "index.html")
+	if JSONEnabled() {
+		return output.PrintJSON(os.Stdout, ReportHTMLResponse{Report: ReportHTMLOutput{
+			Dir:   dir,
+			Index: index,
+			Pages: len(s.Pages),
+		}})
+	}
+	fmt.Printf("Report written to %s (%d pages)\n", index, len(s.Pages))
+	return nil
+}
+
+// buildSite gathers the report from the same data as show --json and
+// tree --json.
+func buildSite(b *board.Board, title string) (*site.Site, error) {
+	s := &site.Site{
+		Title:     title,
+		Generated: time.Now(),
+		Progress:  workProgress(b.Workflow, b.Elements),
+		Tree:      TreeResponse{Tree: buildTree(b, b.FindByType(board.EpicType))},
+	}
+
+	for _, epic := range b.FindByType(board.EpicType) {
+		s.Epics = append(s.Epics, siteSummary(b, epic))
+	}
+	if epics := b.FindByType(board.EpicType); len(epics) > 0 {
+		if p := plan.BuildPlan(epics); !p.HasCycle {
+			s.Plan = plan.GenerateSVG(p, epics, b.Workflow)
+		}
+	}
+
+	elements, err := plan.AllDescendants(b, "")
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range elements {
+		page, err := sitePage(b, e)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", e.ID(), err)
+		}
+		s.Pages = append(s.Pages, page)
+	}
+	return s, nil
+}
+
+func sitePage(b *board.Board, e *board.Element) (*site.Page, error) {
+	pd, err := board.ParseProgressFile(e.ProgressPath())
+	if err != nil {
+		return nil, fmt.Errorf("reading progress: %w", err)
+	}
+	rd, err := board.ParseReadmeFile(e.ReadmePath())
+	if err != nil {
+		return nil, fmt.Errorf("reading README: %w", err)
+	}
+	readme, err := os.ReadFile(e.ReadmePath())
+	if err != nil {
+		return nil, fmt.Errorf("reading README: %w", err)
+	}
+	if WorkspaceEnabled() {
+		pd.BlockedBy = e.BlockedBy
+		pd.Blocks = e.Blocks
+		pd.Links = e.Links
+	}
+	data := showElementJSON(b, e, pd, rd)
+
+	page := &site.Page{
+		ID:        data.ID,
+		Type:      data.Type,
+		Name:      elementTitle(e),
+		Status:    siteStatus(b, e),
+		Assignee:  data.Assignee,
+		Created:   data.CreatedAt,
+		Updated:   data.UpdatedAt,
+		Readme:    stripTitle(string(readme)),
+		BlockedBy: siteLinks(b, data.BlockedBy),
+		Blocks:    siteLinks(b, data.Blocks),
+		History:   pd.History,
+		Notes:     pd.Notes,
+		Data:      data,
+	}
+	for _, item := range data.Checklist {
+		page.Checklist = append(page.Checklist, site.ChecklistItem{Text: item.Text, Done: item.Done})
+	}
+	for parent := b.ParentOf(e); parent != nil; parent = b.ParentOf(parent) {
+		page.Crumbs = append([]site.Link{siteLink(b, parent)}, page.Crumbs...)
+	}
+	children := b.Children(e)
+	for _, c := range children {
+		page.Children = append(page.Children, siteSummary(b, c))
+	}
+	page.Progress = siteSummary(b, e).Progress
+
+	if len(children) > 0 {
+		if elements, p, err := graphElements(b, e.ID(), "phases", false); err == nil && len(elements) > 0 {
+			page.Plan = plan.GenerateSVG(p, elements, b.Workflow)
+		}
+	}
+	return page, nil
+}
+
+// stripTitle drops the "# Title" line the page shows as its heading.
+func stripTitle(readme string) string {
+	first, rest, _ := strings.Cut(readme, "\n")
+	if strings.HasPrefix(first, "# ") {
+		return strings.TrimSpace(rest)
+	}
+	return strings.TrimSpace(readme)
+}
+
+// elementTitle is the README title without the "ID: " prefix the page already
+// shows, or the directory name without a title.
+func elementTitle(e *board.Element) string {
+	if i := strings.Index(e.Title, ": "); i > 0 && !strings.Contains(e.Title[:i], " ") {
+		return e.Title[i+2:]
+	}
+	if e.Title != "" {
+		return e.Title
+	}
+	return e.Name
+}
+
+func siteStatus(b *board.Board, e *board.Element) site.Status {
+	color := b.Flow(e.Type).Color(e.Status)
+	return site.Status{Name: string(e.Status), Color: color, Dark: plan.IsDark(color)}
+}
+
+func siteLink(b *board.Board, e *board.Element) site.Link {
+	return site.Link{ID: e.ID(), Name: elementTitle(e), Status: siteStatus(b, e)}
+}
+
+// siteLinks links to the given IDs; unknown ones keep their ID only.
+func siteLinks(b *board.Board, ids []string) []site.Link {
+	var links []site.Link
+	for _, id := range ids {
+		if e := b.FindByID(id); e != nil {
+			links = append(links, siteLink(b, e))
+		} else {
+			links = append(links, site.Link{ID: id})
+		}
+	}
+	return links
+}
+
+// siteSummary lists an element with its progress: finished tasks and bugs
+// below an epic or story, checked items of a task or bug's checklist.
+func siteSummary(b *board.Board, e *board.Element) site.Summary {
+	summary := site.Summary{Link: siteLink(b, e), Type: string(e.Type), Assignee: e.AssignedTo}
+	if e.Type == board.TaskType || e.Type == board.BugType {
+		for _, item := range e.Checklist {
+			summary.Progress.Total++
+			if item.Checked {
+				summary.Progress.Done++
+			}
+		}
+		return summary
+	}
+	descendants, _ := plan.AllDescendants(b, e.ID())
+	summary.Progress = workProgress(b.Workflow, descendants)
+	return summary
+}
+
+// workProgress counts done tasks and bugs out of those not closed.
+func workProgress(wf *board.Workflow, elements []*board.Element) site.Progress {
+	var p site.Progress
+	for _, e := range elements {
+		if e.Type != board.TaskType && e.Type != board.BugType {
+			continue
+		}
+		switch wf.Category(e) {
+		case board.CategoryClosed:
+			continue
+		case board.CategoryDone:
+			p.Done++
+		}
+		p.Total++
+	}
+	return p
+}
@@ -0,0 +1,377 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aagrigore/task-board/internal/board"
+	"github.com/aagrigore/task-board/internal/output"
+	"github.com/aagrigore/task-board/internal/plan"
+	"github.com/spf13/cobra"
+)
+
+// ReportItem is an element listed in a markdown report
+type ReportItem struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Name     string `json:"name"`
+	Status   string `json:"status"`
+	Assignee string `json:"assignee"`
+	Ancestry string `json:"ancestry"`
+	// At is when the element moved (done, review) or was last updated (stale)
+	At string `json:"at,omitempty"`
+	// Reason says why the element is blocked
+	Reason string `json:"reason,omitempty"`
+	// AcceptanceCriteria is set in release reports
+	AcceptanceCriteria string `json:"acceptanceCriteria,omitempty"`
+}
+
+// StandupResponse is the JSON response for report standup
+type StandupResponse struct {
+	Standup StandupReport `json:"standup"`
+}
+
+// StandupReport lists what changed since a point in time
+type StandupReport struct {
+	Since    string       `json:"since"`
+	Agent    string       `json:"agent"`
+	Done     []ReportItem `json:"done"`
+	ToReview []ReportItem `json:"toReview"`
+	Blocked  []ReportItem `json:"blocked"`
+	Stale    []ReportItem `json:"stale"`
+	Markdown string       `json:"markdown"`
+}
+
+// ReleaseResponse is the JSON response for report release
+type ReleaseResponse struct {
+	Release ReleaseReport `json:"release"`
+}
+
+// ReleaseReport lists the finished work of an epic or story
+type ReleaseReport struct {
+	Scope    string       `json:"scope"`
+	Title    string       `json:"title"`
+	Done     []ReportItem `json:"done"`
+	Pending  int          `json:"pending"` // tasks and bugs not done yet
+	Markdown string       `json:"markdown"`
+}
+
+var reportStandupCmd = &cobra.Command{
+	Use:   "standup",
+	Short: "Markdown of what moved since yesterday, what is blocked and what is stale",
+	Long: `Print a markdown standup report to paste into chat or a PR:
+
+  Done         elements that moved to a done status since --since
+  Entered review  elements that moved to to-review since --since
+  Blocked      unfinished elements in a blocked status or waiting on
+               unfinished blockers
+  Stale        assigned, unfinished elements not updated within --stale
+               minutes (the agents freshness window)
+
+Moves come from the status history in progress.md; elements without one
+count by their last update.`,
+	Args: cobra.NoArgs,
+	RunE: runReportStandup,
+}
+
+var reportReleaseCmd = &cobra.Command{
+	Use:   "release <ID>",
+	Short: "Markdown of the done tasks and bugs of an epic or story with their acceptance criteria",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runReportRelease,
+}
+
+var (
+	reportSince string
+	reportAgent string
+	reportStale int
+)
+
+func init() {
+	reportCmd.AddCommand(reportStandupCmd)
+	reportCmd.AddCommand(reportReleaseCmd)
+	reportStandupCmd.Flags().StringVar(&reportSince, "since", "24h", "Start of the report: an age (24h, 3d) or a date (2006-01-02)")
+	reportStandupCmd.Flags().StringVar(&reportAgent, "agent", "", "Only elements assigned to this agent")
+	reportStandupCmd.Flags().IntVar(&reportStale, "stale", 30, "Freshness window in minutes, as in agents --stale")
+}
+
+func runReportStandup(cmd *cobra.Command, args []string) error {
+	fail := func(code output.ErrorCode, err error) error {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, code, err.Error(), nil)
+			return nil
+		}
+		return err
+	}
+
+	since, err := parseSince(reportSince)
+	if err != nil {
+		return fail(output.ValidationError, err)
+	}
+	b, err := loadViewBoard()
+	if err != nil {
+		return fail(output.InternalError, fmt.Errorf("loading board: %w", err))
+	}
+
+	report := buildStandup(b, since, time.Now().UTC(), reportAgent, time.Duration(reportStale)*time.Minute)
+	if JSONEnabled() {
+		return output.PrintJSON(os.Stdout, StandupResponse{Standup: report})
+	}
+	fmt.Print(report.Markdown)
+	return nil
+}
+
+// buildStandup collects the standup sections; an empty agent means everyone.
+func buildStandup(b *board.Board, since, now time.Time, agent string, freshness time.Duration) StandupReport {
+	wf := b.Workflow
+	report := StandupReport{
+		Since:    since.Format(time.RFC3339),
+		Agent:    agent,
+		Done:     []ReportItem{},
+		ToReview: []ReportItem{},
+		Blocked:  []ReportItem{},
+		Stale:    []ReportItem{},
+	}
+
+	for _, e := range b.Elements {
+		if agent != "" && e.AssignedTo != agent {
+			continue
+		}
+		flow := wf.For(e.Type)
+
+		// The latest move into a done status or into to-review in the window
+		var doneAt, reviewAt time.Time
+		for _, c := range e.History {
+			if c.At.Before(since) {
+				continue
+			}
+			if flow.Category(c.To) == board.CategoryDone {
+				doneAt = c.At
+			}
+			if flow.Normalize(c.To) == board.StatusToReview {
+				reviewAt = c.At
+			}
+		}
+		if len(e.History) == 0 && !e.LastUpdate.Before(since) {
+			switch {
+			case wf.Category(e) == board.CategoryDone:
+				doneAt = e.LastUpdate
+			case e.Status == board.StatusToReview:
+				reviewAt = e.LastUpdate
+			}
+		}
+		if !doneAt.IsZero() && wf.Category(e) == board.CategoryDone {
+			item := reportItem(b, e)
+			item.At = doneAt.UTC().Format(time.RFC3339)
+			report.Done = append(report.Done, item)
+		}
+		if !reviewAt.IsZero() {
+			item := reportItem(b, e)
+			item.At = reviewAt.UTC().Format(time.RFC3339)
+			report.ToReview = append(report.ToReview, item)
+		}
+
+		if wf.Finished(e) {
+			continue
+		}
+		if reason := blockedReason(b, e); reason != "" {
+			item := reportItem(b, e)
+			item.Reason = reason
+			report.Blocked = append(report.Blocked, item)
+		}
+		if e.AssignedTo != "" && !e.LastUpdate.IsZero() && now.Sub(e.LastUpdate) > freshness {
+			item := reportItem(b, e)
+			item.At = e.LastUpdate.UTC().Format(time.RFC3339)
+			report.Stale = append(report.Stale, item)
+		}
+	}
+
+	byTime := func(items []ReportItem) {
+		sort.SliceStable(items, func(i, j int) bool { return items[i].At < items[j].At })
+	}
+	byTime(report.Done)
+	byTime(report.ToReview)
+	byTime(report.Stale)
+
+	report.Markdown = standupMarkdown(report, since, now)
+	return report
+}
+
+// blockedReason explains why an unfinished element can't move: an explicit
+// blocked status, unfinished blockers, or both.
+func blockedReason(b *board.Board, e *board.Element) string {
+	var reasons []string
+	if b.Workflow.Category(e) == board.CategoryBlocked {
+		reasons = append(reasons, "status "+string(e.Status))
+	}
+	var waiting []string
+	for _, blocker := range b.ActiveBlockers(e) {
+		waiting = append(waiting, fmt.Sprintf("%s (%s)", blocker.ID(), blocker.Status))
+	}
+	if len(waiting) > 0 {
+		reasons = append(reasons, "waiting on "+strings.Join(waiting, ", "))
+	}
+	return strings.Join(reasons, "; ")
+}
+
+func reportItem(b *board.Board, e *board.Element) ReportItem {
+	return ReportItem{
+		ID:       e.ID(),
+		Type:     string(e.Type),
+		Name:     elementTitle(e),
+		Status:   string(e.Status),
+		Assignee: e.AssignedTo,
+		Ancestry: b.Ancestry(e),
+	}
+}
+
+func standupMarkdown(r StandupReport, since, now time.Time) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "## Standup — %s\n\n", now.Format("2006-01-02"))
+	scope := fmt.Sprintf("Since %s", since.UTC().Format("2006-01-02 15:04 UTC"))
+	if r.Agent != "" {
+		scope += fmt.Sprintf(" · @%s", r.Agent)
+	}
+	sb.WriteString(scope + "\n")
+
+	section := func(title string, items []ReportItem, detail func(ReportItem) string) {
+		fmt.Fprintf(&sb, "\n### %s (%d)\n", title, len(items))
+		if len(items) == 0 {
+			sb.WriteString("- none\n")
+			return
+		}
+		for _, item := range items {
+			line := fmt.Sprintf("- **%s** %s", item.ID, item.Name)
+			if parent := parentPath(item); parent != "" {
+				line += fmt.Sprintf(" (%s)", parent)
+			}
+			if d := detail(item); d != "" {
+				line += " — " + d
+			}
+			sb.WriteString(line + "\n")
+		}
+	}
+	assignee := func(item ReportItem) string {
+		if item.Assignee == "" {
+			return ""
+		}
+		return "@" + item.Assignee
+	}
+
+	section("Done", r.Done, assignee)
+	section("Entered review", r.ToReview, func(item ReportItem) string {
+		parts := []string{}
+		if a := assignee(item); a != "" {
+			parts = append(parts, a)
+		}
+		if item.Status != string(board.StatusToReview) {
+			parts = append(parts, "now "+item.Status)
+		}
+		return strings.Join(parts, ", ")
+	})
+	section("Blocked", r.Blocked, func(item ReportItem) string { return item.Reason })
+	section("Stale", r.Stale, func(item ReportItem) string {
+		at, _ := time.Parse(time.RFC3339, item.At)
+		return fmt.Sprintf("@%s, %s, last update %s", item.Assignee, item.Status, humanTime(at, now))
+	})
+	return sb.String()
+}
+
+// parentPath is the ancestry without the element itself.
+func parentPath(item ReportItem) string {
+	parts := strings.Split(item.Ancestry, " > ")
+	return strings.Join(parts[:len(parts)-1], " › ")
+}
+
+func runReportRelease(cmd *cobra.Command, args []string) error {
+	fail := func(code output.ErrorCode, err error) error {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, code, err.Error(), nil)
+			return nil
+		}
+		return err
+	}
+
+	b, err := loadViewBoard()
+	if err != nil {
+		return fail(output.InternalError, fmt.Errorf("loading board: %w", err))
+	}
+	scope := b.FindByID(args[0])
+	if scope == nil {
+		return fail(output.NotFound, fmt.Errorf("element %s not found", args[0]))
+	}
+	if scope.Type != board.EpicType && scope.Type != board.StoryType {
+		return fail(output.ValidationError, fmt.Errorf("%s is a %s; release reports cover an epic or a story", scope.ID(), scope.Type))
+	}
+
+	report := buildRelease(b, scope)
+	if JSONEnabled() {
+		return output.PrintJSON(os.Stdout, ReleaseResponse{Release: report})
+	}
+	fmt.Print(report.Markdown)
+	return nil
+}
+
+// buildRelease lists the done tasks and bugs below scope, grouped by story.
+func buildRelease(b *board.Board, scope *board.Element) ReleaseReport {
+	report := ReleaseReport{
+		Scope: scope.ID(),
+		Title: elementTitle(scope),
+		Done:  []ReportItem{},
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "## Release — %s: %s\n", scope.ID(), report.Title)
+
+	stories := []*board.Element{scope}
+	if scope.Type == board.EpicType {
+		stories = b.Children(scope)
+	}
+	for _, story := range stories {
+		var items []ReportItem
+		descendants, _ := plan.AllDescendants(b, story.ID())
+		for _, e := range descendants {
+			if e.Type != board.TaskType && e.Type != board.BugType {
+				continue
+			}
+			switch b.Workflow.Category(e) {
+			case board.CategoryDone:
+				item := reportItem(b, e)
+				item.AcceptanceCriteria = e.Section("Acceptance Criteria")
+				items = append(items, item)
+			case board.CategoryClosed:
+			default:
+				report.Pending++
+			}
+		}
+		if len(items) == 0 {
+			continue
+		}
+		report.Done = append(report.Done, items...)
+
+		if scope.Type == board.EpicType {
+			fmt.Fprintf(&sb, "\n### %s: %s\n", story.ID(), elementTitle(story))
+		}
+		for _, item := range items {
+			kind := ""
+			if item.Type == string(board.BugType) {
+				kind = " (bug)"
+			}
+			fmt.Fprintf(&sb, "\n- **%s** %s%s\n", item.ID, item.Name, kind)
+			for _, criterion := range board.ParseCriteria(item.AcceptanceCriteria) {
+				fmt.Fprintf(&sb, "  - %s\n", criterion)
+			}
+		}
+	}
+
+	if len(report.Done) == 0 {
+		sb.WriteString("\nNo tasks or bugs done yet.\n")
+	}
+	if report.Pending > 0 {
+		fmt.Fprintf(&sb, "\n_%d task(s)/bug(s) not done yet._\n", report.Pending)
+	}
+	report.Markdown = sb.String()
+	return report
+}
@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aagrigore/task-board/internal/board"
+)
+
+func TestReportStandup(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	passReviewForTest(t, bd, testTask1ID)
+	captureOutput(t, func() {
+		for _, status := range []string{"development", "to-review", "done"} {
+			runProgressStatus(progressStatusCmd, []string{testTask1ID, status})
+		}
+		runProgressStatus(progressStatusCmd, []string{testTask3ID, "to-review"})
+		runProgressStatus(progressStatusCmd, []string{testBug1ID, "blocked"})
+	})
+	task4Dir := filepath.Join(bd, testEpic2ID+"_storage", testStory3ID+"_migration", testTask4ID+"_schema")
+	writeAssignee(t, task4Dir, "agent-2", board.StatusDevelopment, time.Now().Add(-3*time.Hour))
+
+	reportSince, reportAgent, reportStale = "24h", "", 30
+	out := captureOutput(t, func() {
+		if err := runReportStandup(reportStandupCmd, nil); err != nil {
+			t.Fatal(err)
+		}
+	})
+	sections := map[string]string{}
+	for _, part := range strings.Split(out, "\n### ")[1:] {
+		title, body, _ := strings.Cut(part, "\n")
+		sections[title] = body
+	}
+	want := map[string][]string{
+		"Done (1)":           {"**" + testTask1ID + "** Interface (" + testEpic1ID + " › " + testStory1ID + ")"},
+		"Entered review (2)": {testTask1ID + "** Interface", "now done", testTask3ID},
+		"Blocked (1)":        {testBug1ID + "** Crash on start", "status blocked"},
+		"Stale (1)":          {testTask4ID, "@agent-2, development, last update"},
+	}
+	for title, parts := range want {
+		body, ok := sections[title]
+		if !ok {
+			t.Errorf("missing section %q in:\n%s", title, out)
+			continue
+		}
+		for _, p := range parts {
+			if !strings.Contains(body, p) {
+				t.Errorf("section %q missing %q:\n%s", title, p, body)
+			}
+		}
+	}
+
+	// --agent keeps only that agent's elements
+	reportAgent = "agent-2"
+	jsonOutput = true
+	defer func() { reportAgent, jsonOutput = "", false }()
+	stdout := captureOutput(t, func() {
+		if err := runReportStandup(reportStandupCmd, nil); err != nil {
+			t.Fatal(err)
+		}
+	})
+	var resp StandupResponse
+	if err := json.Unmarshal([]byte(stdout), &resp); err != nil {
+		t.Fatalf("parse: %v\n%s", err, stdout)
+	}
+	r := resp.Standup
+	if len(r.Done) != 0 || len(r.ToReview) != 0 || len(r.Blocked) != 0 || len(r.Stale) != 1 || r.Stale[0].ID != testTask4ID {
+		t.Errorf("standup for agent-2 = %+v", r)
+	}
+	if !strings.Contains(r.Markdown, "· @agent-2") {
+		t.Errorf("markdown missing agent:\n%s", r.Markdown)
+	}
+}
+
+func TestReportStandupActiveBlockers(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	reportSince, reportAgent, reportStale = "24h", "", 30
+	out := captureOutput(t, func() {
+		if err := runReportStandup(reportStandupCmd, nil); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if !strings.Contains(out, "**"+testTask2ID+"** Implementation ("+testEpic1ID+" › "+testStory1ID+") — waiting on "+testTask1ID+" (backlog)") {
+		t.Errorf("expected %s waiting on %s:\n%s", testTask2ID, testTask1ID, out)
+	}
+	if !strings.Contains(out, "### Done (0)\n- none\n") {
+		t.Errorf("expected empty done section:\n%s", out)
+	}
+}
+
+func TestReportRelease(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	passReviewForTest(t, bd, testTask1ID, testBug1ID)
+	captureOutput(t, func() {
+		runProgressStatus(progressStatusCmd, []string{testTask1ID, "done"})
+		runProgressStatus(progressStatusCmd, []string{testBug1ID, "done"})
+	})
+	// A template placeholder is not a criterion
+	b, _ := board.Load(bd)
+	readme := b.FindByID(testBug1ID).ReadmePath()
+	data, _ := os.ReadFile(readme)
+	os.WriteFile(readme, []byte(strings.Replace(string(data), "- No crash", "(define acceptance criteria)", 1)), 0644)
+
+	out := captureOutput(t, func() {
+		if err := runReportRelease(reportReleaseCmd, []string{testEpic1ID}); err != nil {
+			t.Fatal(err)
+		}
+	})
+	for _, want := range []string{
+		"## Release — " + testEpic1ID + ": Recording\n",
+		"### " + testStory1ID + ": Audio Capture\n",
+		"- **" + testTask1ID + "** Interface\n  - Interface defined\n",
+		"- **" + testBug1ID + "** Crash on start (bug)\n\n",
+		"_2 task(s)/bug(s) not done yet._",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("release missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "(define") {
+		t.Errorf("release lists a placeholder:\n%s", out)
+	}
+	if strings.Contains(out, testStory2ID) || strings.Contains(out, testTask2ID) {
+		t.Errorf("release lists unfinished work:\n%s", out)
+	}
+
+	if err := runReportRelease(reportReleaseCmd, []string{testTask1ID}); err == nil {
+		t.Error("expected an error for a task scope")
+	}
+}
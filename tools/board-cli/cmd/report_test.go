@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReportHTML(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	passReviewForTest(t, bd, testTask1ID)
+	captureOutput(t, func() {
+		runProgressStatus(progressStatusCmd, []string{testTask1ID, "development"})
+		runProgressStatus(progressStatusCmd, []string{testTask1ID, "done"})
+		runLink(linkCmd, []string{testTask2ID, testTask1ID})
+	})
+
+	out := filepath.Join(t.TempDir(), "site")
+	reportOut = out
+	jsonOutput = true
+	defer func() { reportOut, jsonOutput = "", false }()
+	stdout := captureOutput(t, func() {
+		if err := runReportHTML(reportHTMLCmd, nil); err != nil {
+			t.Fatal(err)
+		}
+	})
+	var resp ReportHTMLResponse
+	if err := json.Unmarshal([]byte(stdout), &resp); err != nil {
+		t.Fatalf("parse: %v\n%s", err, stdout)
+	}
+	if resp.Report.Pages != 10 || resp.Report.Index != filepath.Join(out, "index.html") {
+		t.Errorf("report = %+v", resp.Report)
+	}
+
+	read := func(name string) string {
+		t.Helper()
+		data, err := os.ReadFile(filepath.Join(out, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+
+	index := read("index.html")
+	for _, want := range []string{`href="e/` + testEpic1ID + `.html"`, "1/5", `src="plans/board.svg"`} {
+		if !strings.Contains(index, want) {
+			t.Errorf("index.html missing %q", want)
+		}
+	}
+
+	task := read("e/" + testTask2ID + ".html")
+	for _, want := range []string{
+		`<a href="` + testEpic1ID + `.html">` + testEpic1ID + `</a> › <a href="` + testStory1ID + `.html">`,
+		"<h3>Blocked by</h3>",
+		`<a href="` + testTask1ID + `.html">`,
+	} {
+		if !strings.Contains(task, want) {
+			t.Errorf("%s page missing %q", testTask2ID, want)
+		}
+	}
+	if story := read("e/" + testStory1ID + ".html"); !strings.Contains(story, `src="../plans/`+testStory1ID+`.svg"`) {
+		t.Error("story page should embed its plan")
+	}
+
+	// data/<ID>.json is the show --json element
+	var shown ShowElementJSON
+	if err := json.Unmarshal([]byte(read("data/"+testTask2ID+".json")), &shown); err != nil {
+		t.Fatal(err)
+	}
+	if shown.ID != testTask2ID || len(shown.BlockedBy) != 1 || shown.BlockedBy[0] != testTask1ID {
+		t.Errorf("data = %+v", shown)
+	}
+
+	var tree TreeResponse
+	if err := json.Unmarshal([]byte(read("tree.json")), &tree); err != nil || len(tree.Tree) != 2 {
+		t.Errorf("tree.json = %v, %v", tree, err)
+	}
+	if js := read("index.js"); !strings.HasPrefix(js, "var BOARD_INDEX = [") || !strings.Contains(js, `"id":"`+testBug1ID+`"`) {
+		t.Errorf("index.js = %.200s", js)
+	}
+}
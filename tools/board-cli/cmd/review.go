@@ -0,0 +1,238 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aagrigore/task-board/internal/board"
+	"github.com/aagrigore/task-board/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// ReviewResponse is the JSON response for review
+type ReviewResponse struct {
+	ID       string              `json:"id"`
+	Criteria []CriterionJSON     `json:"criteria"`
+	Passed   int                 `json:"passed"`
+	Failed   int                 `json:"failed"`
+	Pending  int                 `json:"pending"`
+	Comments []ReviewCommentJSON `json:"comments"`
+	Message  string              `json:"message"`
+}
+
+// CriterionJSON is an acceptance criterion with its verdict
+type CriterionJSON struct {
+	Number  int    `json:"number"`
+	Text    string `json:"text"`
+	Verdict string `json:"verdict"` // pass, fail or pending
+}
+
+// ReviewCommentJSON is a reviewer's comment
+type ReviewCommentJSON struct {
+	At   string `json:"at"`
+	Text string `json:"text"`
+}
+
+var reviewCmd = &cobra.Command{
+	Use:   "review <ID>",
+	Short: "Record which acceptance criteria pass, or show the review",
+	Long: `Record a verdict on the acceptance criteria of an element. Criteria are
+the bullets of the README's Acceptance Criteria section, numbered from 1
+(see 'task-board show'); verdicts are kept under "## Review" in
+progress.md and survive other reviews until changed. A criterion whose
+text is edited goes back to pending.
+
+Moving an element to a done status is refused with REVIEW_REQUIRED until
+every criterion passes; --force on the status change overrides.
+
+Without --pass, --fail or --comment the review is shown.`,
+	Example: `  task-board review TASK-260101-aaaaaa --pass 1,3 --fail 2 --comment "2: clipping at the start"
+  task-board review TASK-260101-aaaaaa --pass all
+  task-board review TASK-260101-aaaaaa`,
+	Args: cobra.ExactArgs(1),
+	RunE: recorded(runReview),
+}
+
+var (
+	reviewPass    string
+	reviewFail    string
+	reviewComment string
+)
+
+func init() {
+	rootCmd.AddCommand(reviewCmd)
+	reviewCmd.Flags().StringVar(&reviewPass, "pass", "", "Criteria that pass: numbers like 1,3 or all")
+	reviewCmd.Flags().StringVar(&reviewFail, "fail", "", "Criteria that fail: numbers like 2 or all")
+	reviewCmd.Flags().StringVar(&reviewComment, "comment", "", "Comment to keep with the review")
+}
+
+func runReview(cmd *cobra.Command, args []string) error {
+	fail := func(code output.ErrorCode, err error) error {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, code, err.Error(), nil)
+			return nil
+		}
+		return err
+	}
+
+	b, err := board.Load(boardDir)
+	if err != nil {
+		return fail(output.InternalError, fmt.Errorf("loading board: %w", err))
+	}
+	elem := b.FindByID(args[0])
+	if elem == nil {
+		return fail(output.NotFound, fmt.Errorf("element %s not found", args[0]))
+	}
+	pd, err := board.ParseProgressFile(elem.ProgressPath())
+	if err != nil {
+		return fail(output.InternalError, fmt.Errorf("reading progress: %w", err))
+	}
+	criteria := board.MatchCriteria(elem.AC, pd.Review)
+
+	changing := reviewPass != "" || reviewFail != "" || reviewComment != ""
+	if changing {
+		if len(criteria) == 0 && (reviewPass != "" || reviewFail != "") {
+			return fail(output.ValidationError, fmt.Errorf("%s has no acceptance criteria to review", elem.ID()))
+		}
+		passed, err := parseCriteriaNumbers("--pass", reviewPass, len(criteria))
+		if err != nil {
+			return fail(output.ValidationError, err)
+		}
+		failed, err := parseCriteriaNumbers("--fail", reviewFail, len(criteria))
+		if err != nil {
+			return fail(output.ValidationError, err)
+		}
+		for n := range passed {
+			if failed[n] {
+				return fail(output.ValidationError, fmt.Errorf("criterion %d cannot both pass and fail", n))
+			}
+			criteria[n-1].Verdict = board.VerdictPass
+		}
+		for n := range failed {
+			criteria[n-1].Verdict = board.VerdictFail
+		}
+		pd.SetReview(criteria)
+		if reviewComment != "" {
+			pd.ReviewComments = append(pd.ReviewComments, board.ReviewComment{
+				At:   time.Now().UTC().Truncate(time.Second),
+				Text: strings.Join(strings.Fields(reviewComment), " "),
+			})
+		}
+		if err := board.WriteProgressFile(elem.ProgressPath(), pd); err != nil {
+			return fail(output.InternalError, fmt.Errorf("writing progress: %w", err))
+		}
+	}
+
+	response := reviewResponse(elem.ID(), criteria, pd.ReviewComments)
+	if JSONEnabled() {
+		return output.PrintJSON(os.Stdout, response)
+	}
+	fmt.Println(response.Message)
+	for _, c := range criteria {
+		fmt.Printf("  %d. %s %s\n", c.Number, verdictMark(c.Verdict), c.Text)
+	}
+	if len(pd.ReviewComments) > 0 {
+		fmt.Println("Comments:")
+		for _, c := range pd.ReviewComments {
+			fmt.Printf("  %s  %s\n", c.At.Local().Format("2006-01-02 15:04"), c.Text)
+		}
+	}
+	return nil
+}
+
+// parseCriteriaNumbers parses a --pass or --fail list: "all" or numbers
+// between 1 and count, comma separated.
+func parseCriteriaNumbers(flag, s string, count int) (map[int]bool, error) {
+	numbers := map[int]bool{}
+	if s == "" {
+		return numbers, nil
+	}
+	if strings.EqualFold(strings.TrimSpace(s), "all") {
+		for n := 1; n <= count; n++ {
+			numbers[n] = true
+		}
+		return numbers, nil
+	}
+	for _, part := range strings.Split(s, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %q is not a criterion number", flag, part)
+		}
+		if n < 1 || n > count {
+			return nil, fmt.Errorf("%s: criterion %d out of range (1-%d)", flag, n, count)
+		}
+		numbers[n] = true
+	}
+	return numbers, nil
+}
+
+func reviewResponse(id string, criteria []board.Criterion, comments []board.ReviewComment) ReviewResponse {
+	response := ReviewResponse{
+		ID:       id,
+		Criteria: criteriaJSON(criteria),
+		Comments: make([]ReviewCommentJSON, len(comments)),
+	}
+	for _, c := range criteria {
+		switch c.Verdict {
+		case board.VerdictPass:
+			response.Passed++
+		case board.VerdictFail:
+			response.Failed++
+		default:
+			response.Pending++
+		}
+	}
+	for i, c := range comments {
+		response.Comments[i] = ReviewCommentJSON{At: c.At.UTC().Format(time.RFC3339), Text: c.Text}
+	}
+	response.Message = fmt.Sprintf("%s: %d/%d criteria passed", id, response.Passed, len(criteria))
+	if response.Failed > 0 {
+		response.Message += fmt.Sprintf(", %d failed", response.Failed)
+	}
+	return response
+}
+
+func criteriaJSON(criteria []board.Criterion) []CriterionJSON {
+	out := make([]CriterionJSON, len(criteria))
+	for i, c := range criteria {
+		verdict := string(c.Verdict)
+		if c.Verdict == board.VerdictPending {
+			verdict = "pending"
+		}
+		out[i] = CriterionJSON{Number: c.Number, Text: c.Text, Verdict: verdict}
+	}
+	return out
+}
+
+// verdictMark is the box a criterion gets in text output.
+func verdictMark(v board.Verdict) string {
+	switch v {
+	case board.VerdictPass:
+		return output.Green + "[pass]" + output.Reset
+	case board.VerdictFail:
+		return output.Red + "[fail]" + output.Reset
+	default:
+		return "[    ]"
+	}
+}
+
+// reviewRefusal explains why elem may not enter a done status before its
+// acceptance criteria pass, or returns "".
+func reviewRefusal(elem *board.Element) string {
+	open := board.Unpassed(elem.Criteria())
+	if len(open) == 0 {
+		return ""
+	}
+	parts := make([]string, len(open))
+	for i, c := range open {
+		verdict := "pending"
+		if c.Verdict == board.VerdictFail {
+			verdict = "failed"
+		}
+		parts[i] = fmt.Sprintf("%d (%s)", c.Number, verdict)
+	}
+	return "acceptance criteria not passed: " + strings.Join(parts, ", ")
+}
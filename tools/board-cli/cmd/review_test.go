@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/aagrigore/task-board/internal/board"
+	"github.com/aagrigore/task-board/internal/output"
+)
+
+// passReviewForTest records a pass on every acceptance criterion of the
+// elements, so they may be set to done.
+func passReviewForTest(t *testing.T, bd string, ids ...string) {
+	t.Helper()
+	b, _ := board.Load(bd)
+	for _, id := range ids {
+		elem := b.FindByID(id)
+		pd, err := board.ParseProgressFile(elem.ProgressPath())
+		if err != nil {
+			t.Fatal(err)
+		}
+		criteria := elem.Criteria()
+		for i := range criteria {
+			criteria[i].Verdict = board.VerdictPass
+		}
+		pd.SetReview(criteria)
+		if err := board.WriteProgressFile(elem.ProgressPath(), pd); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestReviewGatesDone(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	defer func() { reviewPass, reviewFail, reviewComment = "", "", "" }()
+
+	b, _ := board.Load(bd)
+	elem := b.FindByID(testTask3ID)
+	rd, _ := board.ParseReadmeFile(elem.ReadmePath())
+	rd.AC = "- [ ] Records audio\n- Handles silence\n  without clipping\n1. Exports WAV"
+	board.WriteReadmeFile(elem.ReadmePath(), rd)
+
+	err := runProgressStatus(progressStatusCmd, []string{testTask3ID, "done"})
+	if err == nil || !strings.Contains(err.Error(), "1 (pending), 2 (pending), 3 (pending)") {
+		t.Fatalf("done before review: %v", err)
+	}
+
+	reviewPass, reviewFail, reviewComment = "1,3", "2", "clipping at the start"
+	captureOutput(t, func() {
+		if err := runReview(reviewCmd, []string{testTask3ID}); err != nil {
+			t.Fatalf("review: %v", err)
+		}
+	})
+	err = runProgressStatus(progressStatusCmd, []string{testTask3ID, "done"})
+	if err == nil || !strings.Contains(err.Error(), "2 (failed)") {
+		t.Fatalf("done with a failed criterion: %v", err)
+	}
+
+	// A later review keeps the earlier verdicts
+	reviewPass, reviewFail, reviewComment = "2", "", ""
+	jsonOutput = true
+	stdout := captureOutput(t, func() {
+		if err := runReview(reviewCmd, []string{testTask3ID}); err != nil {
+			t.Fatalf("review: %v", err)
+		}
+	})
+	jsonOutput = false
+	var response ReviewResponse
+	if err := json.Unmarshal([]byte(stdout), &response); err != nil {
+		t.Fatalf("review JSON: %v\n%s", err, stdout)
+	}
+	if response.Passed != 3 || response.Pending != 0 || len(response.Comments) != 1 {
+		t.Errorf("review = %+v", response)
+	}
+	if c := response.Criteria[1]; c.Text != "Handles silence without clipping" || c.Verdict != "pass" {
+		t.Errorf("criterion 2 = %+v", c)
+	}
+
+	captureOutput(t, func() {
+		if err := runProgressStatus(progressStatusCmd, []string{testTask3ID, "done"}); err != nil {
+			t.Fatalf("done after review: %v", err)
+		}
+	})
+
+	// Rewording a criterion sends it back to review
+	rd.AC = "- [ ] Records audio\n- Handles silence\n1. Exports WAV"
+	board.WriteReadmeFile(elem.ReadmePath(), rd)
+	b, _ = board.Load(bd)
+	if open := board.Unpassed(b.FindByID(testTask3ID).Criteria()); len(open) != 1 || open[0].Number != 2 {
+		t.Errorf("unpassed after rewording = %+v", open)
+	}
+}
+
+func TestReviewRequiredJSON(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	jsonOutput = true
+	defer func() { jsonOutput = false }()
+
+	stderr := captureStderr(t, func() {
+		if err := runProgressStatus(progressStatusCmd, []string{testTask3ID, "done"}); err != nil {
+			t.Fatal(err)
+		}
+	})
+	var resp output.JSONError
+	if err := json.Unmarshal([]byte(stderr), &resp); err != nil {
+		t.Fatalf("error JSON: %v\n%s", err, stderr)
+	}
+	if resp.Error.Code != output.ReviewRequired {
+		t.Errorf("code = %s, want REVIEW_REQUIRED", resp.Error.Code)
+	}
+
+	progressStatusForce = true
+	defer func() { progressStatusForce = false }()
+	captureOutput(t, func() {
+		if err := runProgressStatus(progressStatusCmd, []string{testTask3ID, "done"}); err != nil {
+			t.Fatal(err)
+		}
+	})
+	b, _ := board.Load(bd)
+	if s := b.FindByID(testTask3ID).Status; s != board.StatusDone {
+		t.Errorf("--force should skip the review, got %s", s)
+	}
+}
+
+func TestReviewRejectsBadNumbers(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	defer func() { reviewPass, reviewFail = "", "" }()
+
+	for _, c := range []struct{ pass, fail, want string }{
+		{"2", "", "out of range"},
+		{"x", "", "not a criterion number"},
+		{"1", "1", "both pass and fail"},
+	} {
+		reviewPass, reviewFail = c.pass, c.fail
+		err := runReview(reviewCmd, []string{testTask3ID})
+		if err == nil || !strings.Contains(err.Error(), c.want) {
+			t.Errorf("--pass %q --fail %q: %v, want %q", c.pass, c.fail, err, c.want)
+		}
+	}
+}
+
+func TestAutoPromotionWaitsForReview(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	passReviewForTest(t, bd, testTask4ID)
+
+	b, _ := board.Load(bd)
+	story := b.FindByID(testStory3ID)
+	pd, _ := board.ParseProgressFile(story.ProgressPath())
+	pd.SetReview([]board.Criterion{{Number: 1, Text: "Migrated", Verdict: board.VerdictFail}})
+	board.WriteProgressFile(story.ProgressPath(), pd)
+
+	stdout := captureOutput(t, func() {
+		if err := runProgressStatus(progressStatusCmd, []string{testTask4ID, "done"}); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if !strings.Contains(stdout, testStory3ID+" not promoted: cannot set to done — acceptance criteria not passed: 1 (failed)") {
+		t.Errorf("output = %q", stdout)
+	}
+	b, _ = board.Load(bd)
+	for _, id := range []string{testStory3ID, testEpic2ID} {
+		if s := b.FindByID(id).Status; s == board.StatusDone {
+			t.Errorf("%s promoted to done with a failed criterion", id)
+		}
+	}
+}
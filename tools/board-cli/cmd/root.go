@@ -4,12 +4,14 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/aagrigore/task-board/internal/board"
 	"github.com/aagrigore/task-board/internal/output"
 	"github.com/spf13/cobra"
 )
 
 var boardDir string
 var jsonOutput bool
+var workspaceFile string
 
 var rootCmd = &cobra.Command{
 	Use:   "task-board",
@@ -31,9 +33,36 @@ func Execute() {
 func init() {
 	rootCmd.PersistentFlags().StringVar(&boardDir, "board-dir", ".task-board", "Path to the board directory")
 	rootCmd.PersistentFlags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+	rootCmd.PersistentFlags().StringVar(&workspaceFile, "workspace", os.Getenv("TASK_BOARD_WORKSPACE"), "Path to a workspace file listing several boards (env: TASK_BOARD_WORKSPACE)")
 }
 
 // JSONEnabled returns true if JSON output is enabled
 func JSONEnabled() bool {
 	return jsonOutput
 }
+
+// WorkspaceEnabled returns true if commands should operate on a workspace
+func WorkspaceEnabled() bool {
+	return workspaceFile != ""
+}
+
+// loadViewBoard loads the board used by read-only views (list, summary, tree…).
+// In workspace mode every board is merged into one, with IDs prefixed by board name.
+func loadViewBoard() (*board.Board, error) {
+	if !WorkspaceEnabled() {
+		return board.Load(boardDir)
+	}
+	ws, err := board.LoadWorkspace(workspaceFile)
+	if err != nil {
+		return nil, err
+	}
+	return ws.Merged(), nil
+}
+
+// infof prints a notice about a side effect (escalation, auto-promotion…).
+// Notices are text-mode only so JSON output stays a single document.
+func infof(format string, args ...interface{}) {
+	if !JSONEnabled() {
+		fmt.Printf(format, args...)
+	}
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"bufio"
 	"fmt"
+	"math"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -10,17 +11,19 @@ import (
 
 	"github.com/aagrigore/task-board/internal/board"
 	"github.com/aagrigore/task-board/internal/output"
+	"github.com/aagrigore/task-board/internal/search"
 	"github.com/spf13/cobra"
 )
 
 // SearchResult represents a single search match for JSON output
 type SearchResult struct {
-	ID           string `json:"id"`
-	Type         string `json:"type"`
-	Name         string `json:"name"`
-	Status       string `json:"status"`
-	MatchField   string `json:"matchField"`
-	MatchContext string `json:"matchContext"`
+	ID           string  `json:"id"`
+	Type         string  `json:"type"`
+	Name         string  `json:"name"`
+	Status       string  `json:"status"`
+	MatchField   string  `json:"matchField"`
+	MatchContext string  `json:"matchContext"`
+	Score        float64 `json:"score,omitempty"`
 }
 
 // SearchResponse is the JSON response for the search command
@@ -31,18 +34,125 @@ type SearchResponse struct {
 }
 
 var searchCmd = &cobra.Command{
-	Use:   "search <regex>",
-	Short: "Search board content by regex",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runSearch,
+	Use:   "search <query>",
+	Short: "Full-text search with ranking",
+	Long: `Search board content using the full-text index in .task-board/.index.
+
+The index is refreshed incrementally on every search (only elements whose
+files changed are re-read); --reindex rebuilds it from scratch.
+
+Query syntax:
+  audio capture          elements containing both terms
+  "audio capture"        exact phrase
+  title:recorder         term in the title only
+  ac:"records audio"     phrase in acceptance criteria
+  notes:flaky            term in notes
+Fields: title, description, scope, ac, checklist, notes.
+
+Results are ranked with BM25; title and acceptance-criteria matches weigh more.
+Use --regex for the previous line-by-line regex search.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSearch,
 }
 
+var (
+	searchRegex   bool
+	searchReindex bool
+	searchLimit   int
+)
+
 func init() {
 	rootCmd.AddCommand(searchCmd)
+	searchCmd.Flags().BoolVar(&searchRegex, "regex", false, "Treat the query as a regex and scan files line by line")
+	searchCmd.Flags().BoolVar(&searchReindex, "reindex", false, "Rebuild the search index before searching")
+	searchCmd.Flags().IntVar(&searchLimit, "limit", 20, "Maximum number of results (0 = all)")
 }
 
 func runSearch(cmd *cobra.Command, args []string) error {
-	query := args[0]
+	if searchRegex {
+		return runRegexSearch(args[0])
+	}
+
+	q, err := search.ParseQuery(args[0])
+	if err != nil {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.ValidationError, fmt.Sprintf("invalid query: %v", err), nil)
+			return nil
+		}
+		return fmt.Errorf("invalid query: %w", err)
+	}
+
+	b, err := loadViewBoard()
+	if err != nil {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.InternalError, fmt.Sprintf("loading board: %v", err), nil)
+			return nil
+		}
+		return fmt.Errorf("loading board: %w", err)
+	}
+
+	ix := openSearchIndex(b)
+	hits := ix.Search(q, searchLimit)
+
+	if JSONEnabled() {
+		results := []SearchResult{}
+		for _, h := range hits {
+			e := b.FindByID(h.ID)
+			if e == nil {
+				continue
+			}
+			results = append(results, SearchResult{
+				ID:           e.ID(),
+				Type:         string(e.Type),
+				Name:         e.Name,
+				Status:       string(e.Status),
+				MatchField:   h.Field,
+				MatchContext: h.Highlight("**", "**"),
+				Score:        math.Round(h.Score*1000) / 1000,
+			})
+		}
+		return output.PrintJSON(os.Stdout, SearchResponse{Results: results, Count: len(results), Query: args[0]})
+	}
+
+	if len(hits) == 0 {
+		fmt.Println("No matches found.")
+		return nil
+	}
+	for _, h := range hits {
+		e := b.FindByID(h.ID)
+		if e == nil {
+			continue
+		}
+		fmt.Printf("%s%s%s %s %s %s(%.2f)%s\n", output.Bold, e.ID(), output.Reset, e.Name,
+			output.ColorStatus(string(e.Status)), output.Gray, h.Score, output.Reset)
+		fmt.Printf("  %s%s:%s %s\n\n", output.Gray, h.Field, output.Reset, h.Highlight(output.Red, output.Reset))
+	}
+	fmt.Printf("%d result(s).\n", len(hits))
+	return nil
+}
+
+// openSearchIndex loads the board's index, brings it up to date and saves it
+// when something changed. Workspace boards are indexed in memory only.
+func openSearchIndex(b *board.Board) *search.Index {
+	if WorkspaceEnabled() {
+		ix := search.New()
+		ix.Sync(b)
+		return ix
+	}
+
+	ix := search.Load(boardDir)
+	if searchReindex {
+		ix = search.New()
+	}
+	if ix.Sync(b) || searchReindex {
+		// A read-only board still gets correct results; only caching is lost
+		_ = ix.Save(boardDir)
+	}
+	return ix
+}
+
+// runRegexSearch scans README/progress files line by line (search --regex).
+func runRegexSearch(query string) error {
 	pattern, err := regexp.Compile("(?i)" + query)
 	if err != nil {
 		if JSONEnabled() {
@@ -52,7 +162,7 @@ func runSearch(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid regex: %w", err)
 	}
 
-	b, err := board.Load(boardDir)
+	b, err := loadViewBoard()
 	if err != nil {
 		if JSONEnabled() {
 			output.PrintError(os.Stderr, output.InternalError, fmt.Sprintf("loading board: %v", err), nil)
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/aagrigore/task-board/internal/search"
+)
+
+func runSearchJSONOutput(t *testing.T, query string) SearchResponse {
+	t.Helper()
+	jsonOutput = true
+	defer func() { jsonOutput = false }()
+	out := captureOutput(t, func() {
+		if err := runSearch(searchCmd, []string{query}); err != nil {
+			t.Fatalf("runSearch: %v", err)
+		}
+	})
+	var resp SearchResponse
+	if err := json.Unmarshal([]byte(out), &resp); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	return resp
+}
+
+func TestSearchIndexed(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+
+	resp := runSearchJSONOutput(t, "title:schema")
+	if resp.Count != 1 || resp.Results[0].ID != testTask4ID {
+		t.Fatalf("title:schema = %+v", resp.Results)
+	}
+	if resp.Results[0].MatchField != "title" || resp.Results[0].Score <= 0 {
+		t.Errorf("unexpected result metadata: %+v", resp.Results[0])
+	}
+	if _, err := os.Stat(search.Path(bd)); err != nil {
+		t.Errorf("index file should be written: %v", err)
+	}
+}
+
+func TestSearchSeesEditsWithoutReindex(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	runSearchJSONOutput(t, "schema") // builds the index
+
+	captureOutput(t, func() {
+		if err := runProgressNotes(progressNotesCmd, []string{testTask1ID, "Switched to ringbuffer"}); err != nil {
+			t.Fatalf("runProgressNotes: %v", err)
+		}
+	})
+
+	resp := runSearchJSONOutput(t, "notes:ringbuffer")
+	if resp.Count != 1 || resp.Results[0].ID != testTask1ID {
+		t.Errorf("edited notes not found: %+v", resp.Results)
+	}
+}
+
+func TestSearchRegexMode(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	searchRegex = true
+	defer func() { searchRegex = false }()
+
+	resp := runSearchJSONOutput(t, "Sch.ma")
+	if resp.Count == 0 {
+		t.Fatal("regex search should still match")
+	}
+	if resp.Results[0].MatchField != "readme" {
+		t.Errorf("regex mode keeps file-level match fields, got %q", resp.Results[0].MatchField)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/aagrigore/task-board/internal/board"
 	"github.com/aagrigore/task-board/internal/output"
@@ -12,21 +13,34 @@ import (
 
 // ShowElementJSON represents the JSON output for show command
 type ShowElementJSON struct {
-	ID                 string              `json:"id"`
-	Type               string              `json:"type"`
-	Name               string              `json:"name"`
-	Status             string              `json:"status"`
-	Assignee           string              `json:"assignee"`
-	Parent             string              `json:"parent"`
-	Path               string              `json:"path"`
-	CreatedAt          string              `json:"createdAt"`
-	UpdatedAt          string              `json:"updatedAt"`
-	BlockedBy          []string            `json:"blockedBy"`
-	Blocks             []string            `json:"blocks"`
-	Description        string              `json:"description"`
-	AcceptanceCriteria string              `json:"acceptanceCriteria"`
-	Checklist          []ChecklistItemJSON `json:"checklist"`
-	Notes              []NoteJSON          `json:"notes"`
+	ID                 string               `json:"id"`
+	Type               string               `json:"type"`
+	Name               string               `json:"name"`
+	Status             string               `json:"status"`
+	PriorStatus        string               `json:"priorStatus,omitempty"` // the status held before it was blocked
+	Assignee           string               `json:"assignee"`
+	Parent             string               `json:"parent"`
+	Path               string               `json:"path"`
+	CreatedAt          string               `json:"createdAt"`
+	UpdatedAt          string               `json:"updatedAt"`
+	BlockedBy          []string             `json:"blockedBy"`
+	Blocks             []string             `json:"blocks"`
+	Links              []board.Link         `json:"links"`
+	External           []BlockerJSON        `json:"external"` // open external blockers of it or below
+	Description        string               `json:"description"`
+	AcceptanceCriteria string               `json:"acceptanceCriteria"`
+	Criteria           []CriterionJSON      `json:"criteria"` // the AC bullets with their review verdicts
+	Checklist          []ChecklistItemJSON  `json:"checklist"`
+	Notes              []NoteJSON           `json:"notes"`
+	History            []board.StatusChange `json:"history"`
+	TimeLog            []TimeEntryJSON      `json:"timeLog"`
+	// Time rolls up the time log of the element and everything below it
+	Time TimeJSON `json:"time"`
+	// Recurrence is set on recurring templates and their occurrences
+	Recurrence *RecurrenceJSON `json:"recurrence,omitempty"`
+	// SnoozedUntil is empty unless the element itself is snoozed
+	SnoozedUntil string         `json:"snoozedUntil,omitempty"`
+	Scheduled    *ScheduledJSON `json:"scheduled,omitempty"`
 }
 
 // ChecklistItemJSON represents a checklist item in JSON output
@@ -60,7 +74,7 @@ func init() {
 func runShow(cmd *cobra.Command, args []string) error {
 	id := args[0]
 
-	b, err := board.Load(boardDir)
+	b, err := loadViewBoard()
 	if err != nil {
 		if JSONEnabled() {
 			output.PrintError(os.Stderr, output.InternalError, fmt.Sprintf("loading board: %v", err), nil)
@@ -98,6 +112,13 @@ func runShow(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("reading README: %w", err)
 	}
 
+	// In workspace mode show links the way the merged board sees them (qualified)
+	if WorkspaceEnabled() {
+		pd.BlockedBy = elem.BlockedBy
+		pd.Blocks = elem.Blocks
+		pd.Links = elem.Links
+	}
+
 	// JSON output
 	if JSONEnabled() {
 		return outputShowJSON(b, elem, pd, rd)
@@ -107,7 +128,12 @@ func runShow(cmd *cobra.Command, args []string) error {
 	// Header
 	fmt.Printf("%s%s: %s%s\n", output.Bold, elem.ID(), rd.Title, output.Reset)
 	fmt.Printf("Path: %s\n", b.Ancestry(elem))
-	fmt.Printf("Status: %s\n", output.ColorStatus(string(pd.Status)))
+	// A block shows what unblock would return to
+	if pd.PriorStatus != "" && b.Workflow.Category(elem) == board.CategoryBlocked {
+		fmt.Printf("Status: %s (was %s)\n", output.ColorStatus(string(pd.Status)), pd.PriorStatus)
+	} else {
+		fmt.Printf("Status: %s\n", output.ColorStatus(string(pd.Status)))
+	}
 	fmt.Println()
 
 	// Description
@@ -128,11 +154,11 @@ func runShow(cmd *cobra.Command, args []string) error {
 		fmt.Println()
 	}
 
-	// Acceptance Criteria
-	if rd.AC != "" {
+	// Acceptance Criteria, numbered for review
+	if criteria := board.MatchCriteria(rd.AC, pd.Review); len(criteria) > 0 {
 		fmt.Println("Acceptance Criteria:")
-		for _, line := range strings.Split(rd.AC, "\n") {
-			fmt.Printf("  %s\n", line)
+		for _, c := range criteria {
+			fmt.Printf("  %d. %s %s\n", c.Number, verdictMark(c.Verdict), c.Text)
 		}
 		fmt.Println()
 	}
@@ -150,6 +176,16 @@ func runShow(cmd *cobra.Command, args []string) error {
 		fmt.Println()
 	}
 
+	if !pd.Recurrence.IsZero() {
+		fmt.Printf("Recurrence: %s\n", describeRecurrence(pd.Recurrence))
+	}
+	if !pd.SnoozedUntil.IsZero() {
+		fmt.Printf("Snoozed until: %s\n", pd.SnoozedUntil.Local().Format("2006-01-02 15:04"))
+	}
+	if !pd.Scheduled.IsZero() {
+		fmt.Printf("Scheduled: %s\n", describeScheduled(pd.Scheduled))
+	}
+
 	// Blocked By
 	if len(pd.BlockedBy) > 0 {
 		fmt.Printf("Blocked By: %s\n", strings.Join(pd.BlockedBy, ", "))
@@ -164,6 +200,49 @@ func runShow(cmd *cobra.Command, args []string) error {
 		fmt.Println("Blocks: (none)")
 	}
 
+	// Typed links
+	if len(pd.Links) > 0 {
+		fmt.Println("Links:")
+		for _, l := range pd.Links {
+			fmt.Printf("  %-15s %s\n", l.Type, l.Target)
+		}
+	}
+
+	// External blockers
+	if len(elem.External) > 0 {
+		fmt.Println("External blockers:")
+		for _, bl := range elem.External {
+			fmt.Printf("  %-8s %s%s\n", bl.ID, bl.Reason, describeBlockerOwner(bl, time.Now()))
+		}
+	}
+
+	// Status history
+	if len(pd.History) > 0 {
+		fmt.Println()
+		fmt.Println("History:")
+		for _, c := range pd.History {
+			fmt.Printf("  %s  %s → %s\n", c.At.UTC().Format("2006-01-02 15:04"), c.From, c.To)
+		}
+	}
+
+	// Time spent, rolled up from the children
+	now := time.Now().UTC()
+	if totals := b.Totals(elem, now); hasTime(totals) {
+		fmt.Println()
+		line := "Time: " + describeTime(totals)
+		if own := elem.TimeSpent(now); own != totals.Spent {
+			line += fmt.Sprintf(" (own %s)", board.FormatDuration(own))
+		}
+		fmt.Println(line)
+		for _, t := range pd.TimeLog {
+			d := board.FormatDuration(t.Spent(now))
+			if t.Running {
+				d += " running"
+			}
+			fmt.Printf("  %s  %-12s %s\n", t.Start.UTC().Format("2006-01-02 15:04"), d, t.Note)
+		}
+	}
+
 	// Notes
 	if pd.Notes != "" {
 		fmt.Println()
@@ -178,6 +257,12 @@ func runShow(cmd *cobra.Command, args []string) error {
 
 // outputShowJSON outputs the show command result as JSON
 func outputShowJSON(b *board.Board, elem *board.Element, pd *board.ProgressData, rd *board.ReadmeData) error {
+	return output.PrintJSON(os.Stdout, ShowResponse{Element: showElementJSON(b, elem, pd, rd)})
+}
+
+// showElementJSON builds the show --json element, also used for the pages of
+// the HTML report
+func showElementJSON(b *board.Board, elem *board.Element, pd *board.ProgressData, rd *board.ReadmeData) ShowElementJSON {
 	// Build checklist
 	checklist := make([]ChecklistItemJSON, len(pd.Checklist))
 	for i, item := range pd.Checklist {
@@ -211,9 +296,27 @@ func outputShowJSON(b *board.Board, elem *board.Element, pd *board.ProgressData,
 	if blocks == nil {
 		blocks = []string{}
 	}
+	links := pd.Links
+	if links == nil {
+		links = []board.Link{}
+	}
 	if notes == nil {
 		notes = []NoteJSON{}
 	}
+	external := make([]BlockerJSON, len(elem.External))
+	for i, bl := range elem.External {
+		external[i] = blockerJSON(bl, time.Now())
+	}
+	history := pd.History
+	if history == nil {
+		history = []board.StatusChange{}
+	}
+
+	now := time.Now().UTC()
+	timeLog := make([]TimeEntryJSON, len(pd.TimeLog))
+	for i, t := range pd.TimeLog {
+		timeLog[i] = timeEntryJSON(t, now)
+	}
 
 	// Format timestamps
 	createdAt := ""
@@ -225,25 +328,42 @@ func outputShowJSON(b *board.Board, elem *board.Element, pd *board.ProgressData,
 		updatedAt = pd.LastUpdate.UTC().Format("2006-01-02T15:04:05Z")
 	}
 
-	response := ShowResponse{
-		Element: ShowElementJSON{
-			ID:                 elem.ID(),
-			Type:               string(elem.Type),
-			Name:               rd.Title,
-			Status:             string(pd.Status),
-			Assignee:           pd.AssignedTo,
-			Parent:             elem.ParentID,
-			Path:               b.Ancestry(elem),
-			CreatedAt:          createdAt,
-			UpdatedAt:          updatedAt,
-			BlockedBy:          blockedBy,
-			Blocks:             blocks,
-			Description:        rd.Description,
-			AcceptanceCriteria: rd.AC,
-			Checklist:          checklist,
-			Notes:              notes,
-		},
-	}
-
-	return output.PrintJSON(os.Stdout, response)
+	snoozedUntil := ""
+	if !pd.SnoozedUntil.IsZero() {
+		snoozedUntil = pd.SnoozedUntil.UTC().Format("2006-01-02T15:04:05Z")
+	}
+
+	var recurrence *RecurrenceJSON
+	if !pd.Recurrence.IsZero() {
+		r := recurrenceJSON(pd.Recurrence)
+		recurrence = &r
+	}
+
+	return ShowElementJSON{
+		ID:                 elem.ID(),
+		Type:               string(elem.Type),
+		Name:               rd.Title,
+		Status:             string(pd.Status),
+		PriorStatus:        string(pd.PriorStatus),
+		Assignee:           pd.AssignedTo,
+		Parent:             elem.ParentID,
+		Path:               b.Ancestry(elem),
+		CreatedAt:          createdAt,
+		UpdatedAt:          updatedAt,
+		BlockedBy:          blockedBy,
+		Blocks:             blocks,
+		Links:              links,
+		External:           external,
+		Description:        rd.Description,
+		AcceptanceCriteria: rd.AC,
+		Criteria:           criteriaJSON(board.MatchCriteria(rd.AC, pd.Review)),
+		Checklist:          checklist,
+		Notes:              notes,
+		History:            history,
+		TimeLog:            timeLog,
+		Time:               timeJSON(b.Totals(elem, now)),
+		Recurrence:         recurrence,
+		SnoozedUntil:       snoozedUntil,
+		Scheduled:          scheduledJSON(pd.Scheduled),
+	}
 }
@@ -0,0 +1,401 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aagrigore/task-board/internal/board"
+	"github.com/aagrigore/task-board/internal/output"
+	"github.com/aagrigore/task-board/query"
+	"github.com/spf13/cobra"
+)
+
+// SnoozeResponse is the JSON response for snooze and unsnooze
+type SnoozeResponse struct {
+	ID           string `json:"id"`
+	SnoozedUntil string `json:"snoozedUntil"` // empty when awake
+	Message      string `json:"message"`
+}
+
+// ScheduleResponse is the JSON response for schedule
+type ScheduleResponse struct {
+	ID        string         `json:"id"`
+	Scheduled *ScheduledJSON `json:"scheduled"` // nil when nothing is scheduled
+	Message   string         `json:"message"`
+}
+
+// ScheduledJSON is a pending status change
+type ScheduledJSON struct {
+	At     string `json:"at"`
+	Status string `json:"status"`
+}
+
+// ApplyScheduleResponse is the JSON response for apply-schedule
+type ApplyScheduleResponse struct {
+	Applied []ScheduleApplied `json:"applied"`
+	// Woken lists the elements whose snooze ran out
+	Woken []string `json:"woken"`
+	// Problems lists the due changes the workflow refused; they stay pending
+	Problems []ElementProblem `json:"problems"`
+	DryRun   bool             `json:"dryRun"`
+}
+
+// ScheduleApplied is a scheduled status change apply-schedule made
+type ScheduleApplied struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	From string `json:"from"`
+	To   string `json:"to"`
+	At   string `json:"at"` // when it was scheduled for
+}
+
+var snoozeCmd = &cobra.Command{
+	Use:   "snooze <ID>",
+	Short: "Hide an element from list and tree until a date",
+	Long: `Hide an element, and everything below it, from list, tree and the TUI
+until --until. Use --include-snoozed on list and tree to see snoozed
+elements anyway.
+
+--until takes a date (2026-11-01), a local time (2026-11-01 09:00), an
+age from now (3d, 2w) or a weekday (monday, the next one at midnight).`,
+	Args: cobra.ExactArgs(1),
+	RunE: recorded(runSnooze),
+}
+
+var unsnoozeCmd = &cobra.Command{
+	Use:   "unsnooze <ID>",
+	Short: "Show a snoozed element again",
+	Args:  cobra.ExactArgs(1),
+	RunE:  recorded(runSnooze),
+}
+
+var scheduleCmd = &cobra.Command{
+	Use:   "schedule <ID> [STATUS]",
+	Short: "Schedule a status change, or show the scheduled one",
+	Long: `Schedule a status change that 'task-board apply-schedule' makes once --at
+has passed. An element has at most one pending change; scheduling another
+replaces it and --clear drops it. Without a status the pending change is
+shown.
+
+apply-schedule is safe to run as often as you like, e.g. from cron:
+
+  */15 * * * *  cd /path/to/project && task-board apply-schedule`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: recorded(runSchedule),
+}
+
+var applyScheduleCmd = &cobra.Command{
+	Use:   "apply-schedule",
+	Short: "Make the scheduled status changes that are due and wake snoozed elements",
+	Long: `Make every scheduled status change whose time has passed, with the same
+checks as 'progress status': the workflow transitions, blockers and WIP
+limits (--force skips the limits). A refused change stays scheduled and is
+reported, so the next run tries again. Snoozes that have run out are
+cleared. Running it twice changes nothing the second time.`,
+	Args: cobra.NoArgs,
+	RunE: recorded(runApplySchedule),
+}
+
+var (
+	snoozeUntil        string
+	scheduleAt         string
+	scheduleClear      bool
+	applyScheduleDry   bool
+	applyScheduleForce bool
+	includeSnoozed     bool
+)
+
+func init() {
+	rootCmd.AddCommand(snoozeCmd)
+	rootCmd.AddCommand(unsnoozeCmd)
+	rootCmd.AddCommand(scheduleCmd)
+	rootCmd.AddCommand(applyScheduleCmd)
+	snoozeCmd.Flags().StringVar(&snoozeUntil, "until", "", "Snooze until (2006-01-02, \"2006-01-02 15:04\", 3d, monday)")
+	snoozeCmd.MarkFlagRequired("until")
+	scheduleCmd.Flags().StringVar(&scheduleAt, "at", "", "When to change the status (2006-01-02, \"2006-01-02 15:04\", 3d, monday)")
+	scheduleCmd.Flags().BoolVar(&scheduleClear, "clear", false, "Drop the pending change")
+	applyScheduleCmd.Flags().BoolVar(&applyScheduleDry, "dry-run", false, "List what would change without writing")
+	applyScheduleCmd.Flags().BoolVar(&applyScheduleForce, "force", false, "Exceed WIP limits")
+	listCmd.Flags().BoolVar(&includeSnoozed, "include-snoozed", false, "Also list snoozed elements")
+	treeCmd.Flags().BoolVar(&includeSnoozed, "include-snoozed", false, "Also show snoozed elements")
+}
+
+// parseUntil reads a future time for a flag: a date or local time (see
+// parseDate), an age from now (3d, 2w) or a weekday name, meaning the next
+// such day at midnight.
+func parseUntil(flag, s string, now time.Time) (time.Time, error) {
+	if t, err := parseDate(flag, s); err == nil {
+		return t, nil
+	}
+	if age, err := query.ParseAge(s); err == nil {
+		return now.Add(age), nil
+	}
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if name := strings.ToLower(d.String()); strings.ToLower(s) == name || strings.ToLower(s) == name[:3] {
+			days := (int(d)-int(now.Weekday())+6)%7 + 1
+			local := now.Local()
+			return time.Date(local.Year(), local.Month(), local.Day()+days, 0, 0, 0, 0, time.Local), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("--%s: cannot parse %q as a date (2006-01-02 or 2006-01-02 15:04), an age (3d, 2w) or a weekday", flag, s)
+}
+
+func runSnooze(cmd *cobra.Command, args []string) error {
+	fail := func(code output.ErrorCode, err error) error {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, code, err.Error(), nil)
+			return nil
+		}
+		return err
+	}
+
+	b, err := board.Load(boardDir)
+	if err != nil {
+		return fail(output.InternalError, fmt.Errorf("loading board: %w", err))
+	}
+	elem := b.FindByID(args[0])
+	if elem == nil {
+		return fail(output.NotFound, fmt.Errorf("element %s not found", args[0]))
+	}
+	pd, err := board.ParseProgressFile(elem.ProgressPath())
+	if err != nil {
+		return fail(output.InternalError, fmt.Errorf("reading progress: %w", err))
+	}
+
+	now := time.Now()
+	message := "unsnoozed"
+	if cmd.Name() == "snooze" {
+		until, err := parseUntil("until", snoozeUntil, now)
+		if err != nil {
+			return fail(output.ValidationError, err)
+		}
+		if !until.After(now) {
+			return fail(output.ValidationError, fmt.Errorf("--until: %s is in the past", until.Format("2006-01-02 15:04")))
+		}
+		pd.SnoozedUntil = until.UTC().Truncate(time.Second)
+		message = "snoozed until " + pd.SnoozedUntil.Local().Format("2006-01-02 15:04")
+	} else {
+		pd.SnoozedUntil = time.Time{}
+	}
+	if err := board.WriteProgressFile(elem.ProgressPath(), pd); err != nil {
+		return fail(output.InternalError, fmt.Errorf("writing progress: %w", err))
+	}
+
+	if JSONEnabled() {
+		response := SnoozeResponse{ID: elem.ID(), Message: message}
+		if !pd.SnoozedUntil.IsZero() {
+			response.SnoozedUntil = pd.SnoozedUntil.Format(time.RFC3339)
+		}
+		return output.PrintJSON(os.Stdout, response)
+	}
+	fmt.Printf("%s %s\n", elem.ID(), message)
+	return nil
+}
+
+func runSchedule(cmd *cobra.Command, args []string) error {
+	fail := func(code output.ErrorCode, err error) error {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, code, err.Error(), nil)
+			return nil
+		}
+		return err
+	}
+
+	b, err := board.Load(boardDir)
+	if err != nil {
+		return fail(output.InternalError, fmt.Errorf("loading board: %w", err))
+	}
+	elem := b.FindByID(args[0])
+	if elem == nil {
+		return fail(output.NotFound, fmt.Errorf("element %s not found", args[0]))
+	}
+	pd, err := board.ParseProgressFile(elem.ProgressPath())
+	if err != nil {
+		return fail(output.InternalError, fmt.Errorf("reading progress: %w", err))
+	}
+
+	switch {
+	case scheduleClear && len(args) == 2:
+		return fail(output.ValidationError, fmt.Errorf("--clear takes no status"))
+	case scheduleClear:
+		pd.Scheduled = board.ScheduledChange{}
+	case len(args) == 2:
+		if scheduleAt == "" {
+			return fail(output.ValidationError, fmt.Errorf("--at is required with a status"))
+		}
+		flow := b.Flow(elem.Type)
+		status, err := flow.Parse(args[1])
+		if err != nil {
+			return fail(output.InvalidStatus, fmt.Errorf("%w (%s workflow)", err, elem.Type))
+		}
+		at, err := parseUntil("at", scheduleAt, time.Now())
+		if err != nil {
+			return fail(output.ValidationError, err)
+		}
+		pd.Scheduled = board.ScheduledChange{At: at.UTC().Truncate(time.Second), Status: status}
+	case scheduleAt != "":
+		return fail(output.ValidationError, fmt.Errorf("--at needs a status to change to"))
+	}
+	if scheduleClear || len(args) == 2 {
+		if err := board.WriteProgressFile(elem.ProgressPath(), pd); err != nil {
+			return fail(output.InternalError, fmt.Errorf("writing progress: %w", err))
+		}
+	}
+
+	message := describeScheduled(pd.Scheduled)
+	if JSONEnabled() {
+		return output.PrintJSON(os.Stdout, ScheduleResponse{ID: elem.ID(), Scheduled: scheduledJSON(pd.Scheduled), Message: message})
+	}
+	fmt.Printf("%s %s\n", elem.ID(), message)
+	return nil
+}
+
+// describeScheduled reads like "→ development at 2026-11-01 09:00".
+func describeScheduled(s board.ScheduledChange) string {
+	if s.IsZero() {
+		return "has no scheduled change"
+	}
+	return fmt.Sprintf("→ %s at %s", s.Status, s.At.Local().Format("2006-01-02 15:04"))
+}
+
+func scheduledJSON(s board.ScheduledChange) *ScheduledJSON {
+	if s.IsZero() {
+		return nil
+	}
+	return &ScheduledJSON{At: s.At.UTC().Format(time.RFC3339), Status: string(s.Status)}
+}
+
+func runApplySchedule(cmd *cobra.Command, args []string) error {
+	fail := func(code output.ErrorCode, err error) error {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, code, err.Error(), nil)
+			return nil
+		}
+		return err
+	}
+
+	b, err := board.Load(boardDir)
+	if err != nil {
+		return fail(output.InternalError, fmt.Errorf("loading board: %w", err))
+	}
+
+	now := time.Now()
+	response := ApplyScheduleResponse{Applied: []ScheduleApplied{}, Woken: []string{}, Problems: []ElementProblem{}, DryRun: applyScheduleDry}
+	prefix := ""
+	if applyScheduleDry {
+		prefix = "would be: "
+	}
+	for _, elem := range b.Elements {
+		due := elem.Scheduled.Due(now)
+		wake := !elem.SnoozedUntil.IsZero() && !elem.IsSnoozed(now)
+		if !due && !wake {
+			continue
+		}
+		pd, err := board.ParseProgressFile(elem.ProgressPath())
+		if err != nil {
+			return fail(output.InternalError, fmt.Errorf("%s: reading progress: %w", elem.ID(), err))
+		}
+
+		if wake {
+			pd.SnoozedUntil = time.Time{}
+			response.Woken = append(response.Woken, elem.ID())
+			infof("%s%s unsnoozed\n", prefix, elem.ID())
+		}
+		var applied *ScheduleApplied
+		if due {
+			change := elem.Scheduled
+			// An element already in the status just drops the change
+			var refused *refusal
+			if change.Status != elem.Status {
+				refused = transitionRefusal(b, elem, change.Status, applyScheduleForce)
+			}
+			if refused != nil {
+				response.Problems = append(response.Problems, ElementProblem{ID: elem.ID(), Error: refused.message})
+			} else {
+				applied = &ScheduleApplied{
+					ID:   elem.ID(),
+					Name: elem.Name,
+					From: string(elem.Status),
+					To:   string(change.Status),
+					At:   change.At.Local().Format("2006-01-02 15:04"),
+				}
+				response.Applied = append(response.Applied, *applied)
+				pd.Scheduled = board.ScheduledChange{}
+			}
+		}
+		if applied == nil {
+			if !applyScheduleDry && wake {
+				if err := board.WriteProgressFile(elem.ProgressPath(), pd); err != nil {
+					return fail(output.InternalError, fmt.Errorf("%s: writing progress: %w", elem.ID(), err))
+				}
+			}
+			continue
+		}
+
+		note := fmt.Sprintf("%s%s %s → %s (scheduled %s)", prefix, applied.ID, applied.From, applied.To, applied.At)
+		if applyScheduleDry {
+			infof("%s\n", note)
+			continue
+		}
+		if err := setStatus(b, elem, pd, board.Status(applied.To), note); err != nil {
+			return fail(output.InternalError, fmt.Errorf("%s: %w", elem.ID(), err))
+		}
+	}
+
+	if JSONEnabled() {
+		return output.PrintJSON(os.Stdout, response)
+	}
+	for _, p := range response.Problems {
+		fmt.Fprintf(os.Stderr, "warning: %s: %s (still scheduled)\n", p.ID, p.Error)
+	}
+	if len(response.Applied) == 0 && len(response.Woken) == 0 && len(response.Problems) == 0 {
+		fmt.Println("Nothing due.")
+	}
+	return nil
+}
+
+// hideSnoozed drops the elements snoozed at now, themselves or through an
+// ancestor, unless --include-snoozed is set. It also returns how many were
+// dropped.
+func hideSnoozed(b *board.Board, elements []*board.Element, now time.Time) ([]*board.Element, int) {
+	if includeSnoozed {
+		return elements, 0
+	}
+	var kept []*board.Element
+	for _, e := range elements {
+		if !b.Snoozed(e, now) {
+			kept = append(kept, e)
+		}
+	}
+	return kept, len(elements) - len(kept)
+}
+
+// pruneSnoozed removes the snoozed nodes, with everything below them, from a
+// tree unless --include-snoozed is set. It also returns how many nodes were
+// removed at the top of a snoozed branch.
+func pruneSnoozed(b *board.Board, nodes []*TreeNode, now time.Time) ([]*TreeNode, int) {
+	if includeSnoozed {
+		return nodes, 0
+	}
+	kept := make([]*TreeNode, 0, len(nodes))
+	hidden := 0
+	for _, node := range nodes {
+		if e := b.FindByID(node.ID); e != nil && e.IsSnoozed(now) {
+			hidden++
+			continue
+		}
+		var n int
+		node.Children, n = pruneSnoozed(b, node.Children, now)
+		hidden += n
+		kept = append(kept, node)
+	}
+	return kept, hidden
+}
+
+func printSnoozedHint(hidden int) {
+	if hidden > 0 {
+		fmt.Printf("%s%d snoozed hidden (--include-snoozed to show)%s\n", output.Gray, hidden, output.Reset)
+	}
+}
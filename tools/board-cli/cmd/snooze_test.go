@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aagrigore/task-board/internal/board"
+)
+
+func listedIDs(t *testing.T, args ...string) map[string]bool {
+	t.Helper()
+	jsonOutput = true
+	defer func() { jsonOutput = false }()
+	stdout := captureOutput(t, func() {
+		if err := runList(listCmd, args); err != nil {
+			t.Fatal(err)
+		}
+	})
+	var resp ListResponse
+	if err := json.Unmarshal([]byte(stdout), &resp); err != nil {
+		t.Fatalf("parse: %v\n%s", err, stdout)
+	}
+	ids := map[string]bool{}
+	for _, e := range resp.Elements {
+		ids[e.ID] = true
+	}
+	return ids
+}
+
+func TestSnoozeHidesFromListAndTree(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	defer func() { snoozeUntil, includeSnoozed = "", false }()
+
+	snoozeUntil = "3d"
+	out := captureOutput(t, func() {
+		if err := runSnooze(snoozeCmd, []string{testStory1ID}); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if !strings.Contains(out, testStory1ID+" snoozed until "+time.Now().AddDate(0, 0, 3).Format("2006-01-02")) {
+		t.Errorf("snooze output = %q", out)
+	}
+
+	ids := listedIDs(t)
+	if ids[testStory1ID] || ids[testTask1ID] || !ids[testEpic1ID] || !ids[testTask4ID] {
+		t.Errorf("the story and its tasks should be hidden, the rest listed: %v", ids)
+	}
+	out = captureOutput(t, func() { runList(listCmd, []string{"tasks"}) })
+	if !strings.Contains(out, "snoozed hidden (--include-snoozed to show)") {
+		t.Errorf("list should mention the hidden elements:\n%s", out)
+	}
+	out = captureOutput(t, func() { runTree(treeCmd, nil) })
+	if strings.Contains(out, testStory1ID) || !strings.Contains(out, testStory3ID) || !strings.Contains(out, "1 snoozed hidden") {
+		t.Errorf("tree should leave out the snoozed story:\n%s", out)
+	}
+
+	includeSnoozed = true
+	if ids := listedIDs(t); !ids[testTask1ID] {
+		t.Error("--include-snoozed should list snoozed elements")
+	}
+	includeSnoozed = false
+
+	captureOutput(t, func() { runSnooze(unsnoozeCmd, []string{testStory1ID}) })
+	if ids := listedIDs(t); !ids[testTask1ID] {
+		t.Error("unsnooze should list the tasks again")
+	}
+
+	snoozeUntil = "2020-01-01"
+	if err := runSnooze(snoozeCmd, []string{testTask1ID}); err == nil || !strings.Contains(err.Error(), "in the past") {
+		t.Errorf("past date: err = %v", err)
+	}
+	snoozeUntil = "fortnight"
+	if err := runSnooze(snoozeCmd, []string{testTask1ID}); err == nil || !strings.Contains(err.Error(), "cannot parse") {
+		t.Errorf("bad date: err = %v", err)
+	}
+}
+
+func TestParseUntilWeekday(t *testing.T) {
+	now := time.Date(2026, 10, 18, 15, 0, 0, 0, time.Local) // a Sunday
+	for s, want := range map[string]string{"monday": "2026-10-19", "sun": "2026-10-25", "Saturday": "2026-10-24"} {
+		got, err := parseUntil("until", s, now)
+		if err != nil || got.Format("2006-01-02 15:04") != want+" 00:00" {
+			t.Errorf("parseUntil(%q) = %v, %v; want %s", s, got, err, want)
+		}
+	}
+}
+
+func TestScheduleAndApply(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	defer func() { scheduleAt, scheduleClear, applyScheduleDry, applyScheduleForce = "", false, false, false }()
+	os.WriteFile(filepath.Join(bd, board.WorkflowFile), []byte(testWIPWorkflow), 0644)
+
+	captureOutput(t, func() { runProgressStatus(progressStatusCmd, []string{testTask1ID, "development"}) })
+	scheduleAt = "2020-01-01 09:00"
+	out := captureOutput(t, func() {
+		if err := runSchedule(scheduleCmd, []string{testTask3ID, "development"}); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if !strings.Contains(out, testTask3ID+" → development at 2020-01-01 09:00") {
+		t.Errorf("schedule output = %q", out)
+	}
+	scheduleAt = "3d"
+	captureOutput(t, func() { runSchedule(scheduleCmd, []string{testTask4ID, "development"}) })
+	if err := runSchedule(scheduleCmd, []string{testTask4ID, "nonsense"}); err == nil {
+		t.Error("an unknown status should be refused")
+	}
+
+	// An expired snooze is cleared on the way
+	task2 := filepath.Join(bd, testEpic1ID+"_recording", testStory1ID+"_audio-capture", testTask2ID+"_impl", "progress.md")
+	pd, _ := board.ParseProgressFile(task2)
+	pd.SnoozedUntil = time.Now().Add(-time.Hour)
+	board.WriteProgressFile(task2, pd)
+
+	apply := func() ApplyScheduleResponse {
+		jsonOutput = true
+		defer func() { jsonOutput = false }()
+		stdout := captureOutput(t, func() {
+			if err := runApplySchedule(applyScheduleCmd, nil); err != nil {
+				t.Fatal(err)
+			}
+		})
+		var resp ApplyScheduleResponse
+		if err := json.Unmarshal([]byte(stdout), &resp); err != nil {
+			t.Fatalf("parse: %v\n%s", err, stdout)
+		}
+		return resp
+	}
+
+	// The WIP limit refuses the change, which stays scheduled
+	resp := apply()
+	if len(resp.Applied) != 0 || len(resp.Problems) != 1 || !strings.Contains(resp.Problems[0].Error, "WIP limit") {
+		t.Fatalf("applied %+v, problems %+v", resp.Applied, resp.Problems)
+	}
+	if len(resp.Woken) != 1 || resp.Woken[0] != testTask2ID {
+		t.Errorf("woken = %v", resp.Woken)
+	}
+
+	applyScheduleForce = true
+	resp = apply()
+	if len(resp.Applied) != 1 || resp.Applied[0].ID != testTask3ID || resp.Applied[0].To != "development" || len(resp.Woken) != 0 {
+		t.Fatalf("forced: %+v", resp)
+	}
+	b, _ := board.Load(bd)
+	task3 := b.FindByID(testTask3ID)
+	if task3.Status != board.StatusDevelopment || !task3.Scheduled.IsZero() {
+		t.Errorf("task3: status %s, scheduled %+v", task3.Status, task3.Scheduled)
+	}
+	if !b.FindByID(testTask4ID).Scheduled.Due(time.Now().AddDate(0, 0, 4)) {
+		t.Error("the future change should still be pending")
+	}
+
+	// Idempotent
+	if resp := apply(); len(resp.Applied)+len(resp.Woken)+len(resp.Problems) != 0 {
+		t.Errorf("second run: %+v", resp)
+	}
+
+	scheduleClear = true
+	captureOutput(t, func() { runSchedule(scheduleCmd, []string{testTask4ID}) })
+	b, _ = board.Load(bd)
+	if s := b.FindByID(testTask4ID).Scheduled; !s.IsZero() {
+		t.Errorf("--clear left %+v", s)
+	}
+}
@@ -0,0 +1,308 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/aagrigore/task-board/internal/board"
+	"github.com/aagrigore/task-board/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// SplitResponse is the JSON response for split
+type SplitResponse struct {
+	Original string      `json:"original"`
+	Parts    []SplitPart `json:"parts"`
+	Message  string      `json:"message"`
+}
+
+// SplitPart is one element resulting from a split; the first is the original
+type SplitPart struct {
+	ID        string   `json:"id"`
+	Name      string   `json:"name"`
+	Path      string   `json:"path"`
+	Checklist []string `json:"checklist"`
+}
+
+var splitCmd = &cobra.Command{
+	Use:   "split <ID>",
+	Short: "Split an element into several siblings",
+	Long: `Split an element into N parts. The element itself stays as part 1 and
+N-1 new siblings are created under the same parent, copying its
+description, scope and acceptance criteria.
+
+Checklist items stay on part 1 unless assigned elsewhere with --item K=P
+(item K goes to part P) or interactively with --interactive. With
+--deps all (default) every part inherits the element's blocked-by and
+blocks links; with --deps original only part 1 keeps them. Children stay
+with part 1.`,
+	Example: `  task-board split TASK-260101-aaaaaa --into 2 --item 3=2 --item 4=2
+  task-board split STORY-260101-cccccc --into 3 --name "Capture API" --name "Capture UI"
+  task-board split TASK-260101-aaaaaa --into 2 --interactive`,
+	Args: cobra.ExactArgs(1),
+	RunE: recorded(runSplit),
+}
+
+var (
+	splitInto        int
+	splitNames       []string
+	splitItems       []string
+	splitDeps        string
+	splitInteractive bool
+)
+
+func init() {
+	rootCmd.AddCommand(splitCmd)
+	splitCmd.Flags().IntVar(&splitInto, "into", 0, "Number of parts, including the original (required, >= 2)")
+	splitCmd.Flags().StringArrayVar(&splitNames, "name", nil, "Name of a new part (repeatable, in order; default \"<name> (part N)\")")
+	splitCmd.Flags().StringArrayVar(&splitItems, "item", nil, "Move checklist item K to part P, as K=P (repeatable)")
+	splitCmd.Flags().StringVar(&splitDeps, "deps", "all", "Dependency handling: all (every part inherits links) or original")
+	splitCmd.Flags().BoolVarP(&splitInteractive, "interactive", "i", false, "Ask which part each checklist item goes to")
+	splitCmd.MarkFlagRequired("into")
+}
+
+func runSplit(cmd *cobra.Command, args []string) error {
+	id := args[0]
+
+	b, err := board.Load(boardDir)
+	if err != nil {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.InternalError, fmt.Sprintf("loading board: %v", err), nil)
+			return nil
+		}
+		return fmt.Errorf("loading board: %w", err)
+	}
+
+	elem := b.FindByID(id)
+	if elem == nil {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.NotFound, fmt.Sprintf("element %s not found", id), map[string]interface{}{
+				"id": id,
+			})
+			return nil
+		}
+		return fmt.Errorf("element %s not found", id)
+	}
+
+	pd, err := board.ParseProgressFile(elem.ProgressPath())
+	if err != nil {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.InternalError, fmt.Sprintf("reading progress: %v", err), nil)
+			return nil
+		}
+		return fmt.Errorf("reading progress: %w", err)
+	}
+
+	assignment, err := splitAssignment(cmd, pd.Checklist)
+	if err != nil {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.ValidationError, err.Error(), map[string]interface{}{
+				"id": elem.ID(),
+			})
+			return nil
+		}
+		return err
+	}
+
+	parts, err := splitElement(b, elem, pd, assignment)
+	if err != nil {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.InternalError, err.Error(), nil)
+			return nil
+		}
+		return err
+	}
+
+	message := fmt.Sprintf("Split %s into %d parts", elem.ID(), len(parts))
+
+	if JSONEnabled() {
+		return output.PrintJSON(os.Stdout, SplitResponse{Original: elem.ID(), Parts: parts, Message: message})
+	}
+
+	fmt.Println(message)
+	for i, p := range parts {
+		fmt.Printf("  %d. %s %s (%d checklist item(s))\n", i+1, p.ID, p.Name, len(p.Checklist))
+	}
+	return nil
+}
+
+// splitAssignment validates the split flags and returns, for each checklist
+// item, the 1-based part it goes to.
+func splitAssignment(cmd *cobra.Command, checklist []board.ChecklistItem) ([]int, error) {
+	if splitInto < 2 {
+		return nil, fmt.Errorf("--into must be at least 2, got %d", splitInto)
+	}
+	if len(splitNames) > splitInto-1 {
+		return nil, fmt.Errorf("%d names given for %d new parts", len(splitNames), splitInto-1)
+	}
+	if splitDeps != "all" && splitDeps != "original" {
+		return nil, fmt.Errorf("invalid --deps %q (valid: all, original)", splitDeps)
+	}
+
+	assignment := make([]int, len(checklist))
+	for i := range assignment {
+		assignment[i] = 1
+	}
+
+	for _, spec := range splitItems {
+		k, p, ok := strings.Cut(spec, "=")
+		item, err1 := strconv.Atoi(strings.TrimSpace(k))
+		part, err2 := strconv.Atoi(strings.TrimSpace(p))
+		if !ok || err1 != nil || err2 != nil {
+			return nil, fmt.Errorf("invalid --item %q: expected K=P", spec)
+		}
+		if item < 1 || item > len(checklist) {
+			return nil, fmt.Errorf("checklist item %d out of range (1-%d)", item, len(checklist))
+		}
+		if part < 1 || part > splitInto {
+			return nil, fmt.Errorf("part %d out of range (1-%d)", part, splitInto)
+		}
+		assignment[item-1] = part
+	}
+
+	if splitInteractive && len(checklist) > 0 {
+		if err := promptAssignment(cmd.InOrStdin(), cmd.OutOrStdout(), checklist, assignment); err != nil {
+			return nil, err
+		}
+	}
+	return assignment, nil
+}
+
+// promptAssignment asks for the part of each checklist item. An empty answer
+// keeps the current assignment.
+func promptAssignment(in io.Reader, out io.Writer, checklist []board.ChecklistItem, assignment []int) error {
+	scanner := bufio.NewScanner(in)
+	for i, item := range checklist {
+		for {
+			fmt.Fprintf(out, "[%d] %s → part (1-%d) [%d]: ", i+1, item.Text, splitInto, assignment[i])
+			if !scanner.Scan() {
+				fmt.Fprintln(out)
+				return scanner.Err()
+			}
+			answer := strings.TrimSpace(scanner.Text())
+			if answer == "" {
+				break
+			}
+			part, err := strconv.Atoi(answer)
+			if err == nil && part >= 1 && part <= splitInto {
+				assignment[i] = part
+				break
+			}
+			fmt.Fprintf(out, "  enter a number from 1 to %d\n", splitInto)
+		}
+	}
+	return nil
+}
+
+// splitElement creates the new siblings, distributes the checklist and
+// copies dependency links. It returns every part, the original first.
+func splitElement(b *board.Board, elem *board.Element, pd *board.ProgressData, assignment []int) ([]SplitPart, error) {
+	rd, err := board.ParseReadmeFile(elem.ReadmePath())
+	if err != nil {
+		return nil, fmt.Errorf("reading readme: %w", err)
+	}
+	baseName := strings.TrimPrefix(rd.Title, elem.ID()+": ")
+
+	parts := []SplitPart{{
+		ID:        elem.ID(),
+		Name:      baseName,
+		Path:      computeRelativePath(b.Dir, elem.Path),
+		Checklist: []string{},
+	}}
+	partElems := []*board.Element{elem}
+
+	for n := 2; n <= splitInto; n++ {
+		name := fmt.Sprintf("%s (part %d)", baseName, n)
+		if n-2 < len(splitNames) {
+			name = splitNames[n-2]
+		}
+		status := b.Flow(elem.Type).Initial
+		id, path, err := writeNewElement(elem.Type, status, name, rd.Description, filepath.Dir(elem.Path))
+		if err != nil {
+			return nil, err
+		}
+		partRd := *rd
+		partRd.Title = fmt.Sprintf("%s: %s", id, name)
+		if err := board.WriteReadmeFile(filepath.Join(path, "README.md"), &partRd); err != nil {
+			return nil, fmt.Errorf("writing README.md: %w", err)
+		}
+		parts = append(parts, SplitPart{ID: id, Name: name, Path: computeRelativePath(b.Dir, path), Checklist: []string{}})
+		partElems = append(partElems, &board.Element{
+			Type:     elem.Type,
+			RawID:    id,
+			Path:     path,
+			ParentID: elem.ParentID,
+			Status:   status,
+		})
+	}
+
+	// Distribute the checklist
+	checklists := make([][]board.ChecklistItem, len(parts))
+	for i, item := range pd.Checklist {
+		p := assignment[i] - 1
+		checklists[p] = append(checklists[p], item)
+		parts[p].Checklist = append(parts[p].Checklist, item.Text)
+	}
+
+	var newIDs []string
+	for _, p := range parts[1:] {
+		newIDs = append(newIDs, p.ID)
+	}
+
+	for i := 1; i < len(partElems); i++ {
+		part := partElems[i]
+		partPd, err := board.ParseProgressFile(part.ProgressPath())
+		if err != nil {
+			return nil, fmt.Errorf("reading progress for %s: %w", part.ID(), err)
+		}
+		partPd.Checklist = checklists[i]
+		partPd.Notes = fmt.Sprintf("Split from %s", elem.ID())
+		if err := board.WriteProgressFile(part.ProgressPath(), partPd); err != nil {
+			return nil, fmt.Errorf("writing progress for %s: %w", part.ID(), err)
+		}
+
+		if splitDeps != "all" {
+			continue
+		}
+		for _, ref := range pd.BlockedBy {
+			if err := addProgressRef(part, ref, true); err != nil {
+				return nil, err
+			}
+			if blocker := b.FindByID(ref); blocker != nil {
+				if err := addProgressRef(blocker, part.ID(), false); err != nil {
+					return nil, err
+				}
+			}
+		}
+		for _, ref := range pd.Blocks {
+			if err := addProgressRef(part, ref, false); err != nil {
+				return nil, err
+			}
+			if blocked := b.FindByID(ref); blocked != nil {
+				if err := addProgressRef(blocked, part.ID(), true); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	pd.Checklist = checklists[0]
+	note := fmt.Sprintf("Split into %s", strings.Join(newIDs, ", "))
+	if pd.Notes != "" {
+		pd.Notes += "\n" + note
+	} else {
+		pd.Notes = note
+	}
+	if err := board.WriteProgressFile(elem.ProgressPath(), pd); err != nil {
+		return nil, fmt.Errorf("writing progress for %s: %w", elem.ID(), err)
+	}
+
+	// New backlog parts reopen a finished parent
+	reopenParentIfNeeded(b, partElems[1])
+	return parts, nil
+}
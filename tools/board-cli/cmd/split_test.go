@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/aagrigore/task-board/internal/board"
+)
+
+func resetSplitFlags() {
+	splitInto = 0
+	splitNames = nil
+	splitItems = nil
+	splitDeps = "all"
+	splitInteractive = false
+}
+
+func runSplitJSON(t *testing.T, id string) SplitResponse {
+	t.Helper()
+	jsonOutput = true
+	defer func() { jsonOutput = false }()
+	out := captureOutput(t, func() {
+		if err := runSplit(splitCmd, []string{id}); err != nil {
+			t.Fatalf("runSplit: %v", err)
+		}
+	})
+	var resp SplitResponse
+	if err := json.Unmarshal([]byte(out), &resp); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	return resp
+}
+
+func TestSplit(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	defer resetSplitFlags()
+
+	// TASK-1 has checklist [Step 1, Step 2] and blocks TASK-2
+	splitInto = 2
+	splitNames = []string{"Interface docs"}
+	splitItems = []string{"2=2"}
+	resp := runSplitJSON(t, testTask1ID)
+
+	if len(resp.Parts) != 2 || resp.Parts[0].ID != testTask1ID {
+		t.Fatalf("unexpected parts: %+v", resp.Parts)
+	}
+	newID := resp.Parts[1].ID
+	if resp.Parts[1].Name != "Interface docs" {
+		t.Errorf("new part name = %q", resp.Parts[1].Name)
+	}
+
+	b, _ := board.Load(bd)
+	orig := b.FindByID(testTask1ID)
+	part := b.FindByID(newID)
+	if part == nil {
+		t.Fatalf("new part %s not on board", newID)
+	}
+	if part.ParentID != testStory1ID {
+		t.Errorf("new part should be a sibling under %s, got parent %s", testStory1ID, part.ParentID)
+	}
+	if len(orig.Checklist) != 1 || orig.Checklist[0].Text != "Step 1" {
+		t.Errorf("original checklist = %+v", orig.Checklist)
+	}
+	if len(part.Checklist) != 1 || part.Checklist[0].Text != "Step 2" || !part.Checklist[0].Checked {
+		t.Errorf("new part checklist = %+v", part.Checklist)
+	}
+	if part.Description != orig.Description || part.AC != orig.AC {
+		t.Errorf("new part should copy description and AC, got %q / %q", part.Description, part.AC)
+	}
+
+	// Both parts block TASK-2
+	task2 := b.FindByID(testTask2ID)
+	if len(task2.BlockedBy) != 2 {
+		t.Errorf("%s should be blocked by both parts, got %v", testTask2ID, task2.BlockedBy)
+	}
+	if len(part.Blocks) != 1 || part.Blocks[0] != testTask2ID {
+		t.Errorf("new part should block %s, got %v", testTask2ID, part.Blocks)
+	}
+}
+
+func TestSplitDepsOriginal(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	defer resetSplitFlags()
+
+	splitInto = 3
+	splitDeps = "original"
+	resp := runSplitJSON(t, testTask1ID)
+	if len(resp.Parts) != 3 {
+		t.Fatalf("expected 3 parts, got %d", len(resp.Parts))
+	}
+	if resp.Parts[2].Name != "Interface (part 3)" {
+		t.Errorf("default name = %q", resp.Parts[2].Name)
+	}
+
+	b, _ := board.Load(bd)
+	if task2 := b.FindByID(testTask2ID); len(task2.BlockedBy) != 1 {
+		t.Errorf("links should stay on the original, got %v", task2.BlockedBy)
+	}
+}
+
+func TestSplitInteractive(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	defer resetSplitFlags()
+
+	splitInto = 2
+	splitInteractive = true
+	// Item 1: invalid answer, then part 2. Item 2: keep default.
+	splitCmd.SetIn(strings.NewReader("7\n2\n\n"))
+	var prompts bytes.Buffer
+	splitCmd.SetOut(&prompts)
+	defer func() {
+		splitCmd.SetIn(nil)
+		splitCmd.SetOut(nil)
+	}()
+
+	resp := runSplitJSON(t, testTask1ID)
+	if got := resp.Parts[1].Checklist; len(got) != 1 || got[0] != "Step 1" {
+		t.Errorf("new part checklist = %v", got)
+	}
+	if got := resp.Parts[0].Checklist; len(got) != 1 || got[0] != "Step 2" {
+		t.Errorf("original checklist = %v", got)
+	}
+	if !strings.Contains(prompts.String(), "enter a number from 1 to 2") {
+		t.Errorf("invalid answer should be re-prompted, got %q", prompts.String())
+	}
+}
+
+func TestSplitValidation(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	defer resetSplitFlags()
+
+	cases := []func(){
+		func() { splitInto = 1 },
+		func() { splitInto = 2; splitItems = []string{"5=2"} },
+		func() { splitInto = 2; splitItems = []string{"1=3"} },
+		func() { splitInto = 2; splitItems = []string{"one=2"} },
+		func() { splitInto = 2; splitNames = []string{"a", "b"} },
+		func() { splitInto = 2; splitDeps = "some" },
+	}
+	for i, setup := range cases {
+		resetSplitFlags()
+		setup()
+		if err := runSplit(splitCmd, []string{testTask1ID}); err == nil {
+			t.Errorf("case %d should fail", i)
+		}
+	}
+
+	b, _ := board.Load(bd)
+	if n := len(b.Children(b.FindByID(testStory1ID))); n != 4 {
+		t.Errorf("failed splits should not create elements, story has %d children", n)
+	}
+}
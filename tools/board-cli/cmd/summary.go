@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/aagrigore/task-board/internal/board"
 	"github.com/aagrigore/task-board/internal/output"
+	"github.com/aagrigore/task-board/internal/wip"
 	"github.com/spf13/cobra"
 )
 
@@ -20,6 +22,20 @@ type SummaryData struct {
 	ByType  map[string]TypeStats    `json:"byType"`
 	Active  []SummaryActiveElement  `json:"active"`
 	Blocked []SummaryBlockedElement `json:"blocked"`
+	// WIP is the load of every WIP limit in the workflow
+	WIP []wip.Usage `json:"wip"`
+	// Time lists the epics and stories with time logged or estimated
+	Time []SummaryTimeElement `json:"time"`
+	// External lists the open external blockers
+	External []BlockerJSON `json:"external"`
+}
+
+// SummaryTimeElement is the rolled-up time of an epic or story
+type SummaryTimeElement struct {
+	ID   string   `json:"id"`
+	Type string   `json:"type"`
+	Name string   `json:"name"`
+	Time TimeJSON `json:"time"`
 }
 
 // TypeStats contains counts by status group for a type
@@ -59,7 +75,7 @@ func init() {
 }
 
 func runSummary(cmd *cobra.Command, args []string) error {
-	b, err := board.Load(boardDir)
+	b, err := loadViewBoard()
 	if err != nil {
 		if JSONEnabled() {
 			output.PrintError(os.Stderr, output.InternalError, fmt.Sprintf("loading board: %v", err), nil)
@@ -68,7 +84,7 @@ func runSummary(cmd *cobra.Command, args []string) error {
 	}
 
 	// Count by type and status
-	// Group: TODO (backlog, to-dev), ACTIVE (analysis, development, to-review, reviewing), DONE, CLOSED, BLOCKED
+	// Group by workflow category: TODO, ACTIVE, DONE, CLOSED, BLOCKED
 	counts := map[board.ElementType]*TypeStats{
 		board.EpicType:  {},
 		board.StoryType: {},
@@ -79,40 +95,67 @@ func runSummary(cmd *cobra.Command, args []string) error {
 	for _, e := range b.Elements {
 		s := counts[e.Type]
 		s.Total++
-		switch e.Status {
-		case board.StatusBacklog, board.StatusToDev:
+		switch b.Workflow.Category(e) {
+		case board.CategoryTodo:
 			s.Todo++
-		case board.StatusAnalysis, board.StatusDevelopment, board.StatusToReview, board.StatusReviewing:
+		case board.CategoryActive:
 			s.Active++
-		case board.StatusDone:
+		case board.CategoryDone:
 			s.Done++
-		case board.StatusClosed:
+		case board.CategoryClosed:
 			s.Closed++
-		case board.StatusBlocked:
+		case board.CategoryBlocked:
 			s.Blocked++
 		}
 	}
 
-	// Active (analysis, development, to-review, reviewing)
+	// Active (statuses in the active category)
 	var active []*board.Element
 	for _, e := range b.Elements {
-		switch e.Status {
-		case board.StatusAnalysis, board.StatusDevelopment, board.StatusToReview, board.StatusReviewing:
+		if b.Workflow.Category(e) == board.CategoryActive {
 			active = append(active, e)
 		}
 	}
 
-	// Blocked (explicit status or has active blockers)
+	// Blocked (blocked status or has active blockers)
 	var blocked []*board.Element
 	for _, e := range b.Elements {
-		if e.Status == board.StatusBlocked || b.IsBlocked(e) {
+		if b.Workflow.Category(e) == board.CategoryBlocked || b.IsBlocked(e) {
 			blocked = append(blocked, e)
 		}
 	}
 
+	usages := wip.Usages(b)
+
+	// Time of epics and stories, rolled up from their tasks and bugs
+	now := time.Now().UTC()
+	var timed []*board.Element
+	totals := map[*board.Element]board.TimeTotals{}
+	for _, epic := range b.FindByType(board.EpicType) {
+		for _, e := range append([]*board.Element{epic}, b.Children(epic)...) {
+			if t := b.Totals(e, now); hasTime(t) {
+				timed = append(timed, e)
+				totals[e] = t
+			}
+		}
+	}
+
 	// JSON output
 	if JSONEnabled() {
-		return printSummaryJSON(b, counts, active, blocked)
+		timeList := make([]SummaryTimeElement, 0, len(timed))
+		for _, e := range timed {
+			timeList = append(timeList, SummaryTimeElement{
+				ID:   e.ID(),
+				Type: string(e.Type),
+				Name: e.Name,
+				Time: timeJSON(totals[e]),
+			})
+		}
+		external := make([]BlockerJSON, 0)
+		for _, bl := range board.OpenBlockers(b.Elements) {
+			external = append(external, blockerJSON(bl, now))
+		}
+		return printSummaryJSON(b, counts, active, blocked, usages, timeList, external)
 	}
 
 	// Text output
@@ -150,11 +193,41 @@ func runSummary(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if len(usages) > 0 {
+		fmt.Println()
+		fmt.Println(output.Bold + "WIP Limits" + output.Reset)
+		for _, u := range usages {
+			load := fmt.Sprintf("%d/%d", u.Count, u.Limit)
+			if u.Over() {
+				load = output.Red + load + " over" + output.Reset
+			}
+			fmt.Printf("  %-24s %s\n", wipLabel(u), load)
+		}
+	}
+
+	if len(timed) > 0 {
+		fmt.Println()
+		fmt.Println(output.Bold + "Time" + output.Reset)
+		for _, e := range timed {
+			indent := ""
+			if e.Type == board.StoryType {
+				indent = "  "
+			}
+			fmt.Printf("  %s%-*s %s\n", indent, 28-len(indent), e.ID(), describeTime(totals[e]))
+		}
+	}
+
 	if len(blocked) > 0 {
 		fmt.Println()
 		fmt.Println(output.Bold + "Blocked" + output.Reset)
 		for _, e := range blocked {
-			if e.Status == board.StatusBlocked {
+			if held := e.HeldBy(); b.Workflow.Category(e) == board.CategoryBlocked && len(held) > 0 {
+				var ids []string
+				for _, bl := range held {
+					ids = append(ids, bl.ID)
+				}
+				fmt.Printf("  %s %s blocked by: %s\n", b.Ancestry(e), output.Gray+"—"+output.Reset, strings.Join(ids, ", "))
+			} else if b.Workflow.Category(e) == board.CategoryBlocked {
 				fmt.Printf("  %s %s blocked (external)\n", b.Ancestry(e), output.Gray+"—"+output.Reset)
 			} else {
 				activeBlockers := b.ActiveBlockers(e)
@@ -167,11 +240,24 @@ func runSummary(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if external := board.OpenBlockers(b.Elements); len(external) > 0 {
+		fmt.Println()
+		fmt.Println(output.Bold + "External Blockers" + output.Reset)
+		for _, bl := range external {
+			fmt.Printf("  %-8s %s%s\n", bl.ID, bl.Reason, describeBlockerOwner(bl, now))
+			var ids []string
+			for _, held := range bl.Elements {
+				ids = append(ids, held.ID)
+			}
+			fmt.Printf("  %-8s %s holds %s\n", "", output.Gray+"—"+output.Reset, strings.Join(ids, ", "))
+		}
+	}
+
 	return nil
 }
 
 // printSummaryJSON outputs summary data as JSON
-func printSummaryJSON(b *board.Board, counts map[board.ElementType]*TypeStats, active []*board.Element, blocked []*board.Element) error {
+func printSummaryJSON(b *board.Board, counts map[board.ElementType]*TypeStats, active []*board.Element, blocked []*board.Element, usages []wip.Usage, timeList []SummaryTimeElement, external []BlockerJSON) error {
 	// Build byType map
 	byType := map[string]TypeStats{
 		"epic":  {Total: 0, Todo: 0, Active: 0, Done: 0, Closed: 0, Blocked: 0},
@@ -206,8 +292,14 @@ func printSummaryJSON(b *board.Board, counts map[board.ElementType]*TypeStats, a
 	blockedList := make([]SummaryBlockedElement, 0, len(blocked))
 	for _, e := range blocked {
 		blockerIDs := []string{}
-		if e.Status == board.StatusBlocked {
+		if b.Workflow.Category(e) == board.CategoryBlocked {
 			blockerIDs = []string{"external"}
+			if held := e.HeldBy(); len(held) > 0 {
+				blockerIDs = blockerIDs[:0]
+				for _, bl := range held {
+					blockerIDs = append(blockerIDs, bl.ID)
+				}
+			}
 		} else {
 			activeBlockers := b.ActiveBlockers(e)
 			for _, blocker := range activeBlockers {
@@ -224,9 +316,12 @@ func printSummaryJSON(b *board.Board, counts map[board.ElementType]*TypeStats, a
 
 	response := SummaryResponse{
 		Summary: SummaryData{
-			ByType:  byType,
-			Active:  activeList,
-			Blocked: blockedList,
+			ByType:   byType,
+			Active:   activeList,
+			Blocked:  blockedList,
+			WIP:      usages,
+			Time:     timeList,
+			External: external,
 		},
 	}
 
@@ -0,0 +1,376 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/aagrigore/task-board/internal/board"
+	"github.com/aagrigore/task-board/internal/manifest"
+	"github.com/aagrigore/task-board/internal/output"
+	"github.com/aagrigore/task-board/internal/plan"
+	"github.com/aagrigore/task-board/internal/txn"
+	"github.com/spf13/cobra"
+)
+
+// SyncResponse is the JSON response for sync
+type SyncResponse struct {
+	Manifest string       `json:"manifest"`
+	Plan     bool         `json:"plan"`
+	Changes  []SyncChange `json:"changes"`
+	Message  string       `json:"message"`
+}
+
+// SyncChange is one planned or applied change
+type SyncChange struct {
+	Action  string `json:"action"`
+	ID      string `json:"id,omitempty"`
+	Path    string `json:"path"`
+	Type    string `json:"type,omitempty"`
+	Name    string `json:"name,omitempty"`
+	Parent  string `json:"parent,omitempty"`
+	Field   string `json:"field,omitempty"`
+	Old     string `json:"old,omitempty"`
+	New     string `json:"new,omitempty"`
+	Text    string `json:"text,omitempty"`
+	Blocker string `json:"blocker,omitempty"`
+}
+
+var syncCmd = &cobra.Command{
+	Use:   "sync <manifest.yaml>",
+	Short: "Reconcile the board with an epic manifest",
+	Long: `Reconcile the board with a YAML manifest describing one epic.
+
+Elements in the manifest are matched by id, or by name under their parent,
+and created when missing. README fields given in the manifest are updated,
+missing checklist items are added, and each element's blocked-by list is
+made to match the manifest (links implied by escalation are kept).
+Status, assignment, notes and checked items are never touched, and board
+elements absent from the manifest are left alone.
+
+Use --plan to preview the changes; without a board directory yet it plans
+to create the whole manifest. Applying runs under the board lock and
+rolls back entirely if any change fails.
+
+Manifest:
+  name: Audio pipeline          # the epic
+  id: EPIC-260101-aaaaaa        # optional: pin to an existing element
+  description: ...              # description, scope, ac: optional
+  stories:
+    - name: Capture
+      key: capture              # name for blocked-by references
+      tasks:
+        - name: Recorder interface
+          key: iface
+          checklist: [Define API, Write docs]
+        - name: Recorder implementation
+          blocked-by: [iface]   # manifest keys or board IDs
+        - name: Crash on stop
+          type: bug`,
+	Example: `  task-board sync audio.yaml --plan
+  task-board sync audio.yaml --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: recorded(runSync),
+}
+
+var syncPlan bool
+
+func init() {
+	rootCmd.AddCommand(syncCmd)
+	syncCmd.Flags().BoolVar(&syncPlan, "plan", false, "Show the changes without applying them")
+}
+
+func runSync(cmd *cobra.Command, args []string) error {
+	root, err := manifest.Load(args[0])
+	if err != nil {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.ValidationError, err.Error(), nil)
+			return nil
+		}
+		return err
+	}
+
+	if !syncPlan {
+		if err := board.EnsureBoardDir(boardDir); err != nil {
+			if JSONEnabled() {
+				output.PrintError(os.Stderr, output.InternalError, err.Error(), nil)
+				return nil
+			}
+			return err
+		}
+	}
+	var b *board.Board
+	if _, statErr := os.Stat(boardDir); syncPlan && os.IsNotExist(statErr) {
+		// No board yet: the plan creates everything in the manifest
+		b = &board.Board{Dir: boardDir, Counters: &board.Counters{}, Workflow: board.DefaultWorkflow()}
+	} else if b, err = board.Load(boardDir); err != nil {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.InternalError, fmt.Sprintf("loading board: %v", err), nil)
+			return nil
+		}
+		return fmt.Errorf("loading board: %w", err)
+	}
+
+	p, err := manifest.Diff(b, root)
+	if err != nil {
+		var invalid *manifest.InvalidError
+		if JSONEnabled() && errors.As(err, &invalid) {
+			output.PrintError(os.Stderr, output.ValidationError,
+				fmt.Sprintf("%d problem(s) in manifest", len(invalid.Issues)),
+				map[string]interface{}{
+					"issues": invalid.Issues,
+				})
+			return nil
+		}
+		return err
+	}
+
+	if !syncPlan && len(p.Changes) > 0 {
+		tx, err := txn.Journal(boardDir) // recorded holds the lock
+		if err != nil {
+			if JSONEnabled() {
+				output.PrintError(os.Stderr, output.InternalError, err.Error(), nil)
+				return nil
+			}
+			return err
+		}
+		if err := applySyncPlan(b, p); err != nil {
+			rollbackErr := tx.Rollback()
+			msg := fmt.Sprintf("sync failed: %v; all changes rolled back", err)
+			if rollbackErr != nil {
+				msg = fmt.Sprintf("sync failed: %v; rollback failed: %v", err, rollbackErr)
+			}
+			if JSONEnabled() {
+				code := output.InternalError
+				details := map[string]interface{}{"rolledBack": rollbackErr == nil}
+				var cycleErr *errLinkCycle
+				if errors.As(err, &cycleErr) {
+					code = output.CycleDetected
+					details["cycle"] = cycleErr.cycle
+				}
+				output.PrintError(os.Stderr, code, msg, details)
+				return nil
+			}
+			return fmt.Errorf("%s", msg)
+		}
+		tx.Commit()
+	}
+
+	response := SyncResponse{Manifest: args[0], Plan: syncPlan, Changes: []SyncChange{}}
+	for _, c := range p.Changes {
+		response.Changes = append(response.Changes, syncChangeJSON(c))
+	}
+	switch {
+	case len(p.Changes) == 0:
+		response.Message = "Board matches the manifest"
+	case syncPlan:
+		response.Message = fmt.Sprintf("%d change(s) planned", len(p.Changes))
+	default:
+		response.Message = fmt.Sprintf("Applied %d change(s)", len(p.Changes))
+	}
+
+	if JSONEnabled() {
+		return output.PrintJSON(os.Stdout, response)
+	}
+	for _, c := range p.Changes {
+		fmt.Println(formatSyncChange(c))
+	}
+	if syncPlan && len(p.Changes) > 0 {
+		fmt.Printf("%s; run without --plan to apply\n", response.Message)
+	} else {
+		fmt.Println(response.Message)
+	}
+	return nil
+}
+
+// applySyncPlan performs a plan from manifest.Diff: content changes first,
+// then link removals (which de-escalate on disk), then — on a reloaded
+// board — link additions with their escalations.
+func applySyncPlan(b *board.Board, p *manifest.Plan) error {
+	for _, c := range p.Changes {
+		var err error
+		switch c.Kind {
+		case manifest.Create:
+			err = syncCreate(b, c.Entry)
+		case manifest.Update:
+			err = syncUpdate(b.FindByID(c.Entry.ID), c.Field, c.New)
+		case manifest.AddItem:
+			err = syncAddItems(b.FindByID(c.Entry.ID), []string{c.Text})
+		case manifest.Unlink:
+			elem, blocker := b.FindByID(c.Entry.ID), b.FindByID(c.BlockerID)
+			if err = replaceRef(elem, c.BlockerID, "", true); err != nil {
+				break
+			}
+			if blocker == nil {
+				break // dangling reference
+			}
+			if err = replaceRef(blocker, elem.ID(), "", false); err != nil {
+				break
+			}
+			err = deescalateDependency(b, elem, blocker)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	fresh, err := board.Load(b.Dir)
+	if err != nil {
+		return fmt.Errorf("reloading board: %w", err)
+	}
+	for _, c := range p.Changes {
+		if c.Kind != manifest.Link {
+			continue
+		}
+		blockerID := c.BlockerID
+		if c.BlockerEntry != nil {
+			blockerID = c.BlockerEntry.ID
+		}
+		elem, blocker := fresh.FindByID(c.Entry.ID), fresh.FindByID(blockerID)
+		if elem == nil || blocker == nil {
+			return fmt.Errorf("linking %s to %s: element not found", c.Entry.Ref(), blockerID)
+		}
+		edges := plan.LinkEdges(fresh, elem, blocker)
+		if cycle := plan.FindLinkCycle(fresh, edges); cycle != nil {
+			return &errLinkCycle{cycle: cycle}
+		}
+		if err := writeLinkEdges(fresh, edges); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// syncCreate creates a new manifest element with its README fields and checklist.
+func syncCreate(b *board.Board, e *manifest.Entry) error {
+	n := e.Node
+	parentDir, parentID := b.Dir, ""
+	if e.Parent != nil {
+		parent := b.FindByID(e.Parent.ID)
+		if parent == nil {
+			return fmt.Errorf("creating %s: parent %s not found", e.Ref(), e.Parent.Ref())
+		}
+		parentDir, parentID = parent.Path, parent.ID()
+	}
+	description := ""
+	if n.Description != nil {
+		description = *n.Description
+	}
+	status := b.Flow(e.Type).Initial
+	id, path, err := writeNewElement(e.Type, status, n.Name, description, parentDir)
+	if err != nil {
+		return err
+	}
+	elem := &board.Element{
+		Type:        e.Type,
+		RawID:       id,
+		Name:        board.SanitizeName(n.Name),
+		Path:        path,
+		ParentID:    parentID,
+		Status:      status,
+		Title:       fmt.Sprintf("%s: %s", id, n.Name),
+		Description: description,
+	}
+	b.Elements = append(b.Elements, elem)
+	e.ID = id
+
+	if n.Scope != nil {
+		if err := syncUpdate(elem, "scope", *n.Scope); err != nil {
+			return err
+		}
+	}
+	if n.AC != nil {
+		if err := syncUpdate(elem, "ac", *n.AC); err != nil {
+			return err
+		}
+	}
+	if len(n.Checklist) > 0 {
+		return syncAddItems(elem, n.Checklist)
+	}
+	return nil
+}
+
+// syncUpdate sets one README field of an element.
+func syncUpdate(e *board.Element, field, value string) error {
+	rd, err := board.ParseReadmeFile(e.ReadmePath())
+	if err != nil {
+		return fmt.Errorf("reading README.md of %s: %w", e.ID(), err)
+	}
+	switch field {
+	case "title":
+		rd.Title = fmt.Sprintf("%s: %s", e.ID(), value)
+	case "description":
+		rd.Description = value
+	case "scope":
+		rd.Scope = value
+	case "ac":
+		rd.AC = value
+	}
+	if err := board.WriteReadmeFile(e.ReadmePath(), rd); err != nil {
+		return fmt.Errorf("writing README.md of %s: %w", e.ID(), err)
+	}
+	return nil
+}
+
+// syncAddItems appends unchecked checklist items to an element.
+func syncAddItems(e *board.Element, items []string) error {
+	pd, err := board.ParseProgressFile(e.ProgressPath())
+	if err != nil {
+		return fmt.Errorf("reading progress for %s: %w", e.ID(), err)
+	}
+	for _, item := range items {
+		pd.Checklist = append(pd.Checklist, board.ChecklistItem{Text: item})
+	}
+	if err := board.WriteProgressFile(e.ProgressPath(), pd); err != nil {
+		return fmt.Errorf("writing progress for %s: %w", e.ID(), err)
+	}
+	return nil
+}
+
+func syncChangeJSON(c manifest.Change) SyncChange {
+	sc := SyncChange{Action: string(c.Kind), ID: c.Entry.ID, Path: c.Entry.Path}
+	switch c.Kind {
+	case manifest.Create:
+		sc.Type = string(c.Entry.Type)
+		sc.Name = c.Entry.Node.Name
+		if c.Entry.Parent != nil {
+			sc.Parent = c.Entry.Parent.Ref()
+		}
+	case manifest.Update:
+		sc.Field, sc.Old, sc.New = c.Field, c.Old, c.New
+	case manifest.AddItem:
+		sc.Text = c.Text
+	case manifest.Link, manifest.Unlink:
+		sc.Blocker = c.Blocker()
+	}
+	return sc
+}
+
+// formatSyncChange renders a change as one diff-style line.
+func formatSyncChange(c manifest.Change) string {
+	switch c.Kind {
+	case manifest.Create:
+		line := fmt.Sprintf("+ create %s %q", c.Entry.Type, c.Entry.Node.Name)
+		if c.Entry.ID != "" {
+			line = fmt.Sprintf("+ create %s: %s", c.Entry.ID, c.Entry.Node.Name)
+		}
+		if c.Entry.Parent != nil {
+			line += " under " + c.Entry.Parent.Ref()
+		}
+		if n := len(c.Entry.Node.Checklist); n > 0 {
+			line += fmt.Sprintf(" (%d checklist items)", n)
+		}
+		return line
+	case manifest.Update:
+		if c.Field == "title" {
+			return fmt.Sprintf("~ %s title: %q → %q", c.Entry.ID, c.Old, c.New)
+		}
+		return fmt.Sprintf("~ %s %s", c.Entry.ID, c.Field)
+	case manifest.AddItem:
+		return fmt.Sprintf("+ %s checklist: %s", c.Entry.ID, c.Text)
+	case manifest.Unlink:
+		return fmt.Sprintf("- %s blocked by %s", c.Entry.Ref(), c.Blocker())
+	default:
+		return fmt.Sprintf("+ %s blocked by %s", c.Entry.Ref(), c.Blocker())
+	}
+}
@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aagrigore/task-board/internal/board"
+)
+
+func runSyncJSON(t *testing.T, path string) SyncResponse {
+	t.Helper()
+	jsonOutput = true
+	defer func() { jsonOutput = false }()
+	out := captureOutput(t, func() {
+		if err := runSync(syncCmd, []string{path}); err != nil {
+			t.Fatalf("runSync: %v", err)
+		}
+	})
+	var resp SyncResponse
+	if err := json.Unmarshal([]byte(out), &resp); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	return resp
+}
+
+const testManifest = `
+name: Recording
+stories:
+  - name: Audio Capture
+    tasks:
+      - name: Interface
+        key: iface
+        scope: Public API only
+        checklist: [Step 1, Step 3]
+      - name: Buffering
+        key: buf
+        blocked-by: [iface]
+        checklist: [Ring buffer]
+  - name: Playback
+    blocked-by: [` + testStory3ID + `]
+    tasks:
+      - name: Player
+        blocked-by: [buf]
+`
+
+func TestSyncPlanThenApply(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	path := filepath.Join(t.TempDir(), "manifest.yaml")
+	os.WriteFile(path, []byte(testManifest), 0644)
+
+	// TASK-1 "Interface" blocks TASK-2, which the manifest does not list
+	// and therefore leaves alone
+	syncPlan = true
+	plan := runSyncJSON(t, path)
+	syncPlan = false
+	if !plan.Plan || len(plan.Changes) == 0 {
+		t.Fatalf("expected a plan, got %+v", plan)
+	}
+	before, _ := board.Load(bd)
+	if len(before.Elements) != 10 {
+		t.Fatalf("--plan should not write, board has %d elements", len(before.Elements))
+	}
+
+	resp := runSyncJSON(t, path)
+	if resp.Plan || len(resp.Changes) != len(plan.Changes) {
+		t.Fatalf("applied changes differ from plan: %+v", resp.Changes)
+	}
+
+	b, _ := board.Load(bd)
+	task1 := b.FindByID(testTask1ID)
+	if task1.Scope != "Public API only" {
+		t.Errorf("scope = %q", task1.Scope)
+	}
+	if len(task1.Checklist) != 3 || task1.Checklist[2].Text != "Step 3" || !task1.Checklist[1].Checked {
+		t.Errorf("checklist should gain Step 3 and keep checked items, got %+v", task1.Checklist)
+	}
+	if task1.Status != before.FindByID(testTask1ID).Status {
+		t.Errorf("status should be untouched")
+	}
+	if len(task1.Blocks) != 2 {
+		t.Errorf("TASK-1 should block TASK-2 and the new Buffering task, got %v", task1.Blocks)
+	}
+	if task2 := b.FindByID(testTask2ID); len(task2.BlockedBy) != 1 {
+		t.Errorf("TASK-2 is not in the manifest and should keep its link, got %v", task2.BlockedBy)
+	}
+
+	// New story under EPIC-1 with a task; Player is blocked across stories
+	var playback, player *board.Element
+	for _, e := range b.Elements {
+		switch e.Title {
+		case e.ID() + ": Playback":
+			playback = e
+		case e.ID() + ": Player":
+			player = e
+		}
+	}
+	if playback == nil || player == nil {
+		t.Fatal("Playback story and Player task should be created")
+	}
+	if playback.ParentID != testEpic1ID || player.ParentID != playback.ID() {
+		t.Errorf("wrong parents: %s, %s", playback.ParentID, player.ParentID)
+	}
+	if !containsRef(playback.BlockedBy, testStory3ID) || !containsRef(playback.BlockedBy, testStory1ID) {
+		t.Errorf("Playback should be blocked by STORY-3 and (escalated) STORY-1, got %v", playback.BlockedBy)
+	}
+
+	// A second run is a no-op
+	again := runSyncJSON(t, path)
+	if len(again.Changes) != 0 {
+		t.Errorf("second sync should find nothing to do, got %+v", again.Changes)
+	}
+}
+
+func TestSyncPlanWithoutBoard(t *testing.T) {
+	bd := filepath.Join(t.TempDir(), ".task-board")
+	boardDir = bd
+	path := filepath.Join(t.TempDir(), "manifest.yaml")
+	os.WriteFile(path, []byte(`
+name: Recording
+stories:
+  - name: Audio Capture
+    tasks:
+      - name: Interface
+        key: iface
+      - name: Buffering
+        blocked-by: [iface]
+`), 0644)
+
+	syncPlan = true
+	defer func() { syncPlan = false }()
+	plan := runSyncJSON(t, path)
+	creates := 0
+	for _, c := range plan.Changes {
+		if c.Action == "create" {
+			creates++
+		}
+	}
+	if !plan.Plan || creates != 4 {
+		t.Errorf("an empty board should plan to create all 4 elements, got %+v", plan.Changes)
+	}
+	if _, err := os.Stat(bd); !os.IsNotExist(err) {
+		t.Error("--plan should not create the board directory")
+	}
+}
+
+func TestSyncRollsBackOnCycle(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	path := filepath.Join(t.TempDir(), "manifest.yaml")
+	// TASK-1 already blocks TASK-2
+	os.WriteFile(path, []byte(`
+name: Recording
+stories:
+  - name: Audio Capture
+    tasks:
+      - name: Interface
+        blocked-by: [`+testTask2ID+`]
+        checklist: [Extra]
+`), 0644)
+
+	if err := runSync(syncCmd, []string{path}); err == nil {
+		t.Fatal("sync closing a cycle should fail")
+	}
+	b, _ := board.Load(bd)
+	if n := len(b.FindByID(testTask1ID).Checklist); n != 2 {
+		t.Errorf("checklist change should be rolled back, got %d items", n)
+	}
+}
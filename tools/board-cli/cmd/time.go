@@ -0,0 +1,275 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aagrigore/task-board/internal/board"
+	"github.com/aagrigore/task-board/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// TimeResponse is the JSON response for the time commands
+type TimeResponse struct {
+	ID string `json:"id"`
+	// Entry is the entry started, stopped or logged; nil for estimate
+	Entry   *TimeEntryJSON `json:"entry"`
+	Time    TimeJSON       `json:"time"`
+	Message string         `json:"message"`
+}
+
+// TimeEntryJSON is one time log entry in JSON output
+type TimeEntryJSON struct {
+	Start    string `json:"start"`
+	Minutes  int    `json:"minutes"`
+	Duration string `json:"duration"`
+	Running  bool   `json:"running"`
+	Note     string `json:"note"`
+}
+
+// TimeJSON is the time spent on an element and everything below it,
+// against its estimate
+type TimeJSON struct {
+	SpentMinutes    int    `json:"spentMinutes"`
+	Spent           string `json:"spent"`
+	EstimateMinutes int    `json:"estimateMinutes"`
+	Estimate        string `json:"estimate"`
+	// Accuracy is spent as a percentage of the estimate, nil without one
+	Accuracy *int `json:"accuracy"`
+}
+
+var timeCmd = &cobra.Command{
+	Use:   "time",
+	Short: "Track time spent on elements",
+	Long: `Record effort in the "## Time Log" section of progress.md: timers started
+and stopped, or work logged after the fact. show, summary and tree --json
+roll the time up to stories and epics and compare it with the estimate.
+
+An element's estimate is its own, or the sum of its children's when it has
+none. Durations are written like 1h30m, 45m or 2h.
+
+With time-tracking in workflow.yaml, progress status starts the timer when
+an element enters one of the listed statuses and stops it when it leaves:
+
+  time-tracking:
+    auto: [development]`,
+}
+
+var timeStartCmd = &cobra.Command{
+	Use:   "start <ID>",
+	Short: "Start a timer on an element",
+	Args:  cobra.ExactArgs(1),
+	RunE:  recorded(runTimeStart),
+}
+
+var timeStopCmd = &cobra.Command{
+	Use:   "stop [ID]",
+	Short: "Stop a running timer (the only one running without an ID)",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  recorded(runTimeStop),
+}
+
+var timeLogCmd = &cobra.Command{
+	Use:   "log <ID> <duration>",
+	Short: "Log time spent on an element, ending now",
+	Args:  cobra.ExactArgs(2),
+	RunE:  recorded(runTimeLog),
+}
+
+var timeEstimateCmd = &cobra.Command{
+	Use:   "estimate <ID> <duration|none>",
+	Short: "Set or clear an element's estimate",
+	Args:  cobra.ExactArgs(2),
+	RunE:  recorded(runTimeEstimate),
+}
+
+var timeNote string
+
+func init() {
+	rootCmd.AddCommand(timeCmd)
+	timeCmd.AddCommand(timeStartCmd)
+	timeCmd.AddCommand(timeStopCmd)
+	timeCmd.AddCommand(timeLogCmd)
+	timeCmd.AddCommand(timeEstimateCmd)
+	timeStopCmd.Flags().StringVar(&timeNote, "note", "", "What the time was spent on")
+	timeLogCmd.Flags().StringVar(&timeNote, "note", "", "What the time was spent on")
+}
+
+func runTimeStart(cmd *cobra.Command, args []string) error {
+	return updateTime(args[0], func(pd *board.ProgressData, now time.Time) (*board.TimeEntry, string, error) {
+		if err := pd.StartTimer(now); err != nil {
+			return nil, "", err
+		}
+		return pd.Timer(), "timer started", nil
+	})
+}
+
+func runTimeStop(cmd *cobra.Command, args []string) error {
+	id := ""
+	if len(args) == 1 {
+		id = args[0]
+	} else {
+		b, err := board.Load(boardDir)
+		if err != nil {
+			return timeFail(output.InternalError, fmt.Errorf("loading board: %w", err))
+		}
+		var running []string
+		for _, e := range b.Elements {
+			if e.Running() {
+				running = append(running, e.ID())
+			}
+		}
+		switch len(running) {
+		case 0:
+			return timeFail(output.ValidationError, fmt.Errorf("no timer running"))
+		case 1:
+			id = running[0]
+		default:
+			return timeFail(output.ValidationError, fmt.Errorf("%d timers running (%s); name the element to stop", len(running), strings.Join(running, ", ")))
+		}
+	}
+	return updateTime(id, func(pd *board.ProgressData, now time.Time) (*board.TimeEntry, string, error) {
+		entry, err := pd.StopTimer(now, timeNote)
+		if err != nil {
+			return nil, "", err
+		}
+		if entry.Duration <= 0 {
+			return nil, "timer stopped within a minute, nothing logged", nil
+		}
+		return &entry, "timer stopped after " + board.FormatDuration(entry.Duration), nil
+	})
+}
+
+func runTimeLog(cmd *cobra.Command, args []string) error {
+	d, err := board.ParseDuration(args[1])
+	if err == nil && d < time.Minute {
+		err = fmt.Errorf("invalid duration %q: log at least a minute", args[1])
+	}
+	if err != nil {
+		return timeFail(output.ValidationError, err)
+	}
+	return updateTime(args[0], func(pd *board.ProgressData, now time.Time) (*board.TimeEntry, string, error) {
+		entry := pd.LogTime(now, d, timeNote)
+		return &entry, "logged " + board.FormatDuration(d), nil
+	})
+}
+
+func runTimeEstimate(cmd *cobra.Command, args []string) error {
+	var d time.Duration
+	if value := strings.ToLower(args[1]); value != "none" && value != "0" {
+		var err error
+		if d, err = board.ParseDuration(value); err != nil {
+			return timeFail(output.ValidationError, err)
+		}
+	}
+	return updateTime(args[0], func(pd *board.ProgressData, now time.Time) (*board.TimeEntry, string, error) {
+		pd.Estimate = d
+		if d == 0 {
+			return nil, "estimate cleared", nil
+		}
+		return nil, "estimate " + board.FormatDuration(d), nil
+	})
+}
+
+func timeFail(code output.ErrorCode, err error) error {
+	if JSONEnabled() {
+		output.PrintError(os.Stderr, code, err.Error(), nil)
+		return nil
+	}
+	return err
+}
+
+// updateTime applies change to an element's progress.md and reports the
+// element's time afterwards.
+func updateTime(id string, change func(pd *board.ProgressData, now time.Time) (*board.TimeEntry, string, error)) error {
+	b, err := board.Load(boardDir)
+	if err != nil {
+		return timeFail(output.InternalError, fmt.Errorf("loading board: %w", err))
+	}
+	elem := b.FindByID(id)
+	if elem == nil {
+		return timeFail(output.NotFound, fmt.Errorf("element %s not found", id))
+	}
+	pd, err := board.ParseProgressFile(elem.ProgressPath())
+	if err != nil {
+		return timeFail(output.InternalError, fmt.Errorf("reading progress: %w", err))
+	}
+
+	now := time.Now().UTC()
+	entry, message, err := change(pd, now)
+	if err != nil {
+		return timeFail(output.ValidationError, fmt.Errorf("%s: %w", elem.ID(), err))
+	}
+	if err := board.WriteProgressFile(elem.ProgressPath(), pd); err != nil {
+		return timeFail(output.InternalError, fmt.Errorf("writing progress: %w", err))
+	}
+	elem.Estimate, elem.TimeLog = pd.Estimate, pd.TimeLog
+	totals := b.Totals(elem, now)
+
+	if JSONEnabled() {
+		response := TimeResponse{ID: elem.ID(), Time: timeJSON(totals), Message: message}
+		if entry != nil {
+			e := timeEntryJSON(*entry, now)
+			response.Entry = &e
+		}
+		return output.PrintJSON(os.Stdout, response)
+	}
+	fmt.Printf("%s %s (%s)\n", elem.ID(), message, describeTime(totals))
+	return nil
+}
+
+// autoTimer starts or stops an element's timer when a status change enters
+// or leaves the workflow's time-tracking statuses. A timer already running
+// keeps running, and there is nothing to stop without one.
+func autoTimer(wf *board.Workflow, pd *board.ProgressData, from, to board.Status) {
+	now := time.Now().UTC()
+	switch {
+	case wf.Timed(to) && !wf.Timed(from):
+		_ = pd.StartTimer(now)
+	case wf.Timed(from) && !wf.Timed(to):
+		_, _ = pd.StopTimer(now, "")
+	}
+}
+
+func timeJSON(t board.TimeTotals) TimeJSON {
+	j := TimeJSON{
+		SpentMinutes:    int(t.Spent.Round(time.Minute) / time.Minute),
+		Spent:           board.FormatDuration(t.Spent),
+		EstimateMinutes: int(t.Estimate.Round(time.Minute) / time.Minute),
+	}
+	if t.Estimate > 0 {
+		j.Estimate = board.FormatDuration(t.Estimate)
+	}
+	if pct, ok := t.Accuracy(); ok {
+		j.Accuracy = &pct
+	}
+	return j
+}
+
+func timeEntryJSON(t board.TimeEntry, now time.Time) TimeEntryJSON {
+	d := t.Spent(now)
+	return TimeEntryJSON{
+		Start:    t.Start.UTC().Format(time.RFC3339),
+		Minutes:  int(d.Round(time.Minute) / time.Minute),
+		Duration: board.FormatDuration(d),
+		Running:  t.Running,
+		Note:     t.Note,
+	}
+}
+
+// describeTime reads like "3h of 4h estimate, 75%", or "3h spent" without
+// an estimate.
+func describeTime(t board.TimeTotals) string {
+	pct, ok := t.Accuracy()
+	if !ok {
+		return board.FormatDuration(t.Spent) + " spent"
+	}
+	return fmt.Sprintf("%s of %s estimate, %d%%", board.FormatDuration(t.Spent), board.FormatDuration(t.Estimate), pct)
+}
+
+// hasTime reports whether there is anything to show about an element's time.
+func hasTime(t board.TimeTotals) bool {
+	return t.Spent > 0 || t.Estimate > 0
+}
@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aagrigore/task-board/internal/board"
+)
+
+func TestTimeCommands(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	defer func() { timeNote = "" }()
+
+	captureOutput(t, func() {
+		if err := runTimeStart(timeStartCmd, []string{testTask1ID}); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if err := runTimeStart(timeStartCmd, []string{testTask1ID}); err == nil || !strings.Contains(err.Error(), "already running") {
+		t.Errorf("second start: err = %v", err)
+	}
+	captureOutput(t, func() {
+		if err := runTimeStart(timeStartCmd, []string{testTask2ID}); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if err := runTimeStop(timeStopCmd, nil); err == nil || !strings.Contains(err.Error(), "2 timers running") {
+		t.Errorf("ambiguous stop: err = %v", err)
+	}
+
+	timeNote = "first pass"
+	out := captureOutput(t, func() {
+		if err := runTimeStop(timeStopCmd, []string{testTask2ID}); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if !strings.Contains(out, testTask2ID+" timer stopped within a minute, nothing logged") {
+		t.Errorf("stop output = %q", out)
+	}
+	// The only timer left stops without an ID
+	timeNote = ""
+	captureOutput(t, func() {
+		if err := runTimeStop(timeStopCmd, nil); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	timeNote = "review fixes"
+	captureOutput(t, func() {
+		if err := runTimeLog(timeLogCmd, []string{testTask1ID, "1h30m"}); err != nil {
+			t.Fatal(err)
+		}
+		if err := runTimeLog(timeLogCmd, []string{testTask3ID, "30m"}); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if err := runTimeLog(timeLogCmd, []string{testTask1ID, "soon"}); err == nil {
+		t.Error("expected an error for an invalid duration")
+	}
+
+	jsonOutput = true
+	defer func() { jsonOutput = false }()
+	stdout := captureOutput(t, func() {
+		if err := runTimeEstimate(timeEstimateCmd, []string{testStory1ID, "4h"}); err != nil {
+			t.Fatal(err)
+		}
+	})
+	var resp TimeResponse
+	if err := json.Unmarshal([]byte(stdout), &resp); err != nil {
+		t.Fatalf("parse: %v\n%s", err, stdout)
+	}
+	if resp.Entry != nil || resp.Time.SpentMinutes != 120 || resp.Time.Estimate != "4h" || resp.Time.Accuracy == nil || *resp.Time.Accuracy != 50 {
+		t.Errorf("estimate response = %+v", resp)
+	}
+
+	pd, err := board.ParseProgressFile(filepath.Join(bd, testEpic1ID+"_recording", testStory1ID+"_audio-capture", testTask1ID+"_interface", "progress.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The timer stopped right away left nothing behind
+	if len(pd.TimeLog) != 1 || pd.TimeLog[0].Duration != 90*time.Minute || pd.TimeLog[0].Note != "review fixes" {
+		t.Errorf("time log = %+v", pd.TimeLog)
+	}
+
+	// show, tree and summary roll the time up
+	stdout = captureOutput(t, func() {
+		if err := runShow(showCmd, []string{testEpic1ID}); err != nil {
+			t.Fatal(err)
+		}
+	})
+	var show ShowResponse
+	if err := json.Unmarshal([]byte(stdout), &show); err != nil {
+		t.Fatalf("parse: %v\n%s", err, stdout)
+	}
+	if show.Element.Time.Spent != "2h" || show.Element.Time.EstimateMinutes != 240 || len(show.Element.TimeLog) != 0 {
+		t.Errorf("show time = %+v, log %+v", show.Element.Time, show.Element.TimeLog)
+	}
+
+	stdout = captureOutput(t, func() {
+		if err := runTree(treeCmd, nil); err != nil {
+			t.Fatal(err)
+		}
+	})
+	var tree TreeResponse
+	if err := json.Unmarshal([]byte(stdout), &tree); err != nil {
+		t.Fatalf("parse: %v\n%s", err, stdout)
+	}
+	for _, epic := range tree.Tree {
+		switch epic.ID {
+		case testEpic1ID:
+			if epic.Time == nil || epic.Time.SpentMinutes != 120 {
+				t.Errorf("%s tree time = %+v", epic.ID, epic.Time)
+			}
+		case testEpic2ID:
+			if epic.Time != nil {
+				t.Errorf("%s has no time, got %+v", epic.ID, epic.Time)
+			}
+		}
+	}
+
+	stdout = captureOutput(t, func() {
+		if err := runSummary(summaryCmd, nil); err != nil {
+			t.Fatal(err)
+		}
+	})
+	var summary SummaryResponse
+	if err := json.Unmarshal([]byte(stdout), &summary); err != nil {
+		t.Fatalf("parse: %v\n%s", err, stdout)
+	}
+	if got := summary.Summary.Time; len(got) != 2 || got[0].ID != testEpic1ID || got[1].ID != testStory1ID || got[1].Time.Spent != "2h" {
+		t.Errorf("summary time = %+v", got)
+	}
+}
+
+func TestTimeAutoTracking(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	os.WriteFile(filepath.Join(bd, board.WorkflowFile), []byte("time-tracking:\n  auto: [development]\n"), 0644)
+
+	captureOutput(t, func() {
+		runProgressStatus(progressStatusCmd, []string{testTask3ID, "development"})
+	})
+	b, _ := board.Load(bd)
+	if !b.FindByID(testTask3ID).Running() {
+		t.Fatalf("entering development should start a timer: %+v", b.FindByID(testTask3ID).TimeLog)
+	}
+
+	// A quick flip out and back logs nothing
+	captureOutput(t, func() {
+		runProgressStatus(progressStatusCmd, []string{testTask3ID, "to-review"})
+	})
+	b, _ = board.Load(bd)
+	if task := b.FindByID(testTask3ID); task.Running() || len(task.TimeLog) != 0 {
+		t.Errorf("a timer stopped right away should be dropped: %+v", task.TimeLog)
+	}
+
+	captureOutput(t, func() {
+		runProgressStatus(progressStatusCmd, []string{testTask3ID, "development"})
+	})
+	path := b.FindByID(testTask3ID).ProgressPath()
+	pd, _ := board.ParseProgressFile(path)
+	pd.TimeLog[0].Start = pd.TimeLog[0].Start.Add(-10 * time.Minute)
+	board.WriteProgressFile(path, pd)
+	captureOutput(t, func() {
+		runProgressStatus(progressStatusCmd, []string{testTask3ID, "to-review"})
+	})
+	b, _ = board.Load(bd)
+	task := b.FindByID(testTask3ID)
+	if task.Running() || len(task.TimeLog) != 1 || task.TimeLog[0].Duration != 10*time.Minute {
+		t.Errorf("leaving development should stop the timer: %+v", task.TimeLog)
+	}
+}
@@ -0,0 +1,271 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/aagrigore/task-board/internal/board"
+	"github.com/aagrigore/task-board/internal/output"
+	"github.com/aagrigore/task-board/internal/trash"
+	"github.com/spf13/cobra"
+)
+
+// TrashListResponse is the JSON response for trash list
+type TrashListResponse struct {
+	Items []TrashItem `json:"items"`
+}
+
+// TrashItem describes one trashed element
+type TrashItem struct {
+	Key          string   `json:"key"`
+	ID           string   `json:"id"`
+	Type         string   `json:"type"`
+	Title        string   `json:"title"`
+	OriginalPath string   `json:"originalPath"`
+	DeletedAt    string   `json:"deletedAt"`
+	Contains     []string `json:"contains"`
+}
+
+// TrashRestoreResponse is the JSON response for trash restore
+type TrashRestoreResponse struct {
+	Restored      TrashItem `json:"restored"`
+	LinksRestored int       `json:"linksRestored"`
+	Message       string    `json:"message"`
+}
+
+// TrashPurgeResponse is the JSON response for trash purge
+type TrashPurgeResponse struct {
+	Purged  []string `json:"purged"`
+	Message string   `json:"message"`
+}
+
+var trashCmd = &cobra.Command{
+	Use:   "trash",
+	Short: "List, restore or purge deleted elements",
+	Long: `Deleted elements are moved to .task-board/.trash together with their
+children and the dependency links delete removed from other elements.`,
+}
+
+var trashListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List trashed elements, most recent first",
+	Args:  cobra.NoArgs,
+	RunE:  runTrashList,
+}
+
+var trashRestoreCmd = &cobra.Command{
+	Use:   "restore <ID|key>",
+	Short: "Put a trashed element back where it was, with its links",
+	Long: `Put a trashed element back where it was, with its links.
+
+A restore is not recorded for undo; delete the element again instead.`,
+	Args: cobra.ExactArgs(1),
+	RunE: locked(runTrashRestore),
+}
+
+var trashPurgeCmd = &cobra.Command{
+	Use:   "purge [ID|key...]",
+	Short: "Delete trashed elements for good",
+	Example: `  task-board trash purge TASK-260101-aaaaaa
+  task-board trash purge --all`,
+	RunE: locked(runTrashPurge),
+}
+
+var trashPurgeAll bool
+
+func init() {
+	rootCmd.AddCommand(trashCmd)
+	trashCmd.AddCommand(trashListCmd)
+	trashCmd.AddCommand(trashRestoreCmd)
+	trashCmd.AddCommand(trashPurgeCmd)
+	trashPurgeCmd.Flags().BoolVar(&trashPurgeAll, "all", false, "Purge everything in the trash")
+}
+
+func runTrashList(cmd *cobra.Command, args []string) error {
+	items, err := trash.List(boardDir)
+	if err != nil {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.InternalError, err.Error(), nil)
+			return nil
+		}
+		return err
+	}
+
+	if JSONEnabled() {
+		response := TrashListResponse{Items: []TrashItem{}}
+		for _, item := range items {
+			response.Items = append(response.Items, trashItemJSON(item))
+		}
+		return output.PrintJSON(os.Stdout, response)
+	}
+	if len(items) == 0 {
+		fmt.Println("Trash is empty")
+		return nil
+	}
+	table := output.NewTable("ID", "TITLE", "DELETED", "CHILDREN", "FROM")
+	for _, item := range items {
+		table.AddRow(item.ID, item.Title, item.DeletedAt.Local().Format("2006-01-02 15:04"),
+			fmt.Sprintf("%d", len(item.Contains)-1), item.OriginalPath)
+	}
+	fmt.Print(table.String())
+	return nil
+}
+
+func runTrashRestore(cmd *cobra.Command, args []string) error {
+	item, err := trash.Find(boardDir, args[0])
+	if err != nil {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.InternalError, err.Error(), nil)
+			return nil
+		}
+		return err
+	}
+	if item == nil {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.NotFound, fmt.Sprintf("%s is not in the trash", args[0]), map[string]interface{}{
+				"id": args[0],
+			})
+			return nil
+		}
+		return fmt.Errorf("%s is not in the trash", args[0])
+	}
+
+	b, err := board.Load(boardDir)
+	if err != nil {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.InternalError, fmt.Sprintf("loading board: %v", err), nil)
+			return nil
+		}
+		return fmt.Errorf("loading board: %w", err)
+	}
+	for _, id := range item.Contains {
+		if b.FindByID(id) != nil {
+			if JSONEnabled() {
+				output.PrintError(os.Stderr, output.ValidationError, fmt.Sprintf("%s is already on the board", id), map[string]interface{}{
+					"id": id,
+				})
+				return nil
+			}
+			return fmt.Errorf("%s is already on the board", id)
+		}
+	}
+
+	if err := trash.Restore(boardDir, item); err != nil {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.ValidationError, err.Error(), map[string]interface{}{
+				"id": item.ID,
+			})
+			return nil
+		}
+		return err
+	}
+
+	// Put back the references delete stripped, where the other end survived
+	b, err = board.Load(boardDir)
+	if err != nil {
+		return fmt.Errorf("reloading board: %w", err)
+	}
+	restoredLinks := 0
+	for _, ref := range item.Stripped {
+		other := b.FindByID(ref.Element)
+		if other == nil {
+			continue
+		}
+		if ref.LinkType != "" {
+			_, err = updateLink(other, board.Link{Type: board.LinkType(ref.LinkType), Target: ref.Target}, true)
+		} else {
+			err = addProgressRef(other, ref.Target, ref.BlockedBy)
+		}
+		if err != nil {
+			return err
+		}
+		restoredLinks++
+	}
+
+	if JSONEnabled() {
+		return output.PrintJSON(os.Stdout, TrashRestoreResponse{
+			Restored:      trashItemJSON(item),
+			LinksRestored: restoredLinks,
+			Message:       fmt.Sprintf("Restored %s", item.ID),
+		})
+	}
+	fmt.Printf("Restored %s to %s\n", item.ID, item.OriginalPath)
+	if restoredLinks > 0 {
+		fmt.Printf("  %d link reference(s) restored\n", restoredLinks)
+	}
+	return nil
+}
+
+func runTrashPurge(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 && !trashPurgeAll {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.ValidationError, "give IDs to purge, or --all to empty the trash", nil)
+			return nil
+		}
+		return fmt.Errorf("give IDs to purge, or --all to empty the trash")
+	}
+
+	var items []*trash.Item
+	if trashPurgeAll {
+		all, err := trash.List(boardDir)
+		if err != nil {
+			if JSONEnabled() {
+				output.PrintError(os.Stderr, output.InternalError, err.Error(), nil)
+				return nil
+			}
+			return err
+		}
+		items = all
+	}
+	for _, ref := range args {
+		item, err := trash.Find(boardDir, ref)
+		if err != nil {
+			if JSONEnabled() {
+				output.PrintError(os.Stderr, output.InternalError, err.Error(), nil)
+				return nil
+			}
+			return err
+		}
+		if item == nil {
+			if JSONEnabled() {
+				output.PrintError(os.Stderr, output.NotFound, fmt.Sprintf("%s is not in the trash", ref), map[string]interface{}{
+					"id": ref,
+				})
+				return nil
+			}
+			return fmt.Errorf("%s is not in the trash", ref)
+		}
+		items = append(items, item)
+	}
+
+	response := TrashPurgeResponse{Purged: []string{}}
+	for _, item := range items {
+		if err := trash.Purge(boardDir, item); err != nil {
+			if JSONEnabled() {
+				output.PrintError(os.Stderr, output.InternalError, fmt.Sprintf("purging %s: %v", item.ID, err), nil)
+				return nil
+			}
+			return fmt.Errorf("purging %s: %w", item.ID, err)
+		}
+		response.Purged = append(response.Purged, item.ID)
+	}
+	response.Message = fmt.Sprintf("Purged %d element(s)", len(response.Purged))
+
+	if JSONEnabled() {
+		return output.PrintJSON(os.Stdout, response)
+	}
+	fmt.Println(response.Message)
+	return nil
+}
+
+func trashItemJSON(item *trash.Item) TrashItem {
+	return TrashItem{
+		Key:          item.Key,
+		ID:           item.ID,
+		Type:         item.Type,
+		Title:        item.Title,
+		OriginalPath: item.OriginalPath,
+		DeletedAt:    item.DeletedAt.Format("2006-01-02T15:04:05Z"),
+		Contains:     item.Contains,
+	}
+}
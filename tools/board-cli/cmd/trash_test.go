@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/aagrigore/task-board/internal/board"
+	"github.com/aagrigore/task-board/internal/trash"
+)
+
+func TestTrashRestoreAndPurge(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	defer func() { trashPurgeAll = false }()
+
+	// TASK-1 blocks TASK-2
+	captureOutput(t, func() {
+		if err := runDelete(deleteCmd, []string{testTask1ID}); err != nil {
+			t.Fatalf("delete: %v", err)
+		}
+	})
+	b, _ := board.Load(bd)
+	if len(b.FindByID(testTask2ID).BlockedBy) != 0 {
+		t.Fatal("delete should strip TASK-2's link")
+	}
+
+	item, _ := trash.Find(bd, testTask1ID)
+	if item == nil || len(item.Stripped) != 1 {
+		t.Fatalf("trash item = %+v", item)
+	}
+
+	captureOutput(t, func() {
+		if err := runTrashRestore(trashRestoreCmd, []string{testTask1ID}); err != nil {
+			t.Fatalf("restore: %v", err)
+		}
+	})
+	b, _ = board.Load(bd)
+	task1 := b.FindByID(testTask1ID)
+	if task1 == nil || task1.ParentID != testStory1ID {
+		t.Fatal("TASK-1 should be back under STORY-1")
+	}
+	if len(task1.Checklist) != 2 || !containsRef(task1.Blocks, testTask2ID) {
+		t.Errorf("restored element should keep its content, got %+v", task1)
+	}
+	if !containsRef(b.FindByID(testTask2ID).BlockedBy, testTask1ID) {
+		t.Error("restore should put TASK-2's link back")
+	}
+	if err := runTrashRestore(trashRestoreCmd, []string{testTask1ID}); err == nil {
+		t.Error("restoring an element no longer in the trash should fail")
+	}
+
+	// Purge needs IDs or --all
+	captureOutput(t, func() { runDelete(deleteCmd, []string{testTask3ID}) })
+	if err := runTrashPurge(trashPurgeCmd, nil); err == nil {
+		t.Error("purge without IDs or --all should fail")
+	}
+	trashPurgeAll = true
+	captureOutput(t, func() {
+		if err := runTrashPurge(trashPurgeCmd, nil); err != nil {
+			t.Fatalf("purge: %v", err)
+		}
+	})
+	if items, _ := trash.List(bd); len(items) != 0 {
+		t.Errorf("trash should be empty, got %d", len(items))
+	}
+}
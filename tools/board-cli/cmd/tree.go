@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/aagrigore/task-board/internal/board"
 	"github.com/aagrigore/task-board/internal/output"
@@ -26,13 +27,16 @@ func init() {
 
 // TreeNode represents a node in the hierarchical tree output
 type TreeNode struct {
-	ID        string      `json:"id"`
-	Type      string      `json:"type"`
-	Name      string      `json:"name"`
-	Status    string      `json:"status"`
-	Assignee  *string     `json:"assignee"` // nil if not assigned
-	UpdatedAt string      `json:"updatedAt"`
-	Children  []*TreeNode `json:"children"`
+	ID        string  `json:"id"`
+	Type      string  `json:"type"`
+	Name      string  `json:"name"`
+	Status    string  `json:"status"`
+	Assignee  *string `json:"assignee"` // nil if not assigned
+	UpdatedAt string  `json:"updatedAt"`
+	// Time rolls up the time log below the node, nil when nothing is logged
+	// or estimated
+	Time     *TimeJSON   `json:"time,omitempty"`
+	Children []*TreeNode `json:"children"`
 }
 
 // TreeResponse is the JSON response for the tree command
@@ -41,7 +45,7 @@ type TreeResponse struct {
 }
 
 func runTree(cmd *cobra.Command, args []string) error {
-	b, err := board.Load(boardDir)
+	b, err := loadViewBoard()
 	if err != nil {
 		if JSONEnabled() {
 			output.PrintError(os.Stderr, output.InternalError, fmt.Sprintf("loading board: %s", err.Error()), nil)
@@ -73,6 +77,7 @@ func runTree(cmd *cobra.Command, args []string) error {
 
 	// Build tree
 	tree := buildTree(b, epics)
+	tree, hidden := pruneSnoozed(b, tree, time.Now())
 
 	if JSONEnabled() {
 		response := TreeResponse{Tree: tree}
@@ -80,27 +85,38 @@ func runTree(cmd *cobra.Command, args []string) error {
 	}
 
 	// Text output
-	if len(tree) == 0 {
+	if len(tree) == 0 && hidden == 0 {
 		fmt.Println("Board is empty.")
 		return nil
 	}
 
 	printTreeText(tree, "")
+	printSnoozedHint(hidden)
 	return nil
 }
 
 func buildTree(b *board.Board, epics []*board.Element) []*TreeNode {
+	now := time.Now().UTC()
+	toNode := func(e *board.Element) *TreeNode {
+		node := elementToNode(e)
+		if totals := b.Totals(e, now); hasTime(totals) {
+			t := timeJSON(totals)
+			node.Time = &t
+		}
+		return node
+	}
+
 	var nodes []*TreeNode
 	for _, epic := range epics {
-		node := elementToNode(epic)
+		node := toNode(epic)
 		// Add stories as children
 		stories := b.Children(epic)
 		for _, story := range stories {
-			storyNode := elementToNode(story)
+			storyNode := toNode(story)
 			// Add tasks/bugs as children of story
 			tasks := b.Children(story)
 			for _, task := range tasks {
-				taskNode := elementToNode(task)
+				taskNode := toNode(task)
 				storyNode.Children = append(storyNode.Children, taskNode)
 			}
 			node.Children = append(node.Children, storyNode)
@@ -33,8 +33,16 @@ func runTUI(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("resolving board path: %w", err)
 	}
 
-	// Launch TUI with board directory
-	tuiCmd := exec.Command(tuiBinary, "--board-dir", absBoardDir)
+	// Launch TUI with board directory (and workspace, if any)
+	tuiArgs := []string{"--board-dir", absBoardDir}
+	if WorkspaceEnabled() {
+		absWorkspace, err := filepath.Abs(workspaceFile)
+		if err != nil {
+			return fmt.Errorf("resolving workspace path: %w", err)
+		}
+		tuiArgs = append(tuiArgs, "--workspace", absWorkspace)
+	}
+	tuiCmd := exec.Command(tuiBinary, tuiArgs...)
 	tuiCmd.Stdin = os.Stdin
 	tuiCmd.Stdout = os.Stdout
 	tuiCmd.Stderr = os.Stderr
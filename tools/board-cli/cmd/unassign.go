@@ -19,7 +19,7 @@ var unassignCmd = &cobra.Command{
 	Use:   "unassign <ID>",
 	Short: "Unassign element from its agent",
 	Args:  cobra.ExactArgs(1),
-	RunE:  runUnassign,
+	RunE:  recorded(runUnassign),
 }
 
 func init() {
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/aagrigore/task-board/internal/board"
+	"github.com/aagrigore/task-board/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var unblockCmd = &cobra.Command{
+	Use:   "unblock <ID>",
+	Short: "Return a blocked element to the status it had before",
+	Long: `Return a blocked element to the status it had before it was blocked,
+as recorded under "## Prior Status" in progress.md (or, for older
+elements, in its history).
+
+The change is checked like 'progress status': the workflow must allow it,
+blocked-by tasks must be finished for statuses that need it, and WIP limits
+apply unless --force is given. An element an open external blocker holds
+is refused too; resolve the blocker with 'task-board blocker resolve', or
+pass --force to unblock it anyway.`,
+	Args: cobra.ExactArgs(1),
+	RunE: recorded(runUnblock),
+}
+
+var unblockForce bool
+
+func init() {
+	rootCmd.AddCommand(unblockCmd)
+	unblockCmd.Flags().BoolVar(&unblockForce, "force", false, "Exceed WIP limits and ignore external blockers")
+}
+
+func runUnblock(cmd *cobra.Command, args []string) error {
+	fail := func(code output.ErrorCode, err error) error {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, code, err.Error(), nil)
+			return nil
+		}
+		return err
+	}
+
+	b, err := board.Load(boardDir)
+	if err != nil {
+		return fail(output.InternalError, fmt.Errorf("loading board: %w", err))
+	}
+	elem := b.FindByID(args[0])
+	if elem == nil {
+		return fail(output.NotFound, fmt.Errorf("element %s not found", args[0]))
+	}
+	if b.Workflow.Category(elem) != board.CategoryBlocked {
+		return fail(output.ValidationError, fmt.Errorf("%s is not blocked (%s)", elem.ID(), elem.Status))
+	}
+	if held := elem.HeldBy(); len(held) > 0 && !unblockForce {
+		ids := make([]string, len(held))
+		for i, bl := range held {
+			ids[i] = bl.ID
+		}
+		return fail(output.ValidationError, fmt.Errorf("%s is held by %s; resolve it with 'task-board blocker resolve' or use --force",
+			elem.ID(), strings.Join(ids, ", ")))
+	}
+
+	pd, err := board.ParseProgressFile(elem.ProgressPath())
+	if err != nil {
+		return fail(output.InternalError, fmt.Errorf("reading progress: %w", err))
+	}
+	flow := b.Flow(elem.Type)
+	target, ok := priorStatus(flow, pd, func(c board.Category) bool { return c != board.CategoryBlocked })
+	if !ok {
+		return fail(output.ValidationError, fmt.Errorf("%s has no status from before it was blocked; set one with 'task-board progress status'", elem.ID()))
+	}
+	if refusal := transitionRefusal(b, elem, target, unblockForce); refusal != nil {
+		return fail(refusal.code, fmt.Errorf("%s: %s", elem.ID(), refusal.message))
+	}
+
+	if err := setStatus(b, elem, pd, target, fmt.Sprintf("%s → %s (restored)", elem.ID(), target)); err != nil {
+		return fail(output.InternalError, err)
+	}
+
+	if JSONEnabled() {
+		name := ""
+		if rd, _ := board.ParseReadmeFile(elem.ReadmePath()); rd != nil {
+			name = rd.Title
+		}
+		output.PrintJSON(os.Stdout, ProgressResponse{
+			Updated: UpdatedElement{
+				ID:       elem.ID(),
+				Type:     string(elem.Type),
+				Name:     name,
+				Status:   string(target),
+				Assignee: pd.AssignedTo,
+			},
+			Message: fmt.Sprintf("Status restored to %s", target),
+		})
+	}
+	return nil
+}
+
+// priorStatus finds the status an element had before its current one that
+// the flow still knows and want accepts: while it is blocked the status it
+// held before, then the history from the latest change back.
+func priorStatus(flow *board.Flow, pd *board.ProgressData, want func(board.Category) bool) (board.Status, bool) {
+	var candidates []board.Status
+	if flow.Category(pd.Status) == board.CategoryBlocked {
+		candidates = append(candidates, pd.PriorStatus)
+	}
+	for i := len(pd.History) - 1; i >= 0; i-- {
+		candidates = append(candidates, pd.History[i].From)
+	}
+	for _, s := range candidates {
+		if s == "" || s == pd.Status || flow.Def(s) == nil {
+			continue
+		}
+		if want(flow.Category(s)) {
+			return s, true
+		}
+	}
+	return "", false
+}
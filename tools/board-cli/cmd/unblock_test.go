@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aagrigore/task-board/internal/board"
+)
+
+func TestUnblockRestoresPriorStatus(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+
+	captureOutput(t, func() {
+		for _, status := range []string{"development", "blocked"} {
+			if err := runProgressStatus(progressStatusCmd, []string{testTask3ID, status}); err != nil {
+				t.Fatalf("%s: %v", status, err)
+			}
+		}
+		if err := runUnblock(unblockCmd, []string{testTask3ID}); err != nil {
+			t.Fatalf("unblock: %v", err)
+		}
+	})
+	b, _ := board.Load(bd)
+	if task3 := b.FindByID(testTask3ID); task3.Status != board.StatusDevelopment || task3.PriorStatus != "" {
+		t.Errorf("status = %s (prior %s), want development with the prior status cleared", task3.Status, task3.PriorStatus)
+	}
+
+	err := runUnblock(unblockCmd, []string{testTask3ID})
+	if err == nil || !strings.Contains(err.Error(), "is not blocked") {
+		t.Errorf("unblocking an unblocked element: %v", err)
+	}
+}
+
+func TestUnblockFallsBackToHistory(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+
+	// Blocked before prior statuses were recorded
+	b, _ := board.Load(bd)
+	elem := b.FindByID(testTask3ID)
+	pd, _ := board.ParseProgressFile(elem.ProgressPath())
+	pd.SetStatus(board.StatusToDev, board.DefaultWorkflow().For(board.TaskType))
+	pd.SetStatus(board.StatusBlocked, board.DefaultWorkflow().For(board.TaskType))
+	pd.PriorStatus = ""
+	board.WriteProgressFile(elem.ProgressPath(), pd)
+
+	captureOutput(t, func() {
+		if err := runUnblock(unblockCmd, []string{testTask3ID}); err != nil {
+			t.Fatalf("unblock: %v", err)
+		}
+	})
+	b, _ = board.Load(bd)
+	if s := b.FindByID(testTask3ID).Status; s != board.StatusToDev {
+		t.Errorf("status = %s, want to-dev from the history", s)
+	}
+
+	setStatusForTest(t, bd, testTask4ID, board.StatusBlocked)
+	err := runUnblock(unblockCmd, []string{testTask4ID})
+	if err == nil || !strings.Contains(err.Error(), "no status from before") {
+		t.Errorf("unblocking without a prior status: %v", err)
+	}
+}
+
+func TestUnblockRefusesExternallyHeld(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	defer func() { blockerReason, unblockForce = "", false }()
+	setStatusForTest(t, bd, testTask3ID, board.StatusDevelopment)
+
+	blockerReason = "waiting on API keys"
+	captureOutput(t, func() {
+		if err := runBlockerAdd(blockerAddCmd, []string{testTask3ID}); err != nil {
+			t.Fatalf("blocker add: %v", err)
+		}
+	})
+	err := runUnblock(unblockCmd, []string{testTask3ID})
+	if err == nil || !strings.Contains(err.Error(), "held by EXT-1") {
+		t.Fatalf("unblocking a held element: %v", err)
+	}
+
+	unblockForce = true
+	captureOutput(t, func() {
+		if err := runUnblock(unblockCmd, []string{testTask3ID}); err != nil {
+			t.Fatalf("unblock --force: %v", err)
+		}
+	})
+	b, _ := board.Load(bd)
+	if s := b.FindByID(testTask3ID).Status; s != board.StatusDevelopment {
+		t.Errorf("status = %s, want development", s)
+	}
+}
+
+func TestReopenRestoresParentPriorStatus(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	setStatusForTest(t, bd, testStory3ID, board.StatusReviewing)
+	setStatusForTest(t, bd, testTask4ID, board.StatusDevelopment)
+	passReviewForTest(t, bd, testTask4ID, testStory3ID, testEpic2ID)
+
+	captureOutput(t, func() {
+		for _, status := range []string{"done", "development"} {
+			if err := runProgressStatus(progressStatusCmd, []string{testTask4ID, status}); err != nil {
+				t.Fatalf("%s: %v", status, err)
+			}
+		}
+	})
+	b, _ := board.Load(bd)
+	if s := b.FindByID(testStory3ID).Status; s != board.StatusReviewing {
+		t.Errorf("story should be reopened to reviewing, got %s", s)
+	}
+	// The epic was promoted from backlog, so it goes to reopen-to
+	if s := b.FindByID(testEpic2ID).Status; s != board.StatusDevelopment {
+		t.Errorf("epic should be reopened to development, got %s", s)
+	}
+}
+
+func TestReopenAfterEarlierBlockLifted(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	setStatusForTest(t, bd, testStory3ID, board.StatusDevelopment)
+	setStatusForTest(t, bd, testTask4ID, board.StatusDevelopment)
+	passReviewForTest(t, bd, testTask4ID, testStory3ID, testEpic2ID)
+
+	// The story was blocked and unblocked in development, then moved on
+	captureOutput(t, func() {
+		for _, status := range []string{"blocked", "development", "reviewing"} {
+			if err := runProgressStatus(progressStatusCmd, []string{testStory3ID, status}); err != nil {
+				t.Fatalf("%s: %v", status, err)
+			}
+		}
+	})
+	// A file written before the prior status was cleared still holds it
+	b, _ := board.Load(bd)
+	story := b.FindByID(testStory3ID)
+	pd, _ := board.ParseProgressFile(story.ProgressPath())
+	if pd.PriorStatus != "" {
+		t.Errorf("prior status should be cleared on leaving blocked, got %s", pd.PriorStatus)
+	}
+	pd.PriorStatus = board.StatusDevelopment
+	board.WriteProgressFile(story.ProgressPath(), pd)
+
+	captureOutput(t, func() {
+		for _, status := range []string{"done", "development"} {
+			if err := runProgressStatus(progressStatusCmd, []string{testTask4ID, status}); err != nil {
+				t.Fatalf("%s: %v", status, err)
+			}
+		}
+	})
+	b, _ = board.Load(bd)
+	if s := b.FindByID(testStory3ID).Status; s != board.StatusReviewing {
+		t.Errorf("story should be reopened to reviewing, got %s", s)
+	}
+}
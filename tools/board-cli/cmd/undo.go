@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/aagrigore/task-board/internal/history"
+	"github.com/aagrigore/task-board/internal/output"
+	"github.com/aagrigore/task-board/internal/trash"
+	"github.com/aagrigore/task-board/internal/txn"
+	"github.com/spf13/cobra"
+)
+
+// UndoResponse is the JSON response for undo
+type UndoResponse struct {
+	Undone  []HistoryEntry `json:"undone"`
+	Message string         `json:"message"`
+}
+
+// HistoryResponse is the JSON response for undo --list
+type HistoryResponse struct {
+	Entries []HistoryEntry `json:"entries"`
+}
+
+// HistoryEntry describes one recorded command
+type HistoryEntry struct {
+	Seq     int      `json:"seq"`
+	Command string   `json:"command"`
+	Time    string   `json:"time"`
+	Files   []string `json:"files"`
+}
+
+var undoCmd = &cobra.Command{
+	Use:   "undo",
+	Short: "Undo the last board-changing commands",
+	Long: `Undo the last N board-changing commands, most recent first.
+
+Every command that writes to the board records the previous content of the
+files it touched in .task-board/.history (the last 100 commands are kept).
+Undo puts those files back, including links a delete stripped from other
+elements. It refuses when a file was changed since by something other than
+the undone commands; --force discards such changes.`,
+	Example: `  task-board undo
+  task-board undo --steps 3
+  task-board undo --list`,
+	Args: cobra.NoArgs,
+	RunE: runUndo,
+}
+
+var (
+	undoSteps int
+	undoForce bool
+	undoList  bool
+)
+
+func init() {
+	rootCmd.AddCommand(undoCmd)
+	undoCmd.Flags().IntVar(&undoSteps, "steps", 1, "Number of commands to undo")
+	undoCmd.Flags().BoolVar(&undoForce, "force", false, "Undo even if files changed since")
+	undoCmd.Flags().BoolVar(&undoList, "list", false, "List the commands that can be undone")
+}
+
+func runUndo(cmd *cobra.Command, args []string) error {
+	entries, err := history.List(boardDir)
+	if err != nil {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.InternalError, err.Error(), nil)
+			return nil
+		}
+		return err
+	}
+
+	if undoList {
+		return printHistory(entries)
+	}
+
+	if undoSteps < 1 {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.ValidationError, "--steps must be at least 1", nil)
+			return nil
+		}
+		return fmt.Errorf("--steps must be at least 1")
+	}
+	if len(entries) < undoSteps {
+		msg := fmt.Sprintf("only %d command(s) in the history", len(entries))
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.NotFound, msg, map[string]interface{}{
+				"available": len(entries),
+			})
+			return nil
+		}
+		return fmt.Errorf("%s", msg)
+	}
+
+	tx, err := txn.Begin(boardDir)
+	if err != nil {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.InternalError, err.Error(), nil)
+			return nil
+		}
+		return err
+	}
+	var undone []*history.Entry
+	for i := len(entries) - 1; i >= len(entries)-undoSteps; i-- {
+		if err := history.Undo(boardDir, entries[i], undoForce); err != nil {
+			tx.Rollback()
+			var conflict *history.ConflictError
+			if errors.As(err, &conflict) {
+				msg := fmt.Sprintf("cannot undo %s; nothing undone (use --force to discard those changes)", conflict.Error())
+				if JSONEnabled() {
+					output.PrintError(os.Stderr, output.ValidationError, msg, map[string]interface{}{
+						"seq":     conflict.Entry.Seq,
+						"command": conflict.Entry.Command,
+						"files":   conflict.Paths,
+					})
+					return nil
+				}
+				return fmt.Errorf("%s", msg)
+			}
+			if JSONEnabled() {
+				output.PrintError(os.Stderr, output.InternalError, fmt.Sprintf("undo failed: %v; nothing undone", err), nil)
+				return nil
+			}
+			return fmt.Errorf("undo failed: %w; nothing undone", err)
+		}
+		undone = append(undone, entries[i])
+	}
+	if err := dropRestoredTrash(boardDir); err != nil {
+		tx.Rollback()
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.InternalError, fmt.Sprintf("undo failed: %v; nothing undone", err), nil)
+			return nil
+		}
+		return fmt.Errorf("undo failed: %w; nothing undone", err)
+	}
+	if err := tx.Commit(); err != nil {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.InternalError, fmt.Sprintf("releasing lock: %v", err), nil)
+			return nil
+		}
+		return fmt.Errorf("releasing lock: %w", err)
+	}
+	for _, e := range undone {
+		history.Forget(boardDir, e)
+	}
+
+	response := UndoResponse{Message: fmt.Sprintf("Undid %d command(s)", len(undone))}
+	for _, e := range undone {
+		response.Undone = append(response.Undone, historyEntryJSON(e))
+	}
+	if JSONEnabled() {
+		return output.PrintJSON(os.Stdout, response)
+	}
+	for _, e := range undone {
+		fmt.Printf("Undid #%d: %s\n", e.Seq, e.Command)
+	}
+	return nil
+}
+
+func printHistory(entries []*history.Entry) error {
+	if JSONEnabled() {
+		response := HistoryResponse{Entries: []HistoryEntry{}}
+		for i := len(entries) - 1; i >= 0; i-- {
+			response.Entries = append(response.Entries, historyEntryJSON(entries[i]))
+		}
+		return output.PrintJSON(os.Stdout, response)
+	}
+	if len(entries) == 0 {
+		fmt.Println("Nothing to undo")
+		return nil
+	}
+	table := output.NewTable("#", "WHEN", "COMMAND", "FILES")
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		table.AddRow(fmt.Sprintf("%d", e.Seq), e.Time.Local().Format("2006-01-02 15:04"), e.Command, fmt.Sprintf("%d", len(e.Files)))
+	}
+	fmt.Print(table.String())
+	return nil
+}
+
+func historyEntryJSON(e *history.Entry) HistoryEntry {
+	he := HistoryEntry{Seq: e.Seq, Command: e.Command, Time: e.Time.Format("2006-01-02T15:04:05Z"), Files: []string{}}
+	for _, f := range e.Files {
+		he.Files = append(he.Files, f.Path)
+	}
+	return he
+}
+
+// dropRestoredTrash purges the trash items whose element directory is
+// back on the board. The trash is not journaled, so undoing a delete brings
+// the element back from the history and leaves its trashed copy behind.
+func dropRestoredTrash(boardDir string) error {
+	items, err := trash.List(boardDir)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if _, err := os.Stat(filepath.Join(boardDir, item.OriginalPath)); err != nil {
+			continue
+		}
+		if err := trash.Purge(boardDir, item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
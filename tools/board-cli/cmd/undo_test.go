@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/aagrigore/task-board/internal/board"
+	"github.com/aagrigore/task-board/internal/history"
+	"github.com/aagrigore/task-board/internal/trash"
+)
+
+func TestUndoForceDelete(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	defer func() { linkBlockedBy = ""; deleteForce = false; undoSteps = 1 }()
+
+	// TASK-4 (EPIC-2) is blocked by TASK-1 (EPIC-1)
+	linkBlockedBy = testTask1ID
+	captureOutput(t, func() {
+		if err := linkCmd.RunE(linkCmd, []string{testTask4ID}); err != nil {
+			t.Fatalf("link: %v", err)
+		}
+	})
+	deleteForce = true
+	captureOutput(t, func() {
+		if err := deleteCmd.RunE(deleteCmd, []string{testEpic1ID}); err != nil {
+			t.Fatalf("delete: %v", err)
+		}
+	})
+
+	b, _ := board.Load(bd)
+	if b.FindByID(testEpic1ID) != nil || len(b.FindByID(testTask4ID).BlockedBy) != 0 {
+		t.Fatal("delete should remove EPIC-1 and strip TASK-4's link")
+	}
+	if items, _ := trash.List(bd); len(items) != 1 || len(items[0].Contains) != 7 {
+		t.Fatalf("EPIC-1 and its 6 descendants should be in the trash, got %+v", items)
+	}
+
+	captureOutput(t, func() {
+		if err := runUndo(undoCmd, nil); err != nil {
+			t.Fatalf("undo: %v", err)
+		}
+	})
+	b, _ = board.Load(bd)
+	if b.FindByID(testEpic1ID) == nil || b.FindByID(testTask1ID) == nil {
+		t.Fatal("undo should bring EPIC-1 back")
+	}
+	if task4 := b.FindByID(testTask4ID); !containsRef(task4.BlockedBy, testTask1ID) {
+		t.Errorf("undo should restore TASK-4's link, got %v", task4.BlockedBy)
+	}
+	if items, _ := trash.List(bd); len(items) != 0 {
+		t.Errorf("undo of delete should empty the trash, got %d items", len(items))
+	}
+
+	// The link is still in the history and can be undone next
+	entries, _ := history.List(bd)
+	if len(entries) != 1 || !strings.HasPrefix(entries[0].Command, "task-board link "+testTask4ID) {
+		t.Fatalf("history = %+v", entries)
+	}
+	captureOutput(t, func() { runUndo(undoCmd, nil) })
+	b, _ = board.Load(bd)
+	if len(b.FindByID(testTask4ID).BlockedBy) != 0 || len(b.FindByID(testStory3ID).BlockedBy) != 0 {
+		t.Error("undoing link should remove the link and its escalations")
+	}
+}
+
+func TestUndoKeepsRestoredElement(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+
+	captureOutput(t, func() {
+		if err := deleteCmd.RunE(deleteCmd, []string{testTask3ID}); err != nil {
+			t.Fatalf("delete: %v", err)
+		}
+		if err := trashRestoreCmd.RunE(trashRestoreCmd, []string{testTask3ID}); err != nil {
+			t.Fatalf("restore: %v", err)
+		}
+	})
+	// The restore is not recorded, and undoing the delete would now
+	// overwrite the restored element
+	entries, _ := history.List(bd)
+	if len(entries) != 1 || !strings.HasPrefix(entries[0].Command, "task-board delete") {
+		t.Fatalf("history = %+v", entries)
+	}
+	if err := runUndo(undoCmd, nil); err == nil || !strings.Contains(err.Error(), "changed since") {
+		t.Errorf("expected conflict, got %v", err)
+	}
+	if b, _ := board.Load(bd); b.FindByID(testTask3ID) == nil {
+		t.Error("TASK-3 should still be on the board")
+	}
+}
+
+func TestUndoNotesSetAndSteps(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	defer func() { progressNotesSet = false; undoSteps = 1 }()
+
+	progressNotesSet = true
+	captureOutput(t, func() {
+		progressNotesCmd.RunE(progressNotesCmd, []string{testTask1ID, "wiped"})
+		progressNotesCmd.RunE(progressNotesCmd, []string{testTask1ID, "wiped again"})
+	})
+
+	undoSteps = 2
+	captureOutput(t, func() {
+		if err := runUndo(undoCmd, nil); err != nil {
+			t.Fatalf("undo: %v", err)
+		}
+	})
+	b, _ := board.Load(bd)
+	pd, _ := board.ParseProgressFile(b.FindByID(testTask1ID).ProgressPath())
+	if pd.Notes != "Started work" {
+		t.Errorf("notes should be restored, got %q", pd.Notes)
+	}
+	if err := runUndo(undoCmd, nil); err == nil {
+		t.Error("undo beyond the history should fail")
+	}
+}
+
+func TestUndoRefusesAfterManualEdit(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	defer func() { undoForce = false }()
+
+	captureOutput(t, func() {
+		progressAddItemCmd.RunE(progressAddItemCmd, []string{testTask2ID, "New item"})
+	})
+	b, _ := board.Load(bd)
+	path := b.FindByID(testTask2ID).ProgressPath()
+	data, _ := os.ReadFile(path)
+	os.WriteFile(path, append(data, []byte("hand edit\n")...), 0644)
+
+	err := runUndo(undoCmd, nil)
+	if err == nil || !strings.Contains(err.Error(), "changed since") {
+		t.Fatalf("expected conflict, got %v", err)
+	}
+	if entries, _ := history.List(bd); len(entries) != 1 {
+		t.Error("refused undo should keep the entry")
+	}
+
+	undoForce = true
+	captureOutput(t, func() {
+		if err := runUndo(undoCmd, nil); err != nil {
+			t.Fatalf("undo --force: %v", err)
+		}
+	})
+	b, _ = board.Load(bd)
+	if n := len(b.FindByID(testTask2ID).Checklist); n != 0 {
+		t.Errorf("forced undo should remove the item, got %d items", n)
+	}
+}
@@ -25,21 +25,40 @@ type UnlinkUpdate struct {
 var unlinkCmd = &cobra.Command{
 	Use:   "unlink <ID>",
 	Short: "Remove a dependency link",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runUnlink,
+	Long: `Remove a blocked-by link (--blocked-by), or another type of link with
+--type and --to, from both elements.`,
+	Args: cobra.ExactArgs(1),
+	RunE: recorded(runUnlink),
 }
 
-var unlinkBlockedBy string
+var (
+	unlinkBlockedBy string
+	unlinkType      string
+	unlinkTo        string
+)
 
 func init() {
 	rootCmd.AddCommand(unlinkCmd)
-	unlinkCmd.Flags().StringVar(&unlinkBlockedBy, "blocked-by", "", "ID of blocker to remove (required)")
-	unlinkCmd.MarkFlagRequired("blocked-by")
+	unlinkCmd.Flags().StringVar(&unlinkBlockedBy, "blocked-by", "", "ID of blocker to remove")
+	unlinkCmd.Flags().StringVar(&unlinkType, "type", "blocked-by", "Link type: blocked-by, relates-to, duplicates, caused-by, follows-up, parent-of")
+	unlinkCmd.Flags().StringVar(&unlinkTo, "to", "", "ID of the linked element")
 }
 
 func runUnlink(cmd *cobra.Command, args []string) error {
 	id := args[0]
 
+	t, blockerID, err := linkFlags(unlinkBlockedBy, unlinkType, unlinkTo)
+	if err != nil {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.ValidationError, err.Error(), nil)
+			return nil
+		}
+		return err
+	}
+	if t != "" {
+		return runTypedLink(id, blockerID, t, false)
+	}
+
 	b, err := board.Load(boardDir)
 	if err != nil {
 		if JSONEnabled() {
@@ -60,15 +79,15 @@ func runUnlink(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("element %s not found", id)
 	}
 
-	blocker := b.FindByID(unlinkBlockedBy)
+	blocker := b.FindByID(blockerID)
 	if blocker == nil {
 		if JSONEnabled() {
-			output.PrintError(os.Stderr, output.NotFound, fmt.Sprintf("blocker %s not found", unlinkBlockedBy), map[string]interface{}{
-				"id": unlinkBlockedBy,
+			output.PrintError(os.Stderr, output.NotFound, fmt.Sprintf("blocker %s not found", blockerID), map[string]interface{}{
+				"id": blockerID,
 			})
 			return nil
 		}
-		return fmt.Errorf("blocker %s not found", unlinkBlockedBy)
+		return fmt.Errorf("blocker %s not found", blockerID)
 	}
 
 	// Remove from element's BlockedBy
@@ -225,7 +244,7 @@ func deescalateDependency(b *board.Board, elem, blocker *board.Element) error {
 		return fmt.Errorf("writing progress for %s: %w", freshBlockerParent.ID(), err)
 	}
 
-	fmt.Printf("  ↳ de-escalated: %s no longer blocked by %s\n", freshElemParent.ID(), freshBlockerParent.ID())
+	infof("  ↳ de-escalated: %s no longer blocked by %s\n", freshElemParent.ID(), freshBlockerParent.ID())
 
 	// Recurse up
 	return deescalateDependency(freshBoard, freshElemParent, freshBlockerParent)
@@ -28,7 +28,7 @@ var updateCmd = &cobra.Command{
 	Use:   "update <ID>",
 	Short: "Update element README.md fields",
 	Args:  cobra.ExactArgs(1),
-	RunE:  runUpdate,
+	RunE:  recorded(runUpdate),
 }
 
 var (
@@ -3,9 +3,11 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/aagrigore/task-board/internal/board"
 	"github.com/aagrigore/task-board/internal/output"
+	"github.com/aagrigore/task-board/internal/policy"
 	"github.com/spf13/cobra"
 )
 
@@ -19,15 +21,38 @@ type ValidateResponse struct {
 // ValidateIssue represents a validation error or warning
 type ValidateIssue struct {
 	Code       string   `json:"code"`
+	Rule       string   `json:"rule"`
 	Message    string   `json:"message"`
 	ElementID  string   `json:"elementId,omitempty"`
 	ElementIDs []string `json:"elementIds,omitempty"`
 }
 
+// validateLabel is the text output tag of a built-in rule, with the end of
+// its message the tag already says.
+type validateLabel struct {
+	tag, implied string
+}
+
+// validateLabels tag the built-in rules the way validate always has; rules
+// of the policy file are tagged with their ID.
+var validateLabels = map[string]validateLabel{
+	"MISSING_README":   {"MISSING", " missing"},
+	"MISSING_PROGRESS": {"MISSING", " missing"},
+	"NAMING_ERROR":     {tag: "NAMING"},
+	"BROKEN_LINK":      {tag: "BROKEN LINK"},
+	"ORPHAN_ELEMENT":   {tag: "ORPHAN"},
+}
+
 var validateCmd = &cobra.Command{
 	Use:   "validate",
-	Short: "Validate board structure",
-	RunE:  runValidate,
+	Short: "Validate board structure and definition-of-done policies",
+	Long: `Check the board against its rules and report errors and warnings.
+
+Built-in rules check the structure: MISSING_README, MISSING_PROGRESS,
+NAMING_ERROR, BROKEN_LINK and ORPHAN_ELEMENT. The board's policy.yaml adds
+definition-of-done rules, checked for each element in its current status,
+and can change the severity of a built-in rule (error, warn or off).`,
+	RunE: runValidate,
 }
 
 func init() {
@@ -43,96 +68,39 @@ func runValidate(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("loading board: %w", err)
 	}
 
+	p, err := policy.Load(b)
+	if err != nil {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.ValidationError, err.Error(), nil)
+			return nil
+		}
+		return err
+	}
+
 	var errors []ValidateIssue
 	var warnings []ValidateIssue
 
-	for _, e := range b.Elements {
-		// Check README.md exists
-		if _, err := os.Stat(e.ReadmePath()); os.IsNotExist(err) {
-			issue := ValidateIssue{
-				Code:      "MISSING_README",
-				Message:   fmt.Sprintf("%s: README.md missing", e.ID()),
-				ElementID: e.ID(),
-			}
-			errors = append(errors, issue)
-			if !JSONEnabled() {
-				fmt.Printf("%s[MISSING]%s %s: README.md\n", output.Red, output.Reset, e.ID())
-			}
+	for _, v := range p.Validate(b) {
+		issue := ValidateIssue{
+			Code:       v.Code,
+			Rule:       v.Rule,
+			Message:    v.Message,
+			ElementID:  v.ElementID,
+			ElementIDs: v.ElementIDs,
 		}
-
-		// Check progress.md exists
-		if _, err := os.Stat(e.ProgressPath()); os.IsNotExist(err) {
-			issue := ValidateIssue{
-				Code:      "MISSING_PROGRESS",
-				Message:   fmt.Sprintf("%s: progress.md missing", e.ID()),
-				ElementID: e.ID(),
-			}
+		color := output.Yellow
+		if v.Severity == policy.SeverityError {
 			errors = append(errors, issue)
-			if !JSONEnabled() {
-				fmt.Printf("%s[MISSING]%s %s: progress.md\n", output.Red, output.Reset, e.ID())
-			}
-		}
-
-		// Validate naming convention
-		_, _, _, err := board.ParseDirName(e.DirName())
-		if err != nil {
-			issue := ValidateIssue{
-				Code:      "NAMING_ERROR",
-				Message:   fmt.Sprintf("%s: %v", e.ID(), err),
-				ElementID: e.ID(),
-			}
+			color = output.Red
+		} else {
 			warnings = append(warnings, issue)
-			if !JSONEnabled() {
-				fmt.Printf("%s[NAMING]%s %s: %v\n", output.Yellow, output.Reset, e.ID(), err)
-			}
 		}
-
-		// Validate blockedBy references
-		for _, blockerID := range e.BlockedBy {
-			if blockerID == "(none)" {
-				continue
-			}
-			if b.FindByID(blockerID) == nil {
-				issue := ValidateIssue{
-					Code:       "BROKEN_LINK",
-					Message:    fmt.Sprintf("%s: blockedBy %s (not found)", e.ID(), blockerID),
-					ElementID:  e.ID(),
-					ElementIDs: []string{e.ID(), blockerID},
-				}
-				errors = append(errors, issue)
-				if !JSONEnabled() {
-					fmt.Printf("%s[BROKEN LINK]%s %s: blockedBy %s (not found)\n",
-						output.Red, output.Reset, e.ID(), blockerID)
-				}
-			}
-		}
-
-		// Check orphans
-		switch e.Type {
-		case board.StoryType:
-			if e.ParentID == "" {
-				issue := ValidateIssue{
-					Code:      "ORPHAN_ELEMENT",
-					Message:   fmt.Sprintf("%s: story without epic", e.ID()),
-					ElementID: e.ID(),
-				}
-				warnings = append(warnings, issue)
-				if !JSONEnabled() {
-					fmt.Printf("%s[ORPHAN]%s %s: story without epic\n", output.Yellow, output.Reset, e.ID())
-				}
-			}
-		case board.TaskType, board.BugType:
-			if e.ParentID == "" {
-				issue := ValidateIssue{
-					Code:      "ORPHAN_ELEMENT",
-					Message:   fmt.Sprintf("%s: %s without story", e.ID(), e.Type),
-					ElementID: e.ID(),
-				}
-				warnings = append(warnings, issue)
-				if !JSONEnabled() {
-					fmt.Printf("%s[ORPHAN]%s %s: %s without story\n", output.Yellow, output.Reset, e.ID(), e.Type)
-				}
+		if !JSONEnabled() {
+			label, ok := validateLabels[v.Code]
+			if !ok {
+				label.tag = v.Rule
 			}
+			fmt.Printf("%s[%s]%s %s\n", color, label.tag, output.Reset, strings.TrimSuffix(v.Message, label.implied))
 		}
 	}
 
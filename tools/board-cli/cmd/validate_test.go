@@ -1,9 +1,15 @@
 package cmd
 
 import (
+	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/aagrigore/task-board/internal/board"
+	"github.com/aagrigore/task-board/internal/output"
+	"github.com/aagrigore/task-board/internal/policy"
 )
 
 func TestValidateCleanBoard(t *testing.T) {
@@ -21,13 +27,19 @@ func TestValidateMissingReadme(t *testing.T) {
 	boardDir = bd
 
 	// Remove a README.md
-	readmePath := filepath.Join(bd, "EPIC-01_recording", "STORY-01_audio-capture", "TASK-03_tests", "README.md")
-	os.Remove(readmePath)
+	b, _ := board.Load(bd)
+	if err := os.Remove(b.FindByID(testTask3ID).ReadmePath()); err != nil {
+		t.Fatal(err)
+	}
 
 	// Should not error, just report issues
-	err := runValidate(validateCmd, nil)
-	if err != nil {
-		t.Fatalf("runValidate: %v", err)
+	stdout := captureOutput(t, func() {
+		if err := runValidate(validateCmd, nil); err != nil {
+			t.Fatalf("runValidate: %v", err)
+		}
+	})
+	if !strings.Contains(stdout, "[MISSING]"+output.Reset+" "+testTask3ID+": README.md\n") {
+		t.Errorf("expected the missing README line, got:\n%s", stdout)
 	}
 }
 
@@ -45,3 +57,125 @@ func TestValidateBrokenLink(t *testing.T) {
 		t.Fatalf("runValidate: %v", err)
 	}
 }
+
+func TestPolicyGatesStatus(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	os.WriteFile(filepath.Join(bd, policy.File), []byte(`rules:
+  - id: checklist-before-review
+    types: [task]
+    on: [to-review]
+    check: checklist-done
+  - id: estimate-before-review
+    on: [to-review]
+    check: estimate
+    severity: warn
+`), 0644)
+
+	b, _ := board.Load(bd)
+	elem := b.FindByID(testTask3ID)
+	pd, _ := board.ParseProgressFile(elem.ProgressPath())
+	pd.Checklist = []board.ChecklistItem{{Text: "Write tests"}}
+	board.WriteProgressFile(elem.ProgressPath(), pd)
+
+	err := runProgressStatus(progressStatusCmd, []string{testTask3ID, "to-review"})
+	if err == nil || !strings.Contains(err.Error(), "1 unchecked checklist item(s) (checklist-before-review)") {
+		t.Fatalf("to-review with an open checklist: %v", err)
+	}
+
+	jsonOutput = true
+	defer func() { jsonOutput = false }()
+	stderr := captureStderr(t, func() {
+		if err := runProgressStatus(progressStatusCmd, []string{testTask3ID, "to-review"}); err != nil {
+			t.Fatal(err)
+		}
+	})
+	var resp output.JSONError
+	if err := json.Unmarshal([]byte(stderr), &resp); err != nil {
+		t.Fatalf("error JSON: %v\n%s", err, stderr)
+	}
+	if resp.Error.Code != output.PolicyViolation || !strings.Contains(stderr, `"rule": "checklist-before-review"`) {
+		t.Errorf("error = %s", stderr)
+	}
+
+	// Warnings come with the change
+	pd.Checklist[0].Checked = true
+	board.WriteProgressFile(elem.ProgressPath(), pd)
+	stdout := captureOutput(t, func() {
+		if err := runProgressStatus(progressStatusCmd, []string{testTask3ID, "to-review"}); err != nil {
+			t.Fatal(err)
+		}
+	})
+	var response ProgressResponse
+	if err := json.Unmarshal([]byte(stdout), &response); err != nil {
+		t.Fatalf("response JSON: %v\n%s", err, stdout)
+	}
+	if response.Updated.Status != "to-review" || len(response.Warnings) != 1 || response.Warnings[0].Rule != "estimate-before-review" {
+		t.Errorf("response = %+v", response)
+	}
+}
+
+func TestValidatePolicyJSON(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	os.WriteFile(filepath.Join(bd, policy.File), []byte(`rules:
+  - id: stories-have-tasks
+    types: [story]
+    check: children
+    child-types: [task]
+  - id: BROKEN_LINK
+    severity: warn
+`), 0644)
+	b, _ := board.Load(bd)
+	elem := b.FindByID(testTask3ID)
+	pd, _ := board.ParseProgressFile(elem.ProgressPath())
+	pd.BlockedBy = []string{"TASK-999"}
+	board.WriteProgressFile(elem.ProgressPath(), pd)
+
+	jsonOutput = true
+	defer func() { jsonOutput = false }()
+	stdout := captureOutput(t, func() {
+		if err := runValidate(validateCmd, nil); err != nil {
+			t.Fatal(err)
+		}
+	})
+	var response ValidateResponse
+	if err := json.Unmarshal([]byte(stdout), &response); err != nil {
+		t.Fatalf("validate JSON: %v\n%s", err, stdout)
+	}
+	if response.Valid || len(response.Errors) != 1 {
+		t.Fatalf("errors = %+v", response.Errors)
+	}
+	if e := response.Errors[0]; e.Rule != "stories-have-tasks" || e.Code != "POLICY_VIOLATION" || e.ElementID != testStory2ID {
+		t.Errorf("error = %+v", e)
+	}
+	if len(response.Warnings) != 1 || response.Warnings[0].Rule != "BROKEN_LINK" {
+		t.Errorf("warnings = %+v", response.Warnings)
+	}
+}
+
+func TestPolicyGatesAutoPromotion(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	os.WriteFile(filepath.Join(bd, policy.File), []byte(`rules:
+  - id: story-notes
+    types: [story]
+    on: [done]
+    check: section
+    section: Release Notes
+`), 0644)
+	passReviewForTest(t, bd, testTask4ID, testStory3ID, testEpic2ID)
+
+	stdout := captureOutput(t, func() {
+		if err := runProgressStatus(progressStatusCmd, []string{testTask4ID, "done"}); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if !strings.Contains(stdout, testStory3ID+" not promoted: cannot set to done — README has no Release Notes section (story-notes)") {
+		t.Errorf("output = %q", stdout)
+	}
+	b, _ := board.Load(bd)
+	if s := b.FindByID(testStory3ID).Status; s == board.StatusDone {
+		t.Errorf("story promoted to done against an error rule")
+	}
+}
@@ -0,0 +1,229 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/aagrigore/task-board/internal/output"
+	"github.com/aagrigore/task-board/query"
+	"github.com/spf13/cobra"
+)
+
+// ViewListResponse is the JSON response for view list
+type ViewListResponse struct {
+	Views []query.View `json:"views"`
+	Count int          `json:"count"`
+}
+
+// ViewResponse is the JSON response for view show/save/delete
+type ViewResponse struct {
+	View    query.View `json:"view"`
+	Message string     `json:"message,omitempty"`
+}
+
+var viewCmd = &cobra.Command{
+	Use:   "view",
+	Short: "Manage saved views (named queries)",
+	Long: `Manage saved views stored in .task-board/views.yaml.
+
+A view is a named query usable as 'task-board list --view <name>'
+and as '/view <name>' in the TUI.`,
+}
+
+var viewListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List saved views",
+	Args:  cobra.NoArgs,
+	RunE:  runViewList,
+}
+
+var viewShowCmd = &cobra.Command{
+	Use:   "show <name>",
+	Short: "Show a saved view",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runViewShow,
+}
+
+var viewSaveCmd = &cobra.Command{
+	Use:   "save <name> <query>",
+	Short: "Save or replace a view",
+	Example: `  task-board view save mine "assignee:alice -status:done,closed"
+  task-board view save stale "updated:>7d type:task" --description "Untouched for a week"`,
+	Args: cobra.ExactArgs(2),
+	RunE: recorded(runViewSave),
+}
+
+var viewDeleteCmd = &cobra.Command{
+	Use:   "delete <name>",
+	Short: "Delete a saved view",
+	Args:  cobra.ExactArgs(1),
+	RunE:  recorded(runViewDelete),
+}
+
+var viewDescription string
+
+func init() {
+	rootCmd.AddCommand(viewCmd)
+	viewCmd.AddCommand(viewListCmd)
+	viewCmd.AddCommand(viewShowCmd)
+	viewCmd.AddCommand(viewSaveCmd)
+	viewCmd.AddCommand(viewDeleteCmd)
+	viewSaveCmd.Flags().StringVar(&viewDescription, "description", "", "Short description of the view")
+}
+
+func runViewList(cmd *cobra.Command, args []string) error {
+	views, err := query.LoadViews(boardDir)
+	if err != nil {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.InternalError, err.Error(), nil)
+			return nil
+		}
+		return err
+	}
+
+	if JSONEnabled() {
+		if views == nil {
+			views = []query.View{}
+		}
+		return output.PrintJSON(os.Stdout, ViewListResponse{Views: views, Count: len(views)})
+	}
+
+	if len(views) == 0 {
+		fmt.Println("No saved views.")
+		return nil
+	}
+	table := output.NewTable("NAME", "QUERY", "DESCRIPTION")
+	for _, v := range views {
+		table.AddRow(v.Name, v.Query, v.Description)
+	}
+	fmt.Print(table.String())
+	return nil
+}
+
+func runViewShow(cmd *cobra.Command, args []string) error {
+	views, err := query.LoadViews(boardDir)
+	if err != nil {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.InternalError, err.Error(), nil)
+			return nil
+		}
+		return err
+	}
+
+	v := query.FindView(views, args[0])
+	if v == nil {
+		return viewNotFound(args[0])
+	}
+
+	if JSONEnabled() {
+		return output.PrintJSON(os.Stdout, ViewResponse{View: *v})
+	}
+	fmt.Printf("%s: %s\n", v.Name, v.Query)
+	if v.Description != "" {
+		fmt.Println(v.Description)
+	}
+	return nil
+}
+
+func runViewSave(cmd *cobra.Command, args []string) error {
+	name, text := args[0], args[1]
+
+	if !query.ValidViewName(name) {
+		msg := fmt.Sprintf("invalid view name %q: use letters, digits, '-', '_' or '.'", name)
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.ValidationError, msg, map[string]interface{}{"view": name})
+			return nil
+		}
+		return fmt.Errorf("%s", msg)
+	}
+
+	if _, err := query.Parse(text); err != nil {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.ValidationError, fmt.Sprintf("invalid query: %v", err), map[string]interface{}{
+				"query": text,
+			})
+			return nil
+		}
+		return fmt.Errorf("invalid query: %w", err)
+	}
+
+	views, err := query.LoadViews(boardDir)
+	if err != nil {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.InternalError, err.Error(), nil)
+			return nil
+		}
+		return err
+	}
+
+	view := query.View{Name: name, Query: text, Description: viewDescription}
+	message := fmt.Sprintf("Saved view %s", name)
+	if existing := query.FindView(views, name); existing != nil {
+		*existing = view
+		message = fmt.Sprintf("Updated view %s", name)
+	} else {
+		views = append(views, view)
+	}
+
+	if err := query.SaveViews(boardDir, views); err != nil {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.InternalError, err.Error(), nil)
+			return nil
+		}
+		return err
+	}
+
+	if JSONEnabled() {
+		return output.PrintJSON(os.Stdout, ViewResponse{View: view, Message: message})
+	}
+	fmt.Println(message)
+	return nil
+}
+
+func runViewDelete(cmd *cobra.Command, args []string) error {
+	views, err := query.LoadViews(boardDir)
+	if err != nil {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.InternalError, err.Error(), nil)
+			return nil
+		}
+		return err
+	}
+
+	v := query.FindView(views, args[0])
+	if v == nil {
+		return viewNotFound(args[0])
+	}
+	deleted := *v
+
+	kept := make([]query.View, 0, len(views)-1)
+	for _, existing := range views {
+		if existing.Name != deleted.Name {
+			kept = append(kept, existing)
+		}
+	}
+	if err := query.SaveViews(boardDir, kept); err != nil {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.InternalError, err.Error(), nil)
+			return nil
+		}
+		return err
+	}
+
+	message := fmt.Sprintf("Deleted view %s", deleted.Name)
+	if JSONEnabled() {
+		return output.PrintJSON(os.Stdout, ViewResponse{View: deleted, Message: message})
+	}
+	fmt.Println(message)
+	return nil
+}
+
+func viewNotFound(name string) error {
+	if JSONEnabled() {
+		output.PrintError(os.Stderr, output.NotFound, fmt.Sprintf("view %s not found", name), map[string]interface{}{
+			"view": name,
+		})
+		return nil
+	}
+	return fmt.Errorf("view %s not found", name)
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aagrigore/task-board/query"
+)
+
+func TestViewSaveListDelete(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	viewDescription = "My work"
+	defer func() { viewDescription = "" }()
+
+	captureOutput(t, func() {
+		if err := runViewSave(viewSaveCmd, []string{"mine", "assignee:alice -status:done"}); err != nil {
+			t.Fatalf("runViewSave: %v", err)
+		}
+	})
+
+	views, err := query.LoadViews(bd)
+	if err != nil {
+		t.Fatalf("LoadViews: %v", err)
+	}
+	if len(views) != 1 || views[0].Query != "assignee:alice -status:done" || views[0].Description != "My work" {
+		t.Fatalf("unexpected views after save: %+v", views)
+	}
+
+	// Saving again under the same name replaces the query
+	viewDescription = ""
+	captureOutput(t, func() {
+		if err := runViewSave(viewSaveCmd, []string{"mine", "assignee:bob"}); err != nil {
+			t.Fatalf("runViewSave: %v", err)
+		}
+	})
+	views, _ = query.LoadViews(bd)
+	if len(views) != 1 || views[0].Query != "assignee:bob" {
+		t.Fatalf("expected replaced view, got %+v", views)
+	}
+
+	out := captureOutput(t, func() {
+		if err := runViewList(viewListCmd, nil); err != nil {
+			t.Fatalf("runViewList: %v", err)
+		}
+	})
+	if !strings.Contains(out, "mine") || !strings.Contains(out, "assignee:bob") {
+		t.Errorf("view list output missing view:\n%s", out)
+	}
+
+	captureOutput(t, func() {
+		if err := runViewDelete(viewDeleteCmd, []string{"MINE"}); err != nil {
+			t.Fatalf("runViewDelete: %v", err)
+		}
+	})
+	views, _ = query.LoadViews(bd)
+	if len(views) != 0 {
+		t.Errorf("expected no views after delete, got %+v", views)
+	}
+}
+
+func TestViewSaveRejectsInvalidQuery(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+
+	if err := runViewSave(viewSaveCmd, []string{"broken", "status:"}); err == nil {
+		t.Fatal("expected error for invalid query")
+	}
+	if err := runViewSave(viewSaveCmd, []string{"bad name", "status:done"}); err == nil {
+		t.Fatal("expected error for invalid view name")
+	}
+	if views, _ := query.LoadViews(bd); len(views) != 0 {
+		t.Errorf("invalid views must not be saved: %+v", views)
+	}
+}
+
+func TestViewShowNotFound(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+
+	if err := runViewShow(viewShowCmd, []string{"nope"}); err == nil {
+		t.Fatal("expected error for unknown view")
+	}
+}
@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/aagrigore/task-board/internal/board"
+	"github.com/aagrigore/task-board/internal/output"
+	"github.com/aagrigore/task-board/internal/wip"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// WorkflowResponse is the JSON response for workflow show
+type WorkflowResponse struct {
+	Source    string                 `json:"source"`
+	Types     map[string]*board.Flow `json:"types"`
+	WIPLimits board.WIPLimits        `json:"wipLimits"`
+	// TimeTracking lists the statuses that run a timer automatically
+	TimeTracking board.TimeTracking `json:"timeTracking"`
+}
+
+// WorkflowInitResponse is the JSON response for workflow init
+type WorkflowInitResponse struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+var workflowCmd = &cobra.Command{
+	Use:   "workflow",
+	Short: "Show or initialise the statuses of each element type",
+	Long: `Statuses, their categories (todo, active, done, closed, blocked), allowed
+transitions and colours come from .task-board/workflow.yaml. Without the
+file every type uses the default workflow:
+
+  backlog → analysis → to-dev → development → to-review → reviewing → done
+  plus closed and blocked
+
+The top-level keys apply to every type; a section under 'types' overrides
+the keys it sets for that type. A section that lists its own statuses
+starts afresh instead of inheriting transitions and initial/promote-to/
+reopen-to.`,
+}
+
+var workflowShowCmd = &cobra.Command{
+	Use:   "show [type]",
+	Short: "Show the workflow of every type, or of one type",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runWorkflowShow,
+}
+
+var workflowInitCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Write the default workflow to .task-board/workflow.yaml for editing",
+	Args:  cobra.NoArgs,
+	RunE:  recorded(runWorkflowInit),
+}
+
+func init() {
+	rootCmd.AddCommand(workflowCmd)
+	workflowCmd.AddCommand(workflowShowCmd)
+	workflowCmd.AddCommand(workflowInitCmd)
+}
+
+func runWorkflowShow(cmd *cobra.Command, args []string) error {
+	types := board.AllTypes
+	if len(args) == 1 {
+		t, err := board.ParseElementType(args[0])
+		if err != nil {
+			if JSONEnabled() {
+				output.PrintError(os.Stderr, output.ValidationError, err.Error(), nil)
+				return nil
+			}
+			return err
+		}
+		types = []board.ElementType{t}
+	}
+
+	b, err := loadViewBoard()
+	if err != nil {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.InternalError, fmt.Sprintf("loading board: %v", err), nil)
+			return nil
+		}
+		return fmt.Errorf("loading board: %w", err)
+	}
+	source := "default"
+	if b.Workflow != nil && b.Workflow.Path != "" {
+		source = b.Workflow.Path
+	}
+
+	if JSONEnabled() {
+		response := WorkflowResponse{Source: source, Types: map[string]*board.Flow{}, WIPLimits: b.Workflow.Limits()}
+		if b.Workflow != nil {
+			response.TimeTracking = b.Workflow.TimeTracking
+		}
+		for _, t := range types {
+			response.Types[string(t)] = b.Flow(t)
+		}
+		return output.PrintJSON(os.Stdout, response)
+	}
+
+	fmt.Printf("Workflow: %s\n", source)
+	for _, t := range types {
+		flow := b.Flow(t)
+		reopen := string(flow.ReopenTo)
+		if reopen == "" {
+			reopen = "never"
+		}
+		fmt.Println()
+		fmt.Printf("%s%s%s (new: %s, promote to: %s, reopen to: %s)\n", output.Bold, t, output.Reset,
+			flow.Initial, flow.PromoteTo, reopen)
+		table := output.NewTable("STATUS", "CATEGORY", "ALIASES", "NEEDS UNBLOCKED", "NEXT")
+		for _, s := range flow.Statuses {
+			unblocked := ""
+			if s.NeedsUnblocked {
+				unblocked = "yes"
+			}
+			next := "any"
+			if targets, ok := flow.Transitions[s.Name]; ok {
+				next = joinStatuses(targets)
+			}
+			table.AddRow(string(s.Name), string(s.Category), strings.Join(s.Aliases, ", "), unblocked, next)
+		}
+		fmt.Print(table.String())
+	}
+
+	if usages := wip.Usages(b); len(usages) > 0 {
+		fmt.Println()
+		fmt.Println(output.Bold + "WIP limits" + output.Reset)
+		for _, u := range usages {
+			fmt.Printf("  %-24s %d/%d\n", wipLabel(u), u.Count, u.Limit)
+		}
+	}
+
+	if b.Workflow != nil && len(b.Workflow.TimeTracking.Auto) > 0 {
+		fmt.Println()
+		fmt.Printf("%sTime tracking%s: timer runs in %s\n", output.Bold, output.Reset, joinStatuses(b.Workflow.TimeTracking.Auto))
+	}
+	return nil
+}
+
+func runWorkflowInit(cmd *cobra.Command, args []string) error {
+	path := board.WorkflowPath(boardDir)
+	if _, err := os.Stat(path); err == nil {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.ValidationError, fmt.Sprintf("%s already exists", path), map[string]interface{}{
+				"path": path,
+			})
+			return nil
+		}
+		return fmt.Errorf("%s already exists", path)
+	}
+	if err := board.EnsureBoardDir(boardDir); err != nil {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.InternalError, err.Error(), nil)
+			return nil
+		}
+		return err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("# Statuses of every element type; add a 'types:' section to override\n")
+	buf.WriteString("# them per type. See 'task-board workflow --help'.\n")
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	err := enc.Encode(board.DefaultWorkflow())
+	if err == nil {
+		err = os.WriteFile(path, buf.Bytes(), 0644)
+	}
+	if err != nil {
+		if JSONEnabled() {
+			output.PrintError(os.Stderr, output.InternalError, fmt.Sprintf("writing workflow: %v", err), nil)
+			return nil
+		}
+		return fmt.Errorf("writing workflow: %w", err)
+	}
+
+	if JSONEnabled() {
+		return output.PrintJSON(os.Stdout, WorkflowInitResponse{Path: path, Message: "Default workflow written"})
+	}
+	fmt.Printf("Default workflow written to %s\n", path)
+	return nil
+}
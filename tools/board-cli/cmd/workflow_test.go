@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aagrigore/task-board/internal/board"
+)
+
+const testWorkflow = `transitions:
+  backlog: [to-dev, closed]
+types:
+  story:
+    statuses:
+      - {name: open, category: todo}
+      - {name: building, category: active}
+      - {name: accepted, category: done}
+  bug:
+    statuses:
+      - {name: triage, category: todo}
+      - {name: fixing, category: active, aliases: [fix]}
+      - {name: verified, category: done}
+`
+
+func TestWorkflowTransitionsAndPromotion(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	os.WriteFile(filepath.Join(bd, board.WorkflowFile), []byte(testWorkflow), 0644)
+
+	err := runProgressStatus(progressStatusCmd, []string{testTask4ID, "development"})
+	if err == nil || !strings.Contains(err.Error(), "allowed: to-dev, closed") {
+		t.Fatalf("backlog → development should be refused, got %v", err)
+	}
+	if err := runProgressStatus(progressStatusCmd, []string{testBug1ID, "done"}); err == nil {
+		t.Fatal("done is not a bug status")
+	}
+
+	passReviewForTest(t, bd, testTask4ID, testStory3ID, testEpic2ID)
+	captureOutput(t, func() {
+		for _, status := range []string{"to-dev", "development", "done"} {
+			if err := runProgressStatus(progressStatusCmd, []string{testTask4ID, status}); err != nil {
+				t.Fatalf("%s: %v", status, err)
+			}
+		}
+	})
+	b, _ := board.Load(bd)
+	if s := b.FindByID(testStory3ID).Status; s != "accepted" {
+		t.Errorf("story should be promoted to its workflow's done status, got %s", s)
+	}
+	if s := b.FindByID(testEpic2ID).Status; s != board.StatusDone {
+		t.Errorf("epic should be promoted to done, got %s", s)
+	}
+
+	captureOutput(t, func() {
+		if err := runProgressStatus(progressStatusCmd, []string{testTask4ID, "blocked"}); err != nil {
+			t.Fatal(err)
+		}
+		if err := runProgressStatus(progressStatusCmd, []string{testTask4ID, "to-dev"}); err != nil {
+			t.Fatal(err)
+		}
+	})
+	b, _ = board.Load(bd)
+	if s := b.FindByID(testStory3ID).Status; s != "building" {
+		t.Errorf("story should be reopened to its first active status, got %s", s)
+	}
+}
+
+func TestWorkflowSummaryBuckets(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	os.WriteFile(filepath.Join(bd, board.WorkflowFile), []byte(testWorkflow), 0644)
+	captureOutput(t, func() {
+		runProgressStatus(progressStatusCmd, []string{testBug1ID, "fix"})
+	})
+
+	jsonOutput = true
+	defer func() { jsonOutput = false }()
+	out := captureOutput(t, func() {
+		if err := runSummary(summaryCmd, nil); err != nil {
+			t.Fatal(err)
+		}
+	})
+	var resp SummaryResponse
+	if err := json.Unmarshal([]byte(out), &resp); err != nil {
+		t.Fatalf("parse: %v\n%s", err, out)
+	}
+	if bugs := resp.Summary.ByType["bug"]; bugs.Active != 1 || bugs.Todo != 0 {
+		t.Errorf("fixing should count as active, got %+v", bugs)
+	}
+}
+
+func TestWorkflowInvalidFile(t *testing.T) {
+	bd := setupTestBoard(t)
+	os.WriteFile(filepath.Join(bd, board.WorkflowFile), []byte("types:\n  bug:\n    statuses:\n      - {name: new, category: todo}\n"), 0644)
+	if _, err := board.Load(bd); err == nil || !strings.Contains(err.Error(), "done category") {
+		t.Errorf("a flow without a done status should be rejected, got %v", err)
+	}
+}
+
+const testWIPWorkflow = `wip-limits:
+  status:
+    development: 1
+  assignee: 1
+`
+
+func TestWIPLimitOnStatus(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	os.WriteFile(filepath.Join(bd, board.WorkflowFile), []byte(testWIPWorkflow), 0644)
+
+	captureOutput(t, func() {
+		if err := runProgressStatus(progressStatusCmd, []string{testTask1ID, "development"}); err != nil {
+			t.Fatal(err)
+		}
+	})
+	err := runProgressStatus(progressStatusCmd, []string{testTask3ID, "development"})
+	if err == nil || !strings.Contains(err.Error(), "development WIP limit 1 (would be 2)") {
+		t.Fatalf("a second task in development should exceed the limit, got %v", err)
+	}
+
+	jsonOutput = true
+	stderr := captureStderr(t, func() {
+		runProgressStatus(progressStatusCmd, []string{testTask3ID, "development"})
+	})
+	jsonOutput = false
+	if !strings.Contains(stderr, `"code": "WIP_LIMIT"`) {
+		t.Errorf("expected a WIP_LIMIT error, got %s", stderr)
+	}
+
+	progressStatusForce = true
+	defer func() { progressStatusForce = false }()
+	captureOutput(t, func() {
+		if err := runProgressStatus(progressStatusCmd, []string{testTask3ID, "development"}); err != nil {
+			t.Fatalf("--force should exceed the limit: %v", err)
+		}
+	})
+	b, _ := board.Load(bd)
+	if s := b.FindByID(testTask3ID).Status; s != board.StatusDevelopment {
+		t.Errorf("status = %s, want development", s)
+	}
+}
+
+func TestWIPLimitOnAssignee(t *testing.T) {
+	bd := setupTestBoard(t)
+	boardDir = bd
+	os.WriteFile(filepath.Join(bd, board.WorkflowFile), []byte(testWIPWorkflow), 0644)
+
+	assignAgent = "alice"
+	defer func() { assignAgent = "" }()
+	captureOutput(t, func() {
+		if err := runAssign(assignCmd, []string{testTask1ID}); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if err := runAssign(assignCmd, []string{testTask3ID}); err == nil || !strings.Contains(err.Error(), "@alice WIP limit 1 (would be 2)") {
+		t.Fatalf("a second assignment should exceed alice's limit, got %v", err)
+	}
+	captureOutput(t, func() {
+		if err := runAssign(assignCmd, []string{testTask1ID}); err != nil {
+			t.Errorf("reassigning the same element should not count twice: %v", err)
+		}
+	})
+
+	jsonOutput = true
+	defer func() { jsonOutput = false }()
+	out := captureOutput(t, func() {
+		if err := runSummary(summaryCmd, nil); err != nil {
+			t.Fatal(err)
+		}
+	})
+	var resp SummaryResponse
+	if err := json.Unmarshal([]byte(out), &resp); err != nil {
+		t.Fatalf("parse: %v\n%s", err, out)
+	}
+	var found bool
+	for _, u := range resp.Summary.WIP {
+		if u.Key == "alice" && u.Count == 1 && u.Limit == 1 {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("summary should report alice's load, got %+v", resp.Summary.WIP)
+	}
+}
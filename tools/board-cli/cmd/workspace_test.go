@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aagrigore/task-board/internal/board"
+)
+
+// setupTestWorkspace creates two copies of the test board named "api" and "web"
+// and a workspace file listing both. Returns the workspace file path.
+func setupTestWorkspace(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	apiDir := setupTestBoard(t)
+	webDir := setupTestBoard(t)
+	ws := filepath.Join(root, "workspace.yaml")
+	content := "boards:\n  - name: api\n    path: " + apiDir + "\n  - name: web\n    path: " + webDir + "\n"
+	if err := os.WriteFile(ws, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return ws
+}
+
+func TestWorkspaceListQualifiesIDs(t *testing.T) {
+	workspaceFile = setupTestWorkspace(t)
+	defer func() { workspaceFile = "" }()
+	listStatus, listEpic, listStory = "", "", ""
+
+	out := captureOutput(t, func() {
+		if err := runList(listCmd, []string{"epics"}); err != nil {
+			t.Fatalf("runList: %v", err)
+		}
+	})
+
+	for _, want := range []string{"api:" + testEpic1ID, "web:" + testEpic1ID} {
+		if !strings.Contains(out, want) {
+			t.Errorf("list output missing %s:\n%s", want, out)
+		}
+	}
+}
+
+func TestWorkspaceLinkCrossBoard(t *testing.T) {
+	workspaceFile = setupTestWorkspace(t)
+	defer func() { workspaceFile = "" }()
+	linkBlockedBy = "api:" + testTask1ID
+
+	captureOutput(t, func() {
+		if err := runLink(linkCmd, []string{"web:" + testTask3ID}); err != nil {
+			t.Fatalf("runLink: %v", err)
+		}
+	})
+
+	ws, err := board.LoadWorkspace(workspaceFile)
+	if err != nil {
+		t.Fatalf("LoadWorkspace: %v", err)
+	}
+	task3, webBoard := ws.FindByID("web:" + testTask3ID)
+	if len(task3.BlockedBy) != 1 || task3.BlockedBy[0] != "api:"+testTask1ID {
+		t.Errorf("web task blockedBy = %v, want qualified api ref", task3.BlockedBy)
+	}
+	task1, _ := ws.FindByID("api:" + testTask1ID)
+	found := false
+	for _, id := range task1.Blocks {
+		if id == "web:"+testTask3ID {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("api task blocks = %v, want web:%s", task1.Blocks, testTask3ID)
+	}
+
+	if active := ws.ActiveBlockers(webBoard, task3); len(active) != 1 {
+		t.Errorf("expected one active cross-board blocker, got %d", len(active))
+	}
+
+	// The web board on its own must remain valid despite the foreign reference
+	workspaceFile = ""
+	boardDir = webBoard.Board.Dir
+	jsonOutput = true
+	defer func() { jsonOutput = false }()
+	out := captureOutput(t, func() {
+		if err := runValidate(validateCmd, nil); err != nil {
+			t.Fatalf("runValidate: %v", err)
+		}
+	})
+	if strings.Contains(out, "BROKEN_LINK") {
+		t.Errorf("validate reported cross-board ref as broken:\n%s", out)
+	}
+}
+
+func TestWorkspaceLinkRefusesCycleThroughOtherBoard(t *testing.T) {
+	workspaceFile = setupTestWorkspace(t)
+	defer func() { workspaceFile = ""; linkBlockedBy = "" }()
+
+	// api:TASK-4 waits for web:TASK-3, which waits for api:TASK-1
+	captureOutput(t, func() {
+		linkBlockedBy = "api:" + testTask1ID
+		if err := runLink(linkCmd, []string{"web:" + testTask3ID}); err != nil {
+			t.Fatalf("runLink: %v", err)
+		}
+		linkBlockedBy = "web:" + testTask3ID
+		if err := runLink(linkCmd, []string{"api:" + testTask4ID}); err != nil {
+			t.Fatalf("runLink: %v", err)
+		}
+	})
+
+	// Both ends are on api, but the cycle runs through web
+	linkBlockedBy = "api:" + testTask4ID
+	err := runLink(linkCmd, []string{"api:" + testTask1ID})
+	if err == nil || !strings.Contains(err.Error(), "dependency cycle") || !strings.Contains(err.Error(), "web:"+testTask3ID) {
+		t.Fatalf("expected a cycle through web, got %v", err)
+	}
+	ws, _ := board.LoadWorkspace(workspaceFile)
+	if task1, _ := ws.FindByID("api:" + testTask1ID); containsRef(task1.BlockedBy, testTask4ID) {
+		t.Error("the refused link should not be written")
+	}
+}
+
+func TestWorkspaceLinkSameBoardDryRun(t *testing.T) {
+	workspaceFile = setupTestWorkspace(t)
+	linkDryRun = true
+	defer func() { workspaceFile = ""; linkBlockedBy = ""; linkDryRun = false }()
+
+	linkBlockedBy = "api:" + testTask1ID
+	out := captureOutput(t, func() {
+		if err := runLink(linkCmd, []string{"api:" + testTask3ID}); err != nil {
+			t.Fatalf("runLink: %v", err)
+		}
+	})
+	if !strings.Contains(out, "api:"+testTask3ID+" → blocked by api:"+testTask1ID) {
+		t.Errorf("dry run should show qualified IDs:\n%s", out)
+	}
+	ws, _ := board.LoadWorkspace(workspaceFile)
+	if task3, _ := ws.FindByID("api:" + testTask3ID); len(task3.BlockedBy) != 0 {
+		t.Errorf("dry run wrote blockedBy = %v", task3.BlockedBy)
+	}
+}
@@ -0,0 +1,219 @@
+package board
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// BlockersFile is the name of the external blockers file inside the board
+// directory.
+const BlockersFile = "blockers.yaml"
+
+// blockerPrefix starts every external blocker ID, e.g. "EXT-3".
+const blockerPrefix = "EXT-"
+
+// Blocker is something outside the board holding elements up: an external
+// API, another team, a purchase. Its elements are put in the blocked status
+// and return to their prior status when it is resolved.
+type Blocker struct {
+	ID       string           `yaml:"id"`
+	Reason   string           `yaml:"reason"`
+	Owner    string           `yaml:"owner,omitempty"`
+	Expect   time.Time        `yaml:"expect,omitempty"`
+	Created  time.Time        `yaml:"created"`
+	Resolved time.Time        `yaml:"resolved,omitempty"`
+	Elements []BlockedElement `yaml:"elements"`
+}
+
+// BlockedElement is an element held by a blocker. The status it returns
+// to is the prior status in its progress.md.
+type BlockedElement struct {
+	ID string `yaml:"id"`
+}
+
+type blockersDoc struct {
+	Blockers []*Blocker `yaml:"blockers"`
+}
+
+// Open reports whether the blocker is not resolved yet.
+func (bl *Blocker) Open() bool {
+	return bl.Resolved.IsZero()
+}
+
+// Overdue reports whether an open blocker is past its expected date.
+func (bl *Blocker) Overdue(now time.Time) bool {
+	return bl.Open() && !bl.Expect.IsZero() && now.After(bl.Expect)
+}
+
+// Holds returns the entry of an element the blocker holds, or nil.
+func (bl *Blocker) Holds(id string) *BlockedElement {
+	for i := range bl.Elements {
+		if bl.Elements[i].ID == id {
+			return &bl.Elements[i]
+		}
+	}
+	return nil
+}
+
+// BlockersPath returns the path of the blockers file for a board directory.
+func BlockersPath(boardDir string) string {
+	return filepath.Join(boardDir, BlockersFile)
+}
+
+// LoadBlockers reads the external blockers of a board, resolved ones
+// included. A missing file is not an error and yields no blockers.
+func LoadBlockers(boardDir string) ([]*Blocker, error) {
+	data, err := os.ReadFile(BlockersPath(boardDir))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading blockers: %w", err)
+	}
+	var doc blockersDoc
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", BlockersFile, err)
+	}
+	return doc.Blockers, nil
+}
+
+// SaveBlockers writes the blockers to the board directory, replacing the file.
+func SaveBlockers(boardDir string, blockers []*Blocker) error {
+	data, err := yaml.Marshal(blockersDoc{Blockers: blockers})
+	if err != nil {
+		return fmt.Errorf("encoding blockers: %w", err)
+	}
+	return os.WriteFile(BlockersPath(boardDir), data, 0644)
+}
+
+// FindBlocker returns the blocker with the given ID (case-insensitive), or nil.
+func FindBlocker(blockers []*Blocker, id string) *Blocker {
+	for _, bl := range blockers {
+		if strings.EqualFold(bl.ID, id) {
+			return bl
+		}
+	}
+	return nil
+}
+
+// NextBlockerID returns the ID after the highest one in use.
+func NextBlockerID(blockers []*Blocker) string {
+	max := 0
+	for _, bl := range blockers {
+		if n, err := strconv.Atoi(strings.TrimPrefix(strings.ToUpper(bl.ID), blockerPrefix)); err == nil && n > max {
+			max = n
+		}
+	}
+	return fmt.Sprintf("%s%d", blockerPrefix, max+1)
+}
+
+// attachBlockers sets External on the elements held by an open blocker and
+// on their ancestors, the way blocked-by escalates up the hierarchy.
+func (b *Board) attachBlockers(blockers []*Blocker) {
+	for _, bl := range blockers {
+		if !bl.Open() {
+			continue
+		}
+		for _, held := range bl.Elements {
+			for e := b.FindByID(held.ID); e != nil; e = b.ParentOf(e) {
+				if !containsBlocker(e.External, bl) {
+					e.External = append(e.External, bl)
+				}
+			}
+		}
+	}
+}
+
+func containsBlocker(blockers []*Blocker, bl *Blocker) bool {
+	for _, existing := range blockers {
+		if existing == bl {
+			return true
+		}
+	}
+	return false
+}
+
+// ExternalEdge is an open blocker holding up an element.
+type ExternalEdge struct {
+	Blocker *Blocker
+	To      string
+}
+
+// ExternalEdges lists the open blockers of elements, each pointing at the
+// lowest elements in scope it holds up: an epic in a project plan, the task
+// itself when the task is in scope. Blockers are in ID order.
+func ExternalEdges(elements []*Element) []ExternalEdge {
+	inScope := make(map[string]bool, len(elements))
+	for _, e := range elements {
+		inScope[e.ID()] = true
+	}
+	// A child in scope that is held too takes the edge
+	heldBelow := map[string]map[*Blocker]bool{}
+	for _, e := range elements {
+		if !inScope[e.ParentID] {
+			continue
+		}
+		for _, bl := range e.External {
+			if heldBelow[e.ParentID] == nil {
+				heldBelow[e.ParentID] = map[*Blocker]bool{}
+			}
+			heldBelow[e.ParentID][bl] = true
+		}
+	}
+	var edges []ExternalEdge
+	for _, e := range elements {
+		for _, bl := range e.External {
+			if !heldBelow[e.ID()][bl] {
+				edges = append(edges, ExternalEdge{Blocker: bl, To: e.ID()})
+			}
+		}
+	}
+	sort.SliceStable(edges, func(i, j int) bool {
+		return blockerLess(edges[i].Blocker.ID, edges[j].Blocker.ID)
+	})
+	return edges
+}
+
+// blockerLess orders EXT-2 before EXT-10.
+func blockerLess(a, b string) bool {
+	na, errA := strconv.Atoi(strings.TrimPrefix(a, blockerPrefix))
+	nb, errB := strconv.Atoi(strings.TrimPrefix(b, blockerPrefix))
+	if errA != nil || errB != nil {
+		return a < b
+	}
+	return na < nb
+}
+
+// OpenBlockers lists the open blockers holding up any of elements, in ID
+// order.
+func OpenBlockers(elements []*Element) []*Blocker {
+	var open []*Blocker
+	for _, e := range elements {
+		for _, bl := range e.External {
+			if !containsBlocker(open, bl) {
+				open = append(open, bl)
+			}
+		}
+	}
+	sort.SliceStable(open, func(i, j int) bool { return blockerLess(open[i].ID, open[j].ID) })
+	return open
+}
+
+// HeldBy lists the open blockers holding the element itself, not just
+// something below it.
+func (e *Element) HeldBy() []*Blocker {
+	var held []*Blocker
+	for _, bl := range e.External {
+		if bl.Holds(e.ID()) != nil {
+			held = append(held, bl)
+		}
+	}
+	return held
+}
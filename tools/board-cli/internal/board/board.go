@@ -12,6 +12,12 @@ type Board struct {
 	Dir      string
 	Counters *Counters
 	Elements []*Element
+	// Workflow defines the statuses of each element type. Nil means the
+	// default workflow.
+	Workflow *Workflow
+	// workflows holds the workflow of each element of a merged workspace
+	// board that comes from another board; see WorkflowOf.
+	workflows map[*Element]*Workflow
 }
 
 // Load reads the entire board from the given directory.
@@ -24,6 +30,12 @@ func Load(boardDir string) (*Board, error) {
 	}
 	b.Counters = counters
 
+	workflow, err := LoadWorkflow(boardDir)
+	if err != nil {
+		return nil, err
+	}
+	b.Workflow = workflow
+
 	// Walk epics
 	entries, err := os.ReadDir(boardDir)
 	if err != nil {
@@ -111,20 +123,59 @@ func Load(boardDir string) (*Board, error) {
 		}
 	}
 
+	// Resolve aliases the workflow file adds
+	for _, e := range b.Elements {
+		flow := workflow.For(e.Type)
+		e.Status = flow.Normalize(e.Status)
+		for i := range e.History {
+			e.History[i].From = flow.Normalize(e.History[i].From)
+			e.History[i].To = flow.Normalize(e.History[i].To)
+		}
+	}
+
+	blockers, err := LoadBlockers(boardDir)
+	if err != nil {
+		return nil, err
+	}
+	b.attachBlockers(blockers)
+
 	return b, nil
 }
 
+// Flow returns the workflow of an element type.
+func (b *Board) Flow(t ElementType) *Flow {
+	return b.Workflow.For(t)
+}
+
+// WorkflowOf returns the workflow that judges e's status: that of the
+// board it comes from on a merged workspace board, else the board's own.
+func (b *Board) WorkflowOf(e *Element) *Workflow {
+	if w := b.workflows[e]; w != nil {
+		return w
+	}
+	return b.Workflow
+}
+
 func loadElementDetails(e *Element) {
 	// Load progress
 	pd, err := ParseProgressFile(e.ProgressPath())
 	if err == nil {
 		e.Status = pd.Status
+		e.PriorStatus = pd.PriorStatus
 		e.AssignedTo = pd.AssignedTo
 		e.CreatedAt = pd.CreatedAt
 		e.LastUpdate = pd.LastUpdate
 		e.BlockedBy = pd.BlockedBy
 		e.Blocks = pd.Blocks
+		e.Links = pd.Links
 		e.Checklist = pd.Checklist
+		e.History = pd.History
+		e.Estimate = pd.Estimate
+		e.TimeLog = pd.TimeLog
+		e.Recurrence = pd.Recurrence
+		e.SnoozedUntil = pd.SnoozedUntil
+		e.Scheduled = pd.Scheduled
+		e.Review = pd.Review
 	} else {
 		e.Status = StatusBacklog
 	}
@@ -136,6 +187,7 @@ func loadElementDetails(e *Element) {
 		e.Description = rd.Description
 		e.Scope = rd.Scope
 		e.AC = rd.AC
+		e.Extra = rd.Extra
 	}
 }
 
@@ -240,12 +292,13 @@ func (b *Board) Ancestry(e *Element) string {
 	return strings.Join(parts, " > ")
 }
 
-// IsBlocked returns true if any of the element's blockedBy dependencies are not done/closed.
+// IsBlocked returns true if any of the element's blockedBy dependencies are not finished.
 func (b *Board) IsBlocked(e *Element) bool {
 	return len(b.ActiveBlockers(e)) > 0
 }
 
-// ActiveBlockers returns the list of blockers that are not done/closed.
+// ActiveBlockers returns the list of blockers that are not finished (done or
+// closed in the workflow of their own board).
 func (b *Board) ActiveBlockers(e *Element) []*Element {
 	var active []*Element
 	for _, blockerID := range e.BlockedBy {
@@ -253,7 +306,7 @@ func (b *Board) ActiveBlockers(e *Element) []*Element {
 		if blocker == nil {
 			continue // blocker not found, skip
 		}
-		if blocker.Status != StatusDone && blocker.Status != StatusClosed {
+		if !b.WorkflowOf(blocker).Finished(blocker) {
 			active = append(active, blocker)
 		}
 	}
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
 // Test IDs in new distributed format
@@ -223,3 +224,116 @@ func TestFilterByStatus(t *testing.T) {
 		t.Errorf("development count = %d, want 1", len(development))
 	}
 }
+
+func TestSnoozed(t *testing.T) {
+	boardDir := setupTestBoard(t)
+	b, err := Load(boardDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	story, task := b.FindByID(tStory1), b.FindByID(tTask1)
+	if b.Snoozed(task, now) {
+		t.Fatal("nothing is snoozed yet")
+	}
+	story.SnoozedUntil = now.Add(time.Hour)
+	if !b.Snoozed(task, now) || !b.Snoozed(story, now) || b.Snoozed(b.FindByID(tEpic1), now) {
+		t.Error("a snoozed story should hide itself and its tasks, not its epic")
+	}
+	if b.Snoozed(task, now.Add(2*time.Hour)) {
+		t.Error("the snooze should run out")
+	}
+}
+
+func TestLinkEdges(t *testing.T) {
+	boardDir := setupTestBoard(t)
+	b, err := Load(boardDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	task1, task2 := b.FindByID(tTask1), b.FindByID(tTask2)
+	task1.Links = []Link{{LinkRelatesTo, tTask2}, {LinkCauses, tTask2}, {LinkDuplicates, "TASK-260101-zzzzzz"}}
+	task2.Links = []Link{{LinkRelatesTo, tTask1}, {LinkCausedBy, tTask1}}
+	edges := LinkEdges(b.Elements)
+	want := []LinkEdge{{tTask2, tTask1, LinkCausedBy}, {tTask1, tTask2, LinkRelatesTo}}
+	if len(edges) != 2 {
+		t.Fatalf("edges = %+v, want %+v", edges, want)
+	}
+	for _, w := range want {
+		found := false
+		for _, e := range edges {
+			found = found || e == w
+		}
+		if !found {
+			t.Errorf("missing edge %+v in %+v", w, edges)
+		}
+	}
+}
+
+func TestBlockers(t *testing.T) {
+	boardDir := setupTestBoard(t)
+	expect := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	blockers := []*Blocker{
+		{ID: "EXT-1", Reason: "waiting on API keys", Owner: "ops", Expect: expect, Created: expect.AddDate(0, 0, -7),
+			Elements: []BlockedElement{{ID: tTask1}}},
+		{ID: "EXT-2", Reason: "done with", Created: expect, Resolved: expect,
+			Elements: []BlockedElement{{ID: tTask2}}},
+	}
+	if err := SaveBlockers(boardDir, blockers); err != nil {
+		t.Fatal(err)
+	}
+	if NextBlockerID(blockers) != "EXT-3" {
+		t.Errorf("NextBlockerID = %s, want EXT-3", NextBlockerID(blockers))
+	}
+
+	b, err := Load(boardDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	task1, story, epic := b.FindByID(tTask1), b.FindByID(tStory1), b.FindByID(tEpic1)
+	if len(task1.External) != 1 || task1.External[0].Owner != "ops" || !task1.External[0].Expect.Equal(expect) {
+		t.Fatalf("task external = %+v", task1.External)
+	}
+	if len(story.External) != 1 || len(epic.External) != 1 || len(b.FindByID(tTask2).External) != 0 {
+		t.Error("an open blocker should reach the ancestors, a resolved one nothing")
+	}
+	if len(task1.HeldBy()) != 1 || len(story.HeldBy()) != 0 {
+		t.Error("only the task itself is held")
+	}
+	if !task1.External[0].Overdue(expect.Add(time.Hour)) || task1.External[0].Overdue(expect.Add(-time.Hour)) {
+		t.Error("overdue once past the expected date")
+	}
+
+	// The lowest element in scope takes the edge
+	edges := ExternalEdges([]*Element{epic, story, task1})
+	if len(edges) != 1 || edges[0].To != tTask1 {
+		t.Errorf("edges = %+v, want one to %s", edges, tTask1)
+	}
+	edges = ExternalEdges([]*Element{epic})
+	if len(edges) != 1 || edges[0].To != tEpic1 {
+		t.Errorf("edges = %+v, want one to %s", edges, tEpic1)
+	}
+}
+
+func TestReadmeKeepsExtraSections(t *testing.T) {
+	content := "# Crash\n\n## Description\nIt crashes.\n\n## Reproduction\n1. Open the app\n2. Press record\n\n## Scope\n(define bug scope / affected area)\n\n## Acceptance Criteria\n- No crash\n"
+	rd, err := ParseReadme(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rd.Extra) != 1 || rd.Extra[0].Heading != "Reproduction" || rd.Extra[0].Text != "1. Open the app\n2. Press record" {
+		t.Fatalf("extra = %+v", rd.Extra)
+	}
+	rd.Description = "It crashes on record."
+	again, _ := ParseReadme(WriteReadme(rd))
+	if len(again.Extra) != 1 || again.Extra[0].Text != rd.Extra[0].Text || again.AC != "- No crash" {
+		t.Errorf("rewritten = %+v", again)
+	}
+
+	e := &Element{Scope: "(define bug scope / affected area)", Extra: rd.Extra}
+	if e.Section("reproduction") == "" || e.Section("Scope") != "" || e.Section("Workaround") != "" {
+		t.Errorf("sections: reproduction %q, scope %q", e.Section("reproduction"), e.Section("Scope"))
+	}
+}
@@ -32,29 +32,10 @@ const (
 	StatusBlocked     Status = "blocked"
 )
 
+// ParseStatus parses a status name or alias of the default workflow. Use
+// the board's Workflow for statuses a workflow file adds.
 func ParseStatus(s string) (Status, error) {
-	switch strings.ToLower(s) {
-	case "backlog":
-		return StatusBacklog, nil
-	case "analysis":
-		return StatusAnalysis, nil
-	case "to-dev", "todev":
-		return StatusToDev, nil
-	case "development", "dev":
-		return StatusDevelopment, nil
-	case "to-review", "toreview":
-		return StatusToReview, nil
-	case "reviewing", "review":
-		return StatusReviewing, nil
-	case "done":
-		return StatusDone, nil
-	case "closed":
-		return StatusClosed, nil
-	case "blocked":
-		return StatusBlocked, nil
-	default:
-		return "", fmt.Errorf("unknown status: %s (valid: backlog, analysis, to-dev, development, to-review, reviewing, done, closed, blocked)", s)
-	}
+	return defaultWorkflow.For(TaskType).Parse(s)
 }
 
 func ParseElementType(s string) (ElementType, error) {
@@ -105,12 +86,28 @@ type Element struct {
 	LastUpdate time.Time
 	BlockedBy  []string
 	Blocks     []string
+	Links      []Link
 	Checklist  []ChecklistItem
+	History    []StatusChange
+	Estimate   time.Duration
+	TimeLog    []TimeEntry
+	Recurrence Recurrence
+	// PriorStatus: see ProgressData
+	PriorStatus Status
+	// SnoozedUntil and Scheduled: see ProgressData
+	SnoozedUntil time.Time
+	Scheduled    ScheduledChange
+	// Review: see ProgressData; Criteria matches it to AC
+	Review []ReviewVerdict
+	// External lists the open external blockers holding up the element or
+	// an element below it; set by Load from blockers.yaml
+	External []*Blocker
 	// README fields
 	Title       string
 	Description string
 	Scope       string
 	AC          string // acceptance criteria
+	Extra       []ReadmeSection
 }
 
 type ChecklistItem struct {
@@ -118,6 +115,32 @@ type ChecklistItem struct {
 	Checked bool
 }
 
+// Section returns the text of a README section by heading
+// (case-insensitive), or "" when the section is missing or still holds a
+// template placeholder in parentheses.
+func (e *Element) Section(heading string) string {
+	var text string
+	switch strings.ToLower(heading) {
+	case "description":
+		text = e.Description
+	case "scope":
+		text = e.Scope
+	case "acceptance criteria":
+		text = e.AC
+	default:
+		for _, sec := range e.Extra {
+			if strings.EqualFold(sec.Heading, heading) {
+				text = sec.Text
+				break
+			}
+		}
+	}
+	if strings.HasPrefix(text, "(") && strings.HasSuffix(text, ")") {
+		return ""
+	}
+	return text
+}
+
 // ID returns the element ID, e.g. "EPIC-260101-aaaaaa" or "TASK-12" (legacy).
 func (e *Element) ID() string {
 	if e.RawID != "" {
@@ -0,0 +1,154 @@
+package board
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// LinkType names a relationship between two elements other than blocked-by.
+// Links are stored on both ends, the other end under the inverse type, the
+// way Blocked By and Blocks are. They never affect the plan.
+type LinkType string
+
+const (
+	LinkRelatesTo   LinkType = "relates-to"
+	LinkDuplicates  LinkType = "duplicates"
+	LinkCausedBy    LinkType = "caused-by"
+	LinkFollowsUp   LinkType = "follows-up"
+	LinkParentOf    LinkType = "parent-of"
+	LinkDuplicateOf LinkType = "duplicated-by"
+	LinkCauses      LinkType = "causes"
+	LinkFollowedBy  LinkType = "followed-up-by"
+	LinkChildOf     LinkType = "child-of"
+)
+
+// LinkTypes lists the types link --type takes, in display order.
+var LinkTypes = []LinkType{LinkRelatesTo, LinkDuplicates, LinkCausedBy, LinkFollowsUp, LinkParentOf}
+
+var linkInverse = map[LinkType]LinkType{
+	LinkRelatesTo:   LinkRelatesTo,
+	LinkDuplicates:  LinkDuplicateOf,
+	LinkCausedBy:    LinkCauses,
+	LinkFollowsUp:   LinkFollowedBy,
+	LinkParentOf:    LinkChildOf,
+	LinkDuplicateOf: LinkDuplicates,
+	LinkCauses:      LinkCausedBy,
+	LinkFollowedBy:  LinkFollowsUp,
+	LinkChildOf:     LinkParentOf,
+}
+
+// ParseLinkType parses a link type or its inverse, e.g. "causes".
+func ParseLinkType(s string) (LinkType, error) {
+	t := LinkType(strings.ToLower(strings.TrimSpace(s)))
+	if _, ok := linkInverse[t]; !ok {
+		names := make([]string, len(LinkTypes))
+		for i, lt := range LinkTypes {
+			names[i] = string(lt)
+		}
+		return "", fmt.Errorf("invalid link type %q (valid: blocked-by, %s)", s, strings.Join(names, ", "))
+	}
+	return t, nil
+}
+
+// Inverse returns the type recorded on the other end of a link.
+func (t LinkType) Inverse() LinkType {
+	return linkInverse[t]
+}
+
+// Forward reports whether t is one of LinkTypes rather than an inverse, so
+// every link is drawn once. relates-to is its own inverse and counts as
+// forward on both ends.
+func (t LinkType) Forward() bool {
+	for _, lt := range LinkTypes {
+		if t == lt {
+			return true
+		}
+	}
+	return false
+}
+
+// Link is one typed link from an element to Target.
+type Link struct {
+	Type   LinkType `json:"type"`
+	Target string   `json:"id"`
+}
+
+// parseLink parses a Links line like "caused-by TASK-260101-aaaaaa".
+func parseLink(line string) (Link, bool) {
+	fields := strings.Fields(line)
+	if len(fields) != 2 {
+		return Link{}, false
+	}
+	t, err := ParseLinkType(fields[0])
+	if err != nil {
+		return Link{}, false
+	}
+	return Link{Type: t, Target: fields[1]}, true
+}
+
+// AddLink records a link unless it is already there and reports whether
+// it was added.
+func (pd *ProgressData) AddLink(l Link) bool {
+	for _, existing := range pd.Links {
+		if existing == l {
+			return false
+		}
+	}
+	pd.Links = append(pd.Links, l)
+	return true
+}
+
+// RemoveLink drops a link and reports whether it was there.
+func (pd *ProgressData) RemoveLink(l Link) bool {
+	for i, existing := range pd.Links {
+		if existing == l {
+			pd.Links = append(pd.Links[:i], pd.Links[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// LinkEdge is a typed link between two elements, seen from the end that
+// holds the forward type.
+type LinkEdge struct {
+	From, To string
+	Type     LinkType
+}
+
+// LinkEdges lists the typed links among elements once each, sorted.
+func LinkEdges(elements []*Element) []LinkEdge {
+	inScope := make(map[string]bool, len(elements))
+	for _, e := range elements {
+		inScope[e.ID()] = true
+	}
+	seen := map[LinkEdge]bool{}
+	var edges []LinkEdge
+	for _, e := range elements {
+		for _, l := range e.Links {
+			if !l.Type.Forward() || !inScope[l.Target] {
+				continue
+			}
+			edge := LinkEdge{From: e.ID(), To: l.Target, Type: l.Type}
+			if l.Type == l.Type.Inverse() && edge.To < edge.From {
+				edge.From, edge.To = edge.To, edge.From
+			}
+			if !seen[edge] {
+				seen[edge] = true
+				edges = append(edges, edge)
+			}
+		}
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		a, b := edges[i], edges[j]
+		if a.From != b.From {
+			return a.From < b.From
+		}
+		if a.To != b.To {
+			return a.To < b.To
+		}
+		return a.Type < b.Type
+	})
+	return edges
+}
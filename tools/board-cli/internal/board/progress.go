@@ -16,8 +16,64 @@ type ProgressData struct {
 	LastUpdate time.Time
 	BlockedBy  []string
 	Blocks     []string
+	// Links are the typed links other than blocked-by
+	Links      []Link
 	Checklist  []ChecklistItem
-	Notes      string
+	History    []StatusChange
+	Estimate   time.Duration
+	TimeLog    []TimeEntry
+	Recurrence Recurrence
+	// PriorStatus is the status held before the element was last blocked,
+	// so unblock can return to it; it is cleared once the element leaves
+	// the blocked category
+	PriorStatus Status
+	// SnoozedUntil hides the element from list and tree until then
+	SnoozedUntil time.Time
+	Scheduled    ScheduledChange
+	// Review holds the verdicts on the acceptance criteria in the README
+	// and the reviewers' comments
+	Review         []ReviewVerdict
+	ReviewComments []ReviewComment
+	Notes          string
+}
+
+// StatusChange is one entry of an element's status history.
+type StatusChange struct {
+	At   time.Time `json:"at"`
+	From Status    `json:"from"`
+	To   Status    `json:"to"`
+}
+
+// SetStatus changes the status and records the change in the history. On
+// entering a blocked status of flow it remembers the status it leaves, and
+// forgets it again on leaving the blocked category.
+func (pd *ProgressData) SetStatus(s Status, flow *Flow) {
+	if s == pd.Status {
+		return
+	}
+	wasBlocked := flow.Category(pd.Status) == CategoryBlocked
+	switch isBlocked := flow.Category(s) == CategoryBlocked; {
+	case isBlocked && !wasBlocked:
+		pd.PriorStatus = pd.Status
+	case !isBlocked && wasBlocked:
+		pd.PriorStatus = ""
+	}
+	pd.History = append(pd.History, StatusChange{At: time.Now().UTC(), From: pd.Status, To: s})
+	pd.Status = s
+}
+
+// parseStatusChange parses a history line like
+// "2026-01-02T15:04:05Z development → done".
+func parseStatusChange(line string) (StatusChange, bool) {
+	fields := strings.Fields(line)
+	if len(fields) != 4 || (fields[2] != "→" && fields[2] != "->") {
+		return StatusChange{}, false
+	}
+	at, err := time.Parse(time.RFC3339, fields[0])
+	if err != nil {
+		return StatusChange{}, false
+	}
+	return StatusChange{At: at, From: Status(strings.ToLower(fields[1])), To: Status(strings.ToLower(fields[3]))}, true
 }
 
 // ParseProgressFile reads and parses a progress.md file.
@@ -51,11 +107,17 @@ func ParseProgress(content string) (*ProgressData, error) {
 		switch currentSection {
 		case "status":
 			if trimmed != "" && !strings.HasPrefix(trimmed, "#") {
-				s, err := ParseStatus(trimmed)
-				if err == nil {
+				// Statuses a workflow file adds are kept as written
+				if s, err := ParseStatus(trimmed); err == nil {
 					pd.Status = s
+				} else {
+					pd.Status = Status(strings.ToLower(trimmed))
 				}
 			}
+		case "prior status":
+			if trimmed != "" && trimmed != "(none)" {
+				pd.PriorStatus = Status(strings.ToLower(trimmed))
+			}
 		case "assigned to":
 			if trimmed != "" && trimmed != "(none)" {
 				pd.AssignedTo = trimmed
@@ -88,6 +150,12 @@ func ParseProgress(content string) (*ProgressData, error) {
 					pd.Blocks = append(pd.Blocks, id)
 				}
 			}
+		case "links":
+			if strings.HasPrefix(trimmed, "- ") {
+				if l, ok := parseLink(strings.TrimPrefix(trimmed, "- ")); ok {
+					pd.Links = append(pd.Links, l)
+				}
+			}
 		case "checklist":
 			if strings.HasPrefix(trimmed, "- [x] ") {
 				text := strings.TrimPrefix(trimmed, "- [x] ")
@@ -96,6 +164,42 @@ func ParseProgress(content string) (*ProgressData, error) {
 				text := strings.TrimPrefix(trimmed, "- [ ] ")
 				pd.Checklist = append(pd.Checklist, ChecklistItem{Text: text, Checked: false})
 			}
+		case "history":
+			if strings.HasPrefix(trimmed, "- ") {
+				if c, ok := parseStatusChange(strings.TrimPrefix(trimmed, "- ")); ok {
+					pd.History = append(pd.History, c)
+				}
+			}
+		case "estimate":
+			if trimmed != "" && trimmed != "(none)" {
+				if d, err := ParseDuration(trimmed); err == nil {
+					pd.Estimate = d
+				}
+			}
+		case "time log":
+			if strings.HasPrefix(trimmed, "- ") {
+				if t, ok := parseTimeEntry(strings.TrimPrefix(trimmed, "- ")); ok {
+					pd.TimeLog = append(pd.TimeLog, t)
+				}
+			}
+		case "recurrence":
+			if strings.HasPrefix(trimmed, "- ") {
+				parseRecurrenceLine(&pd.Recurrence, trimmed)
+			}
+		case "snoozed until":
+			if trimmed != "" && trimmed != "(none)" {
+				if t, err := time.Parse(time.RFC3339, trimmed); err == nil {
+					pd.SnoozedUntil = t
+				}
+			}
+		case "scheduled":
+			if strings.HasPrefix(trimmed, "- ") {
+				if s, ok := parseScheduledChange(strings.TrimPrefix(trimmed, "- ")); ok {
+					pd.Scheduled = s
+				}
+			}
+		case "review":
+			parseReviewLine(pd, trimmed)
 		case "notes":
 			if trimmed != "" {
 				if pd.Notes != "" {
@@ -117,6 +221,10 @@ func WriteProgress(pd *ProgressData) string {
 	b.WriteString(string(pd.Status))
 	b.WriteString("\n\n")
 
+	if pd.PriorStatus != "" {
+		fmt.Fprintf(&b, "## Prior Status\n%s\n\n", pd.PriorStatus)
+	}
+
 	b.WriteString("## Assigned To\n")
 	if pd.AssignedTo == "" {
 		b.WriteString("(none)\n")
@@ -156,6 +264,14 @@ func WriteProgress(pd *ProgressData) string {
 	}
 	b.WriteString("\n")
 
+	if len(pd.Links) > 0 {
+		b.WriteString("## Links\n")
+		for _, l := range pd.Links {
+			fmt.Fprintf(&b, "- %s %s\n", l.Type, l.Target)
+		}
+		b.WriteString("\n")
+	}
+
 	b.WriteString("## Checklist\n")
 	if len(pd.Checklist) == 0 {
 		b.WriteString("(empty)\n")
@@ -170,6 +286,38 @@ func WriteProgress(pd *ProgressData) string {
 	}
 	b.WriteString("\n")
 
+	// Written only once there is something to record, so untouched
+	// elements keep the template layout
+	if len(pd.History) > 0 {
+		b.WriteString("## History\n")
+		for _, c := range pd.History {
+			fmt.Fprintf(&b, "- %s %s → %s\n", c.At.UTC().Format(time.RFC3339), c.From, c.To)
+		}
+		b.WriteString("\n")
+	}
+	if pd.Estimate > 0 {
+		fmt.Fprintf(&b, "## Estimate\n%s\n\n", FormatDuration(pd.Estimate))
+	}
+	if len(pd.TimeLog) > 0 {
+		b.WriteString("## Time Log\n")
+		for _, t := range pd.TimeLog {
+			fmt.Fprintf(&b, "- %s\n", formatTimeEntry(t))
+		}
+		b.WriteString("\n")
+	}
+	if !pd.Recurrence.IsZero() {
+		writeRecurrence(&b, pd.Recurrence)
+	}
+	if !pd.SnoozedUntil.IsZero() {
+		fmt.Fprintf(&b, "## Snoozed Until\n%s\n\n", formatTime(pd.SnoozedUntil))
+	}
+	if !pd.Scheduled.IsZero() {
+		fmt.Fprintf(&b, "## Scheduled\n- %s\n\n", formatScheduledChange(pd.Scheduled))
+	}
+	if len(pd.Review) > 0 || len(pd.ReviewComments) > 0 {
+		writeReview(&b, pd)
+	}
+
 	b.WriteString("## Notes\n")
 	if pd.Notes != "" {
 		b.WriteString(pd.Notes)
@@ -1,7 +1,9 @@
 package board
 
 import (
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestParseProgress(t *testing.T) {
@@ -129,3 +131,182 @@ func TestWriteProgress(t *testing.T) {
 		t.Errorf("Checklist len = %d", len(pd2.Checklist))
 	}
 }
+
+func TestStatusHistory(t *testing.T) {
+	pd := &ProgressData{Status: StatusBacklog}
+	pd.SetStatus(StatusDevelopment, DefaultWorkflow().For(TaskType))
+	pd.SetStatus(StatusDevelopment, DefaultWorkflow().For(TaskType))
+	pd.SetStatus(StatusDone, DefaultWorkflow().For(TaskType))
+	if len(pd.History) != 2 {
+		t.Fatalf("History = %+v, want two changes", pd.History)
+	}
+
+	content := WriteProgress(pd)
+	if !strings.Contains(content, "## History\n- ") || !strings.Contains(content, " development → done\n") {
+		t.Errorf("history not written:\n%s", content)
+	}
+	edited := strings.Replace(content, "\n## Notes", "- 2026-01-02T10:00:00Z done -> to-dev\n- not a change\n\n## Notes", 1)
+	pd2, err := ParseProgress(edited)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pd2.History) != 3 || pd2.History[0].From != StatusBacklog || pd2.History[2].To != StatusToDev {
+		t.Errorf("History = %+v", pd2.History)
+	}
+
+	if strings.Contains(WriteProgress(&ProgressData{Status: StatusBacklog}), "## History") {
+		t.Error("an empty history should not be written")
+	}
+}
+
+func TestRecurrenceSection(t *testing.T) {
+	next := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	pd := &ProgressData{Status: StatusBacklog, Recurrence: Recurrence{Rule: "0 9 * * 1", Next: next, Last: "TASK-2"}}
+	content := WriteProgress(pd)
+	if !strings.Contains(content, "## Recurrence\n- rule: 0 9 * * 1\n- next: 2026-10-19T09:00:00Z\n- last: TASK-2\n\n## Notes") {
+		t.Errorf("recurrence not written:\n%s", content)
+	}
+	pd2, err := ParseProgress(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pd2.Recurrence != pd.Recurrence {
+		t.Errorf("Recurrence = %+v, want %+v", pd2.Recurrence, pd.Recurrence)
+	}
+
+	pd3, _ := ParseProgress("## Recurrence\n- template: TASK-1\n- previous: TASK-2\n- bogus\n")
+	if pd3.Recurrence != (Recurrence{Template: "TASK-1", Previous: "TASK-2"}) {
+		t.Errorf("occurrence = %+v", pd3.Recurrence)
+	}
+	if strings.Contains(WriteProgress(&ProgressData{}), "## Recurrence") {
+		t.Error("an empty recurrence should not be written")
+	}
+}
+
+func TestSnoozeAndScheduleSections(t *testing.T) {
+	until := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	at := time.Date(2026, 11, 2, 9, 0, 0, 0, time.UTC)
+	pd := &ProgressData{Status: StatusBacklog, SnoozedUntil: until, Scheduled: ScheduledChange{At: at, Status: StatusDevelopment}}
+	content := WriteProgress(pd)
+	if !strings.Contains(content, "## Snoozed Until\n2026-11-01T00:00:00Z\n\n## Scheduled\n- 2026-11-02T09:00:00Z development\n\n## Notes") {
+		t.Errorf("sections not written:\n%s", content)
+	}
+	pd2, err := ParseProgress(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !pd2.SnoozedUntil.Equal(until) || pd2.Scheduled.Status != StatusDevelopment || !pd2.Scheduled.At.Equal(at) {
+		t.Errorf("parsed %v, %+v", pd2.SnoozedUntil, pd2.Scheduled)
+	}
+	if pd2.Scheduled.Due(at.Add(-time.Minute)) || !pd2.Scheduled.Due(at) {
+		t.Error("Due should turn true at the scheduled time")
+	}
+
+	empty := WriteProgress(&ProgressData{})
+	if strings.Contains(empty, "## Snoozed Until") || strings.Contains(empty, "## Scheduled") {
+		t.Error("empty sections should not be written")
+	}
+}
+
+func TestLinksSection(t *testing.T) {
+	pd := &ProgressData{Status: StatusBacklog}
+	if !pd.AddLink(Link{Type: LinkCausedBy, Target: tTask1}) || pd.AddLink(Link{Type: LinkCausedBy, Target: tTask1}) {
+		t.Fatal("AddLink should add a link once")
+	}
+	pd.AddLink(Link{Type: LinkRelatesTo, Target: tStory1})
+	content := WriteProgress(pd)
+	if !strings.Contains(content, "## Links\n- caused-by "+tTask1+"\n- relates-to "+tStory1+"\n") {
+		t.Errorf("links not written:\n%s", content)
+	}
+	pd2, err := ParseProgress(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pd2.Links) != 2 || pd2.Links[0] != (Link{Type: LinkCausedBy, Target: tTask1}) {
+		t.Errorf("parsed links = %+v", pd2.Links)
+	}
+	if !pd2.RemoveLink(Link{Type: LinkCausedBy, Target: tTask1}) || len(pd2.Links) != 1 {
+		t.Errorf("RemoveLink left %+v", pd2.Links)
+	}
+	if strings.Contains(WriteProgress(&ProgressData{}), "## Links") {
+		t.Error("an empty Links section should not be written")
+	}
+	if LinkCausedBy.Inverse() != LinkCauses || LinkCauses.Forward() || !LinkRelatesTo.Forward() {
+		t.Error("unexpected inverse or direction")
+	}
+}
+
+func TestPriorStatusSection(t *testing.T) {
+	pd := &ProgressData{Status: StatusDevelopment}
+	pd.SetStatus(StatusBlocked, DefaultWorkflow().For(TaskType))
+	if pd.PriorStatus != StatusDevelopment {
+		t.Fatalf("PriorStatus = %q, want development", pd.PriorStatus)
+	}
+	content := WriteProgress(pd)
+	if !strings.Contains(content, "## Status\nblocked\n\n## Prior Status\ndevelopment\n") {
+		t.Errorf("prior status not written:\n%s", content)
+	}
+	pd2, err := ParseProgress(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pd2.PriorStatus != StatusDevelopment {
+		t.Errorf("parsed PriorStatus = %q", pd2.PriorStatus)
+	}
+	if strings.Contains(WriteProgress(&ProgressData{Status: StatusBacklog}), "## Prior Status") {
+		t.Error("an element that never changed should have no prior status")
+	}
+	other := &ProgressData{Status: StatusBacklog}
+	other.SetStatus(StatusDevelopment, DefaultWorkflow().For(TaskType))
+	if other.PriorStatus != "" {
+		t.Errorf("PriorStatus = %q, want it recorded only on entering blocked", other.PriorStatus)
+	}
+}
+
+func TestParseCriteria(t *testing.T) {
+	got := ParseCriteria("Intro line\n- [ ] Records audio\n* [x] Handles silence\n  without clipping\n2) Exports WAV\n-\n")
+	want := []string{"Records audio", "Handles silence without clipping", "Exports WAV"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("ParseCriteria = %q, want %q", got, want)
+	}
+	if got := ParseCriteria("It works\nend to end"); len(got) != 1 || got[0] != "It works end to end" {
+		t.Errorf("free text = %q, want one criterion", got)
+	}
+	if got := ParseCriteria("(define acceptance criteria)"); len(got) != 0 {
+		t.Errorf("template placeholder = %q, want none", got)
+	}
+	if got := ParseCriteria(""); len(got) != 0 {
+		t.Errorf("empty = %q", got)
+	}
+}
+
+func TestReviewSection(t *testing.T) {
+	pd := &ProgressData{Status: StatusReviewing}
+	criteria := MatchCriteria("- Records\n- Exports", nil)
+	criteria[0].Verdict = VerdictPass
+	criteria[1].Verdict = VerdictFail
+	pd.SetReview(criteria)
+	pd.ReviewComments = []ReviewComment{{At: time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC), Text: "export drops the header"}}
+
+	content := WriteProgress(pd)
+	if !strings.Contains(content, "## Review\n- pass Records\n- fail Exports\n> 2026-10-18T10:00:00Z export drops the header\n") {
+		t.Errorf("review not written:\n%s", content)
+	}
+	pd2, err := ParseProgress(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := MatchCriteria("- Exports\n- Records\n- Imports", pd2.Review)
+	if got[0].Verdict != VerdictFail || got[1].Verdict != VerdictPass || got[2].Verdict != VerdictPending {
+		t.Errorf("matched criteria = %+v", got)
+	}
+	if len(pd2.ReviewComments) != 1 || pd2.ReviewComments[0].Text != "export drops the header" {
+		t.Errorf("comments = %+v", pd2.ReviewComments)
+	}
+	if open := Unpassed(got); len(open) != 2 {
+		t.Errorf("Unpassed = %+v", open)
+	}
+	if strings.Contains(WriteProgress(&ProgressData{}), "## Review") {
+		t.Error("an empty review should not be written")
+	}
+}
@@ -13,6 +13,15 @@ type ReadmeData struct {
 	Description string
 	Scope       string
 	AC          string // acceptance criteria
+	// Extra holds the other "## " sections in file order, such as a bug's
+	// Reproduction, so rewriting the README keeps them.
+	Extra []ReadmeSection
+}
+
+// ReadmeSection is a README section outside the standard ones.
+type ReadmeSection struct {
+	Heading string
+	Text    string
 }
 
 // ParseReadmeFile reads and parses a README.md file.
@@ -30,6 +39,7 @@ func ParseReadme(content string) (*ReadmeData, error) {
 
 	scanner := bufio.NewScanner(strings.NewReader(content))
 	currentSection := ""
+	heading := ""
 	var sectionContent strings.Builder
 
 	flushSection := func() {
@@ -43,6 +53,9 @@ func ParseReadme(content string) (*ReadmeData, error) {
 			rd.Scope = text
 		case "acceptance criteria":
 			rd.AC = text
+		case "":
+		default:
+			rd.Extra = append(rd.Extra, ReadmeSection{Heading: heading, Text: text})
 		}
 		sectionContent.Reset()
 	}
@@ -61,7 +74,8 @@ func ParseReadme(content string) (*ReadmeData, error) {
 		// H2 = section
 		if strings.HasPrefix(trimmed, "## ") {
 			flushSection()
-			currentSection = strings.ToLower(strings.TrimPrefix(trimmed, "## "))
+			heading = strings.TrimPrefix(trimmed, "## ")
+			currentSection = strings.ToLower(heading)
 			continue
 		}
 
@@ -82,6 +96,9 @@ func WriteReadme(rd *ReadmeData) string {
 	fmt.Fprintf(&b, "## Description\n%s\n\n", rd.Description)
 	fmt.Fprintf(&b, "## Scope\n%s\n\n", rd.Scope)
 	fmt.Fprintf(&b, "## Acceptance Criteria\n%s\n", rd.AC)
+	for _, sec := range rd.Extra {
+		fmt.Fprintf(&b, "\n## %s\n%s\n", sec.Heading, sec.Text)
+	}
 	return b.String()
 }
 
@@ -0,0 +1,73 @@
+package board
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Recurrence makes a task or bug a template that tick copies when it is
+// due, or records that an element is one of those copies.
+type Recurrence struct {
+	// Template side: the rule (see package recur), when the next copy is
+	// due and the latest copy made.
+	Rule string
+	Next time.Time
+	Last string
+	// Instance side: the template it was copied from and the occurrence
+	// before it.
+	Template string
+	Previous string
+}
+
+// IsZero reports whether there is nothing to record.
+func (r Recurrence) IsZero() bool {
+	return r == Recurrence{}
+}
+
+// parseRecurrenceLine reads one "- key: value" line of the Recurrence
+// section into r.
+func parseRecurrenceLine(r *Recurrence, line string) {
+	key, value, ok := strings.Cut(strings.TrimPrefix(line, "- "), ":")
+	if !ok {
+		return
+	}
+	value = strings.TrimSpace(value)
+	switch strings.ToLower(strings.TrimSpace(key)) {
+	case "rule":
+		r.Rule = value
+	case "next":
+		if t, err := time.Parse(time.RFC3339, value); err == nil {
+			r.Next = t
+		}
+	case "last":
+		r.Last = value
+	case "template":
+		r.Template = value
+	case "previous":
+		r.Previous = value
+	}
+}
+
+func writeRecurrence(b *strings.Builder, r Recurrence) {
+	b.WriteString("## Recurrence\n")
+	for _, kv := range []struct{ key, value string }{
+		{"rule", r.Rule},
+		{"next", formatTime(r.Next)},
+		{"last", r.Last},
+		{"template", r.Template},
+		{"previous", r.Previous},
+	} {
+		if kv.value != "" {
+			fmt.Fprintf(b, "- %s: %s\n", kv.key, kv.value)
+		}
+	}
+	b.WriteString("\n")
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
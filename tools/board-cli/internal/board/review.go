@@ -0,0 +1,174 @@
+package board
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Verdict is a reviewer's call on one acceptance criterion.
+type Verdict string
+
+const (
+	VerdictPending Verdict = ""
+	VerdictPass    Verdict = "pass"
+	VerdictFail    Verdict = "fail"
+)
+
+// ReviewVerdict is a verdict recorded in progress.md. It names the
+// criterion by its text, so reordering the README keeps verdicts and
+// rewording a criterion sends it back to review.
+type ReviewVerdict struct {
+	Criterion string
+	Verdict   Verdict
+}
+
+// ReviewComment is a reviewer's comment, kept with the review.
+type ReviewComment struct {
+	At   time.Time
+	Text string
+}
+
+// Criterion is one acceptance criterion of an element, numbered from 1 in
+// README order, with its verdict.
+type Criterion struct {
+	Number  int
+	Text    string
+	Verdict Verdict
+}
+
+// ParseCriteria splits an Acceptance Criteria section into criteria: one
+// per bullet ("- ", "* ", "1. ", with or without a "[ ]" box), continuation
+// lines joined to the bullet above. Text without bullets is one criterion,
+// unless it is a template placeholder in parentheses.
+func ParseCriteria(ac string) []string {
+	var criteria []string
+	var loose []string
+	for _, line := range strings.Split(ac, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		if text, ok := cutBullet(trimmed); ok {
+			if text != "" {
+				criteria = append(criteria, text)
+			}
+			continue
+		}
+		if len(criteria) > 0 {
+			criteria[len(criteria)-1] += " " + trimmed
+		} else {
+			loose = append(loose, trimmed)
+		}
+	}
+	if len(criteria) == 0 && len(loose) > 0 {
+		text := strings.Join(loose, " ")
+		if strings.HasPrefix(text, "(") && strings.HasSuffix(text, ")") {
+			return nil
+		}
+		return []string{text}
+	}
+	return criteria
+}
+
+// cutBullet strips a list marker and checkbox from a line.
+func cutBullet(line string) (string, bool) {
+	rest := ""
+	switch {
+	case line == "-", line == "*", line == "+":
+		return "", true
+	case strings.HasPrefix(line, "- "), strings.HasPrefix(line, "* "), strings.HasPrefix(line, "+ "):
+		rest = line[2:]
+	default:
+		i := 0
+		for i < len(line) && line[i] >= '0' && line[i] <= '9' {
+			i++
+		}
+		if i == 0 || i+1 >= len(line) || (line[i] != '.' && line[i] != ')') || line[i+1] != ' ' {
+			return "", false
+		}
+		rest = line[i+2:]
+	}
+	rest = strings.TrimSpace(rest)
+	for _, box := range []string{"[ ]", "[x]", "[X]"} {
+		if strings.HasPrefix(rest, box) {
+			rest = strings.TrimSpace(strings.TrimPrefix(rest, box))
+			break
+		}
+	}
+	return rest, true
+}
+
+// Criteria returns the element's acceptance criteria with their verdicts.
+func (e *Element) Criteria() []Criterion {
+	return MatchCriteria(e.AC, e.Review)
+}
+
+// MatchCriteria numbers the criteria of an Acceptance Criteria section and
+// gives each the verdict recorded for its text.
+func MatchCriteria(ac string, review []ReviewVerdict) []Criterion {
+	texts := ParseCriteria(ac)
+	criteria := make([]Criterion, len(texts))
+	for i, text := range texts {
+		criteria[i] = Criterion{Number: i + 1, Text: text}
+		for _, v := range review {
+			if v.Criterion == text {
+				criteria[i].Verdict = v.Verdict
+			}
+		}
+	}
+	return criteria
+}
+
+// Unpassed lists the criteria that failed or still wait for review.
+func Unpassed(criteria []Criterion) []Criterion {
+	var open []Criterion
+	for _, c := range criteria {
+		if c.Verdict != VerdictPass {
+			open = append(open, c)
+		}
+	}
+	return open
+}
+
+// SetReview replaces the recorded verdicts with those of criteria, so
+// verdicts for criteria no longer in the README are dropped.
+func (pd *ProgressData) SetReview(criteria []Criterion) {
+	pd.Review = nil
+	for _, c := range criteria {
+		if c.Verdict != VerdictPending {
+			pd.Review = append(pd.Review, ReviewVerdict{Criterion: c.Text, Verdict: c.Verdict})
+		}
+	}
+}
+
+// parseReviewLine parses "- pass <criterion>" or "> <time> <comment>".
+func parseReviewLine(pd *ProgressData, line string) {
+	if rest, ok := strings.CutPrefix(line, "> "); ok {
+		at, text, _ := strings.Cut(rest, " ")
+		if t, err := time.Parse(time.RFC3339, at); err == nil {
+			pd.ReviewComments = append(pd.ReviewComments, ReviewComment{At: t, Text: strings.TrimSpace(text)})
+		}
+		return
+	}
+	rest, ok := strings.CutPrefix(line, "- ")
+	if !ok {
+		return
+	}
+	verdict, text, _ := strings.Cut(rest, " ")
+	v := Verdict(strings.ToLower(verdict))
+	if (v == VerdictPass || v == VerdictFail) && strings.TrimSpace(text) != "" {
+		pd.Review = append(pd.Review, ReviewVerdict{Criterion: strings.TrimSpace(text), Verdict: v})
+	}
+}
+
+func writeReview(b *strings.Builder, pd *ProgressData) {
+	b.WriteString("## Review\n")
+	for _, v := range pd.Review {
+		fmt.Fprintf(b, "- %s %s\n", v.Verdict, v.Criterion)
+	}
+	for _, c := range pd.ReviewComments {
+		fmt.Fprintf(b, "> %s %s\n", formatTime(c.At), c.Text)
+	}
+	b.WriteString("\n")
+}
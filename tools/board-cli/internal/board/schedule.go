@@ -0,0 +1,57 @@
+package board
+
+import (
+	"strings"
+	"time"
+)
+
+// ScheduledChange is a status transition that apply-schedule makes once
+// its time has come.
+type ScheduledChange struct {
+	At     time.Time
+	Status Status
+}
+
+// IsZero reports whether nothing is scheduled.
+func (s ScheduledChange) IsZero() bool {
+	return s.At.IsZero() && s.Status == ""
+}
+
+// Due reports whether the change should be applied at now.
+func (s ScheduledChange) Due(now time.Time) bool {
+	return !s.IsZero() && !s.At.After(now)
+}
+
+// parseScheduledChange parses a Scheduled line like
+// "2026-01-02T09:00:00Z development".
+func parseScheduledChange(line string) (ScheduledChange, bool) {
+	fields := strings.Fields(line)
+	if len(fields) != 2 {
+		return ScheduledChange{}, false
+	}
+	at, err := time.Parse(time.RFC3339, fields[0])
+	if err != nil {
+		return ScheduledChange{}, false
+	}
+	return ScheduledChange{At: at, Status: Status(strings.ToLower(fields[1]))}, true
+}
+
+func formatScheduledChange(s ScheduledChange) string {
+	return formatTime(s.At) + " " + string(s.Status)
+}
+
+// IsSnoozed reports whether the element itself is snoozed at now.
+func (e *Element) IsSnoozed(now time.Time) bool {
+	return e.SnoozedUntil.After(now)
+}
+
+// Snoozed reports whether the element or one of its ancestors is snoozed
+// at now; snoozing a story hides its tasks too.
+func (b *Board) Snoozed(e *Element, now time.Time) bool {
+	for ; e != nil; e = b.ParentOf(e) {
+		if e.IsSnoozed(now) {
+			return true
+		}
+	}
+	return false
+}
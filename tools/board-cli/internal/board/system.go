@@ -117,11 +117,27 @@ func WriteCounters(boardDir string, c *Counters) error {
 	return os.WriteFile(path, []byte(content), 0644)
 }
 
-// EnsureBoardDir creates the board directory if it doesn't exist.
+// gitignore keeps what commands derive inside a board out of git: the
+// search index, renders, the trash, the undo history and the board lock.
+const gitignore = `.history/
+.index/
+.lock
+.temp/
+.trash/
+`
+
+// EnsureBoardDir creates the board directory if it doesn't exist, with a
+// .gitignore for the board's derived files unless it has one.
 // Note: system.md is no longer created for new boards (distributed IDs don't need counters).
 func EnsureBoardDir(boardDir string) error {
 	if err := os.MkdirAll(boardDir, 0755); err != nil {
 		return fmt.Errorf("creating board directory: %w", err)
 	}
+	path := filepath.Join(boardDir, ".gitignore")
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		if err := os.WriteFile(path, []byte(gitignore), 0644); err != nil {
+			return fmt.Errorf("writing %s: %w", path, err)
+		}
+	}
 	return nil
 }
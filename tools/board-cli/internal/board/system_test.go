@@ -61,3 +61,26 @@ func TestReadWriteCounters(t *testing.T) {
 		t.Errorf("got %+v, want %+v", loaded, original)
 	}
 }
+
+func TestEnsureBoardDirIgnoresDerivedFiles(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), ".task-board")
+	if err := EnsureBoardDir(dir); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, ".gitignore"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != gitignore {
+		t.Errorf(".gitignore = %q", data)
+	}
+
+	// A .gitignore of the user's own is left alone
+	os.WriteFile(filepath.Join(dir, ".gitignore"), []byte("mine\n"), 0644)
+	if err := EnsureBoardDir(dir); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, ".gitignore")); string(data) != "mine\n" {
+		t.Errorf("existing .gitignore overwritten: %q", data)
+	}
+}
@@ -0,0 +1,189 @@
+package board
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TimeEntry is one entry of an element's time log: work logged after the
+// fact or a stopped timer, or a timer still running.
+type TimeEntry struct {
+	Start    time.Time
+	Duration time.Duration // zero while running
+	Running  bool
+	Note     string
+}
+
+// Spent returns the time of the entry, counting a running timer up to now.
+func (t TimeEntry) Spent(now time.Time) time.Duration {
+	if t.Running {
+		return now.Sub(t.Start)
+	}
+	return t.Duration
+}
+
+// FormatDuration writes a duration in whole minutes, e.g. "1h30m", "45m",
+// "2h" or "0m".
+func FormatDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	h, m := int(d/time.Hour), int(d%time.Hour/time.Minute)
+	switch {
+	case h == 0:
+		return fmt.Sprintf("%dm", m)
+	case m == 0:
+		return fmt.Sprintf("%dh", h)
+	}
+	return fmt.Sprintf("%dh%dm", h, m)
+}
+
+// ParseDuration parses a logged duration such as "1h30m", "90m" or "2h".
+func ParseDuration(s string) (time.Duration, error) {
+	d, err := time.ParseDuration(strings.ToLower(strings.TrimSpace(s)))
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid duration %q (use e.g. 1h30m, 45m, 2h)", s)
+	}
+	return d, nil
+}
+
+// parseTimeEntry parses a time log line like
+// "2026-01-02T15:04:05Z 1h30m fixed the parser" or
+// "2026-01-02T15:04:05Z running".
+func parseTimeEntry(line string) (TimeEntry, bool) {
+	fields := strings.SplitN(line, " ", 3)
+	if len(fields) < 2 {
+		return TimeEntry{}, false
+	}
+	start, err := time.Parse(time.RFC3339, fields[0])
+	if err != nil {
+		return TimeEntry{}, false
+	}
+	entry := TimeEntry{Start: start}
+	if len(fields) == 3 {
+		entry.Note = strings.TrimSpace(fields[2])
+	}
+	if fields[1] == "running" {
+		entry.Running = true
+		return entry, true
+	}
+	if entry.Duration, err = ParseDuration(fields[1]); err != nil {
+		return TimeEntry{}, false
+	}
+	return entry, true
+}
+
+func formatTimeEntry(t TimeEntry) string {
+	d := "running"
+	if !t.Running {
+		d = FormatDuration(t.Duration)
+	}
+	line := t.Start.UTC().Format(time.RFC3339) + " " + d
+	if t.Note != "" {
+		line += " " + t.Note
+	}
+	return line
+}
+
+// Timer returns the running timer of the time log, if any.
+func (pd *ProgressData) Timer() *TimeEntry {
+	for i := range pd.TimeLog {
+		if pd.TimeLog[i].Running {
+			return &pd.TimeLog[i]
+		}
+	}
+	return nil
+}
+
+// StartTimer starts a timer at now; an element runs one timer at a time.
+func (pd *ProgressData) StartTimer(now time.Time) error {
+	if t := pd.Timer(); t != nil {
+		return fmt.Errorf("timer already running since %s", t.Start.UTC().Format(time.RFC3339))
+	}
+	pd.TimeLog = append(pd.TimeLog, TimeEntry{Start: now.UTC(), Running: true})
+	return nil
+}
+
+// StopTimer stops the running timer at now, rounded to the minute, and
+// returns the finished entry. A non-empty note replaces the entry's note.
+// A timer stopped within half a minute is dropped from the log, as a
+// zero entry could not be logged by hand either; it is returned with no
+// duration.
+func (pd *ProgressData) StopTimer(now time.Time, note string) (TimeEntry, error) {
+	for i := range pd.TimeLog {
+		t := &pd.TimeLog[i]
+		if !t.Running {
+			continue
+		}
+		t.Running = false
+		t.Duration = now.Sub(t.Start).Round(time.Minute)
+		if note != "" {
+			t.Note = note
+		}
+		entry := *t
+		if entry.Duration <= 0 {
+			pd.TimeLog = append(pd.TimeLog[:i], pd.TimeLog[i+1:]...)
+		}
+		return entry, nil
+	}
+	return TimeEntry{}, fmt.Errorf("no timer running")
+}
+
+// LogTime records work of the given duration that ended now.
+func (pd *ProgressData) LogTime(now time.Time, d time.Duration, note string) TimeEntry {
+	entry := TimeEntry{Start: now.Add(-d).UTC().Truncate(time.Second), Duration: d, Note: note}
+	pd.TimeLog = append(pd.TimeLog, entry)
+	return entry
+}
+
+// Running reports whether the element has a timer running.
+func (e *Element) Running() bool {
+	for _, t := range e.TimeLog {
+		if t.Running {
+			return true
+		}
+	}
+	return false
+}
+
+// TimeSpent is the time logged on an element itself, with running timers
+// counted up to now.
+func (e *Element) TimeSpent(now time.Time) time.Duration {
+	var d time.Duration
+	for _, t := range e.TimeLog {
+		d += t.Spent(now)
+	}
+	return d
+}
+
+// TimeTotals is the time of an element and everything below it.
+type TimeTotals struct {
+	Spent time.Duration
+	// Estimate is the element's own estimate, or the sum of its children's
+	// when it has none.
+	Estimate time.Duration
+}
+
+// Totals rolls up the time spent below an element and its estimate.
+func (b *Board) Totals(e *Element, now time.Time) TimeTotals {
+	totals := TimeTotals{Spent: e.TimeSpent(now)}
+	var childEstimates time.Duration
+	for _, c := range b.Children(e) {
+		ct := b.Totals(c, now)
+		totals.Spent += ct.Spent
+		childEstimates += ct.Estimate
+	}
+	totals.Estimate = e.Estimate
+	if totals.Estimate == 0 {
+		totals.Estimate = childEstimates
+	}
+	return totals
+}
+
+// Accuracy returns the time spent as a whole percentage of the estimate, and
+// false without an estimate.
+func (t TimeTotals) Accuracy() (int, bool) {
+	if t.Estimate == 0 {
+		return 0, false
+	}
+	return int(t.Spent * 100 / t.Estimate), true
+}
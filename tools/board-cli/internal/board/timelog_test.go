@@ -0,0 +1,115 @@
+package board
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTimeLogRoundTrip(t *testing.T) {
+	start := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	pd := &ProgressData{Status: StatusDevelopment, Estimate: 4 * time.Hour}
+	if err := pd.StartTimer(start); err != nil {
+		t.Fatal(err)
+	}
+	if err := pd.StartTimer(start); err == nil {
+		t.Error("expected an error starting a second timer")
+	}
+	entry, err := pd.StopTimer(start.Add(90*time.Minute+20*time.Second), "parser")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.Duration != 90*time.Minute || entry.Running {
+		t.Errorf("stopped entry = %+v", entry)
+	}
+	if _, err := pd.StopTimer(start, ""); err == nil {
+		t.Error("expected an error without a running timer")
+	}
+	if err := pd.StartTimer(start.Add(2 * time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if entry, err := pd.StopTimer(start.Add(2*time.Hour+20*time.Second), ""); err != nil || entry.Duration != 0 {
+		t.Errorf("quick stop = %+v, %v", entry, err)
+	}
+	if len(pd.TimeLog) != 1 {
+		t.Errorf("a timer stopped within half a minute should be dropped, got %+v", pd.TimeLog)
+	}
+	pd.LogTime(start.Add(5*time.Hour), 45*time.Minute, "")
+	if err := pd.StartTimer(start.Add(6 * time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	content := WriteProgress(pd)
+	for _, want := range []string{
+		"## Estimate\n4h\n",
+		"## Time Log\n- 2026-01-02T10:00:00Z 1h30m parser\n- 2026-01-02T14:15:00Z 45m\n- 2026-01-02T16:00:00Z running\n",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("missing %q in:\n%s", want, content)
+		}
+	}
+
+	pd2, err := ParseProgress(content + "\n## Time Log\n- yesterday 2h\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pd2.Estimate != 4*time.Hour || len(pd2.TimeLog) != 3 || pd2.TimeLog[0].Note != "parser" || !pd2.TimeLog[2].Running {
+		t.Errorf("parsed = %+v", pd2)
+	}
+
+	empty := WriteProgress(&ProgressData{Status: StatusBacklog})
+	if strings.Contains(empty, "## Estimate") || strings.Contains(empty, "## Time Log") {
+		t.Errorf("an empty time log should not be written:\n%s", empty)
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	for d, want := range map[time.Duration]string{
+		0:                               "0m",
+		45 * time.Minute:                "45m",
+		2 * time.Hour:                   "2h",
+		90*time.Minute + 40*time.Second: "1h31m",
+		26 * time.Hour:                  "26h",
+	} {
+		if got := FormatDuration(d); got != want {
+			t.Errorf("FormatDuration(%v) = %q, want %q", d, got, want)
+		}
+	}
+	if d, err := ParseDuration("1H30m"); err != nil || d != 90*time.Minute {
+		t.Errorf("ParseDuration = %v, %v", d, err)
+	}
+	if _, err := ParseDuration("soon"); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestTotals(t *testing.T) {
+	now := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+	epic := &Element{Type: EpicType, RawID: "EPIC-1"}
+	story := &Element{Type: StoryType, RawID: "STORY-1", ParentID: "EPIC-1", Estimate: 10 * time.Hour,
+		TimeLog: []TimeEntry{{Start: now.Add(-5 * time.Hour), Duration: time.Hour}}}
+	task := &Element{Type: TaskType, RawID: "TASK-1", ParentID: "STORY-1", Estimate: 2 * time.Hour,
+		TimeLog: []TimeEntry{{Start: now.Add(-30 * time.Minute), Running: true}}}
+	bug := &Element{Type: BugType, RawID: "BUG-1", ParentID: "STORY-1", Estimate: time.Hour}
+	b := &Board{Elements: []*Element{epic, story, task, bug}}
+
+	got := b.Totals(story, now)
+	if got.Spent != 90*time.Minute || got.Estimate != 10*time.Hour {
+		t.Errorf("story totals = %+v", got)
+	}
+	// The epic has no estimate of its own and takes its story's
+	got = b.Totals(epic, now)
+	if got.Spent != 90*time.Minute || got.Estimate != 10*time.Hour {
+		t.Errorf("epic totals = %+v", got)
+	}
+	if pct, ok := got.Accuracy(); !ok || pct != 15 {
+		t.Errorf("accuracy = %d, %v", pct, ok)
+	}
+	story.Estimate = 0
+	if got := b.Totals(epic, now); got.Estimate != 3*time.Hour {
+		t.Errorf("estimate from tasks = %v", got.Estimate)
+	}
+	if !task.Running() || story.Running() {
+		t.Error("Running() wrong")
+	}
+}
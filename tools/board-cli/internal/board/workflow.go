@@ -0,0 +1,550 @@
+package board
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// WorkflowFile is the optional workflow definition in the board directory.
+const WorkflowFile = "workflow.yaml"
+
+// Category groups statuses by what they mean for summaries and automation.
+type Category string
+
+const (
+	CategoryTodo    Category = "todo"    // not started
+	CategoryActive  Category = "active"  // being worked on
+	CategoryDone    Category = "done"    // finished
+	CategoryClosed  Category = "closed"  // finished without being done
+	CategoryBlocked Category = "blocked" // on hold
+)
+
+// Categories lists the valid categories in display order.
+var Categories = []Category{CategoryTodo, CategoryActive, CategoryDone, CategoryClosed, CategoryBlocked}
+
+// Finished reports whether elements in the category no longer block others
+// and count towards promoting their parent.
+func (c Category) Finished() bool {
+	return c == CategoryDone || c == CategoryClosed
+}
+
+// categoryColors are the DOT fill and terminal colours of statuses that
+// don't set their own.
+var categoryColors = map[Category][2]string{
+	CategoryTodo:    {"#f5f5f5", "#808080"},
+	CategoryActive:  {"#fff3cd", "#FFA500"},
+	CategoryDone:    {"#d4edda", "#32CD32"},
+	CategoryClosed:  {"#6c757d", "#32CD32"},
+	CategoryBlocked: {"#f8d7da", "#FF4500"},
+}
+
+// StatusDef describes one status of a flow.
+type StatusDef struct {
+	Name     Status   `yaml:"name" json:"name"`
+	Category Category `yaml:"category" json:"category"`
+	// Color is the DOT fill colour, TextColor the colour used in terminals.
+	Color     string   `yaml:"color,omitempty" json:"color"`
+	TextColor string   `yaml:"text-color,omitempty" json:"textColor"`
+	Aliases   []string `yaml:"aliases,omitempty" json:"aliases,omitempty"`
+	// NeedsUnblocked refuses the status while the element has unfinished blockers.
+	NeedsUnblocked bool `yaml:"needs-unblocked,omitempty" json:"needsUnblocked"`
+}
+
+// Flow is the set of statuses one element type moves through.
+type Flow struct {
+	Statuses []StatusDef `yaml:"statuses,omitempty" json:"statuses"`
+	// Transitions lists the statuses each status may be changed to. A status
+	// without an entry may change to any status.
+	Transitions map[Status][]Status `yaml:"transitions,omitempty" json:"transitions,omitempty"`
+	// Initial is the status of new elements (default: the first status).
+	Initial Status `yaml:"initial,omitempty" json:"initial"`
+	// PromoteTo is set on a parent once all its children are finished
+	// (default: the first done status).
+	PromoteTo Status `yaml:"promote-to,omitempty" json:"promoteTo"`
+	// ReopenTo is set on a finished parent when a child becomes active
+	// again (default: the first active status; none means never reopen).
+	ReopenTo Status `yaml:"reopen-to,omitempty" json:"reopenTo"`
+}
+
+// WIPLimits caps work in progress. A zero or missing limit means none.
+type WIPLimits struct {
+	// Status caps the tasks and bugs in each status.
+	Status map[Status]int `yaml:"status,omitempty" json:"status,omitempty"`
+	// Story caps the tasks and bugs in an active status under one story.
+	Story int `yaml:"story,omitempty" json:"story,omitempty"`
+	// Assignee caps the unfinished elements assigned to one agent;
+	// Assignees overrides it per agent.
+	Assignee  int            `yaml:"assignee,omitempty" json:"assignee,omitempty"`
+	Assignees map[string]int `yaml:"assignees,omitempty" json:"assignees,omitempty"`
+}
+
+// TimeTracking runs a timer on an element while it is in one of the Auto
+// statuses: entering one starts the timer, leaving them stops it.
+type TimeTracking struct {
+	Auto []Status `yaml:"auto,omitempty" json:"auto,omitempty"`
+}
+
+// AssigneeLimit returns the WIP limit of an agent, 0 for none.
+func (l WIPLimits) AssigneeLimit(agent string) int {
+	if n, ok := l.Assignees[agent]; ok {
+		return n
+	}
+	return l.Assignee
+}
+
+// Workflow holds the flow of every element type. The top-level flow applies
+// to all types; a section under types overrides the keys it sets.
+type Workflow struct {
+	Flow  `yaml:",inline"`
+	Types map[ElementType]*Flow `yaml:"types,omitempty"`
+	// WIP holds the work-in-progress limits of the board.
+	WIP WIPLimits `yaml:"wip-limits,omitempty"`
+	// TimeTracking holds the statuses that run a timer automatically.
+	TimeTracking TimeTracking `yaml:"time-tracking,omitempty"`
+
+	// Path is the file the workflow was read from, empty for the default.
+	Path string `yaml:"-"`
+
+	resolved map[ElementType]*Flow
+}
+
+// AllTypes lists the element types from the top of the hierarchy down.
+var AllTypes = []ElementType{EpicType, StoryType, TaskType, BugType}
+
+func defaultFlow() Flow {
+	return Flow{
+		Statuses: []StatusDef{
+			{Name: StatusBacklog, Category: CategoryTodo, Color: "#f5f5f5", TextColor: "#808080"},
+			{Name: StatusAnalysis, Category: CategoryActive, Color: "#cce5ff", TextColor: "#00BFFF"},
+			{Name: StatusToDev, Category: CategoryTodo, Color: "#ffffff", TextColor: "#00BFFF", Aliases: []string{"todev"}},
+			{Name: StatusDevelopment, Category: CategoryActive, Color: "#fff3cd", TextColor: "#FFA500", Aliases: []string{"dev"}, NeedsUnblocked: true},
+			{Name: StatusToReview, Category: CategoryActive, Color: "#ffd699", TextColor: "#9370DB", Aliases: []string{"toreview"}, NeedsUnblocked: true},
+			{Name: StatusReviewing, Category: CategoryActive, Color: "#ffeb99", TextColor: "#9370DB", Aliases: []string{"review"}, NeedsUnblocked: true},
+			{Name: StatusDone, Category: CategoryDone, Color: "#d4edda", TextColor: "#32CD32", NeedsUnblocked: true},
+			{Name: StatusClosed, Category: CategoryClosed, Color: "#6c757d", TextColor: "#32CD32"},
+			{Name: StatusBlocked, Category: CategoryBlocked, Color: "#f8d7da", TextColor: "#FF4500"},
+		},
+		Initial:   StatusBacklog,
+		PromoteTo: StatusDone,
+		ReopenTo:  StatusDevelopment,
+	}
+}
+
+var defaultWorkflow = mustResolve(&Workflow{Flow: defaultFlow()})
+
+func mustResolve(w *Workflow) *Workflow {
+	if err := w.resolve(); err != nil {
+		panic(err)
+	}
+	return w
+}
+
+// DefaultWorkflow returns the built-in workflow used when the board has no
+// workflow file.
+func DefaultWorkflow() *Workflow {
+	return defaultWorkflow
+}
+
+// WorkflowPath returns the path of the workflow file in the board directory.
+func WorkflowPath(boardDir string) string {
+	return filepath.Join(boardDir, WorkflowFile)
+}
+
+// LoadWorkflow reads the board's workflow file, falling back to the default
+// workflow when there is none.
+func LoadWorkflow(boardDir string) (*Workflow, error) {
+	path := WorkflowPath(boardDir)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return DefaultWorkflow(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading workflow: %w", err)
+	}
+	w, err := ParseWorkflow(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	w.Path = path
+	return w, nil
+}
+
+// ParseWorkflow parses and checks a workflow definition.
+func ParseWorkflow(data []byte) (*Workflow, error) {
+	w := &Workflow{}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(w); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("parsing workflow: %w", err)
+	}
+	if err := w.resolve(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// resolve works out the flow of each type. A level that lists its own
+// statuses starts afresh; otherwise it inherits the statuses and the keys it
+// doesn't set from the level above (type → top level → built-in default).
+func (w *Workflow) resolve() error {
+	for t := range w.Types {
+		if t.Prefix() == "" {
+			return fmt.Errorf("types: unknown element type %q (valid: epic, story, task, bug)", t)
+		}
+	}
+	base := defaultFlow()
+	top := inherit(w.Flow, base)
+	w.resolved = make(map[ElementType]*Flow, len(AllTypes))
+	for _, t := range AllTypes {
+		f := top
+		if override := w.Types[t]; override != nil {
+			f = inherit(*override, top)
+		}
+		f.fillDefaults()
+		if err := f.check(); err != nil {
+			return fmt.Errorf("%s workflow: %w", t, err)
+		}
+		w.resolved[t] = &f
+	}
+	if err := w.checkWIP(); err != nil {
+		return err
+	}
+	return w.checkTimeTracking()
+}
+
+func (w *Workflow) checkTimeTracking() error {
+	for i, s := range w.TimeTracking.Auto {
+		known := false
+		for _, f := range w.resolved {
+			if n := f.Normalize(s); f.Def(n) != nil {
+				s, known = n, true
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("time-tracking: unknown status %q", s)
+		}
+		w.TimeTracking.Auto[i] = s
+	}
+	return nil
+}
+
+func (w *Workflow) checkWIP() error {
+	for s, n := range w.WIP.Status {
+		if n < 0 {
+			return fmt.Errorf("wip-limits: status %s: limit must not be negative", s)
+		}
+		known := false
+		for _, f := range w.resolved {
+			known = known || f.Def(s) != nil
+		}
+		if !known {
+			return fmt.Errorf("wip-limits: unknown status %q", s)
+		}
+	}
+	for agent, n := range w.WIP.Assignees {
+		if n < 0 {
+			return fmt.Errorf("wip-limits: assignee %s: limit must not be negative", agent)
+		}
+	}
+	if w.WIP.Story < 0 || w.WIP.Assignee < 0 {
+		return fmt.Errorf("wip-limits: limits must not be negative")
+	}
+	return nil
+}
+
+// inherit fills the keys f leaves unset from parent.
+func inherit(f, parent Flow) Flow {
+	if len(f.Statuses) == 0 {
+		f.Statuses = parent.Statuses
+		if f.Transitions == nil {
+			f.Transitions = parent.Transitions
+		}
+		if f.Initial == "" {
+			f.Initial = parent.Initial
+		}
+		if f.PromoteTo == "" {
+			f.PromoteTo = parent.PromoteTo
+		}
+		if f.ReopenTo == "" {
+			f.ReopenTo = parent.ReopenTo
+		}
+	}
+	return f
+}
+
+func (f *Flow) fillDefaults() {
+	statuses := make([]StatusDef, len(f.Statuses))
+	for i, s := range f.Statuses {
+		s.Name = Status(strings.ToLower(string(s.Name)))
+		s.Category = Category(strings.ToLower(string(s.Category)))
+		if colors, ok := categoryColors[s.Category]; ok {
+			if s.Color == "" {
+				s.Color = colors[0]
+			}
+			if s.TextColor == "" {
+				s.TextColor = colors[1]
+			}
+		}
+		statuses[i] = s
+	}
+	f.Statuses = statuses
+	if f.Initial == "" && len(f.Statuses) > 0 {
+		f.Initial = f.Statuses[0].Name
+	}
+	if f.PromoteTo == "" {
+		f.PromoteTo, _ = f.First(CategoryDone)
+	}
+	if f.ReopenTo == "" {
+		f.ReopenTo, _ = f.First(CategoryActive)
+	}
+}
+
+func (f *Flow) check() error {
+	if len(f.Statuses) == 0 {
+		return fmt.Errorf("no statuses defined")
+	}
+	seen := map[string]bool{}
+	for _, s := range f.Statuses {
+		if s.Name == "" || strings.ContainsAny(string(s.Name), " \t") {
+			return fmt.Errorf("invalid status name %q", s.Name)
+		}
+		if _, ok := categoryColors[s.Category]; !ok {
+			return fmt.Errorf("status %s: unknown category %q (valid: todo, active, done, closed, blocked)", s.Name, s.Category)
+		}
+		for _, name := range append([]string{string(s.Name)}, s.Aliases...) {
+			name = strings.ToLower(name)
+			if seen[name] {
+				return fmt.Errorf("status %q is defined twice", name)
+			}
+			seen[name] = true
+		}
+	}
+	if _, ok := f.First(CategoryDone); !ok {
+		return fmt.Errorf("at least one status needs the done category")
+	}
+	for _, key := range []struct {
+		name   string
+		status Status
+		ok     func(Category) bool
+	}{
+		{"initial", f.Initial, func(Category) bool { return true }},
+		{"promote-to", f.PromoteTo, Category.Finished},
+		{"reopen-to", f.ReopenTo, func(c Category) bool { return c == CategoryTodo || c == CategoryActive }},
+	} {
+		if key.status == "" && key.name == "reopen-to" {
+			continue
+		}
+		def := f.Def(key.status)
+		if def == nil {
+			return fmt.Errorf("%s: unknown status %q", key.name, key.status)
+		}
+		if !key.ok(def.Category) {
+			return fmt.Errorf("%s: status %s has the wrong category (%s)", key.name, def.Name, def.Category)
+		}
+	}
+	for from, targets := range f.Transitions {
+		if f.Def(from) == nil {
+			return fmt.Errorf("transitions: unknown status %q", from)
+		}
+		for _, to := range targets {
+			if f.Def(to) == nil {
+				return fmt.Errorf("transitions from %s: unknown status %q", from, to)
+			}
+		}
+	}
+	return nil
+}
+
+// For returns the flow of an element type. A nil workflow is the default.
+func (w *Workflow) For(t ElementType) *Flow {
+	if w == nil {
+		w = defaultWorkflow
+	}
+	if f, ok := w.resolved[t]; ok {
+		return f
+	}
+	return defaultWorkflow.resolved[TaskType]
+}
+
+// Limits returns the WIP limits. A nil workflow has none.
+func (w *Workflow) Limits() WIPLimits {
+	if w == nil {
+		return WIPLimits{}
+	}
+	return w.WIP
+}
+
+// Timed reports whether the status runs a timer automatically.
+func (w *Workflow) Timed(s Status) bool {
+	if w == nil {
+		return false
+	}
+	for _, t := range w.TimeTracking.Auto {
+		if t == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Category returns the category of an element's current status.
+func (w *Workflow) Category(e *Element) Category {
+	return w.For(e.Type).Category(e.Status)
+}
+
+// Finished reports whether an element is done or closed.
+func (w *Workflow) Finished(e *Element) bool {
+	return w.Category(e).Finished()
+}
+
+// ParseAny parses a status that exists in the flow of any element type.
+func (w *Workflow) ParseAny(s string) (Status, error) {
+	var names []string
+	for _, t := range AllTypes {
+		f := w.For(t)
+		if status, err := f.Parse(s); err == nil {
+			return status, nil
+		}
+		for _, name := range f.Names() {
+			if !containsString(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	return "", fmt.Errorf("unknown status: %s (valid: %s)", s, strings.Join(names, ", "))
+}
+
+// Statuses returns every status used by any element type, in the order they
+// are first defined. Where types define the same status, the first wins.
+func (w *Workflow) Statuses() []StatusDef {
+	var result []StatusDef
+	seen := map[Status]bool{}
+	for _, t := range AllTypes {
+		for _, s := range w.For(t).Statuses {
+			if !seen[s.Name] {
+				seen[s.Name] = true
+				result = append(result, s)
+			}
+		}
+	}
+	return result
+}
+
+// Parse resolves a status name or alias, ignoring case.
+func (f *Flow) Parse(s string) (Status, error) {
+	lower := strings.ToLower(strings.TrimSpace(s))
+	for _, def := range f.Statuses {
+		if string(def.Name) == lower || containsString(def.Aliases, lower) {
+			return def.Name, nil
+		}
+	}
+	return "", fmt.Errorf("unknown status: %s (valid: %s)", s, strings.Join(f.Names(), ", "))
+}
+
+// Normalize resolves aliases in a status read from progress.md. The "open"
+// placeholder of the progress templates becomes the initial status unless
+// the flow defines it; unknown statuses are kept as written.
+func (f *Flow) Normalize(s Status) Status {
+	if parsed, err := f.Parse(string(s)); err == nil {
+		return parsed
+	}
+	if s == "open" {
+		return f.Initial
+	}
+	return s
+}
+
+// Names returns the status names in order.
+func (f *Flow) Names() []string {
+	names := make([]string, len(f.Statuses))
+	for i, def := range f.Statuses {
+		names[i] = string(def.Name)
+	}
+	return names
+}
+
+// Def returns the definition of a status, or nil if the flow doesn't have it.
+func (f *Flow) Def(s Status) *StatusDef {
+	for i := range f.Statuses {
+		if f.Statuses[i].Name == s {
+			return &f.Statuses[i]
+		}
+	}
+	return nil
+}
+
+// Category returns the category of a status. Statuses the flow doesn't
+// define fall back to the default workflow, then to todo.
+func (f *Flow) Category(s Status) Category {
+	if def := f.Def(s); def != nil {
+		return def.Category
+	}
+	if f != defaultWorkflow.resolved[TaskType] {
+		return defaultWorkflow.For(TaskType).Category(s)
+	}
+	return CategoryTodo
+}
+
+// First returns the first status of a category.
+func (f *Flow) First(c Category) (Status, bool) {
+	for _, def := range f.Statuses {
+		if def.Category == c {
+			return def.Name, true
+		}
+	}
+	return "", false
+}
+
+// Allows reports whether a status may be changed from one value to another.
+func (f *Flow) Allows(from, to Status) bool {
+	if from == to {
+		return true
+	}
+	targets, ok := f.Transitions[from]
+	if !ok {
+		return true
+	}
+	for _, t := range targets {
+		if t == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Color returns the DOT fill colour of a status.
+func (f *Flow) Color(s Status) string {
+	if def := f.Def(s); def != nil {
+		return def.Color
+	}
+	if f != defaultWorkflow.resolved[TaskType] {
+		return defaultWorkflow.For(TaskType).Color(s)
+	}
+	return "#ffffff"
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClosedStatus returns the status elements are closed with when they are
+// dropped rather than finished: the first closed status, else PromoteTo.
+func (f *Flow) ClosedStatus() Status {
+	if s, ok := f.First(CategoryClosed); ok {
+		return s
+	}
+	return f.PromoteTo
+}
@@ -0,0 +1,132 @@
+package board
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDefaultWorkflow(t *testing.T) {
+	w, err := LoadWorkflow(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, typ := range AllTypes {
+		f := w.For(typ)
+		if len(f.Statuses) != 9 || f.Initial != StatusBacklog || f.PromoteTo != StatusDone || f.ReopenTo != StatusDevelopment {
+			t.Errorf("%s: unexpected default flow %+v", typ, f)
+		}
+	}
+	f := w.For(TaskType)
+	if s, err := f.Parse("DEV"); err != nil || s != StatusDevelopment {
+		t.Errorf("Parse(DEV) = %q, %v", s, err)
+	}
+	if f.Category(StatusToDev) != CategoryTodo || f.Category(StatusReviewing) != CategoryActive {
+		t.Error("wrong default categories")
+	}
+	if !f.Def(StatusDone).NeedsUnblocked || f.Def(StatusAnalysis).NeedsUnblocked {
+		t.Error("done needs unblocked work, analysis doesn't")
+	}
+	if !f.Allows(StatusBacklog, StatusDone) {
+		t.Error("the default workflow allows every transition")
+	}
+}
+
+func TestWorkflowInheritance(t *testing.T) {
+	w, err := ParseWorkflow([]byte(`
+transitions:
+  backlog: [analysis]
+types:
+  epic:
+    reopen-to: analysis
+  bug:
+    statuses:
+      - {name: triage, category: todo}
+      - {name: fixing, category: active, color: "#123456"}
+      - {name: verified, category: done}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	epic := w.For(EpicType)
+	if epic.ReopenTo != StatusAnalysis || epic.Allows(StatusBacklog, StatusDone) {
+		t.Errorf("epic should inherit transitions and override reopen-to: %+v", epic)
+	}
+	bug := w.For(BugType)
+	if bug.Initial != "triage" || bug.PromoteTo != "verified" || bug.ReopenTo != "fixing" {
+		t.Errorf("bug defaults = %s/%s/%s", bug.Initial, bug.PromoteTo, bug.ReopenTo)
+	}
+	if !bug.Allows("triage", "verified") {
+		t.Error("a flow with its own statuses should not inherit transitions")
+	}
+	if bug.Color("fixing") != "#123456" || bug.Def("triage").Color != "#f5f5f5" {
+		t.Error("colours should default by category")
+	}
+	if bug.ClosedStatus() != "verified" {
+		t.Errorf("without a closed status, elements close as done, got %s", bug.ClosedStatus())
+	}
+	if s, err := w.ParseAny("verified"); err != nil || s != "verified" {
+		t.Errorf("ParseAny(verified) = %q, %v", s, err)
+	}
+	if _, err := bug.Parse("done"); err == nil {
+		t.Error("done is not a bug status")
+	}
+}
+
+func TestWorkflowErrors(t *testing.T) {
+	tests := []struct {
+		yaml string
+		want string
+	}{
+		{"types:\n  chore: {}\n", "unknown element type"},
+		{"statuses:\n  - {name: a, category: waiting}\n", "unknown category"},
+		{"statuses:\n  - {name: a, category: todo}\n", "done category"},
+		{"statuses:\n  - {name: a, category: done}\n  - {name: b, category: todo, aliases: [a]}\n", "defined twice"},
+		{"transitions:\n  backlog: [shipped]\n", "unknown status"},
+		{"promote-to: backlog\n", "wrong category"},
+		{"colour: red\n", "not found"},
+		{"wip-limits:\n  status: {shipping: 2}\n", "unknown status"},
+		{"wip-limits:\n  assignee: -1\n", "must not be negative"},
+		{"time-tracking:\n  auto: [coding]\n", "time-tracking: unknown status"},
+	}
+	for _, tt := range tests {
+		if _, err := ParseWorkflow([]byte(tt.yaml)); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("ParseWorkflow(%q) error = %v, want %q", tt.yaml, err, tt.want)
+		}
+	}
+}
+
+func TestTimeTracking(t *testing.T) {
+	w, err := ParseWorkflow([]byte("time-tracking:\n  auto: [dev, reviewing]\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !w.Timed(StatusDevelopment) || !w.Timed(StatusReviewing) || w.Timed(StatusToReview) {
+		t.Errorf("auto = %v", w.TimeTracking.Auto)
+	}
+	if DefaultWorkflow().Timed(StatusDevelopment) {
+		t.Error("the default workflow should not track time")
+	}
+}
+
+func TestLoadCustomStatuses(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, WorkflowFile),
+		[]byte("types:\n  epic:\n    statuses:\n      - {name: idea, category: todo, aliases: [new]}\n      - {name: shipped, category: done}\n"), 0644)
+	epicDir := filepath.Join(dir, "EPIC-260101-aaaaaa_e")
+	os.MkdirAll(epicDir, 0755)
+	os.WriteFile(filepath.Join(epicDir, "progress.md"), []byte("## Status\nNew\n"), 0644)
+
+	b, err := Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	epic := b.Elements[0]
+	if epic.Status != "idea" {
+		t.Errorf("status = %q, want idea", epic.Status)
+	}
+	if b.Workflow.Finished(epic) {
+		t.Error("idea is not finished")
+	}
+}
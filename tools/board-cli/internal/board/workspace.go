@@ -0,0 +1,227 @@
+package board
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// WorkspaceSeparator separates the board name from the element ID in a
+// qualified ID, e.g. "api:TASK-260101-aaaaaa".
+const WorkspaceSeparator = ":"
+
+// WorkspaceConfig is the on-disk format of a workspace file.
+//
+//	boards:
+//	  - name: api
+//	    path: services/api/.task-board
+//	  - name: web
+//	    path: services/web/.task-board
+type WorkspaceConfig struct {
+	Boards []WorkspaceBoardConfig `yaml:"boards"`
+}
+
+// WorkspaceBoardConfig declares one board root in a workspace.
+// Relative paths are resolved against the workspace file's directory.
+type WorkspaceBoardConfig struct {
+	Name string `yaml:"name"`
+	Path string `yaml:"path"`
+}
+
+// Dir returns the board directory, resolving a relative path against the
+// directory of the workspace file at workspacePath.
+func (bc WorkspaceBoardConfig) Dir(workspacePath string) string {
+	if filepath.IsAbs(bc.Path) {
+		return bc.Path
+	}
+	return filepath.Join(filepath.Dir(workspacePath), bc.Path)
+}
+
+// WorkspaceBoard is a loaded board together with its workspace name.
+type WorkspaceBoard struct {
+	Name  string
+	Board *Board
+}
+
+// Workspace wraps several boards so they can be viewed and linked together.
+type Workspace struct {
+	Path   string // path to the workspace file
+	Boards []*WorkspaceBoard
+}
+
+// ReadWorkspaceConfig reads and validates a workspace file.
+func ReadWorkspaceConfig(path string) (*WorkspaceConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading workspace file: %w", err)
+	}
+	var cfg WorkspaceConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parsing workspace file: %w", err)
+	}
+	if len(cfg.Boards) == 0 {
+		return nil, fmt.Errorf("workspace file %s declares no boards", path)
+	}
+	seen := make(map[string]bool)
+	for _, bc := range cfg.Boards {
+		if bc.Name == "" || bc.Path == "" {
+			return nil, fmt.Errorf("workspace board entries need both name and path")
+		}
+		if strings.Contains(bc.Name, WorkspaceSeparator) {
+			return nil, fmt.Errorf("workspace board name %q must not contain %q", bc.Name, WorkspaceSeparator)
+		}
+		if seen[bc.Name] {
+			return nil, fmt.Errorf("duplicate workspace board name: %s", bc.Name)
+		}
+		seen[bc.Name] = true
+	}
+	return &cfg, nil
+}
+
+// LoadWorkspace reads the workspace file and loads every board it lists.
+func LoadWorkspace(path string) (*Workspace, error) {
+	cfg, err := ReadWorkspaceConfig(path)
+	if err != nil {
+		return nil, err
+	}
+
+	ws := &Workspace{Path: path}
+	for _, bc := range cfg.Boards {
+		b, err := Load(bc.Dir(path))
+		if err != nil {
+			return nil, fmt.Errorf("loading board %s: %w", bc.Name, err)
+		}
+		ws.Boards = append(ws.Boards, &WorkspaceBoard{Name: bc.Name, Board: b})
+	}
+	return ws, nil
+}
+
+// SplitQualifiedID splits "api:TASK-01" into ("api", "TASK-01").
+// Unqualified IDs return an empty board name.
+func SplitQualifiedID(id string) (string, string) {
+	if i := strings.Index(id, WorkspaceSeparator); i >= 0 {
+		return id[:i], id[i+1:]
+	}
+	return "", id
+}
+
+// IsQualifiedID reports whether id carries a board prefix.
+func IsQualifiedID(id string) bool {
+	return strings.Contains(id, WorkspaceSeparator)
+}
+
+// QualifyID prefixes id with the board name unless it is already qualified.
+func QualifyID(boardName, id string) string {
+	if IsQualifiedID(id) {
+		return id
+	}
+	return boardName + WorkspaceSeparator + id
+}
+
+// BoardByName returns the workspace board with the given name, or nil.
+func (w *Workspace) BoardByName(name string) *WorkspaceBoard {
+	for _, wb := range w.Boards {
+		if strings.EqualFold(wb.Name, name) {
+			return wb
+		}
+	}
+	return nil
+}
+
+// FindByID resolves a qualified ("api:TASK-01") or bare ID across all boards.
+// Bare IDs are looked up in every board and the first match wins.
+func (w *Workspace) FindByID(id string) (*Element, *WorkspaceBoard) {
+	name, raw := SplitQualifiedID(id)
+	if name != "" {
+		wb := w.BoardByName(name)
+		if wb == nil {
+			return nil, nil
+		}
+		if e := wb.Board.FindByID(raw); e != nil {
+			return e, wb
+		}
+		return nil, nil
+	}
+	for _, wb := range w.Boards {
+		if e := wb.Board.FindByID(raw); e != nil {
+			return e, wb
+		}
+	}
+	return nil, nil
+}
+
+// Ref returns how an element of `to` should be referenced from a file that
+// lives in board `from`: bare within the same board, qualified across boards.
+func (w *Workspace) Ref(from, to *WorkspaceBoard, e *Element) string {
+	if from == to {
+		return e.ID()
+	}
+	return QualifyID(to.Name, e.ID())
+}
+
+// ActiveBlockers returns blockers of e (which lives in wb) that are not
+// done/closed, resolving cross-board references.
+func (w *Workspace) ActiveBlockers(wb *WorkspaceBoard, e *Element) []*Element {
+	var active []*Element
+	for _, blockerID := range e.BlockedBy {
+		ref := blockerID
+		if !IsQualifiedID(ref) {
+			ref = QualifyID(wb.Name, ref)
+		}
+		blocker, owner := w.FindByID(ref)
+		if blocker == nil {
+			continue
+		}
+		if !owner.Board.Workflow.Finished(blocker) {
+			active = append(active, blocker)
+		}
+	}
+	return active
+}
+
+// Merged returns a single read-only Board containing copies of every element
+// with IDs, parents and links qualified by board name. Board helpers such as
+// Children, Ancestry and ActiveBlockers then work across boards unchanged.
+// The copies keep their on-disk Path; never write through them. Workflow is
+// the first board's; WorkflowOf, and so ActiveBlockers, judge each copy by
+// the workflow of its own board.
+func (w *Workspace) Merged() *Board {
+	merged := &Board{Dir: filepath.Dir(w.Path), Counters: &Counters{}, workflows: map[*Element]*Workflow{}}
+	if len(w.Boards) > 0 {
+		merged.Workflow = w.Boards[0].Board.Workflow
+	}
+	for _, wb := range w.Boards {
+		for _, e := range wb.Board.Elements {
+			c := *e
+			c.RawID = QualifyID(wb.Name, e.ID())
+			if e.ParentID != "" {
+				c.ParentID = QualifyID(wb.Name, e.ParentID)
+			}
+			c.BlockedBy = qualifyAll(wb.Name, e.BlockedBy)
+			c.Blocks = qualifyAll(wb.Name, e.Blocks)
+			if e.Links != nil {
+				c.Links = make([]Link, len(e.Links))
+				for i, l := range e.Links {
+					c.Links[i] = Link{Type: l.Type, Target: QualifyID(wb.Name, l.Target)}
+				}
+			}
+			merged.Elements = append(merged.Elements, &c)
+			merged.workflows[&c] = wb.Board.Workflow
+		}
+	}
+	return merged
+}
+
+func qualifyAll(boardName string, ids []string) []string {
+	if ids == nil {
+		return nil
+	}
+	out := make([]string, len(ids))
+	for i, id := range ids {
+		out[i] = QualifyID(boardName, id)
+	}
+	return out
+}
@@ -0,0 +1,180 @@
+package board
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// setupWorkspace creates two boards ("api" and "web") and a workspace file.
+// web's task is blocked by api's task through a qualified reference.
+func setupWorkspace(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+
+	writeElem := func(dir, status, blockedBy, blocks string) {
+		os.MkdirAll(dir, 0755)
+		os.WriteFile(filepath.Join(dir, "README.md"), []byte("# Title\n\n## Description\nd\n"), 0644)
+		os.WriteFile(filepath.Join(dir, "progress.md"),
+			[]byte("## Status\n"+status+"\n\n## Blocked By\n- "+blockedBy+"\n\n## Blocks\n- "+blocks+"\n"), 0644)
+	}
+
+	apiEpic := filepath.Join(root, "api", ".task-board", "EPIC-260101-aaaaaa_auth")
+	apiStory := filepath.Join(apiEpic, "STORY-260101-bbbbbb_login")
+	writeElem(apiEpic, "development", "(none)", "(none)")
+	writeElem(apiStory, "development", "(none)", "(none)")
+	writeElem(filepath.Join(apiStory, "TASK-260101-cccccc_endpoint"), "development", "(none)", "web:TASK-260101-ffffff")
+
+	webEpic := filepath.Join(root, "web", ".task-board", "EPIC-260101-dddddd_ui")
+	webStory := filepath.Join(webEpic, "STORY-260101-eeeeee_forms")
+	writeElem(webEpic, "backlog", "(none)", "(none)")
+	writeElem(webStory, "backlog", "(none)", "(none)")
+	writeElem(filepath.Join(webStory, "TASK-260101-ffffff_form"), "backlog", "api:TASK-260101-cccccc", "(none)")
+
+	wsPath := filepath.Join(root, "workspace.yaml")
+	os.WriteFile(wsPath, []byte("boards:\n  - name: api\n    path: api/.task-board\n  - name: web\n    path: web/.task-board\n"), 0644)
+	return wsPath
+}
+
+func TestLoadWorkspace(t *testing.T) {
+	ws, err := LoadWorkspace(setupWorkspace(t))
+	if err != nil {
+		t.Fatalf("LoadWorkspace: %v", err)
+	}
+	if len(ws.Boards) != 2 {
+		t.Fatalf("expected 2 boards, got %d", len(ws.Boards))
+	}
+	if ws.Boards[0].Name != "api" || len(ws.Boards[0].Board.Elements) != 3 {
+		t.Errorf("api board not loaded correctly: %+v", ws.Boards[0])
+	}
+}
+
+func TestWorkspaceFindByID(t *testing.T) {
+	ws, err := LoadWorkspace(setupWorkspace(t))
+	if err != nil {
+		t.Fatalf("LoadWorkspace: %v", err)
+	}
+
+	e, wb := ws.FindByID("web:TASK-260101-ffffff")
+	if e == nil || wb.Name != "web" {
+		t.Fatalf("qualified lookup failed: %v %v", e, wb)
+	}
+
+	e, wb = ws.FindByID("task-260101-cccccc")
+	if e == nil || wb.Name != "api" {
+		t.Fatalf("bare lookup failed: %v %v", e, wb)
+	}
+
+	if e, _ := ws.FindByID("web:TASK-260101-cccccc"); e != nil {
+		t.Error("element must not be found in the wrong board")
+	}
+	if e, _ := ws.FindByID("nope:TASK-260101-cccccc"); e != nil {
+		t.Error("unknown board must not resolve")
+	}
+}
+
+func TestWorkspaceActiveBlockersCrossBoard(t *testing.T) {
+	ws, err := LoadWorkspace(setupWorkspace(t))
+	if err != nil {
+		t.Fatalf("LoadWorkspace: %v", err)
+	}
+	task, wb := ws.FindByID("web:TASK-260101-ffffff")
+	active := ws.ActiveBlockers(wb, task)
+	if len(active) != 1 || active[0].ID() != "TASK-260101-cccccc" {
+		t.Fatalf("expected api task as active blocker, got %v", active)
+	}
+}
+
+func TestWorkspaceMerged(t *testing.T) {
+	ws, err := LoadWorkspace(setupWorkspace(t))
+	if err != nil {
+		t.Fatalf("LoadWorkspace: %v", err)
+	}
+	merged := ws.Merged()
+	if len(merged.Elements) != 6 {
+		t.Fatalf("expected 6 merged elements, got %d", len(merged.Elements))
+	}
+
+	task := merged.FindByID("web:TASK-260101-ffffff")
+	if task == nil {
+		t.Fatal("merged board should expose qualified IDs")
+	}
+	if task.ParentID != "web:STORY-260101-eeeeee" {
+		t.Errorf("ParentID = %q, want qualified parent", task.ParentID)
+	}
+	if !merged.IsBlocked(task) {
+		t.Error("cross-board blocker should be resolved in merged board")
+	}
+	if got := merged.Ancestry(task); got != "web:EPIC-260101-dddddd > web:STORY-260101-eeeeee > web:TASK-260101-ffffff" {
+		t.Errorf("Ancestry = %q", got)
+	}
+
+	// Originals stay untouched
+	orig, _ := ws.FindByID("web:TASK-260101-ffffff")
+	if orig.ID() != "TASK-260101-ffffff" {
+		t.Errorf("original element ID was modified: %s", orig.ID())
+	}
+}
+
+func TestReadWorkspaceConfigErrors(t *testing.T) {
+	dir := t.TempDir()
+	cases := map[string]string{
+		"empty":     "boards: []\n",
+		"no-path":   "boards:\n  - name: a\n",
+		"separator": "boards:\n  - name: a:b\n    path: x\n",
+		"duplicate": "boards:\n  - name: a\n    path: x\n  - name: a\n    path: y\n",
+	}
+	for name, content := range cases {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, name+".yaml")
+			os.WriteFile(path, []byte(content), 0644)
+			if _, err := ReadWorkspaceConfig(path); err == nil {
+				t.Errorf("expected error for %s", name)
+			}
+		})
+	}
+}
+
+func TestQualifyID(t *testing.T) {
+	if got := QualifyID("api", "TASK-01"); got != "api:TASK-01" {
+		t.Errorf("QualifyID = %q", got)
+	}
+	if got := QualifyID("api", "web:TASK-01"); got != "web:TASK-01" {
+		t.Errorf("already qualified ID changed: %q", got)
+	}
+	name, raw := SplitQualifiedID("web:TASK-01")
+	if name != "web" || raw != "TASK-01" {
+		t.Errorf("SplitQualifiedID = %q, %q", name, raw)
+	}
+}
+
+func TestWorkspaceMergedJudgesStatusByOwnWorkflow(t *testing.T) {
+	wsPath := setupWorkspace(t)
+	root := filepath.Dir(wsPath)
+	// web finishes tasks as "shipped", a status api's workflow does not know;
+	// the api task now waits for the web one
+	os.WriteFile(filepath.Join(root, "web", ".task-board", WorkflowFile), []byte(`types:
+  task:
+    statuses:
+      - {name: todo, category: todo}
+      - {name: shipped, category: done}
+`), 0644)
+	webTask := filepath.Join(root, "web", ".task-board", "EPIC-260101-dddddd_ui", "STORY-260101-eeeeee_forms", "TASK-260101-ffffff_form")
+	os.WriteFile(filepath.Join(webTask, "progress.md"), []byte("## Status\nshipped\n"), 0644)
+	apiTask := filepath.Join(root, "api", ".task-board", "EPIC-260101-aaaaaa_auth", "STORY-260101-bbbbbb_login", "TASK-260101-cccccc_endpoint")
+	os.WriteFile(filepath.Join(apiTask, "progress.md"), []byte("## Status\ndevelopment\n\n## Blocked By\n- web:TASK-260101-ffffff\n"), 0644)
+
+	ws, err := LoadWorkspace(wsPath)
+	if err != nil {
+		t.Fatalf("LoadWorkspace: %v", err)
+	}
+	merged := ws.Merged()
+	task := merged.FindByID("api:TASK-260101-cccccc")
+	if active := merged.ActiveBlockers(task); len(active) != 0 {
+		t.Errorf("a blocker shipped on its own board should not hold the task, got %v", active)
+	}
+	blocker := merged.FindByID("web:TASK-260101-ffffff")
+	if c := merged.WorkflowOf(blocker).Category(blocker); c != CategoryDone {
+		t.Errorf("web task category = %s, want done", c)
+	}
+}
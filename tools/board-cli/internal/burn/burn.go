@@ -0,0 +1,170 @@
+// Package burn builds burndown and burnup series from what the board knows
+// about each element's past: its creation time, the status history in
+// progress.md, the git history of progress.md and its last update.
+package burn
+
+import (
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/aagrigore/task-board/chart"
+	"github.com/aagrigore/task-board/internal/board"
+)
+
+// Options control how the series is built.
+type Options struct {
+	// Checklist counts checklist items instead of elements.
+	Checklist bool
+	// Git reads earlier versions of each progress.md from git, when the
+	// board is in a repository.
+	Git bool
+	// Since starts the series at this time instead of at the first known
+	// change. Zero means the whole history.
+	Since time.Time
+	// Now ends the series.
+	Now time.Time
+}
+
+// snapshot is the state of an element from a point in time onwards.
+// Status-only snapshots (from the status history) keep the checklist of
+// the snapshot before them.
+type snapshot struct {
+	at         time.Time
+	status     board.Status
+	checked    int
+	items      int
+	statusOnly bool
+	recorded   bool // from progress.md itself; wins ties with git commits
+}
+
+// snapshots returns an element's known states in time order, given the
+// committed versions of its progress.md.
+func snapshots(wf *board.Workflow, e *board.Element, revs []revision, opts Options) []snapshot {
+	flow := wf.For(e.Type)
+	var snaps []snapshot
+	if !e.CreatedAt.IsZero() {
+		snaps = append(snaps, snapshot{at: e.CreatedAt, status: flow.Initial})
+	}
+	if opts.Git {
+		for _, rev := range revs {
+			pd, err := board.ParseProgress(rev.content)
+			if err != nil {
+				continue
+			}
+			checked, items := countChecklist(pd.Checklist)
+			snaps = append(snaps, snapshot{at: rev.at, status: flow.Normalize(pd.Status), checked: checked, items: items})
+		}
+	}
+	for _, c := range e.History {
+		snaps = append(snaps, snapshot{at: c.At, status: c.To, statusOnly: true, recorded: true})
+	}
+	current := e.LastUpdate
+	if current.IsZero() {
+		current = opts.Now
+	}
+	checked, items := countChecklist(e.Checklist)
+	snaps = append(snaps, snapshot{at: current, status: e.Status, checked: checked, items: items, recorded: true})
+
+	sort.SliceStable(snaps, func(i, j int) bool {
+		if !snaps[i].at.Equal(snaps[j].at) {
+			return snaps[i].at.Before(snaps[j].at)
+		}
+		return !snaps[i].recorded && snaps[j].recorded
+	})
+	for i := range snaps {
+		if i > 0 && snaps[i].statusOnly {
+			snaps[i].checked, snaps[i].items = snaps[i-1].checked, snaps[i-1].items
+		}
+	}
+	return snaps
+}
+
+func countChecklist(items []board.ChecklistItem) (checked, total int) {
+	for _, item := range items {
+		if item.Checked {
+			checked++
+		}
+	}
+	return checked, len(items)
+}
+
+// stateAt returns the snapshot in effect at t, if the element existed.
+func stateAt(snaps []snapshot, t time.Time) (snapshot, bool) {
+	var found snapshot
+	ok := false
+	for _, s := range snaps {
+		if s.at.After(t) {
+			break
+		}
+		found, ok = s, true
+	}
+	return found, ok
+}
+
+// Build returns one point per day from the first known change (or Since)
+// to Now. Closed elements drop out of the scope; done ones count as done.
+func Build(wf *board.Workflow, elements []*board.Element, opts Options) []chart.Point {
+	if opts.Now.IsZero() {
+		opts.Now = time.Now().UTC()
+	}
+	// One git log per board rather than one per element
+	histories := map[string]gitHistory{}
+	all := make([][]snapshot, len(elements))
+	var first time.Time
+	for i, e := range elements {
+		var revs []revision
+		if opts.Git {
+			dir := boardDirOf(e.Path)
+			history, ok := histories[dir]
+			if !ok {
+				history = loadGitHistory(dir)
+				histories[dir] = history
+			}
+			revs = history[board.ExtractRawID(filepath.Base(e.Path))]
+		}
+		all[i] = snapshots(wf, e, revs, opts)
+		if at := all[i][0].at; first.IsZero() || at.Before(first) {
+			first = at
+		}
+	}
+	points := []chart.Point{}
+	if first.IsZero() {
+		return points
+	}
+	if opts.Since.After(first) {
+		first = opts.Since
+	}
+
+	first = first.UTC()
+	day := time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, time.UTC)
+	for ; !day.After(opts.Now); day = day.AddDate(0, 0, 1) {
+		end := day.AddDate(0, 0, 1).Add(-time.Second)
+		if end.After(opts.Now) {
+			end = opts.Now
+		}
+		p := chart.Point{Date: day.Format("2006-01-02")}
+		for i, e := range elements {
+			s, ok := stateAt(all[i], end)
+			if !ok {
+				continue
+			}
+			category := wf.For(e.Type).Category(s.status)
+			if category == board.CategoryClosed {
+				continue
+			}
+			if opts.Checklist {
+				p.Total += s.items
+				p.Done += s.checked
+				continue
+			}
+			p.Total++
+			if category == board.CategoryDone {
+				p.Done++
+			}
+		}
+		p.Remaining = p.Total - p.Done
+		points = append(points, p)
+	}
+	return points
+}
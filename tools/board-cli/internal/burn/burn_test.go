@@ -0,0 +1,105 @@
+package burn
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aagrigore/task-board/internal/board"
+)
+
+func at(day, hour int) time.Time {
+	return time.Date(2026, 3, day, hour, 0, 0, 0, time.UTC)
+}
+
+func TestBuild(t *testing.T) {
+	wf := board.DefaultWorkflow()
+	elements := []*board.Element{
+		{Type: board.TaskType, RawID: "TASK-1", Status: board.StatusDone, CreatedAt: at(1, 9), LastUpdate: at(3, 9),
+			History: []board.StatusChange{
+				{At: at(2, 9), From: board.StatusBacklog, To: board.StatusDevelopment},
+				{At: at(3, 9), From: board.StatusDevelopment, To: board.StatusDone},
+			}},
+		// Added to the scope on day 2 and dropped on day 4
+		{Type: board.TaskType, RawID: "TASK-2", Status: board.StatusClosed, CreatedAt: at(2, 9), LastUpdate: at(4, 9),
+			History: []board.StatusChange{{At: at(4, 9), From: board.StatusBacklog, To: board.StatusClosed}}},
+		// No history: done at its last update
+		{Type: board.BugType, RawID: "BUG-1", Status: board.StatusDone, CreatedAt: at(1, 9), LastUpdate: at(4, 9),
+			Checklist: []board.ChecklistItem{{Text: "a", Checked: true}, {Text: "b"}}},
+	}
+
+	points := Build(wf, elements, Options{Now: at(5, 0)})
+	want := []struct{ total, done int }{{2, 0}, {3, 0}, {3, 1}, {2, 2}, {2, 2}}
+	if len(points) != len(want) {
+		t.Fatalf("got %d points, want %d: %+v", len(points), len(want), points)
+	}
+	for i, w := range want {
+		p := points[i]
+		if p.Total != w.total || p.Done != w.done || p.Remaining != w.total-w.done {
+			t.Errorf("day %s = %+v, want total %d done %d", p.Date, p, w.total, w.done)
+		}
+	}
+
+	points = Build(wf, elements, Options{Now: at(5, 0), Checklist: true, Since: at(4, 0)})
+	if len(points) != 2 || points[0].Date != "2026-03-04" {
+		t.Fatalf("since: %+v", points)
+	}
+	if p := points[0]; p.Total != 2 || p.Done != 1 {
+		t.Errorf("checklist = %+v, want 1 of 2 items", p)
+	}
+}
+
+func TestGitRevisions(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	repo := t.TempDir()
+	dir := filepath.Join(repo, "TASK-1_a")
+	os.MkdirAll(dir, 0755)
+	path := filepath.Join(dir, "progress.md")
+	git := func(date string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", repo}, args...)...)
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@t", "GIT_COMMITTER_NAME=t",
+			"GIT_COMMITTER_EMAIL=t@t", "GIT_COMMITTER_DATE="+date, "GIT_AUTHOR_DATE="+date)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	git("2026-03-01T10:00:00Z", "init", "-q")
+	os.WriteFile(path, []byte("## Status\nbacklog\n\n## Checklist\n- [ ] a\n- [ ] b\n"), 0644)
+	git("2026-03-01T10:00:00Z", "add", ".")
+	git("2026-03-01T10:00:00Z", "commit", "-qm", "create")
+	os.WriteFile(path, []byte("## Status\ndevelopment\n\n## Checklist\n- [x] a\n- [ ] b\n"), 0644)
+	git("2026-03-02T10:00:00Z", "commit", "-qam", "start")
+	// Moving the element keeps its history
+	moved := filepath.Join(repo, "STORY-2_b", "TASK-1_a")
+	os.MkdirAll(filepath.Dir(moved), 0755)
+	git("2026-03-02T11:00:00Z", "mv", "TASK-1_a", moved)
+	git("2026-03-02T11:00:00Z", "commit", "-qm", "move")
+	dir = moved
+
+	revs := loadGitHistory(repo)["TASK-1"]
+	if len(revs) != 3 || !revs[0].at.Equal(at(1, 10)) || !revs[1].at.Equal(at(2, 10)) {
+		t.Fatalf("revisions = %+v", revs)
+	}
+	if revs[0].content != "## Status\nbacklog\n\n## Checklist\n- [ ] a\n- [ ] b\n" || revs[2].content != revs[1].content {
+		t.Errorf("contents = %q", []string{revs[0].content, revs[1].content, revs[2].content})
+	}
+	if got := boardDirOf(moved); got != repo {
+		t.Errorf("boardDirOf = %s, want %s", got, repo)
+	}
+
+	e := &board.Element{Type: board.TaskType, RawID: "TASK-1", Path: dir, Status: board.StatusDevelopment,
+		LastUpdate: at(3, 10), Checklist: []board.ChecklistItem{{Text: "a", Checked: true}, {Text: "b", Checked: true}}}
+	points := Build(board.DefaultWorkflow(), []*board.Element{e}, Options{Now: at(3, 12), Checklist: true, Git: true})
+	var done []int
+	for _, p := range points {
+		done = append(done, p.Done)
+	}
+	if len(points) != 3 || done[0] != 0 || done[1] != 1 || done[2] != 2 || points[0].Total != 2 {
+		t.Errorf("checklist from git = %+v", points)
+	}
+}
@@ -0,0 +1,101 @@
+package burn
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aagrigore/task-board/internal/board"
+)
+
+// revision is one committed version of a file.
+type revision struct {
+	at      time.Time
+	content string
+}
+
+// gitHistory holds the committed versions of every progress.md of a board,
+// oldest first, keyed by element ID.
+type gitHistory map[string][]revision
+
+// wholeFile is a diff context large enough that every hunk of a
+// progress.md diff spans the whole file.
+const wholeFile = "-U1000000"
+
+// loadGitHistory reads every committed version of the progress.md files
+// below boardDir from a single git log. Versions are keyed by the element
+// ID in the directory name, so an element keeps its history when it is
+// moved or renamed. Boards outside a repository, or without git installed,
+// have none.
+func loadGitHistory(boardDir string) gitHistory {
+	out, err := exec.Command("git", "-C", boardDir, "-c", "core.quotePath=false",
+		"log", "--reverse", "--no-renames", "--no-color", "--no-ext-diff", "-p", wholeFile,
+		"--format=%x00%cI", "--", "*progress.md").Output()
+	if err != nil {
+		return nil
+	}
+
+	history := gitHistory{}
+	for _, record := range strings.Split(string(out), "\x00") {
+		header, patch, _ := strings.Cut(record, "\n")
+		at, err := time.Parse(time.RFC3339, strings.TrimSpace(header))
+		if err != nil {
+			continue
+		}
+		for id, content := range parsePatch(patch) {
+			history[id] = append(history[id], revision{at: at.UTC(), content: content})
+		}
+	}
+	return history
+}
+
+// parsePatch returns the content each progress.md has after a commit,
+// keyed by element ID, from the commit's whole-file diff. Deleted files
+// are left out.
+func parsePatch(patch string) map[string]string {
+	contents := map[string]string{}
+	var id string
+	var content strings.Builder
+	inHunk := false
+	flush := func() {
+		if id != "" {
+			contents[id] = content.String()
+		}
+		id = ""
+		content.Reset()
+		inHunk = false
+	}
+	for _, line := range strings.Split(patch, "\n") {
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			flush()
+		case !inHunk && strings.HasPrefix(line, "+++ "):
+			path := strings.TrimPrefix(line, "+++ ")
+			if path != "/dev/null" && filepath.Base(path) == "progress.md" {
+				id = board.ExtractRawID(filepath.Base(filepath.Dir(path)))
+			}
+		case strings.HasPrefix(line, "@@"):
+			inHunk = true
+		case inHunk && (strings.HasPrefix(line, "+") || strings.HasPrefix(line, " ")):
+			content.WriteString(line[1:])
+			content.WriteByte('\n')
+		}
+	}
+	flush()
+	return contents
+}
+
+// boardDirOf returns the board directory an element lives in: the nearest
+// ancestor of its directory that is not itself an element directory.
+func boardDirOf(elemPath string) string {
+	dir := filepath.Dir(elemPath)
+	for board.ExtractRawID(filepath.Base(dir)) != "" {
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+	return dir
+}
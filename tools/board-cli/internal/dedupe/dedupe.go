@@ -0,0 +1,228 @@
+// Package dedupe finds board elements whose names and descriptions are
+// similar enough to be likely duplicates.
+package dedupe
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/aagrigore/task-board/internal/board"
+)
+
+// DefaultThreshold is the similarity above which two elements are reported
+// as likely duplicates.
+const DefaultThreshold = 0.6
+
+// Weights of name and description when both elements have a description.
+const (
+	nameWeight        = 0.7
+	descriptionWeight = 0.3
+)
+
+// stopwords are ignored when comparing texts; they carry no meaning in task titles.
+var stopwords = map[string]bool{
+	"a": true, "an": true, "and": true, "the": true, "of": true, "to": true,
+	"for": true, "in": true, "on": true, "with": true, "from": true, "by": true,
+	"at": true, "or": true, "is": true, "be": true, "it": true, "as": true,
+	"into": true, "should": true, "must": true,
+}
+
+// Text is the comparable content of an element.
+type Text struct {
+	Name        string
+	Description string
+}
+
+// ElementText returns the name and description of an element. The README
+// title carries an "ID: " prefix which is stripped.
+func ElementText(e *board.Element) Text {
+	name := e.Title
+	if name == "" {
+		name = strings.ReplaceAll(e.Name, "-", " ")
+	}
+	name = strings.TrimPrefix(name, e.ID()+": ")
+	return Text{Name: name, Description: e.Description}
+}
+
+// Match is an element similar to a given text.
+type Match struct {
+	Element *board.Element
+	Score   float64
+}
+
+// Similarity scores two texts between 0 (unrelated) and 1 (identical).
+// Names dominate; descriptions only contribute when both are present and
+// can never lower the score below the name similarity alone.
+func Similarity(a, b Text) float64 {
+	score := similarity(a.Name, b.Name)
+	if strings.TrimSpace(a.Description) == "" || strings.TrimSpace(b.Description) == "" {
+		return score
+	}
+	combined := nameWeight*score + descriptionWeight*similarity(a.Description, b.Description)
+	if combined > score {
+		return combined
+	}
+	return score
+}
+
+// FindSimilar returns elements whose text scores at least threshold
+// against t, best first.
+func FindSimilar(elements []*board.Element, t Text, threshold float64) []Match {
+	var matches []Match
+	for _, e := range elements {
+		if score := Similarity(t, ElementText(e)); score >= threshold {
+			matches = append(matches, Match{Element: e, Score: score})
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].Element.ID() < matches[j].Element.ID()
+	})
+	return matches
+}
+
+// Pair is two similar elements.
+type Pair struct {
+	A, B  *board.Element
+	Score float64
+}
+
+// Cluster is a group of elements connected by similar pairs.
+type Cluster struct {
+	Elements []*board.Element
+	Pairs    []Pair
+}
+
+// Clusters groups elements of the same type whose pairwise similarity
+// reaches threshold. Clusters are ordered by their best pair score.
+func Clusters(elements []*board.Element, threshold float64) []Cluster {
+	texts := make([]Text, len(elements))
+	for i, e := range elements {
+		texts[i] = ElementText(e)
+	}
+
+	parent := make([]int, len(elements))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	var pairs []Pair
+	for i := range elements {
+		for j := i + 1; j < len(elements); j++ {
+			if elements[i].Type != elements[j].Type {
+				continue
+			}
+			score := Similarity(texts[i], texts[j])
+			if score < threshold {
+				continue
+			}
+			pairs = append(pairs, Pair{A: elements[i], B: elements[j], Score: score})
+			parent[find(i)] = find(j)
+		}
+	}
+	if len(pairs) == 0 {
+		return nil
+	}
+
+	byRoot := make(map[int]*Cluster)
+	var roots []int
+	index := make(map[*board.Element]int, len(elements))
+	for i, e := range elements {
+		index[e] = i
+	}
+	for _, p := range pairs {
+		root := find(index[p.A])
+		c, ok := byRoot[root]
+		if !ok {
+			c = &Cluster{}
+			byRoot[root] = c
+			roots = append(roots, root)
+		}
+		c.Pairs = append(c.Pairs, p)
+	}
+	for i, e := range elements {
+		if c, ok := byRoot[find(i)]; ok {
+			c.Elements = append(c.Elements, e)
+		}
+	}
+
+	clusters := make([]Cluster, 0, len(roots))
+	for _, root := range roots {
+		c := byRoot[root]
+		sort.Slice(c.Pairs, func(i, j int) bool { return c.Pairs[i].Score > c.Pairs[j].Score })
+		sort.Slice(c.Elements, func(i, j int) bool { return c.Elements[i].ID() < c.Elements[j].ID() })
+		clusters = append(clusters, *c)
+	}
+	sort.SliceStable(clusters, func(i, j int) bool {
+		return clusters[i].Pairs[0].Score > clusters[j].Pairs[0].Score
+	})
+	return clusters
+}
+
+// similarity averages token and character-trigram Jaccard similarity.
+// Tokens catch reordered words; trigrams catch inflections and typos.
+func similarity(a, b string) float64 {
+	ta, tb := tokens(a), tokens(b)
+	if len(ta) == 0 || len(tb) == 0 {
+		return 0
+	}
+	return 0.5*jaccard(set(ta), set(tb)) + 0.5*jaccard(trigrams(ta), trigrams(tb))
+}
+
+// tokens lowercases text and splits it into words, dropping stopwords.
+func tokens(s string) []string {
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	out := words[:0]
+	for _, w := range words {
+		if !stopwords[w] {
+			out = append(out, w)
+		}
+	}
+	return out
+}
+
+func set(words []string) map[string]bool {
+	m := make(map[string]bool, len(words))
+	for _, w := range words {
+		m[w] = true
+	}
+	return m
+}
+
+// trigrams returns the character trigrams of each word padded with spaces,
+// so short words still produce at least one trigram.
+func trigrams(words []string) map[string]bool {
+	m := make(map[string]bool)
+	for _, w := range words {
+		r := []rune(" " + w + " ")
+		for i := 0; i+3 <= len(r); i++ {
+			m[string(r[i:i+3])] = true
+		}
+	}
+	return m
+}
+
+func jaccard(a, b map[string]bool) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 0
+	}
+	inter := 0
+	for k := range a {
+		if b[k] {
+			inter++
+		}
+	}
+	return float64(inter) / float64(len(a)+len(b)-inter)
+}
@@ -0,0 +1,110 @@
+package dedupe
+
+import (
+	"testing"
+
+	"github.com/aagrigore/task-board/internal/board"
+)
+
+func elem(id string, typ board.ElementType, title, desc string) *board.Element {
+	return &board.Element{Type: typ, RawID: id, Title: id + ": " + title, Description: desc}
+}
+
+func TestSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b    string
+		atLeast float64
+		below   float64
+	}{
+		{"Implement audio capture", "Implement audio capture", 1, 1.01},
+		{"Implement audio capture", "implement the Audio-Capture", 1, 1.01},
+		{"Implement audio capture", "Audio capture implementation", DefaultThreshold, 1},
+		{"Add login page", "Implement audio capture", 0, 0.2},
+	}
+	for _, tt := range tests {
+		got := Similarity(Text{Name: tt.a}, Text{Name: tt.b})
+		if got < tt.atLeast || got >= tt.below {
+			t.Errorf("Similarity(%q, %q) = %.2f, want [%.2f, %.2f)", tt.a, tt.b, got, tt.atLeast, tt.below)
+		}
+	}
+}
+
+func TestSimilarityDescriptionOnlyRaises(t *testing.T) {
+	a := Text{Name: "Audio capture", Description: "Record microphone input to a WAV buffer"}
+	b := Text{Name: "Capture audio stream", Description: "Record microphone input into a WAV buffer"}
+	unrelated := Text{Name: "Capture audio stream", Description: "Design the settings page"}
+
+	nameOnly := Similarity(Text{Name: a.Name}, Text{Name: b.Name})
+	if got := Similarity(a, b); got <= nameOnly {
+		t.Errorf("matching descriptions should raise the score: %.2f <= %.2f", got, nameOnly)
+	}
+	if got := Similarity(a, unrelated); got != nameOnly {
+		t.Errorf("unrelated descriptions should not lower the score: %.2f != %.2f", got, nameOnly)
+	}
+}
+
+func TestElementTextStripsID(t *testing.T) {
+	e := elem("TASK-260101-aaaaaa", board.TaskType, "Audio capture", "desc")
+	if got := ElementText(e); got.Name != "Audio capture" || got.Description != "desc" {
+		t.Errorf("ElementText = %+v", got)
+	}
+	bare := &board.Element{Type: board.TaskType, RawID: "TASK-260101-bbbbbb", Name: "audio-capture"}
+	if got := ElementText(bare); got.Name != "audio capture" {
+		t.Errorf("ElementText without README title = %q", got.Name)
+	}
+}
+
+func TestFindSimilar(t *testing.T) {
+	elements := []*board.Element{
+		elem("TASK-260101-aaaaaa", board.TaskType, "Implement audio capture", ""),
+		elem("TASK-260101-bbbbbb", board.TaskType, "Implement audio capturing", ""),
+		elem("TASK-260101-cccccc", board.TaskType, "Write release notes", ""),
+	}
+	matches := FindSimilar(elements, Text{Name: "Implement audio capture"}, DefaultThreshold)
+	if len(matches) != 2 {
+		t.Fatalf("expected 2 matches, got %d", len(matches))
+	}
+	if matches[0].Element.ID() != "TASK-260101-aaaaaa" || matches[0].Score != 1 {
+		t.Errorf("best match should be the identical task, got %s (%.2f)", matches[0].Element.ID(), matches[0].Score)
+	}
+}
+
+func TestClusters(t *testing.T) {
+	elements := []*board.Element{
+		elem("TASK-260101-aaaaaa", board.TaskType, "Implement audio capture", ""),
+		elem("TASK-260101-bbbbbb", board.TaskType, "Implement audio capturing", ""),
+		elem("TASK-260101-cccccc", board.TaskType, "Audio capturing implementation", ""),
+		elem("TASK-260101-dddddd", board.TaskType, "Write release notes", ""),
+		elem("TASK-260101-eeeeee", board.TaskType, "Write the release notes", ""),
+		// Same name but a different type never clusters
+		elem("STORY-260101-ffffff", board.StoryType, "Write release notes", ""),
+	}
+	clusters := Clusters(elements, DefaultThreshold)
+	if len(clusters) != 2 {
+		t.Fatalf("expected 2 clusters, got %d", len(clusters))
+	}
+	// The release-notes pair is identical after stopwords and ranks first
+	if len(clusters[0].Elements) != 2 || clusters[0].Elements[0].ID() != "TASK-260101-dddddd" {
+		t.Errorf("unexpected first cluster: %+v", clusters[0].Elements)
+	}
+	if len(clusters[1].Elements) != 3 {
+		t.Errorf("audio cluster should hold 3 elements, got %d", len(clusters[1].Elements))
+	}
+	for _, c := range clusters {
+		for _, e := range c.Elements {
+			if e.Type != board.TaskType {
+				t.Errorf("cluster contains %s of another type", e.ID())
+			}
+		}
+	}
+}
+
+func TestClustersNone(t *testing.T) {
+	elements := []*board.Element{
+		elem("TASK-260101-aaaaaa", board.TaskType, "Implement audio capture", ""),
+		elem("TASK-260101-bbbbbb", board.TaskType, "Write release notes", ""),
+	}
+	if clusters := Clusters(elements, DefaultThreshold); clusters != nil {
+		t.Errorf("expected no clusters, got %d", len(clusters))
+	}
+}
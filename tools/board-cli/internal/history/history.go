@@ -0,0 +1,236 @@
+// Package history keeps pre-images of the board files each command
+// changed, so the most recent commands can be undone.
+package history
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aagrigore/task-board/internal/txn"
+)
+
+// Dir is the history directory inside the board directory.
+const Dir = ".history"
+
+// Limit is how many entries are kept; older ones are pruned on Record.
+const Limit = 100
+
+// Entry is one recorded command.
+type Entry struct {
+	Seq         int          `json:"seq"`
+	Command     string       `json:"command"`
+	Time        time.Time    `json:"time"`
+	Files       []FileChange `json:"files"`
+	CreatedDirs []string     `json:"createdDirs,omitempty"`
+	DeletedDirs []string     `json:"deletedDirs,omitempty"`
+}
+
+// FileChange is one file a command wrote, created or removed.
+type FileChange struct {
+	Path string `json:"path"` // relative to the board directory
+	// Before is the content before the command; nil when it created the file.
+	Before *string `json:"before"`
+	// After is the sha256 of the content the command left, "" if it removed the file.
+	After string `json:"after"`
+}
+
+// ConflictError reports files changed outside the history since an entry
+// was recorded; undoing it would discard those changes.
+type ConflictError struct {
+	Entry *Entry
+	Paths []string
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%q: changed since by hand: %s", e.Entry.Command, strings.Join(e.Paths, ", "))
+}
+
+// Record stores the changes a command made and prunes old entries.
+func Record(boardDir, command string, c *txn.Changes) (*Entry, error) {
+	entries, err := List(boardDir)
+	if err != nil {
+		return nil, err
+	}
+	e := &Entry{
+		Seq:         1,
+		Command:     command,
+		Time:        time.Now().UTC(),
+		CreatedDirs: c.CreatedDirs,
+		DeletedDirs: c.DeletedDirs,
+	}
+	if len(entries) > 0 {
+		e.Seq = entries[len(entries)-1].Seq + 1
+	}
+
+	for path, data := range c.Before {
+		before := string(data)
+		after, err := hashFile(filepath.Join(boardDir, path))
+		if err != nil {
+			return nil, err
+		}
+		e.Files = append(e.Files, FileChange{Path: path, Before: &before, After: after})
+	}
+	for _, path := range c.Created {
+		after, err := hashFile(filepath.Join(boardDir, path))
+		if err != nil {
+			return nil, err
+		}
+		e.Files = append(e.Files, FileChange{Path: path, After: after})
+	}
+	sort.Slice(e.Files, func(i, j int) bool { return e.Files[i].Path < e.Files[j].Path })
+
+	dir := filepath.Join(boardDir, Dir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("creating history: %w", err)
+	}
+	// Claim the entry file before writing it: a seq another process took
+	// since List moves this entry on to the next one
+	var f *os.File
+	for {
+		f, err = os.OpenFile(entryPath(boardDir, e.Seq), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if err == nil {
+			break
+		}
+		if !os.IsExist(err) {
+			return nil, fmt.Errorf("writing history: %w", err)
+		}
+		e.Seq++
+	}
+	data, err := json.MarshalIndent(e, "", "  ")
+	if err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return nil, err
+	}
+	_, err = f.Write(data)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return nil, fmt.Errorf("writing history: %w", err)
+	}
+
+	for i := 0; i < len(entries)+1-Limit; i++ {
+		os.Remove(entryPath(boardDir, entries[i].Seq))
+	}
+	return e, nil
+}
+
+// List returns the recorded entries, oldest first.
+func List(boardDir string) ([]*Entry, error) {
+	files, err := os.ReadDir(filepath.Join(boardDir, Dir))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading history: %w", err)
+	}
+	var entries []*Entry
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != ".json" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(boardDir, Dir, f.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("reading history: %w", err)
+		}
+		var e Entry
+		if err := json.Unmarshal(data, &e); err != nil {
+			return nil, fmt.Errorf("reading history entry %s: %w", f.Name(), err)
+		}
+		entries = append(entries, &e)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Seq < entries[j].Seq })
+	return entries, nil
+}
+
+// Conflicts lists the files of an entry whose current content is not what
+// the command left behind.
+func Conflicts(boardDir string, e *Entry) ([]string, error) {
+	var paths []string
+	for _, f := range e.Files {
+		current, err := hashFile(filepath.Join(boardDir, f.Path))
+		if err != nil {
+			return nil, err
+		}
+		if current != f.After {
+			paths = append(paths, f.Path)
+		}
+	}
+	return paths, nil
+}
+
+// Undo puts the files of an entry back as they were before the command.
+// Unless force is set it refuses with *ConflictError when a file has
+// changed since. The entry itself is left in place; see Forget.
+func Undo(boardDir string, e *Entry, force bool) error {
+	if !force {
+		conflicts, err := Conflicts(boardDir, e)
+		if err != nil {
+			return err
+		}
+		if len(conflicts) > 0 {
+			return &ConflictError{Entry: e, Paths: conflicts}
+		}
+	}
+
+	for _, dir := range e.DeletedDirs {
+		if err := os.MkdirAll(filepath.Join(boardDir, dir), 0755); err != nil {
+			return fmt.Errorf("restoring %s: %w", dir, err)
+		}
+	}
+	for _, f := range e.Files {
+		path := filepath.Join(boardDir, f.Path)
+		if f.Before == nil {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("removing %s: %w", f.Path, err)
+			}
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("restoring %s: %w", f.Path, err)
+		}
+		if err := os.WriteFile(path, []byte(*f.Before), 0644); err != nil {
+			return fmt.Errorf("restoring %s: %w", f.Path, err)
+		}
+	}
+	// Deepest directories first so parents are empty when removed
+	created := append([]string(nil), e.CreatedDirs...)
+	sort.Slice(created, func(i, j int) bool { return len(created[i]) > len(created[j]) })
+	for _, dir := range created {
+		if err := os.Remove(filepath.Join(boardDir, dir)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("removing %s: %w", dir, err)
+		}
+	}
+	return nil
+}
+
+// Forget removes an entry from the history.
+func Forget(boardDir string, e *Entry) error {
+	return os.Remove(entryPath(boardDir, e.Seq))
+}
+
+func entryPath(boardDir string, seq int) string {
+	return filepath.Join(boardDir, Dir, fmt.Sprintf("%06d.json", seq))
+}
+
+// hashFile returns the sha256 of a file, or "" when it does not exist.
+func hashFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
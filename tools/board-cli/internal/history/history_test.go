@@ -0,0 +1,122 @@
+package history
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/aagrigore/task-board/internal/txn"
+)
+
+// change runs fn against the board and records what it changed.
+func change(t *testing.T, dir, command string, fn func()) *Entry {
+	t.Helper()
+	snap, err := txn.TakeSnapshot(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fn()
+	c, err := snap.Changes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, err := Record(dir, command, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return e
+}
+
+func TestRecordAndUndo(t *testing.T) {
+	dir := t.TempDir()
+	readme := filepath.Join(dir, "EPIC-1_a", "README.md")
+	os.MkdirAll(filepath.Dir(readme), 0755)
+	os.WriteFile(readme, []byte("v1"), 0644)
+
+	e := change(t, dir, "edit", func() {
+		os.WriteFile(readme, []byte("v2"), 0644)
+		os.MkdirAll(filepath.Join(dir, "EPIC-2_b"), 0755)
+		os.WriteFile(filepath.Join(dir, "EPIC-2_b", "README.md"), []byte("new"), 0644)
+	})
+	if e.Seq != 1 || len(e.Files) != 2 {
+		t.Fatalf("entry = %+v", e)
+	}
+
+	if err := Undo(dir, e, false); err != nil {
+		t.Fatalf("Undo: %v", err)
+	}
+	if data, _ := os.ReadFile(readme); string(data) != "v1" {
+		t.Errorf("README = %q", data)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "EPIC-2_b")); !os.IsNotExist(err) {
+		t.Error("created directory should be removed")
+	}
+}
+
+func TestUndoConflict(t *testing.T) {
+	dir := t.TempDir()
+	readme := filepath.Join(dir, "README.md")
+	os.WriteFile(readme, []byte("v1"), 0644)
+	e := change(t, dir, "edit", func() { os.WriteFile(readme, []byte("v2"), 0644) })
+
+	os.WriteFile(readme, []byte("v3"), 0644)
+	var conflict *ConflictError
+	if err := Undo(dir, e, false); !errors.As(err, &conflict) || conflict.Paths[0] != "README.md" {
+		t.Fatalf("expected conflict on README.md, got %v", err)
+	}
+	if err := Undo(dir, e, true); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(readme); string(data) != "v1" {
+		t.Errorf("forced undo should restore v1, got %q", data)
+	}
+}
+
+func TestRecordPrunes(t *testing.T) {
+	dir := t.TempDir()
+	readme := filepath.Join(dir, "README.md")
+	for i := 0; i < Limit+5; i++ {
+		change(t, dir, "edit", func() { os.WriteFile(readme, []byte{byte(i)}, 0644) })
+	}
+	entries, err := List(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != Limit || entries[0].Seq != 6 {
+		t.Errorf("expected the last %d entries from #6, got %d from #%d", Limit, len(entries), entries[0].Seq)
+	}
+}
+
+func TestConcurrentRecordsGetDistinctSeqs(t *testing.T) {
+	dir := t.TempDir()
+	c := &txn.Changes{Created: []string{"README.md"}}
+	const n = 8
+	seqs := make(chan int, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			e, err := Record(dir, "edit", c)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			seqs <- e.Seq
+		}()
+	}
+	wg.Wait()
+	close(seqs)
+	seen := map[int]bool{}
+	for seq := range seqs {
+		if seen[seq] {
+			t.Errorf("seq %d recorded twice", seq)
+		}
+		seen[seq] = true
+	}
+	if entries, _ := List(dir); len(entries) != n {
+		t.Errorf("expected %d entries, got %d", n, len(entries))
+	}
+}
@@ -0,0 +1,396 @@
+package manifest
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aagrigore/task-board/internal/board"
+)
+
+// Entry is a manifest node resolved against the board.
+type Entry struct {
+	Node   *Node
+	Type   board.ElementType
+	Parent *Entry
+	// Path locates the node in the manifest, e.g. "epic.stories[1].tasks[0]".
+	Path string
+	// ID is the board element the node stands for; empty until it is created.
+	ID string
+}
+
+// Ref names the entry in plans: its ID, or a description while it is new.
+func (e *Entry) Ref() string {
+	if e.ID != "" {
+		return e.ID
+	}
+	return fmt.Sprintf("new %s %q", e.Type, e.Node.Name)
+}
+
+// ChangeKind is the kind of a planned change.
+type ChangeKind string
+
+const (
+	Create  ChangeKind = "create"
+	Update  ChangeKind = "update"
+	AddItem ChangeKind = "add-item"
+	Unlink  ChangeKind = "unlink"
+	Link    ChangeKind = "link"
+)
+
+// Change is one step of a plan. Entry is the element changed; for links it
+// is the blocked element and the blocker is either BlockerEntry (a manifest
+// element, possibly new) or BlockerID (any other board element).
+type Change struct {
+	Kind         ChangeKind
+	Entry        *Entry
+	Field        string // update: title, description, scope or ac
+	Old, New     string // update
+	Text         string // add-item
+	BlockerEntry *Entry
+	BlockerID    string
+}
+
+// Blocker returns the ID or description of a link change's blocker.
+func (c Change) Blocker() string {
+	if c.BlockerEntry != nil {
+		return c.BlockerEntry.Ref()
+	}
+	return c.BlockerID
+}
+
+// Plan is the ordered list of changes that reconciles a board with a
+// manifest: creates, README updates and checklist items top-down, then
+// link removals, then link additions.
+type Plan struct {
+	Entries []*Entry
+	Changes []Change
+}
+
+// Issue is a problem that prevents a manifest from being planned.
+type Issue struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// InvalidError lists every problem found in a manifest.
+type InvalidError struct {
+	Issues []Issue
+}
+
+func (e *InvalidError) Error() string {
+	lines := make([]string, len(e.Issues))
+	for i, issue := range e.Issues {
+		lines[i] = fmt.Sprintf("%s: %s", issue.Path, issue.Message)
+	}
+	return fmt.Sprintf("invalid manifest:\n  %s", strings.Join(lines, "\n  "))
+}
+
+// Diff resolves the manifest against the board and returns the changes
+// needed to reconcile them. Status, assignment and notes are never part of
+// a plan, checklist items are only added, and elements missing from the
+// manifest are left alone. Dependencies of manifest elements are
+// authoritative: links not listed (and not implied by escalation from
+// listed ones) are removed. Problems are returned as *InvalidError.
+func Diff(b *board.Board, root *Node) (*Plan, error) {
+	d := &differ{b: b, keys: make(map[string]*Entry), claimed: make(map[string]string)}
+	d.resolve(root, board.EpicType, nil, "epic")
+	refs := d.resolveRefs()
+	if len(d.issues) > 0 {
+		return nil, &InvalidError{Issues: d.issues}
+	}
+
+	p := &Plan{Entries: d.entries}
+	for _, e := range d.entries {
+		p.Changes = append(p.Changes, d.contentChanges(e)...)
+	}
+	p.Changes = append(p.Changes, d.linkChanges(refs)...)
+	return p, nil
+}
+
+type differ struct {
+	b       *board.Board
+	entries []*Entry
+	keys    map[string]*Entry
+	claimed map[string]string // element ID -> path of the entry matched to it
+	issues  []Issue
+}
+
+func (d *differ) fail(path, format string, args ...interface{}) {
+	d.issues = append(d.issues, Issue{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// resolve records the node as an entry, matches it to a board element and
+// recurses into its children.
+func (d *differ) resolve(n *Node, t board.ElementType, parent *Entry, path string) {
+	if n == nil {
+		d.fail(path, "empty entry")
+		return
+	}
+	e := &Entry{Node: n, Type: t, Parent: parent, Path: path}
+	d.entries = append(d.entries, e)
+
+	if strings.TrimSpace(n.Name) == "" {
+		d.fail(path, "name is required")
+	}
+	if n.Key != "" {
+		if other, dup := d.keys[n.Key]; dup {
+			d.fail(path, "key %q is already used by %s", n.Key, other.Path)
+		}
+		d.keys[n.Key] = e
+	}
+	if t != board.TaskType && t != board.BugType && n.Type != "" {
+		d.fail(path, "type is only allowed on tasks")
+	}
+	if t == board.EpicType && len(n.Tasks) > 0 {
+		d.fail(path, "tasks belong to stories, not epics")
+	}
+	if t == board.StoryType && len(n.Stories) > 0 {
+		d.fail(path, "stories cannot be nested")
+	}
+	if (t == board.TaskType || t == board.BugType) && (len(n.Stories) > 0 || len(n.Tasks) > 0) {
+		d.fail(path, "%ss cannot have children", t)
+	}
+
+	if elem := d.match(e); elem != nil {
+		if other, dup := d.claimed[elem.ID()]; dup {
+			d.fail(path, "%s is already matched by %s", elem.ID(), other)
+		} else {
+			d.claimed[elem.ID()] = path
+			e.ID = elem.ID()
+		}
+	}
+
+	for i, child := range n.Stories {
+		d.resolve(child, board.StoryType, e, fmt.Sprintf("%s.stories[%d]", path, i))
+	}
+	for i, child := range n.Tasks {
+		childType := board.TaskType
+		if child != nil && child.Type != "" {
+			parsed, err := board.ParseElementType(child.Type)
+			if err != nil || (parsed != board.TaskType && parsed != board.BugType) {
+				d.fail(fmt.Sprintf("%s.tasks[%d]", path, i), "type must be task or bug, not %q", child.Type)
+			} else {
+				childType = parsed
+			}
+		}
+		d.resolve(child, childType, e, fmt.Sprintf("%s.tasks[%d]", path, i))
+	}
+}
+
+// match finds the board element an entry stands for, by ID or by name
+// among its parent's children, or returns nil when it has to be created.
+func (d *differ) match(e *Entry) *board.Element {
+	n := e.Node
+	if n.ID != "" {
+		elem := d.b.FindByID(n.ID)
+		if elem == nil {
+			d.fail(e.Path, "element %s not found", n.ID)
+			return nil
+		}
+		if elem.Type != e.Type {
+			d.fail(e.Path, "%s is a %s, not a %s", elem.ID(), elem.Type, e.Type)
+			return nil
+		}
+		if e.Parent != nil && elem.ParentID != e.Parent.ID {
+			d.fail(e.Path, "%s is under %s, not %s; move it first", elem.ID(), elem.ParentID, e.Parent.Ref())
+			return nil
+		}
+		return elem
+	}
+
+	var candidates []*board.Element
+	if e.Parent == nil {
+		candidates = d.b.FindByType(e.Type)
+	} else if e.Parent.ID != "" {
+		candidates = d.b.Children(d.b.FindByID(e.Parent.ID))
+	}
+	var found []*board.Element
+	for _, c := range candidates {
+		if c.Type == e.Type && strings.EqualFold(titleName(c), strings.TrimSpace(n.Name)) {
+			found = append(found, c)
+		}
+	}
+	if len(found) > 1 {
+		ids := make([]string, len(found))
+		for i, f := range found {
+			ids[i] = f.ID()
+		}
+		d.fail(e.Path, "name %q matches %s; set id to pick one", n.Name, strings.Join(ids, ", "))
+		return nil
+	}
+	if len(found) == 1 {
+		return found[0]
+	}
+	return nil
+}
+
+// blockerRef is a resolved blocked-by reference.
+type blockerRef struct {
+	entry *Entry // set when the blocker is in the manifest
+	id    string // set when the blocker exists on the board
+}
+
+// resolveRefs resolves every entry's blocked-by references.
+func (d *differ) resolveRefs() map[*Entry][]blockerRef {
+	byID := make(map[string]*Entry)
+	for _, e := range d.entries {
+		if e.ID != "" {
+			byID[e.ID] = e
+		}
+	}
+	refs := make(map[*Entry][]blockerRef)
+	for _, e := range d.entries {
+		for _, ref := range e.Node.BlockedBy {
+			var r blockerRef
+			if target, ok := d.keys[ref]; ok {
+				r.entry = target
+			} else if board.IsQualifiedID(ref) {
+				d.fail(e.Path, "cross-board reference %s is not supported in manifests", ref)
+				continue
+			} else if elem := d.b.FindByID(ref); elem != nil {
+				r.entry = byID[elem.ID()]
+				r.id = elem.ID()
+			} else {
+				d.fail(e.Path, "blocked-by %s is neither a manifest key nor an element on the board", ref)
+				continue
+			}
+			if r.entry == e {
+				d.fail(e.Path, "an element cannot block itself")
+				continue
+			}
+			refs[e] = append(refs[e], r)
+		}
+	}
+	return refs
+}
+
+// contentChanges compares an entry's README fields and checklist with the board.
+func (d *differ) contentChanges(e *Entry) []Change {
+	if e.ID == "" {
+		// New elements get their fields and checklist as part of the create
+		return []Change{{Kind: Create, Entry: e}}
+	}
+	elem := d.b.FindByID(e.ID)
+	n := e.Node
+	var changes []Change
+	field := func(name, old string, want *string) {
+		if want != nil && strings.TrimSpace(*want) != strings.TrimSpace(old) {
+			changes = append(changes, Change{Kind: Update, Entry: e, Field: name, Old: strings.TrimSpace(old), New: strings.TrimSpace(*want)})
+		}
+	}
+	name := strings.TrimSpace(n.Name)
+	field("title", titleName(elem), &name)
+	field("description", elem.Description, n.Description)
+	field("scope", elem.Scope, n.Scope)
+	field("ac", elem.AC, n.AC)
+
+	have := make(map[string]bool)
+	for _, item := range elem.Checklist {
+		have[strings.TrimSpace(item.Text)] = true
+	}
+	for _, item := range n.Checklist {
+		item = strings.TrimSpace(item)
+		if item != "" && !have[item] {
+			have[item] = true
+			changes = append(changes, Change{Kind: AddItem, Entry: e, Text: item})
+		}
+	}
+	return changes
+}
+
+// linkChanges compares the blocked-by lists of manifest entries with the
+// board. A link is kept when the manifest lists it or when it is the
+// escalation of a listed link or of a link on an element outside the manifest.
+func (d *differ) linkChanges(refs map[*Entry][]blockerRef) []Change {
+	// Elements are identified by ID, new entries by their manifest path
+	node := func(e *Entry) string {
+		if e.ID != "" {
+			return e.ID
+		}
+		return "+" + e.Path
+	}
+	parent := make(map[string]string)
+	for _, elem := range d.b.Elements {
+		parent[elem.ID()] = elem.ParentID
+	}
+	for _, e := range d.entries {
+		if e.Parent != nil {
+			parent[node(e)] = node(e.Parent)
+		}
+	}
+
+	type edge struct{ from, to string }
+	var direct []edge
+	managed := make(map[string]bool)
+	for _, e := range d.entries {
+		managed[node(e)] = true
+		for _, r := range refs[e] {
+			to := r.id
+			if r.entry != nil {
+				to = node(r.entry)
+			}
+			direct = append(direct, edge{node(e), to})
+		}
+	}
+	for _, elem := range d.b.Elements {
+		if managed[elem.ID()] {
+			continue
+		}
+		for _, dep := range elem.BlockedBy {
+			direct = append(direct, edge{elem.ID(), dep})
+		}
+	}
+	implied := make(map[edge]bool)
+	for _, ed := range direct {
+		from, to := parent[ed.from], parent[ed.to]
+		for from != "" && to != "" && from != to {
+			implied[edge{from, to}] = true
+			from, to = parent[from], parent[to]
+		}
+	}
+
+	var unlinks, links []Change
+	for _, e := range d.entries {
+		want := make(map[string]bool)
+		for _, r := range refs[e] {
+			if r.entry != nil {
+				want[node(r.entry)] = true
+			} else {
+				want[r.id] = true
+			}
+		}
+		var have []string
+		if e.ID != "" {
+			have = d.b.FindByID(e.ID).BlockedBy
+		}
+		haveSet := make(map[string]bool)
+		for _, dep := range have {
+			haveSet[dep] = true
+			if want[dep] || implied[edge{e.ID, dep}] || board.IsQualifiedID(dep) {
+				continue
+			}
+			unlinks = append(unlinks, Change{Kind: Unlink, Entry: e, BlockerID: dep})
+		}
+		seen := make(map[string]bool)
+		for _, r := range refs[e] {
+			target := r.id
+			if r.entry != nil {
+				target = node(r.entry)
+			}
+			if haveSet[target] || seen[target] {
+				continue
+			}
+			seen[target] = true
+			links = append(links, Change{Kind: Link, Entry: e, BlockerEntry: r.entry, BlockerID: r.id})
+		}
+	}
+	return append(unlinks, links...)
+}
+
+// titleName returns an element's name as written in its README title.
+func titleName(e *board.Element) string {
+	if e.Title == "" {
+		return strings.ReplaceAll(e.Name, "-", " ")
+	}
+	return strings.TrimSpace(strings.TrimPrefix(e.Title, e.ID()+": "))
+}
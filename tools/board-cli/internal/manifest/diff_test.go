@@ -0,0 +1,224 @@
+package manifest
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/aagrigore/task-board/internal/board"
+)
+
+// diffBoard builds:
+//
+//	EPIC-A "Audio" / STORY-A "Capture" / TASK-A1 "Interface", TASK-A2 "Impl" (blocked by TASK-A1)
+//	EPIC-B "Storage" / STORY-B "Schema" / TASK-B1 "Tables"
+func diffBoard() *board.Board {
+	el := func(typ board.ElementType, id, name, parent string, blockedBy ...string) *board.Element {
+		return &board.Element{Type: typ, RawID: id, Title: id + ": " + name, ParentID: parent, BlockedBy: blockedBy}
+	}
+	b := &board.Board{Elements: []*board.Element{
+		el(board.EpicType, "EPIC-A", "Audio", ""),
+		el(board.EpicType, "EPIC-B", "Storage", ""),
+		el(board.StoryType, "STORY-A", "Capture", "EPIC-A"),
+		el(board.StoryType, "STORY-B", "Schema", "EPIC-B"),
+		el(board.TaskType, "TASK-A1", "Interface", "STORY-A"),
+		el(board.TaskType, "TASK-A2", "Impl", "STORY-A", "TASK-A1"),
+		el(board.TaskType, "TASK-B1", "Tables", "STORY-B"),
+	}}
+	b.FindByID("TASK-A1").Checklist = []board.ChecklistItem{{Text: "Define API", Checked: true}}
+	b.FindByID("TASK-A1").Description = "Old"
+	return b
+}
+
+func mustParse(t *testing.T, src string) *Node {
+	t.Helper()
+	n, err := Parse([]byte(src))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	return n
+}
+
+func kinds(p *Plan) string {
+	var parts []string
+	for _, c := range p.Changes {
+		parts = append(parts, string(c.Kind)+" "+c.Entry.Ref())
+	}
+	return strings.Join(parts, "; ")
+}
+
+func TestDiffInSync(t *testing.T) {
+	p, err := Diff(diffBoard(), mustParse(t, `
+name: Audio
+stories:
+  - name: Capture
+    tasks:
+      - name: Interface
+        key: iface
+        checklist: [Define API]
+      - name: Impl
+        blocked-by: [iface]
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Changes) != 0 {
+		t.Errorf("expected no changes, got %s", kinds(p))
+	}
+	if p.Entries[1].ID != "STORY-A" {
+		t.Errorf("story should match by name, got %q", p.Entries[1].ID)
+	}
+}
+
+func TestDiffChanges(t *testing.T) {
+	p, err := Diff(diffBoard(), mustParse(t, `
+name: Audio
+stories:
+  - name: Capture
+    tasks:
+      - id: TASK-A1
+        name: Recorder interface
+        description: New
+        checklist: [Define API, Write docs]
+      - name: Impl
+        blocked-by: [TASK-B1]
+      - name: Tests
+        key: tests
+        blocked-by: [TASK-A1]
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		kind ChangeKind
+		ref  string
+	}{
+		{Update, "TASK-A1"}, // title
+		{Update, "TASK-A1"}, // description
+		{AddItem, "TASK-A1"},
+		{Create, `new task "Tests"`},
+		{Unlink, "TASK-A2"},
+		{Link, "TASK-A2"},
+		{Link, `new task "Tests"`},
+	}
+	if len(p.Changes) != len(want) {
+		t.Fatalf("changes = %s", kinds(p))
+	}
+	for i, w := range want {
+		if c := p.Changes[i]; c.Kind != w.kind || c.Entry.Ref() != w.ref {
+			t.Errorf("change %d = %s %s, want %s %s", i, c.Kind, c.Entry.Ref(), w.kind, w.ref)
+		}
+	}
+	if c := p.Changes[0]; c.Field != "title" || c.New != "Recorder interface" {
+		t.Errorf("title change = %+v", c)
+	}
+	if c := p.Changes[2]; c.Text != "Write docs" {
+		t.Errorf("only the missing item should be added, got %q", c.Text)
+	}
+	if c := p.Changes[4]; c.BlockerID != "TASK-A1" {
+		t.Errorf("unlink blocker = %s", c.Blocker())
+	}
+}
+
+func TestDiffKeepsEscalatedLinks(t *testing.T) {
+	b := diffBoard()
+	// TASK-A2 blocked by TASK-B1 has escalated to STORY-A and EPIC-A
+	b.FindByID("TASK-A2").BlockedBy = []string{"TASK-B1"}
+	b.FindByID("STORY-A").BlockedBy = []string{"STORY-B"}
+	b.FindByID("EPIC-A").BlockedBy = []string{"EPIC-B"}
+
+	p, err := Diff(b, mustParse(t, `
+name: Audio
+stories:
+  - name: Capture
+    tasks:
+      - name: Impl
+        blocked-by: [TASK-B1]
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Changes) != 0 {
+		t.Errorf("escalated links should be kept, got %s", kinds(p))
+	}
+
+	// Dropping the task link drops the escalations with it
+	p, err = Diff(b, mustParse(t, `
+name: Audio
+stories:
+  - name: Capture
+    tasks:
+      - name: Impl
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := kinds(p); got != "unlink EPIC-A; unlink STORY-A; unlink TASK-A2" {
+		t.Errorf("changes = %s", got)
+	}
+}
+
+func TestDiffNewEpic(t *testing.T) {
+	p, err := Diff(diffBoard(), mustParse(t, `
+name: Playback
+stories:
+  - name: Decoder
+    key: dec
+    tasks:
+      - name: Crash on seek
+        type: bug
+  - name: Output
+    blocked-by: [dec]
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := kinds(p); got != `create new epic "Playback"; create new story "Decoder"; create new bug "Crash on seek"; create new story "Output"; link new story "Output"` {
+		t.Errorf("changes = %s", got)
+	}
+}
+
+func TestDiffInvalid(t *testing.T) {
+	_, err := Diff(diffBoard(), mustParse(t, `
+name: Audio
+tasks:
+  - name: Stray
+stories:
+  - id: STORY-B
+    name: Schema
+  - name: Capture
+    key: a
+    tasks:
+      - name: ""
+        key: a
+        type: story
+      - name: Impl
+        blocked-by: [missing, other:TASK-1]
+`))
+	var invalid *InvalidError
+	if !errors.As(err, &invalid) {
+		t.Fatalf("expected InvalidError, got %v", err)
+	}
+	var paths []string
+	for _, issue := range invalid.Issues {
+		paths = append(paths, issue.Path)
+	}
+	want := []string{
+		"epic",                     // tasks on epic
+		"epic.stories[0]",          // STORY-B is under EPIC-B
+		"epic.stories[1].tasks[0]", // bad type
+		"epic.stories[1].tasks[0]", // no name
+		"epic.stories[1].tasks[0]", // duplicate key
+		"epic.stories[1].tasks[1]", // missing ref
+		"epic.stories[1].tasks[1]", // cross-board ref
+	}
+	if strings.Join(paths, ",") != strings.Join(want, ",") {
+		t.Errorf("issues = %+v", invalid.Issues)
+	}
+}
+
+func TestParseRejectsUnknownKeys(t *testing.T) {
+	if _, err := Parse([]byte("name: Audio\nstorys: []\n")); err == nil {
+		t.Error("unknown key should be rejected")
+	}
+}
@@ -0,0 +1,57 @@
+// Package manifest describes the structure of an epic — its stories, tasks,
+// checklists and dependencies — in a YAML file, and computes the changes
+// that bring a board in line with it.
+package manifest
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Node is one element of a manifest. The top-level node is the epic; its
+// Stories hold Tasks. README fields left nil are not managed by the manifest.
+type Node struct {
+	// Key names the node for blocked-by references within the manifest.
+	Key string `yaml:"key"`
+	// ID pins the node to an existing element. Without it the node is
+	// matched by name under its parent, and created when nothing matches.
+	ID          string   `yaml:"id"`
+	Type        string   `yaml:"type"` // tasks only: "task" (default) or "bug"
+	Name        string   `yaml:"name"`
+	Description *string  `yaml:"description"`
+	Scope       *string  `yaml:"scope"`
+	AC          *string  `yaml:"ac"`
+	Checklist   []string `yaml:"checklist"`
+	// BlockedBy lists manifest keys or board IDs.
+	BlockedBy []string `yaml:"blocked-by"`
+	Stories   []*Node  `yaml:"stories"`
+	Tasks     []*Node  `yaml:"tasks"`
+}
+
+// Load reads a manifest file.
+func Load(path string) (*Node, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading manifest: %w", err)
+	}
+	return Parse(data)
+}
+
+// Parse decodes a manifest, rejecting unknown keys so typos are not
+// silently ignored.
+func Parse(data []byte) (*Node, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	var root Node
+	if err := dec.Decode(&root); err != nil {
+		if err == io.EOF {
+			return nil, fmt.Errorf("manifest is empty")
+		}
+		return nil, fmt.Errorf("parsing manifest: %w", err)
+	}
+	return &root, nil
+}
@@ -0,0 +1,415 @@
+// Package metrics derives flow metrics — cycle time, lead time,
+// throughput, time in status and cumulative flow — from the status history
+// recorded in each element's progress.md.
+package metrics
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/aagrigore/task-board/internal/board"
+)
+
+// Period is the bucket size of the throughput series.
+type Period string
+
+const (
+	Day  Period = "day"
+	Week Period = "week"
+)
+
+// ParsePeriod parses "day" or "week".
+func ParsePeriod(s string) (Period, error) {
+	switch s {
+	case "day", "daily":
+		return Day, nil
+	case "week", "weekly":
+		return Week, nil
+	}
+	return "", fmt.Errorf("unknown period %q (valid: day, week)", s)
+}
+
+// Key returns the bucket a time falls in: "2006-01-02" for days and
+// "2006-W01" (ISO week) for weeks.
+func (p Period) Key(t time.Time) string {
+	if p == Week {
+		year, week := t.UTC().ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	}
+	return t.UTC().Format("2006-01-02")
+}
+
+func (p Period) step() time.Duration {
+	if p == Week {
+		return 7 * 24 * time.Hour
+	}
+	return 24 * time.Hour
+}
+
+// Options control how the report is computed.
+type Options struct {
+	// Start is the status that starts the cycle; elements that skip it
+	// start at their first active status.
+	Start board.Status
+	// Period buckets the throughput series.
+	Period Period
+	// Since limits completions, throughput and the cumulative flow to
+	// this time onwards. Zero means the whole history.
+	Since time.Time
+	// Now ends the open-ended intervals.
+	Now time.Time
+}
+
+// ElementTimes are the key moments of one element.
+type ElementTimes struct {
+	ID         string     `json:"id"`
+	Type       string     `json:"type"`
+	Status     string     `json:"status"`
+	Assignee   string     `json:"assignee"`
+	CreatedAt  *time.Time `json:"createdAt"`
+	StartedAt  *time.Time `json:"startedAt"`
+	DoneAt     *time.Time `json:"doneAt"`
+	CycleHours *float64   `json:"cycleHours"`
+	LeadHours  *float64   `json:"leadHours"`
+}
+
+// Stats summarise a set of durations in hours.
+type Stats struct {
+	Count  int     `json:"count"`
+	Mean   float64 `json:"meanHours"`
+	Median float64 `json:"medianHours"`
+	P85    float64 `json:"p85Hours"`
+	Max    float64 `json:"maxHours"`
+}
+
+// PeriodCount is the number of elements finished in one period.
+type PeriodCount struct {
+	Period string   `json:"period"`
+	Count  int      `json:"count"`
+	IDs    []string `json:"ids"`
+}
+
+// StatusTime is the time elements spent in one status. Intervals that are
+// still open count up to now, except in finished statuses.
+type StatusTime struct {
+	Status     board.Status   `json:"status"`
+	Category   board.Category `json:"category"`
+	Visits     int            `json:"visits"`
+	TotalHours float64        `json:"totalHours"`
+	MeanHours  float64        `json:"meanHours"`
+}
+
+// FlowPoint is the number of elements in each status at the end of a day.
+type FlowPoint struct {
+	Date   string               `json:"date"`
+	Counts map[board.Status]int `json:"counts"`
+}
+
+// Report holds every metric for a set of elements.
+type Report struct {
+	Elements       []ElementTimes `json:"elements"`
+	CycleTime      Stats          `json:"cycleTime"`
+	LeadTime       Stats          `json:"leadTime"`
+	Throughput     []PeriodCount  `json:"throughput"`
+	TimeInStatus   []StatusTime   `json:"timeInStatus"`
+	Statuses       []board.Status `json:"statuses"` // series order of the cumulative flow
+	CumulativeFlow []FlowPoint    `json:"cumulativeFlow"`
+}
+
+// segment is a stretch of time an element spent in one status.
+type segment struct {
+	status   board.Status
+	from, to time.Time
+	open     bool
+}
+
+// timeline returns the statuses an element went through. The first status
+// is the one its history starts from, or its current status without
+// history. Elements without a creation time start at their first change.
+func timeline(e *board.Element, now time.Time) []segment {
+	start := e.CreatedAt
+	status := e.Status
+	if len(e.History) > 0 {
+		status = e.History[0].From
+		if start.IsZero() || e.History[0].At.Before(start) {
+			start = e.History[0].At
+		}
+	}
+	if start.IsZero() {
+		return nil
+	}
+	var segs []segment
+	for _, c := range e.History {
+		segs = append(segs, segment{status: status, from: start, to: c.At})
+		status, start = c.To, c.At
+	}
+	return append(segs, segment{status: status, from: start, to: now, open: true})
+}
+
+func statusAt(segs []segment, t time.Time) (board.Status, bool) {
+	if len(segs) == 0 || t.Before(segs[0].from) {
+		return "", false
+	}
+	for _, s := range segs {
+		if t.Before(s.to) {
+			return s.status, true
+		}
+	}
+	return segs[len(segs)-1].status, true
+}
+
+func times(wf *board.Workflow, e *board.Element, opts Options) ElementTimes {
+	et := ElementTimes{ID: e.ID(), Type: string(e.Type), Status: string(e.Status), Assignee: e.AssignedTo}
+	if !e.CreatedAt.IsZero() {
+		created := e.CreatedAt
+		et.CreatedAt = &created
+	}
+	flow := wf.For(e.Type)
+
+	// Started: first entry into the start status, else into any active status
+	var started, firstActive time.Time
+	for _, c := range e.History {
+		if c.To == opts.Start && started.IsZero() {
+			started = c.At
+		}
+		if flow.Category(c.To) == board.CategoryActive && firstActive.IsZero() {
+			firstActive = c.At
+		}
+	}
+	if started.IsZero() {
+		started = firstActive
+	}
+	if !started.IsZero() {
+		et.StartedAt = &started
+	}
+
+	// Done: the last change into the done status it is still in. Elements
+	// finished before history was recorded fall back to their last update.
+	if flow.Category(e.Status) != board.CategoryDone {
+		return et
+	}
+	var done time.Time
+	for i := len(e.History) - 1; i >= 0; i-- {
+		if e.History[i].To == e.Status {
+			done = e.History[i].At
+			break
+		}
+	}
+	if done.IsZero() {
+		done = e.LastUpdate
+	}
+	if done.IsZero() {
+		return et
+	}
+	et.DoneAt = &done
+	if et.StartedAt != nil && !done.Before(started) {
+		h := done.Sub(started).Hours()
+		et.CycleHours = &h
+	}
+	if et.CreatedAt != nil && !done.Before(e.CreatedAt) {
+		h := done.Sub(e.CreatedAt).Hours()
+		et.LeadHours = &h
+	}
+	return et
+}
+
+// Compute builds the report for the given elements.
+func Compute(wf *board.Workflow, elements []*board.Element, opts Options) *Report {
+	if opts.Now.IsZero() {
+		opts.Now = time.Now().UTC()
+	}
+	if opts.Period == "" {
+		opts.Period = Day
+	}
+	if opts.Start == "" {
+		opts.Start = board.StatusDevelopment
+	}
+
+	r := &Report{Elements: []ElementTimes{}, Throughput: []PeriodCount{}, TimeInStatus: []StatusTime{},
+		Statuses: []board.Status{}, CumulativeFlow: []FlowPoint{}}
+	var cycle, lead []float64
+	finished := map[string][]string{}
+	var firstDone time.Time
+	for _, e := range elements {
+		et := times(wf, e, opts)
+		r.Elements = append(r.Elements, et)
+		if et.DoneAt == nil || et.DoneAt.Before(opts.Since) {
+			continue
+		}
+		if et.CycleHours != nil {
+			cycle = append(cycle, *et.CycleHours)
+		}
+		if et.LeadHours != nil {
+			lead = append(lead, *et.LeadHours)
+		}
+		key := opts.Period.Key(*et.DoneAt)
+		finished[key] = append(finished[key], et.ID)
+		if firstDone.IsZero() || et.DoneAt.Before(firstDone) {
+			firstDone = *et.DoneAt
+		}
+	}
+	r.CycleTime = summarise(cycle)
+	r.LeadTime = summarise(lead)
+
+	// Throughput, with empty periods filled in up to now
+	from := firstDone
+	if !opts.Since.IsZero() {
+		from = opts.Since
+	}
+	if !from.IsZero() {
+		last := opts.Period.Key(opts.Now)
+		for t := from; ; t = t.Add(opts.Period.step()) {
+			key := opts.Period.Key(t)
+			ids := finished[key]
+			if ids == nil {
+				ids = []string{}
+			}
+			r.Throughput = append(r.Throughput, PeriodCount{Period: key, Count: len(ids), IDs: ids})
+			if key == last || t.After(opts.Now) {
+				break
+			}
+		}
+	}
+
+	timelines := make([][]segment, len(elements))
+	for i, e := range elements {
+		timelines[i] = timeline(e, opts.Now)
+	}
+	r.TimeInStatus = timeInStatus(wf, elements, timelines)
+	r.Statuses, r.CumulativeFlow = cumulativeFlow(wf, elements, timelines, opts)
+	return r
+}
+
+func timeInStatus(wf *board.Workflow, elements []*board.Element, timelines [][]segment) []StatusTime {
+	byStatus := map[board.Status]*StatusTime{}
+	for i, e := range elements {
+		flow := wf.For(e.Type)
+		for _, s := range timelines[i] {
+			category := flow.Category(s.status)
+			if s.open && category.Finished() {
+				continue
+			}
+			st, ok := byStatus[s.status]
+			if !ok {
+				st = &StatusTime{Status: s.status, Category: category}
+				byStatus[s.status] = st
+			}
+			st.Visits++
+			st.TotalHours += s.to.Sub(s.from).Hours()
+		}
+	}
+	set := map[board.Status]bool{}
+	for s := range byStatus {
+		set[s] = true
+	}
+	var result []StatusTime
+	for _, s := range orderStatuses(wf, set) {
+		st := byStatus[s]
+		st.MeanHours = st.TotalHours / float64(st.Visits)
+		result = append(result, *st)
+	}
+	if result == nil {
+		result = []StatusTime{}
+	}
+	return result
+}
+
+func cumulativeFlow(wf *board.Workflow, elements []*board.Element, timelines [][]segment, opts Options) ([]board.Status, []FlowPoint) {
+	var first time.Time
+	for _, segs := range timelines {
+		if len(segs) > 0 && (first.IsZero() || segs[0].from.Before(first)) {
+			first = segs[0].from
+		}
+	}
+	if first.IsZero() {
+		return []board.Status{}, []FlowPoint{}
+	}
+	if opts.Since.After(first) {
+		first = opts.Since
+	}
+
+	used := map[board.Status]bool{}
+	var points []FlowPoint
+	day := time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, time.UTC)
+	for ; !day.After(opts.Now); day = day.AddDate(0, 0, 1) {
+		end := day.AddDate(0, 0, 1).Add(-time.Second)
+		if end.After(opts.Now) {
+			end = opts.Now
+		}
+		point := FlowPoint{Date: day.Format("2006-01-02"), Counts: map[board.Status]int{}}
+		for i := range elements {
+			if s, ok := statusAt(timelines[i], end); ok {
+				point.Counts[s]++
+				used[s] = true
+			}
+		}
+		points = append(points, point)
+	}
+	statuses := orderStatuses(wf, used)
+	// Every point carries every series, so charts need no gap handling
+	for _, p := range points {
+		for _, s := range statuses {
+			if _, ok := p.Counts[s]; !ok {
+				p.Counts[s] = 0
+			}
+		}
+	}
+	return statuses, points
+}
+
+// orderStatuses returns the keys in workflow order, then any statuses the
+// workflow no longer has, alphabetically.
+func orderStatuses(wf *board.Workflow, set map[board.Status]bool) []board.Status {
+	result := []board.Status{}
+	for _, def := range wf.Statuses() {
+		if set[def.Name] {
+			result = append(result, def.Name)
+		}
+	}
+	var rest []board.Status
+	for s := range set {
+		if !contains(result, s) {
+			rest = append(rest, s)
+		}
+	}
+	sort.Slice(rest, func(i, j int) bool { return rest[i] < rest[j] })
+	return append(result, rest...)
+}
+
+func contains(statuses []board.Status, s board.Status) bool {
+	for _, x := range statuses {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
+
+func summarise(hours []float64) Stats {
+	if len(hours) == 0 {
+		return Stats{}
+	}
+	sorted := append([]float64(nil), hours...)
+	sort.Float64s(sorted)
+	var sum float64
+	for _, h := range sorted {
+		sum += h
+	}
+	return Stats{
+		Count:  len(sorted),
+		Mean:   sum / float64(len(sorted)),
+		Median: percentile(sorted, 50),
+		P85:    percentile(sorted, 85),
+		Max:    sorted[len(sorted)-1],
+	}
+}
+
+// percentile uses the nearest-rank method on sorted values.
+func percentile(sorted []float64, p int) float64 {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
@@ -0,0 +1,106 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aagrigore/task-board/internal/board"
+)
+
+func at(day, hour int) time.Time {
+	return time.Date(2026, 3, day, hour, 0, 0, 0, time.UTC)
+}
+
+func change(t time.Time, from, to board.Status) board.StatusChange {
+	return board.StatusChange{At: t, From: from, To: to}
+}
+
+func TestCompute(t *testing.T) {
+	wf := board.DefaultWorkflow()
+	elements := []*board.Element{
+		{Type: board.TaskType, RawID: "TASK-1", Status: board.StatusDone, CreatedAt: at(1, 0), History: []board.StatusChange{
+			change(at(2, 0), board.StatusBacklog, board.StatusDevelopment),
+			change(at(2, 12), board.StatusDevelopment, board.StatusToReview),
+			change(at(3, 0), board.StatusToReview, board.StatusDone),
+		}},
+		// Skips development: the cycle starts at its first active status
+		{Type: board.TaskType, RawID: "TASK-2", Status: board.StatusDone, CreatedAt: at(1, 0), History: []board.StatusChange{
+			change(at(3, 0), board.StatusBacklog, board.StatusAnalysis),
+			change(at(4, 0), board.StatusAnalysis, board.StatusDone),
+		}},
+		{Type: board.BugType, RawID: "BUG-1", Status: board.StatusDevelopment, CreatedAt: at(2, 0), History: []board.StatusChange{
+			change(at(4, 0), board.StatusBacklog, board.StatusDevelopment),
+		}},
+	}
+	r := Compute(wf, elements, Options{Now: at(5, 0)})
+
+	if r.CycleTime.Count != 2 || r.CycleTime.Mean != 24 || r.CycleTime.Max != 24 {
+		t.Errorf("CycleTime = %+v, want two of 24h", r.CycleTime)
+	}
+	if r.LeadTime.Count != 2 || r.LeadTime.Median != 48 || r.LeadTime.Max != 72 {
+		t.Errorf("LeadTime = %+v, want 48h and 72h", r.LeadTime)
+	}
+
+	var throughput []int
+	for _, p := range r.Throughput {
+		throughput = append(throughput, p.Count)
+	}
+	if len(r.Throughput) != 3 || r.Throughput[0].Period != "2026-03-03" || throughput[0] != 1 || throughput[1] != 1 || throughput[2] != 0 {
+		t.Errorf("Throughput = %+v, want 1, 1, 0 from 2026-03-03", r.Throughput)
+	}
+
+	byStatus := map[board.Status]StatusTime{}
+	for _, s := range r.TimeInStatus {
+		byStatus[s.Status] = s
+	}
+	if dev := byStatus[board.StatusDevelopment]; dev.Visits != 2 || dev.TotalHours != 36 {
+		t.Errorf("development = %+v, want 2 visits, 12h + 24h open", dev)
+	}
+	if _, ok := byStatus[board.StatusDone]; ok {
+		t.Error("open intervals in finished statuses should not count")
+	}
+
+	if len(r.CumulativeFlow) != 5 {
+		t.Fatalf("CumulativeFlow has %d days, want 5", len(r.CumulativeFlow))
+	}
+	last := r.CumulativeFlow[4]
+	if last.Counts[board.StatusDone] != 2 || last.Counts[board.StatusDevelopment] != 1 || last.Counts[board.StatusBacklog] != 0 {
+		t.Errorf("last day = %+v", last.Counts)
+	}
+	if first := r.CumulativeFlow[0]; first.Counts[board.StatusBacklog] != 2 {
+		t.Errorf("first day = %+v, want 2 in backlog", first.Counts)
+	}
+	if r.Statuses[0] != board.StatusBacklog || r.Statuses[len(r.Statuses)-1] != board.StatusDone {
+		t.Errorf("Statuses = %v, want workflow order", r.Statuses)
+	}
+
+	r = Compute(wf, elements, Options{Now: at(5, 0), Since: at(4, 0), Period: Week})
+	if r.CycleTime.Count != 1 || len(r.Throughput) != 1 || r.Throughput[0].Period != "2026-W10" {
+		t.Errorf("since: cycle %+v, throughput %+v", r.CycleTime, r.Throughput)
+	}
+	if r.CumulativeFlow[0].Date != "2026-03-04" {
+		t.Errorf("since: flow starts %s, want 2026-03-04", r.CumulativeFlow[0].Date)
+	}
+}
+
+func TestComputeWithoutHistory(t *testing.T) {
+	e := &board.Element{Type: board.TaskType, RawID: "TASK-1", Status: board.StatusDone,
+		CreatedAt: at(1, 0), LastUpdate: at(2, 0)}
+	r := Compute(board.DefaultWorkflow(), []*board.Element{e}, Options{Now: at(3, 0)})
+	if r.LeadTime.Count != 1 || r.LeadTime.Max != 24 {
+		t.Errorf("LeadTime = %+v, want 24h from the last update", r.LeadTime)
+	}
+	if r.CycleTime.Count != 0 {
+		t.Errorf("CycleTime = %+v, want none without a start", r.CycleTime)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	sorted := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	if got := percentile(sorted, 50); got != 5 {
+		t.Errorf("median = %v, want 5", got)
+	}
+	if got := percentile(sorted, 85); got != 9 {
+		t.Errorf("85th = %v, want 9", got)
+	}
+}
@@ -15,6 +15,10 @@ const (
 	CycleDetected   ErrorCode = "CYCLE_DETECTED"
 	ValidationError ErrorCode = "VALIDATION_ERROR"
 	InternalError   ErrorCode = "INTERNAL_ERROR"
+	Duplicate       ErrorCode = "DUPLICATE"
+	WIPLimit        ErrorCode = "WIP_LIMIT"
+	ReviewRequired  ErrorCode = "REVIEW_REQUIRED"
+	PolicyViolation ErrorCode = "POLICY_VIOLATION"
 )
 
 // JSONError represents the error response structure
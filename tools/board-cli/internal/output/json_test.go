@@ -107,6 +107,8 @@ func TestErrorCodes(t *testing.T) {
 		CycleDetected,
 		ValidationError,
 		InternalError,
+		Duplicate,
+		WIPLimit,
 	}
 
 	expected := []string{
@@ -116,6 +118,8 @@ func TestErrorCodes(t *testing.T) {
 		"CYCLE_DETECTED",
 		"VALIDATION_ERROR",
 		"INTERNAL_ERROR",
+		"DUPLICATE",
+		"WIP_LIMIT",
 	}
 
 	for i, code := range codes {
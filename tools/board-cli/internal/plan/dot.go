@@ -7,30 +7,9 @@ import (
 	"github.com/aagrigore/task-board/internal/board"
 )
 
-// statusColor returns the fillcolor for a given element status.
-func statusColor(s board.Status) string {
-	switch s {
-	case board.StatusBacklog:
-		return "#f5f5f5" // light grey
-	case board.StatusAnalysis:
-		return "#cce5ff" // light blue
-	case board.StatusToDev:
-		return "#ffffff" // white
-	case board.StatusDevelopment:
-		return "#fff3cd" // light yellow
-	case board.StatusToReview:
-		return "#ffd699" // orange
-	case board.StatusReviewing:
-		return "#ffeb99" // yellow
-	case board.StatusDone:
-		return "#d4edda" // green
-	case board.StatusClosed:
-		return "#6c757d" // dark grey
-	case board.StatusBlocked:
-		return "#f8d7da" // red
-	default:
-		return "#ffffff"
-	}
+// statusColor returns the fillcolor for an element's status in the workflow.
+func statusColor(wf *board.Workflow, e *board.Element) string {
+	return wf.For(e.Type).Color(e.Status)
 }
 
 // safeDOTID converts an element ID like "TASK-01" to a DOT-safe identifier "TASK_01".
@@ -40,8 +19,9 @@ func safeDOTID(id string) string {
 
 // GenerateDOT produces a Graphviz DOT representation of the plan.
 // The plan provides phase grouping; elements provides the full element data
-// (for status colors, names, and blocked-by edges).
-func GenerateDOT(p *Plan, elements []*board.Element) string {
+// (for status colors, names, and blocked-by edges). A nil workflow means the
+// default one.
+func GenerateDOT(p *Plan, elements []*board.Element, wf *board.Workflow) string {
 	var b strings.Builder
 
 	b.WriteString("digraph plan {\n")
@@ -62,7 +42,7 @@ func GenerateDOT(p *Plan, elements []*board.Element) string {
 
 		for _, e := range phase.Elements {
 			id := safeDOTID(e.ID())
-			color := statusColor(e.Status)
+			color := statusColor(wf, e)
 			label := fmt.Sprintf("%s\\n%s", e.ID(), e.Name)
 			b.WriteString(fmt.Sprintf("    %s [label=\"%s\", fillcolor=\"%s\"];\n", id, label, color))
 		}
@@ -80,8 +60,10 @@ func GenerateDOT(p *Plan, elements []*board.Element) string {
 			b.WriteString(fmt.Sprintf("  %s -> %s;\n", safeDOTID(blockerID), safeDOTID(e.ID())))
 		}
 	}
+	writeDOTLinks(&b, elements)
+	writeDOTExternal(&b, elements)
 
-	writeLegend(&b)
+	writeLegend(&b, wf)
 
 	b.WriteString("}\n")
 	return b.String()
@@ -104,67 +86,42 @@ func GenerateFullDOT(brd *board.Board, elements []*board.Element) string {
 	for _, e := range elements {
 		inScope[e.ID()] = true
 	}
-
-	// Group elements by type and parent.
-	epics := filterType(elements, board.EpicType)
-	storiesByEpic := make(map[string][]*board.Element)
-	tasksByStory := make(map[string][]*board.Element)
-	// Loose stories/tasks (parent not in scope).
-	var looseStories []*board.Element
-	var looseTasks []*board.Element
-
-	for _, e := range elements {
-		switch e.Type {
-		case board.StoryType:
-			if inScope[e.ParentID] {
-				storiesByEpic[e.ParentID] = append(storiesByEpic[e.ParentID], e)
-			} else {
-				looseStories = append(looseStories, e)
-			}
-		case board.TaskType, board.BugType:
-			if inScope[e.ParentID] {
-				tasksByStory[e.ParentID] = append(tasksByStory[e.ParentID], e)
-			} else {
-				looseTasks = append(looseTasks, e)
-			}
-		}
-	}
+	h := groupHierarchy(elements)
 
 	clusterIdx := 0
 
 	// Render epics as top-level clusters.
-	for _, epic := range epics {
+	for _, epic := range h.epics {
 		clusterIdx++
 		b.WriteString(fmt.Sprintf("\n  subgraph cluster_%d {\n", clusterIdx))
 		b.WriteString(fmt.Sprintf("    label=\"%s: %s\";\n", epic.ID(), epic.Name))
 		b.WriteString("    style=rounded;\n")
-		b.WriteString(fmt.Sprintf("    color=\"%s\";\n", clusterBorderColor(epic.Status)))
+		b.WriteString(fmt.Sprintf("    color=\"%s\";\n", clusterBorderColor(brd.Workflow, epic)))
 		b.WriteString("    fontname=\"Helvetica Bold\";\n")
 		b.WriteString("    fontsize=13;\n")
 
 		// Epic node itself.
-		writeNode(&b, epic, "    ")
+		writeNode(&b, brd.Workflow, epic, "    ")
 
 		// Stories inside this epic.
-		stories := storiesByEpic[epic.ID()]
-		for _, story := range stories {
-			tasks := tasksByStory[story.ID()]
+		for _, story := range h.stories[epic.ID()] {
+			tasks := h.tasks[story.ID()]
 			if len(tasks) > 0 {
 				clusterIdx++
 				b.WriteString(fmt.Sprintf("\n    subgraph cluster_%d {\n", clusterIdx))
 				b.WriteString(fmt.Sprintf("      label=\"%s: %s\";\n", story.ID(), story.Name))
 				b.WriteString("      style=dashed;\n")
-				b.WriteString(fmt.Sprintf("      color=\"%s\";\n", clusterBorderColor(story.Status)))
+				b.WriteString(fmt.Sprintf("      color=\"%s\";\n", clusterBorderColor(brd.Workflow, story)))
 				b.WriteString("      fontname=\"Helvetica\";\n")
 				b.WriteString("      fontsize=11;\n")
 
-				writeNode(&b, story, "      ")
+				writeNode(&b, brd.Workflow, story, "      ")
 				for _, t := range tasks {
-					writeNode(&b, t, "      ")
+					writeNode(&b, brd.Workflow, t, "      ")
 				}
 				b.WriteString("    }\n")
 			} else {
-				writeNode(&b, story, "    ")
+				writeNode(&b, brd.Workflow, story, "    ")
 			}
 		}
 
@@ -172,29 +129,29 @@ func GenerateFullDOT(brd *board.Board, elements []*board.Element) string {
 	}
 
 	// Loose stories (when rendering from a story scope, these are direct children).
-	for _, story := range looseStories {
-		tasks := tasksByStory[story.ID()]
+	for _, story := range h.looseStories {
+		tasks := h.tasks[story.ID()]
 		if len(tasks) > 0 {
 			clusterIdx++
 			b.WriteString(fmt.Sprintf("\n  subgraph cluster_%d {\n", clusterIdx))
 			b.WriteString(fmt.Sprintf("    label=\"%s: %s\";\n", story.ID(), story.Name))
 			b.WriteString("    style=dashed;\n")
-			b.WriteString(fmt.Sprintf("    color=\"%s\";\n", clusterBorderColor(story.Status)))
+			b.WriteString(fmt.Sprintf("    color=\"%s\";\n", clusterBorderColor(brd.Workflow, story)))
 			b.WriteString("    fontname=\"Helvetica\";\n")
 
-			writeNode(&b, story, "    ")
+			writeNode(&b, brd.Workflow, story, "    ")
 			for _, t := range tasks {
-				writeNode(&b, t, "    ")
+				writeNode(&b, brd.Workflow, t, "    ")
 			}
 			b.WriteString("  }\n")
 		} else {
-			writeNode(&b, story, "  ")
+			writeNode(&b, brd.Workflow, story, "  ")
 		}
 	}
 
 	// Loose tasks.
-	for _, t := range looseTasks {
-		writeNode(&b, t, "  ")
+	for _, t := range h.looseTasks {
+		writeNode(&b, brd.Workflow, t, "  ")
 	}
 
 	// Edges: all blocked-by within scope.
@@ -207,16 +164,58 @@ func GenerateFullDOT(brd *board.Board, elements []*board.Element) string {
 			b.WriteString(fmt.Sprintf("  %s -> %s;\n", safeDOTID(blockerID), safeDOTID(e.ID())))
 		}
 	}
+	writeDOTLinks(&b, elements)
+	writeDOTExternal(&b, elements)
 
-	writeLegend(&b)
+	writeLegend(&b, brd.Workflow)
 
 	b.WriteString("}\n")
 	return b.String()
 }
 
-func writeNode(b *strings.Builder, e *board.Element, indent string) {
+// hierarchy groups elements by parent for the hierarchy layouts. Stories
+// and tasks whose parent is out of scope are loose: they sit at the top
+// level of the graph.
+type hierarchy struct {
+	epics        []*board.Element
+	stories      map[string][]*board.Element // by epic ID
+	tasks        map[string][]*board.Element // by story ID
+	looseStories []*board.Element
+	looseTasks   []*board.Element
+}
+
+func groupHierarchy(elements []*board.Element) hierarchy {
+	inScope := make(map[string]bool, len(elements))
+	for _, e := range elements {
+		inScope[e.ID()] = true
+	}
+	h := hierarchy{
+		epics:   filterType(elements, board.EpicType),
+		stories: make(map[string][]*board.Element),
+		tasks:   make(map[string][]*board.Element),
+	}
+	for _, e := range elements {
+		switch e.Type {
+		case board.StoryType:
+			if inScope[e.ParentID] {
+				h.stories[e.ParentID] = append(h.stories[e.ParentID], e)
+			} else {
+				h.looseStories = append(h.looseStories, e)
+			}
+		case board.TaskType, board.BugType:
+			if inScope[e.ParentID] {
+				h.tasks[e.ParentID] = append(h.tasks[e.ParentID], e)
+			} else {
+				h.looseTasks = append(h.looseTasks, e)
+			}
+		}
+	}
+	return h
+}
+
+func writeNode(b *strings.Builder, wf *board.Workflow, e *board.Element, indent string) {
 	id := safeDOTID(e.ID())
-	color := statusColor(e.Status)
+	color := statusColor(wf, e)
 	shape := "box"
 	if e.Type == board.EpicType {
 		shape = "box3d"
@@ -227,7 +226,9 @@ func writeNode(b *strings.Builder, e *board.Element, indent string) {
 	b.WriteString(fmt.Sprintf("%s%s [label=\"%s\", fillcolor=\"%s\", shape=%s];\n", indent, id, label, color, shape))
 }
 
-func writeLegend(b *strings.Builder) {
+// writeLegend lists the workflow's statuses: the main flow on one row,
+// blocked and closed statuses on another.
+func writeLegend(b *strings.Builder, wf *board.Workflow) {
 	b.WriteString("\n  subgraph cluster_legend {\n")
 	b.WriteString("    label=\"Legend\";\n")
 	b.WriteString("    style=rounded;\n")
@@ -235,29 +236,44 @@ func writeLegend(b *strings.Builder) {
 	b.WriteString("    fontname=\"Helvetica Bold\";\n")
 	b.WriteString("    fontsize=11;\n")
 	b.WriteString("    node [shape=box, style=filled, fontname=\"Helvetica\", fontsize=9, width=1.0];\n")
-	b.WriteString("    leg_backlog [label=\"backlog\", fillcolor=\"#f5f5f5\"];\n")
-	b.WriteString("    leg_analysis [label=\"analysis\", fillcolor=\"#cce5ff\"];\n")
-	b.WriteString("    leg_todev [label=\"to-dev\", fillcolor=\"#ffffff\"];\n")
-	b.WriteString("    leg_dev [label=\"development\", fillcolor=\"#fff3cd\"];\n")
-	b.WriteString("    leg_toreview [label=\"to-review\", fillcolor=\"#ffd699\"];\n")
-	b.WriteString("    leg_reviewing [label=\"reviewing\", fillcolor=\"#ffeb99\"];\n")
-	b.WriteString("    leg_done [label=\"done\", fillcolor=\"#d4edda\"];\n")
-	b.WriteString("    leg_blocked [label=\"blocked\", fillcolor=\"#f8d7da\"];\n")
-	b.WriteString("    leg_closed [label=\"closed\", fillcolor=\"#6c757d\", fontcolor=\"#ffffff\"];\n")
-	b.WriteString("    leg_backlog -> leg_analysis -> leg_todev -> leg_dev -> leg_toreview -> leg_reviewing -> leg_done [style=invis];\n")
-	b.WriteString("    leg_blocked -> leg_closed [style=invis];\n")
+	var flow, aside []string
+	for _, s := range wf.Statuses() {
+		id := "leg_" + strings.ReplaceAll(string(s.Name), "-", "_")
+		font := ""
+		if IsDark(s.Color) {
+			font = ", fontcolor=\"#ffffff\""
+		}
+		b.WriteString(fmt.Sprintf("    %s [label=\"%s\", fillcolor=\"%s\"%s];\n", id, s.Name, s.Color, font))
+		if s.Category == board.CategoryBlocked || s.Category == board.CategoryClosed {
+			aside = append(aside, id)
+		} else {
+			flow = append(flow, id)
+		}
+	}
+	for _, row := range [][]string{flow, aside} {
+		if len(row) > 1 {
+			b.WriteString(fmt.Sprintf("    %s [style=invis];\n", strings.Join(row, " -> ")))
+		}
+	}
 	b.WriteString("  }\n")
 }
 
-func clusterBorderColor(s board.Status) string {
-	switch s {
-	case board.StatusDone:
+// IsDark reports whether a #rrggbb colour needs light text on top of it.
+func IsDark(color string) bool {
+	var r, g, bl int
+	if _, err := fmt.Sscanf(color, "#%02x%02x%02x", &r, &g, &bl); err != nil {
+		return false
+	}
+	return r*299+g*587+bl*114 < 128*1000
+}
+
+func clusterBorderColor(wf *board.Workflow, e *board.Element) string {
+	switch wf.Category(e) {
+	case board.CategoryDone:
 		return "#28a745" // green
-	case board.StatusDevelopment, board.StatusToReview, board.StatusReviewing:
+	case board.CategoryActive:
 		return "#ffc107" // yellow (active work)
-	case board.StatusAnalysis:
-		return "#17a2b8" // blue (research)
-	case board.StatusBlocked:
+	case board.CategoryBlocked:
 		return "#dc3545" // red
 	default:
 		return "#6c757d" // grey
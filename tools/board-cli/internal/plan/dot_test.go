@@ -23,7 +23,7 @@ func TestGenerateDOTBasicStructure(t *testing.T) {
 
 	elements := []*board.Element{a, b}
 	plan := BuildPlan(elements)
-	dot := GenerateDOT(plan, elements)
+	dot := GenerateDOT(plan, elements, nil)
 
 	// Must contain digraph header.
 	if !strings.Contains(dot, "digraph plan {") {
@@ -49,7 +49,7 @@ func TestGenerateDOTPhaseCluster(t *testing.T) {
 
 	elements := []*board.Element{a, b}
 	plan := BuildPlan(elements)
-	dot := GenerateDOT(plan, elements)
+	dot := GenerateDOT(plan, elements, nil)
 
 	if !strings.Contains(dot, "subgraph cluster_phase_1") {
 		t.Error("missing phase 1 cluster")
@@ -71,7 +71,7 @@ func TestGenerateDOTNodeLabels(t *testing.T) {
 
 	elements := []*board.Element{a, b}
 	plan := BuildPlan(elements)
-	dot := GenerateDOT(plan, elements)
+	dot := GenerateDOT(plan, elements, nil)
 
 	// Node IDs must be safe (underscore, not dash).
 	if !strings.Contains(dot, "TASK_01") {
@@ -106,7 +106,7 @@ func TestGenerateDOTNodeColors(t *testing.T) {
 			e := makeElementWithStatus(board.TaskType, 1, "test", tt.status)
 			elements := []*board.Element{e}
 			plan := BuildPlan(elements)
-			dot := GenerateDOT(plan, elements)
+			dot := GenerateDOT(plan, elements, nil)
 
 			expected := `fillcolor="` + tt.color + `"`
 			if !strings.Contains(dot, expected) {
@@ -123,7 +123,7 @@ func TestGenerateDOTEdges(t *testing.T) {
 
 	elements := []*board.Element{a, b, c}
 	plan := BuildPlan(elements)
-	dot := GenerateDOT(plan, elements)
+	dot := GenerateDOT(plan, elements, nil)
 
 	if !strings.Contains(dot, "TASK_01 -> TASK_02") {
 		t.Error("missing edge TASK_01 -> TASK_02")
@@ -143,7 +143,7 @@ func TestGenerateDOTEdgesOutOfScopeIgnored(t *testing.T) {
 
 	elements := []*board.Element{a, b}
 	plan := BuildPlan(elements)
-	dot := GenerateDOT(plan, elements)
+	dot := GenerateDOT(plan, elements, nil)
 
 	if strings.Contains(dot, "TASK_99") {
 		t.Error("out-of-scope TASK_99 should not appear in DOT output")
@@ -156,7 +156,7 @@ func TestGenerateDOTEdgesOutOfScopeIgnored(t *testing.T) {
 func TestGenerateDOTEmpty(t *testing.T) {
 	elements := []*board.Element{}
 	plan := BuildPlan(elements)
-	dot := GenerateDOT(plan, elements)
+	dot := GenerateDOT(plan, elements, nil)
 
 	if !strings.Contains(dot, "digraph plan {") {
 		t.Error("empty plan should still produce valid digraph header")
@@ -204,9 +204,52 @@ func TestStatusColor(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		got := statusColor(tt.status)
+		got := statusColor(nil, &board.Element{Type: board.TaskType, Status: tt.status})
 		if got != tt.want {
 			t.Errorf("statusColor(%q) = %q, want %q", tt.status, got, tt.want)
 		}
 	}
 }
+
+func TestGenerateDOTTypedLinks(t *testing.T) {
+	a := makeElementWithStatus(board.TaskType, 1, "first", board.StatusDone)
+	b := makeElementWithStatus(board.BugType, 2, "crash", board.StatusToDev)
+	a.Links = []board.Link{{Type: board.LinkCauses, Target: "BUG-02"}}
+	b.Links = []board.Link{{Type: board.LinkCausedBy, Target: "TASK-01"}}
+
+	elements := []*board.Element{a, b}
+	plan := BuildPlan(elements)
+	if len(plan.Phases) != 1 {
+		t.Errorf("typed links should not affect the plan, got %d phases", len(plan.Phases))
+	}
+	dot := GenerateDOT(plan, elements, nil)
+	if !strings.Contains(dot, `BUG_02 -> TASK_01 [style=dashed, color="#d62728"`) || !strings.Contains(dot, `label="caused-by"`) {
+		t.Errorf("missing styled caused-by edge:\n%s", dot)
+	}
+	if strings.Count(dot, "caused-by") != 1 {
+		t.Error("a link should be drawn once")
+	}
+	if !strings.Contains(GenerateMermaid(plan, elements, nil), "BUG_02 -. caused-by .-> TASK_01") {
+		t.Error("missing mermaid link")
+	}
+}
+
+func TestGenerateDOTExternalBlockers(t *testing.T) {
+	a := makeElementWithStatus(board.TaskType, 1, "first", board.StatusBlocked)
+	b := makeElementWithStatus(board.TaskType, 2, "second", board.StatusToDev, "TASK-01")
+	a.External = []*board.Blocker{{ID: "EXT-1", Reason: "waiting on API keys", Owner: "ops",
+		Elements: []board.BlockedElement{{ID: "TASK-01"}}}}
+
+	elements := []*board.Element{a, b}
+	plan := BuildPlan(elements)
+	dot := GenerateDOT(plan, elements, nil)
+	if !strings.Contains(dot, `EXT_1 [label="EXT-1\nwaiting on API keys\n@ops", shape=note`) {
+		t.Errorf("missing blocker node:\n%s", dot)
+	}
+	if !strings.Contains(dot, `EXT_1 -> TASK_01 [color="#dc3545", style=bold]`) || strings.Contains(dot, "EXT_1 -> TASK_02") {
+		t.Errorf("the blocker should point at what it holds only:\n%s", dot)
+	}
+	if !strings.Contains(GenerateSVG(plan, elements, nil), ">waiting on API keys</text>") {
+		t.Error("missing blocker node in SVG")
+	}
+}
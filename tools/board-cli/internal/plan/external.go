@@ -0,0 +1,139 @@
+package plan
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"strings"
+
+	"github.com/aagrigore/task-board/internal/board"
+)
+
+// External blockers are drawn as extra nodes outside the phases and
+// clusters, in the blocked colours, with a bold arrow to what they hold up.
+const (
+	externalFill   = "#f8d7da"
+	externalStroke = "#dc3545"
+)
+
+// externalLabel is the text of a blocker node: ID, reason, then owner and
+// expected date when known.
+func externalLabel(bl *board.Blocker) []string {
+	lines := []string{bl.ID, bl.Reason}
+	var meta []string
+	if bl.Owner != "" {
+		meta = append(meta, "@"+bl.Owner)
+	}
+	if !bl.Expect.IsZero() {
+		meta = append(meta, "expected "+bl.Expect.Local().Format("2006-01-02"))
+	}
+	if len(meta) > 0 {
+		lines = append(lines, strings.Join(meta, ", "))
+	}
+	return lines
+}
+
+// externalBlockers returns the blockers of edges once each, in order.
+func externalBlockers(edges []board.ExternalEdge) []*board.Blocker {
+	var blockers []*board.Blocker
+	seen := map[*board.Blocker]bool{}
+	for _, edge := range edges {
+		if !seen[edge.Blocker] {
+			seen[edge.Blocker] = true
+			blockers = append(blockers, edge.Blocker)
+		}
+	}
+	return blockers
+}
+
+func writeDOTExternal(b *strings.Builder, elements []*board.Element) {
+	edges := board.ExternalEdges(elements)
+	for _, bl := range externalBlockers(edges) {
+		label := strings.ReplaceAll(strings.Join(externalLabel(bl), "\\n"), `"`, `'`)
+		fmt.Fprintf(b, "  %s [label=\"%s\", shape=note, style=\"filled,dashed\", fillcolor=\"%s\", color=\"%s\"];\n",
+			safeDOTID(bl.ID), label, externalFill, externalStroke)
+	}
+	for _, edge := range edges {
+		fmt.Fprintf(b, "  %s -> %s [color=\"%s\", style=bold];\n", safeDOTID(edge.Blocker.ID), safeDOTID(edge.To), externalStroke)
+	}
+}
+
+func writeMermaidExternal(b *strings.Builder, elements []*board.Element) {
+	edges := board.ExternalEdges(elements)
+	for _, bl := range externalBlockers(edges) {
+		fmt.Fprintf(b, "  %s>\"%s\"]:::external\n", safeDOTID(bl.ID), mermaidText(strings.Join(externalLabel(bl), "<br/>")))
+	}
+	for _, edge := range edges {
+		fmt.Fprintf(b, "  %s ==> %s\n", safeDOTID(edge.Blocker.ID), safeDOTID(edge.To))
+	}
+	if len(edges) > 0 {
+		fmt.Fprintf(b, "  classDef external fill:%s,stroke:%s,stroke-dasharray:4 2\n", externalFill, externalStroke)
+	}
+}
+
+func writePlantUMLExternal(b *strings.Builder, elements []*board.Element) {
+	edges := board.ExternalEdges(elements)
+	for _, bl := range externalBlockers(edges) {
+		fmt.Fprintf(b, "card \"%s\" as %s %s;line:%s;line.dashed\n",
+			plantUMLText(strings.Join(externalLabel(bl), "\\n")), safeDOTID(bl.ID), externalFill, strings.TrimPrefix(externalStroke, "#"))
+	}
+	for _, edge := range edges {
+		fmt.Fprintf(b, "%s -[%s,bold]-> %s\n", safeDOTID(edge.Blocker.ID), externalStroke, safeDOTID(edge.To))
+	}
+}
+
+// sceneExternal is a blocker node of the built-in layout.
+type sceneExternal struct {
+	bl *board.Blocker
+	rect
+}
+
+// addSVGExternal puts the blockers in a row below the laid out scene, with
+// straight edges up to what they hold.
+func addSVGExternal(s *scene, elements []*board.Element) {
+	edges := board.ExternalEdges(elements)
+	if len(edges) == 0 {
+		return
+	}
+	boxes := map[string]rect{}
+	for _, n := range s.nodes {
+		boxes[n.e.ID()] = n.rect
+	}
+	at := map[*board.Blocker]rect{}
+	x, y := margin, s.h
+	for _, bl := range externalBlockers(edges) {
+		r := rect{x, y, nodeW, nodeH + 14}
+		s.externals = append(s.externals, sceneExternal{bl, r})
+		at[bl] = r
+		x += nodeW + gapX
+	}
+	for _, edge := range edges {
+		to, ok := boxes[edge.To]
+		if !ok {
+			continue
+		}
+		from := at[edge.Blocker]
+		s.externalEdges = append(s.externalEdges, [2]point{from.clip(to.center()), to.clip(from.center())})
+	}
+	s.w = math.Max(s.w, x-gapX+margin)
+	s.h = y + nodeH + 14 + margin
+}
+
+func writeSVGExternal(b *strings.Builder, s *scene) {
+	for _, e := range s.externalEdges {
+		fmt.Fprintf(b, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="%s" stroke-width="2" marker-end="url(#arrow)"/>`+"\n",
+			e[0].x, e[0].y, e[1].x, e[1].y, externalStroke)
+	}
+	for _, n := range s.externals {
+		fmt.Fprintf(b, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" rx="4" fill="%s" stroke="%s" stroke-dasharray="4 2"/>`+"\n",
+			n.x, n.y, n.w, n.h, externalFill, externalStroke)
+		for i, line := range externalLabel(n.bl) {
+			weight := ""
+			if i == 0 {
+				weight = ` font-weight="bold"`
+			}
+			fmt.Fprintf(b, `<text x="%.1f" y="%.1f"%s>%s</text>`+"\n",
+				n.x+8, n.y+16+float64(i)*14, weight, html.EscapeString(truncate(line, int((n.w-16)/nameChar))))
+		}
+	}
+}
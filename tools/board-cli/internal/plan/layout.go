@@ -0,0 +1,159 @@
+package plan
+
+import (
+	"sort"
+
+	"github.com/aagrigore/task-board/internal/board"
+)
+
+// lnode is a node of a layered layout: an element, or a dummy point where
+// an edge crosses a layer it doesn't stop in.
+type lnode struct {
+	e    *board.Element // nil for a dummy
+	x, y float64        // top-left for elements, centre line for dummies
+}
+
+// ledge joins nodes in adjacent layers.
+type ledge struct {
+	from, to *lnode
+}
+
+// layered is a Sugiyama-style layout: nodes sit in layers, long edges are
+// split into chains through dummy nodes, and each layer is ordered to
+// reduce edge crossings. Assigning coordinates is left to the caller.
+type layered struct {
+	layers [][]*lnode
+	edges  []ledge
+	chains [][]*lnode // one per blocked-by edge: blocker, dummies, blocked
+	preds  map[*lnode][]*lnode
+	succs  map[*lnode][]*lnode
+}
+
+// buildLayered places elements in the given layers and adds the blocked-by
+// edges between them. Edges that don't point to a later layer (a cycle, or
+// a blocker outside the layers) are left out.
+func buildLayered(layers [][]*board.Element) *layered {
+	l := &layered{
+		layers: make([][]*lnode, len(layers)),
+		preds:  map[*lnode][]*lnode{},
+		succs:  map[*lnode][]*lnode{},
+	}
+	byID := map[string]*lnode{}
+	layerOf := map[*lnode]int{}
+	for k, layer := range layers {
+		for _, e := range layer {
+			n := &lnode{e: e}
+			l.layers[k] = append(l.layers[k], n)
+			byID[e.ID()] = n
+			layerOf[n] = k
+		}
+	}
+	for _, layer := range layers {
+		for _, e := range layer {
+			to := byID[e.ID()]
+			for _, blockerID := range e.BlockedBy {
+				from, ok := byID[blockerID]
+				if !ok || layerOf[from] >= layerOf[to] {
+					continue
+				}
+				chain := []*lnode{from}
+				for k := layerOf[from] + 1; k < layerOf[to]; k++ {
+					dummy := &lnode{}
+					l.layers[k] = append(l.layers[k], dummy)
+					chain = append(chain, dummy)
+				}
+				chain = append(chain, to)
+				for i := 1; i < len(chain); i++ {
+					l.addEdge(chain[i-1], chain[i])
+				}
+				l.chains = append(l.chains, chain)
+			}
+		}
+	}
+	return l
+}
+
+func (l *layered) addEdge(from, to *lnode) {
+	l.edges = append(l.edges, ledge{from, to})
+	l.succs[from] = append(l.succs[from], to)
+	l.preds[to] = append(l.preds[to], from)
+}
+
+// order sweeps down and up the layers, sorting each by the barycentre of
+// its neighbours in the layer before, and keeps the ordering with the
+// fewest crossings.
+func (l *layered) order(iterations int) {
+	best, fewest := l.snapshot(), l.crossings()
+	for i := 0; i < iterations && fewest > 0; i++ {
+		for k := 1; k < len(l.layers); k++ {
+			l.sortByBarycenter(k, k-1, l.preds)
+		}
+		for k := len(l.layers) - 2; k >= 0; k-- {
+			l.sortByBarycenter(k, k+1, l.succs)
+		}
+		if c := l.crossings(); c < fewest {
+			best, fewest = l.snapshot(), c
+		}
+	}
+	l.layers = best
+}
+
+func (l *layered) snapshot() [][]*lnode {
+	result := make([][]*lnode, len(l.layers))
+	for k, layer := range l.layers {
+		result[k] = append([]*lnode(nil), layer...)
+	}
+	return result
+}
+
+// sortByBarycenter orders layer k by the mean position of each node's
+// neighbours in layer ref. Nodes without neighbours keep their place.
+func (l *layered) sortByBarycenter(k, ref int, neighbours map[*lnode][]*lnode) {
+	pos := positions(l.layers[ref])
+	bary := map[*lnode]float64{}
+	for i, n := range l.layers[k] {
+		bary[n] = float64(i)
+		if len(neighbours[n]) == 0 {
+			continue
+		}
+		sum := 0.0
+		for _, m := range neighbours[n] {
+			sum += float64(pos[m])
+		}
+		bary[n] = sum / float64(len(neighbours[n]))
+	}
+	sort.SliceStable(l.layers[k], func(i, j int) bool {
+		return bary[l.layers[k][i]] < bary[l.layers[k][j]]
+	})
+}
+
+// crossings counts pairs of edges that cross between adjacent layers.
+func (l *layered) crossings() int {
+	pos := map[*lnode]int{}
+	layerOf := map[*lnode]int{}
+	for k, layer := range l.layers {
+		for i, n := range layer {
+			pos[n], layerOf[n] = i, k
+		}
+	}
+	count := 0
+	for i, a := range l.edges {
+		for _, b := range l.edges[i+1:] {
+			if layerOf[a.from] != layerOf[b.from] || a.from == b.from || a.to == b.to {
+				continue
+			}
+			if (pos[a.from] < pos[b.from]) != (pos[a.to] < pos[b.to]) {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+func positions(layer []*lnode) map[*lnode]int {
+	pos := make(map[*lnode]int, len(layer))
+	for i, n := range layer {
+		pos[n] = i
+	}
+	return pos
+}
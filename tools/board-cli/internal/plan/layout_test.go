@@ -0,0 +1,52 @@
+package plan
+
+import (
+	"testing"
+
+	"github.com/aagrigore/task-board/internal/board"
+)
+
+func TestBuildLayeredAddsDummies(t *testing.T) {
+	a := makeElementWithStatus(board.TaskType, 1, "a", board.StatusToDev)
+	b := makeElementWithStatus(board.TaskType, 2, "b", board.StatusToDev, "TASK-01")
+	c := makeElementWithStatus(board.TaskType, 3, "c", board.StatusToDev, "TASK-01", "TASK-02")
+
+	l := buildLayered([][]*board.Element{{a}, {b}, {c}})
+
+	if len(l.chains) != 3 {
+		t.Fatalf("chains = %d, want 3", len(l.chains))
+	}
+	// TASK-01 → TASK-03 skips a layer and passes a dummy in the middle one
+	if len(l.layers[1]) != 2 || l.layers[1][1].e != nil {
+		t.Errorf("layer 1 should hold TASK-02 and a dummy, got %d nodes", len(l.layers[1]))
+	}
+	if len(l.edges) != 4 {
+		t.Errorf("edges = %d, want 4", len(l.edges))
+	}
+}
+
+func TestBuildLayeredSkipsBackwardEdges(t *testing.T) {
+	a := makeElementWithStatus(board.TaskType, 1, "a", board.StatusToDev, "TASK-02")
+	b := makeElementWithStatus(board.TaskType, 2, "b", board.StatusToDev, "TASK-09")
+
+	l := buildLayered([][]*board.Element{{a, b}})
+	if len(l.chains) != 0 {
+		t.Errorf("same-layer and out-of-scope edges should be skipped, got %d", len(l.chains))
+	}
+}
+
+func TestOrderRemovesCrossings(t *testing.T) {
+	a := makeElementWithStatus(board.TaskType, 1, "a", board.StatusToDev)
+	b := makeElementWithStatus(board.TaskType, 2, "b", board.StatusToDev)
+	c := makeElementWithStatus(board.TaskType, 3, "c", board.StatusToDev, "TASK-02")
+	d := makeElementWithStatus(board.TaskType, 4, "d", board.StatusToDev, "TASK-01")
+
+	l := buildLayered([][]*board.Element{{a, b}, {c, d}})
+	if got := l.crossings(); got != 1 {
+		t.Fatalf("crossings before ordering = %d, want 1", got)
+	}
+	l.order(4)
+	if got := l.crossings(); got != 0 {
+		t.Errorf("crossings after ordering = %d, want 0", got)
+	}
+}
@@ -0,0 +1,126 @@
+package plan
+
+import (
+	"github.com/aagrigore/task-board/internal/board"
+)
+
+// Edge is a blocked-by dependency: From is blocked by To.
+type Edge struct {
+	From      string
+	To        string
+	Escalated bool // implied between parents rather than requested directly
+	Exists    bool // already recorded on the board
+}
+
+// LinkEdges returns every edge that linking elem as blocked by blocker would
+// write: the direct edge followed by the edges escalated between their
+// differing ancestors, story level first, mirroring what link records.
+func LinkEdges(b *board.Board, elem, blocker *board.Element) []Edge {
+	edges := []Edge{{
+		From:   elem.ID(),
+		To:     blocker.ID(),
+		Exists: containsID(elem.BlockedBy, blocker.ID()),
+	}}
+	for {
+		elemParent, blockerParent := b.ParentOf(elem), b.ParentOf(blocker)
+		if elemParent == nil || blockerParent == nil || elemParent.ID() == blockerParent.ID() {
+			return edges
+		}
+		edges = append(edges, Edge{
+			From:      elemParent.ID(),
+			To:        blockerParent.ID(),
+			Escalated: true,
+			Exists:    containsID(elemParent.BlockedBy, blockerParent.ID()),
+		})
+		elem, blocker = elemParent, blockerParent
+	}
+}
+
+// FindLinkCycle reports whether adding edges to the board's blocked-by graph
+// would close a dependency cycle. It returns the cycle as a path of IDs
+// ending with its first node (e.g. [A, B, C, A]), or nil.
+func FindLinkCycle(b *board.Board, edges []Edge) []string {
+	blockedByMap := make(map[string][]string)
+	for _, e := range b.Elements {
+		for _, dep := range e.BlockedBy {
+			blockedByMap[e.ID()] = append(blockedByMap[e.ID()], dep)
+		}
+	}
+	for _, edge := range edges {
+		if !edge.Exists {
+			blockedByMap[edge.From] = append(blockedByMap[edge.From], edge.To)
+		}
+	}
+	for _, edge := range edges {
+		if edge.Exists {
+			continue // existing cycles are reported by plan and validate
+		}
+		// Restrict the search to nodes on some cycle through edge.From so
+		// traceCycle never wanders through unrelated parts of the board.
+		component := stronglyConnected(edge.From, blockedByMap)
+		if len(component) == 0 {
+			continue
+		}
+		restricted := make(map[string][]string)
+		for id := range component {
+			for _, dep := range blockedByMap[id] {
+				if component[dep] {
+					restricted[id] = append(restricted[id], dep)
+				}
+			}
+		}
+		if path := traceCycle(edge.From, restricted); path != nil {
+			return path
+		}
+	}
+	return nil
+}
+
+// stronglyConnected returns the nodes that are both reachable from start and
+// can reach it back, or nil when start is on no cycle.
+func stronglyConnected(start string, blockedByMap map[string][]string) map[string]bool {
+	forward := reachable(start, blockedByMap)
+	reverseMap := make(map[string][]string)
+	for id, deps := range blockedByMap {
+		for _, dep := range deps {
+			reverseMap[dep] = append(reverseMap[dep], id)
+		}
+	}
+	backward := reachable(start, reverseMap)
+
+	component := make(map[string]bool)
+	for id := range forward {
+		if backward[id] {
+			component[id] = true
+		}
+	}
+	if !component[start] {
+		return nil
+	}
+	return component
+}
+
+// reachable returns every node reachable from start in one or more steps.
+func reachable(start string, adj map[string][]string) map[string]bool {
+	seen := make(map[string]bool)
+	stack := append([]string(nil), adj[start]...)
+	for len(stack) > 0 {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		stack = append(stack, adj[id]...)
+	}
+	return seen
+}
+
+func containsID(ids []string, id string) bool {
+	for _, existing := range ids {
+		if existing == id {
+			return true
+		}
+	}
+	return false
+}
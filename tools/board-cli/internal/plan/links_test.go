@@ -0,0 +1,82 @@
+package plan
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aagrigore/task-board/internal/board"
+)
+
+// linkBoard builds two epics, each with one story holding two tasks:
+//
+//	EPIC-A / STORY-A / TASK-A1, TASK-A2
+//	EPIC-B / STORY-B / TASK-B1, TASK-B2
+func linkBoard() *board.Board {
+	el := func(typ board.ElementType, id, parent string, blockedBy ...string) *board.Element {
+		return &board.Element{Type: typ, RawID: id, ParentID: parent, BlockedBy: blockedBy}
+	}
+	return &board.Board{Elements: []*board.Element{
+		el(board.EpicType, "EPIC-A", ""),
+		el(board.EpicType, "EPIC-B", ""),
+		el(board.StoryType, "STORY-A", "EPIC-A"),
+		el(board.StoryType, "STORY-B", "EPIC-B"),
+		el(board.TaskType, "TASK-A1", "STORY-A"),
+		el(board.TaskType, "TASK-A2", "STORY-A", "TASK-A1"),
+		el(board.TaskType, "TASK-B1", "STORY-B"),
+		el(board.TaskType, "TASK-B2", "STORY-B"),
+	}}
+}
+
+func TestLinkEdgesEscalates(t *testing.T) {
+	b := linkBoard()
+	edges := LinkEdges(b, b.FindByID("TASK-B1"), b.FindByID("TASK-A2"))
+	want := []Edge{
+		{From: "TASK-B1", To: "TASK-A2"},
+		{From: "STORY-B", To: "STORY-A", Escalated: true},
+		{From: "EPIC-B", To: "EPIC-A", Escalated: true},
+	}
+	if !reflect.DeepEqual(edges, want) {
+		t.Errorf("LinkEdges = %+v, want %+v", edges, want)
+	}
+
+	// Siblings never escalate
+	edges = LinkEdges(b, b.FindByID("TASK-A2"), b.FindByID("TASK-A1"))
+	if len(edges) != 1 || !edges[0].Exists {
+		t.Errorf("sibling link should be a single existing edge, got %+v", edges)
+	}
+}
+
+func TestFindLinkCycleDirect(t *testing.T) {
+	b := linkBoard()
+	// TASK-A2 is blocked by TASK-A1; making TASK-A1 wait for TASK-A2 closes a loop
+	path := FindLinkCycle(b, LinkEdges(b, b.FindByID("TASK-A1"), b.FindByID("TASK-A2")))
+	want := []string{"TASK-A1", "TASK-A2", "TASK-A1"}
+	if !reflect.DeepEqual(path, want) {
+		t.Errorf("cycle = %v, want %v", path, want)
+	}
+}
+
+func TestFindLinkCycleThroughEscalation(t *testing.T) {
+	b := linkBoard()
+	// STORY-A already waits for STORY-B (escalated from an earlier link)
+	b.FindByID("STORY-A").BlockedBy = []string{"STORY-B"}
+
+	// TASK-B2 → TASK-A1 has no task-level cycle but escalates STORY-B → STORY-A
+	path := FindLinkCycle(b, LinkEdges(b, b.FindByID("TASK-B2"), b.FindByID("TASK-A1")))
+	want := []string{"STORY-B", "STORY-A", "STORY-B"}
+	if !reflect.DeepEqual(path, want) {
+		t.Errorf("cycle = %v, want %v", path, want)
+	}
+}
+
+func TestFindLinkCycleNone(t *testing.T) {
+	b := linkBoard()
+	if path := FindLinkCycle(b, LinkEdges(b, b.FindByID("TASK-B1"), b.FindByID("TASK-A2"))); path != nil {
+		t.Errorf("unexpected cycle %v", path)
+	}
+	// Self-links are cycles too
+	self := b.FindByID("TASK-B1")
+	if path := FindLinkCycle(b, LinkEdges(b, self, self)); len(path) != 2 {
+		t.Errorf("self link should be a cycle, got %v", path)
+	}
+}
@@ -0,0 +1,123 @@
+package plan
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aagrigore/task-board/internal/board"
+)
+
+// statusClass turns a status into a class name usable in both Mermaid and
+// PlantUML ("to-dev" → "st_to_dev").
+func statusClass(s board.Status) string {
+	return "st_" + strings.ReplaceAll(string(s), "-", "_")
+}
+
+// mermaidText escapes a label for a quoted Mermaid string.
+func mermaidText(s string) string {
+	return strings.ReplaceAll(s, `"`, "#quot;")
+}
+
+// GenerateMermaid produces a Mermaid flowchart of the plan with one
+// subgraph per phase, the counterpart of GenerateDOT. A nil workflow means
+// the default one.
+func GenerateMermaid(p *Plan, elements []*board.Element, wf *board.Workflow) string {
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+
+	for _, phase := range p.Phases {
+		b.WriteString(fmt.Sprintf("  subgraph phase_%d[\"Phase %d\"]\n", phase.Number, phase.Number))
+		for _, e := range phase.Elements {
+			writeMermaidNode(&b, wf, e, "    ")
+		}
+		b.WriteString("  end\n")
+	}
+
+	writeMermaidEdges(&b, elements)
+	writeMermaidClasses(&b, wf)
+	return b.String()
+}
+
+// GenerateFullMermaid produces a Mermaid flowchart of the full hierarchy:
+// epics contain stories, stories contain tasks/bugs, like GenerateFullDOT.
+func GenerateFullMermaid(brd *board.Board, elements []*board.Element) string {
+	var b strings.Builder
+	b.WriteString("flowchart TB\n")
+
+	wf := brd.Workflow
+	h := groupHierarchy(elements)
+	writeStory := func(story *board.Element, indent string) {
+		tasks := h.tasks[story.ID()]
+		if len(tasks) == 0 {
+			writeMermaidNode(&b, wf, story, indent)
+			return
+		}
+		b.WriteString(fmt.Sprintf("%ssubgraph %s_group[\"%s: %s\"]\n", indent, safeDOTID(story.ID()), story.ID(), mermaidText(story.Name)))
+		writeMermaidNode(&b, wf, story, indent+"  ")
+		for _, t := range tasks {
+			writeMermaidNode(&b, wf, t, indent+"  ")
+		}
+		b.WriteString(indent + "end\n")
+	}
+
+	for _, epic := range h.epics {
+		b.WriteString(fmt.Sprintf("  subgraph %s_group[\"%s: %s\"]\n", safeDOTID(epic.ID()), epic.ID(), mermaidText(epic.Name)))
+		writeMermaidNode(&b, wf, epic, "    ")
+		for _, story := range h.stories[epic.ID()] {
+			writeStory(story, "    ")
+		}
+		b.WriteString("  end\n")
+	}
+	for _, story := range h.looseStories {
+		writeStory(story, "  ")
+	}
+	for _, t := range h.looseTasks {
+		writeMermaidNode(&b, wf, t, "  ")
+	}
+
+	writeMermaidEdges(&b, elements)
+	writeMermaidClasses(&b, wf)
+	return b.String()
+}
+
+// writeMermaidNode writes an element as a node tagged with its status
+// class: epics as subroutines, bugs as hexagons, the rest as boxes.
+func writeMermaidNode(b *strings.Builder, wf *board.Workflow, e *board.Element, indent string) {
+	open, close := "[\"", "\"]"
+	switch e.Type {
+	case board.EpicType:
+		open, close = "[[\"", "\"]]"
+	case board.BugType:
+		open, close = "{{\"", "\"}}"
+	}
+	b.WriteString(fmt.Sprintf("%s%s%s%s<br/>%s%s:::%s\n", indent, safeDOTID(e.ID()), open, e.ID(), mermaidText(e.Name), close,
+		statusClass(wf.For(e.Type).Normalize(e.Status))))
+}
+
+func writeMermaidEdges(b *strings.Builder, elements []*board.Element) {
+	inScope := make(map[string]bool, len(elements))
+	for _, e := range elements {
+		inScope[e.ID()] = true
+	}
+	for _, e := range elements {
+		for _, blockerID := range e.BlockedBy {
+			if !inScope[blockerID] {
+				continue
+			}
+			b.WriteString(fmt.Sprintf("  %s --> %s\n", safeDOTID(blockerID), safeDOTID(e.ID())))
+		}
+	}
+	writeMermaidLinks(b, elements)
+	writeMermaidExternal(b, elements)
+}
+
+// writeMermaidClasses defines one class per workflow status in its colour.
+func writeMermaidClasses(b *strings.Builder, wf *board.Workflow) {
+	for _, s := range wf.Statuses() {
+		font := "#000000"
+		if IsDark(s.Color) {
+			font = "#ffffff"
+		}
+		b.WriteString(fmt.Sprintf("  classDef %s fill:%s,color:%s,stroke:#666666\n", statusClass(s.Name), s.Color, font))
+	}
+}
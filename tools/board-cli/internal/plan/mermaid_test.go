@@ -0,0 +1,86 @@
+package plan
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aagrigore/task-board/internal/board"
+)
+
+func TestGenerateMermaidPhases(t *testing.T) {
+	a := makeElementWithStatus(board.TaskType, 1, "interface", board.StatusDone)
+	b := makeElementWithStatus(board.TaskType, 2, "say \"hi\"", board.StatusToDev, "TASK-01")
+
+	elements := []*board.Element{a, b}
+	out := GenerateMermaid(BuildPlan(elements), elements, nil)
+
+	for _, want := range []string{
+		"flowchart LR\n",
+		"  subgraph phase_1[\"Phase 1\"]\n",
+		"    TASK_01[\"TASK-01<br/>interface\"]:::st_done\n",
+		"  subgraph phase_2[\"Phase 2\"]\n",
+		"    TASK_02[\"TASK-02<br/>say #quot;hi#quot;\"]:::st_to_dev\n",
+		"  TASK_01 --> TASK_02\n",
+		"  classDef st_done fill:",
+		"  classDef st_to_dev fill:",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+}
+
+func TestGenerateFullMermaidHierarchy(t *testing.T) {
+	epic := &board.Element{Type: board.EpicType, Number: 1, Name: "recording", Status: board.StatusDevelopment}
+	story := &board.Element{Type: board.StoryType, Number: 1, Name: "capture", Status: board.StatusDevelopment, ParentID: "EPIC-01"}
+	task := &board.Element{Type: board.TaskType, Number: 1, Name: "mic", Status: board.StatusDone, ParentID: "STORY-01"}
+	bug := &board.Element{Type: board.BugType, Number: 2, Name: "crash", Status: board.StatusBlocked, ParentID: "STORY-01", BlockedBy: []string{"TASK-01", "TASK-99"}}
+
+	out := GenerateFullMermaid(&board.Board{}, []*board.Element{epic, story, task, bug})
+
+	for _, want := range []string{
+		"flowchart TB\n",
+		"  subgraph EPIC_01_group[\"EPIC-01: recording\"]\n",
+		"    EPIC_01[[\"EPIC-01<br/>recording\"]]:::st_development\n",
+		"    subgraph STORY_01_group[\"STORY-01: capture\"]\n",
+		"      BUG_02{{\"BUG-02<br/>crash\"}}:::st_blocked\n",
+		"    end\n",
+		"  TASK_01 --> BUG_02\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "TASK_99") {
+		t.Errorf("out-of-scope blocker drawn:\n%s", out)
+	}
+}
+
+func TestGeneratePlantUML(t *testing.T) {
+	a := makeElementWithStatus(board.TaskType, 1, "interface", board.StatusDone)
+	b := makeElementWithStatus(board.TaskType, 2, "implementation", board.StatusDevelopment, "TASK-01")
+
+	elements := []*board.Element{a, b}
+	out := GeneratePlantUML(BuildPlan(elements), elements, nil)
+
+	for _, want := range []string{
+		"@startuml\n",
+		"skinparam rectangle<<st_done>> {\n",
+		"rectangle \"Phase 2\" as phase_2 {\n",
+		"  rectangle \"TASK-02\\nimplementation\" <<st_development>> as TASK_02\n",
+		"TASK_01 --> TASK_02\n",
+		"legend right\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+	if !strings.HasSuffix(out, "@enduml\n") {
+		t.Errorf("missing @enduml:\n%s", out)
+	}
+
+	full := GenerateFullPlantUML(&board.Board{}, elements)
+	if !strings.Contains(full, "rectangle \"TASK-01\\ninterface\" <<st_done>> as TASK_01\n") {
+		t.Errorf("hierarchy missing loose task:\n%s", full)
+	}
+}
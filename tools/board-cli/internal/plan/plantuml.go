@@ -0,0 +1,133 @@
+package plan
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aagrigore/task-board/internal/board"
+)
+
+// plantUMLText escapes a label for a quoted PlantUML string.
+func plantUMLText(s string) string {
+	return strings.ReplaceAll(s, `"`, "'")
+}
+
+// GeneratePlantUML produces a PlantUML diagram of the plan with one
+// rectangle per phase, the counterpart of GenerateDOT. A nil workflow means
+// the default one.
+func GeneratePlantUML(p *Plan, elements []*board.Element, wf *board.Workflow) string {
+	var b strings.Builder
+	b.WriteString("@startuml\n")
+	b.WriteString("left to right direction\n")
+	writePlantUMLStyles(&b, wf)
+
+	for _, phase := range p.Phases {
+		b.WriteString(fmt.Sprintf("\nrectangle \"Phase %d\" as phase_%d {\n", phase.Number, phase.Number))
+		for _, e := range phase.Elements {
+			writePlantUMLNode(&b, wf, e, "  ")
+		}
+		b.WriteString("}\n")
+	}
+
+	writePlantUMLEdges(&b, elements)
+	writePlantUMLLegend(&b, wf)
+	b.WriteString("@enduml\n")
+	return b.String()
+}
+
+// GenerateFullPlantUML produces a PlantUML diagram of the full hierarchy:
+// epics contain stories, stories contain tasks/bugs, like GenerateFullDOT.
+func GenerateFullPlantUML(brd *board.Board, elements []*board.Element) string {
+	var b strings.Builder
+	b.WriteString("@startuml\n")
+	wf := brd.Workflow
+	writePlantUMLStyles(&b, wf)
+
+	h := groupHierarchy(elements)
+	writeStory := func(story *board.Element, indent string) {
+		tasks := h.tasks[story.ID()]
+		if len(tasks) == 0 {
+			writePlantUMLNode(&b, wf, story, indent)
+			return
+		}
+		b.WriteString(fmt.Sprintf("%spackage \"%s: %s\" as %s_group {\n", indent, story.ID(), plantUMLText(story.Name), safeDOTID(story.ID())))
+		writePlantUMLNode(&b, wf, story, indent+"  ")
+		for _, t := range tasks {
+			writePlantUMLNode(&b, wf, t, indent+"  ")
+		}
+		b.WriteString(indent + "}\n")
+	}
+
+	for _, epic := range h.epics {
+		b.WriteString(fmt.Sprintf("\npackage \"%s: %s\" as %s_group {\n", epic.ID(), plantUMLText(epic.Name), safeDOTID(epic.ID())))
+		writePlantUMLNode(&b, wf, epic, "  ")
+		for _, story := range h.stories[epic.ID()] {
+			writeStory(story, "  ")
+		}
+		b.WriteString("}\n")
+	}
+	for _, story := range h.looseStories {
+		writeStory(story, "")
+	}
+	for _, t := range h.looseTasks {
+		writePlantUMLNode(&b, wf, t, "")
+	}
+
+	writePlantUMLEdges(&b, elements)
+	writePlantUMLLegend(&b, wf)
+	b.WriteString("@enduml\n")
+	return b.String()
+}
+
+// writePlantUMLStyles colours each status stereotype, PlantUML's version
+// of the Mermaid classDefs.
+func writePlantUMLStyles(b *strings.Builder, wf *board.Workflow) {
+	b.WriteString("skinparam shadowing false\n")
+	b.WriteString("skinparam defaultFontName Helvetica\n")
+	b.WriteString("hide stereotype\n")
+	for _, s := range wf.Statuses() {
+		b.WriteString(fmt.Sprintf("skinparam rectangle<<%s>> {\n", statusClass(s.Name)))
+		b.WriteString(fmt.Sprintf("  BackgroundColor %s\n", s.Color))
+		if IsDark(s.Color) {
+			b.WriteString("  FontColor #ffffff\n")
+		}
+		b.WriteString("}\n")
+	}
+}
+
+// writePlantUMLNode writes an element as a rectangle stereotyped with its
+// status; epics are bold.
+func writePlantUMLNode(b *strings.Builder, wf *board.Workflow, e *board.Element, indent string) {
+	name := plantUMLText(e.Name)
+	if e.Type == board.EpicType {
+		name = "<b>" + name + "</b>"
+	}
+	b.WriteString(fmt.Sprintf("%srectangle \"%s\\n%s\" <<%s>> as %s\n", indent, e.ID(), name,
+		statusClass(wf.For(e.Type).Normalize(e.Status)), safeDOTID(e.ID())))
+}
+
+func writePlantUMLEdges(b *strings.Builder, elements []*board.Element) {
+	inScope := make(map[string]bool, len(elements))
+	for _, e := range elements {
+		inScope[e.ID()] = true
+	}
+	b.WriteString("\n")
+	for _, e := range elements {
+		for _, blockerID := range e.BlockedBy {
+			if !inScope[blockerID] {
+				continue
+			}
+			b.WriteString(fmt.Sprintf("%s --> %s\n", safeDOTID(blockerID), safeDOTID(e.ID())))
+		}
+	}
+	writePlantUMLLinks(b, elements)
+	writePlantUMLExternal(b, elements)
+}
+
+func writePlantUMLLegend(b *strings.Builder, wf *board.Workflow) {
+	b.WriteString("\nlegend right\n")
+	for _, s := range wf.Statuses() {
+		b.WriteString(fmt.Sprintf("  <back:%s> %s </back>\n", s.Color, s.Name))
+	}
+	b.WriteString("endlegend\n")
+}
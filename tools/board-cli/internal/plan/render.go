@@ -56,6 +56,12 @@ func RenderDOT(dot string, outputPath string, format string, engine string) erro
 	return nil
 }
 
+// HasEngine reports whether a Graphviz engine binary is on PATH.
+func HasEngine(engine string) bool {
+	_, err := exec.LookPath(engine)
+	return err == nil
+}
+
 // RenderOutputPath determines the output path for a rendered plan diagram.
 // It creates a .temp/ directory inside the scope element's directory and returns
 // the full path for plan.{format}.
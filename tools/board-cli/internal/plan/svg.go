@@ -0,0 +1,378 @@
+package plan
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/aagrigore/task-board/internal/board"
+)
+
+// Built-in SVG geometry
+const (
+	nodeW    = 180.0
+	nodeH    = 44.0
+	gapX     = 48.0
+	gapY     = 16.0
+	dummyH   = 12.0
+	margin   = 20.0
+	padding  = 12.0
+	labelH   = 22.0
+	nameChar = 6.6 // average width of a 11px character
+)
+
+type point struct{ x, y float64 }
+
+type rect struct{ x, y, w, h float64 }
+
+func (r rect) center() point { return point{r.x + r.w/2, r.y + r.h/2} }
+
+// clip returns where the segment from r's centre towards p leaves r.
+func (r rect) clip(p point) point {
+	c := r.center()
+	dx, dy := p.x-c.x, p.y-c.y
+	if dx == 0 && dy == 0 {
+		return c
+	}
+	t := math.Inf(1)
+	if dx != 0 {
+		t = math.Min(t, r.w/2/math.Abs(dx))
+	}
+	if dy != 0 {
+		t = math.Min(t, r.h/2/math.Abs(dy))
+	}
+	return point{c.x + dx*t, c.y + dy*t}
+}
+
+type sceneNode struct {
+	e *board.Element
+	rect
+}
+
+type sceneCluster struct {
+	label  string
+	color  string
+	dashed bool
+	depth  int // outer clusters are drawn first
+	rect
+}
+
+// scene is a laid out graph ready to be written as SVG.
+type scene struct {
+	nodes    []sceneNode
+	clusters []sceneCluster
+	edges    [][]point
+	links    []sceneLink
+	// externals are the external blockers, in a row below the rest
+	externals     []sceneExternal
+	externalEdges [][2]point
+	w, h          float64
+}
+
+// sceneLink is a typed link, drawn straight from box to box.
+type sceneLink struct {
+	t        board.LinkType
+	from, to point
+}
+
+// GenerateSVG lays out the plan with the built-in layered layout, one
+// column per phase, and returns it as SVG. It is the Graphviz-free
+// counterpart of GenerateDOT. A nil workflow means the default one.
+func GenerateSVG(p *Plan, elements []*board.Element, wf *board.Workflow) string {
+	layers := make([][]*board.Element, len(p.Phases))
+	for i, phase := range p.Phases {
+		layers[i] = phase.Elements
+	}
+	l := buildLayered(layers)
+	l.order(8)
+
+	height := func(layer []*lnode) float64 {
+		h := 0.0
+		for _, n := range layer {
+			if n.e == nil {
+				h += dummyH
+			} else {
+				h += nodeH + gapY
+			}
+		}
+		return h
+	}
+	tallest := 0.0
+	for _, layer := range l.layers {
+		tallest = math.Max(tallest, height(layer))
+	}
+
+	s := &scene{}
+	top := margin + labelH
+	for k, layer := range l.layers {
+		x := margin + padding + float64(k)*(nodeW+gapX)
+		y := top + (tallest-height(layer))/2
+		for _, n := range layer {
+			n.x = x
+			if n.e == nil {
+				n.y = y + dummyH/2
+				y += dummyH
+				continue
+			}
+			n.y = y + gapY/2
+			s.nodes = append(s.nodes, sceneNode{n.e, rect{n.x, n.y, nodeW, nodeH}})
+			y += nodeH + gapY
+		}
+		s.clusters = append(s.clusters, sceneCluster{
+			label:  fmt.Sprintf("Phase %d", p.Phases[k].Number),
+			color:  "#999999",
+			dashed: true,
+			rect:   rect{x - padding, margin, nodeW + 2*padding, labelH + tallest + padding},
+		})
+	}
+
+	// Edges leave the right side of the blocker, run straight through the
+	// columns they cross and enter the left side of the blocked element.
+	for _, chain := range l.chains {
+		from, to := chain[0], chain[len(chain)-1]
+		route := []point{{from.x + nodeW, from.y + nodeH/2}}
+		for _, d := range chain[1 : len(chain)-1] {
+			route = append(route, point{d.x, d.y}, point{d.x + nodeW, d.y})
+		}
+		route = append(route, point{to.x, to.y + nodeH/2})
+		s.edges = append(s.edges, route)
+	}
+	addSVGLinks(s, elements)
+
+	s.w = 2*margin + float64(len(l.layers))*(nodeW+gapX) - gapX + 2*padding
+	s.h = margin + labelH + tallest + padding + margin
+	addSVGExternal(s, elements)
+	return writeSVG(s, wf)
+}
+
+// GenerateFullSVG lays out the full hierarchy with the built-in layout and
+// returns it as SVG, the Graphviz-free counterpart of GenerateFullDOT.
+// Epics are stacked top to bottom with their stories side by side; tasks
+// and bugs fill their story in rows, one per phase of the story's plan.
+func GenerateFullSVG(brd *board.Board, elements []*board.Element) string {
+	wf := brd.Workflow
+	h := groupHierarchy(elements)
+	s := &scene{}
+
+	place := func(e *board.Element, x, y float64) {
+		s.nodes = append(s.nodes, sceneNode{e, rect{x, y, nodeW, nodeH}})
+	}
+
+	// rows places elements in rows by dependency phase, ordered within a
+	// row to reduce crossings, and returns the size they take.
+	rows := func(elements []*board.Element, x, y float64) (float64, float64) {
+		p := BuildPlan(elements)
+		var layers [][]*board.Element
+		if p.HasCycle {
+			layers = [][]*board.Element{elements}
+		} else {
+			for _, phase := range p.Phases {
+				layers = append(layers, phase.Elements)
+			}
+		}
+		l := buildLayered(layers)
+		l.order(8)
+		w, rowY := 0.0, y
+		for _, layer := range l.layers {
+			rowX := x
+			for _, n := range layer {
+				if n.e == nil {
+					continue
+				}
+				place(n.e, rowX, rowY)
+				rowX += nodeW + gapX
+			}
+			w = math.Max(w, rowX-gapX-x)
+			rowY += nodeH + gapY
+		}
+		return w, rowY - gapY - y
+	}
+
+	// story places a story with its tasks (a cluster) or on its own.
+	story := func(st *board.Element, x, y float64, depth int) (float64, float64) {
+		tasks := h.tasks[st.ID()]
+		if len(tasks) == 0 {
+			place(st, x, y)
+			return nodeW, nodeH
+		}
+		place(st, x+padding, y+labelH)
+		w, rh := rows(tasks, x+padding, y+labelH+nodeH+gapY)
+		r := rect{x, y, math.Max(w, nodeW) + 2*padding, labelH + nodeH + gapY + rh + padding}
+		s.clusters = append(s.clusters, sceneCluster{
+			label: fmt.Sprintf("%s: %s", st.ID(), st.Name), color: clusterBorderColor(wf, st), dashed: true, depth: depth, rect: r,
+		})
+		return r.w, r.h
+	}
+
+	// side places stories next to each other.
+	side := func(stories []*board.Element, x, y float64, depth int) (float64, float64) {
+		w, hh := 0.0, 0.0
+		for _, st := range stories {
+			sw, sh := story(st, x+w, y, depth)
+			w += sw + gapX
+			hh = math.Max(hh, sh)
+		}
+		return w - gapX, hh
+	}
+
+	x, y := margin, margin
+	width := 0.0
+	for _, epic := range h.epics {
+		place(epic, x+padding, y+labelH)
+		w, ch := nodeW, nodeH
+		if stories := h.stories[epic.ID()]; len(stories) > 0 {
+			sw, sh := side(stories, x+padding, y+labelH+nodeH+gapY, 1)
+			w, ch = math.Max(w, sw), ch+gapY+sh
+		}
+		r := rect{x, y, w + 2*padding, labelH + ch + padding}
+		s.clusters = append(s.clusters, sceneCluster{
+			label: fmt.Sprintf("%s: %s", epic.ID(), epic.Name), color: clusterBorderColor(wf, epic), rect: r,
+		})
+		width = math.Max(width, r.w)
+		y += r.h + gapX
+	}
+	if len(h.looseStories) > 0 {
+		w, hh := side(h.looseStories, x, y, 0)
+		width = math.Max(width, w)
+		y += hh + gapX
+	}
+	if len(h.looseTasks) > 0 {
+		w, hh := rows(h.looseTasks, x, y)
+		width = math.Max(width, w)
+		y += hh + gapX
+	}
+
+	// Edges run straight between the element boxes
+	boxes := map[string]rect{}
+	for _, n := range s.nodes {
+		boxes[n.e.ID()] = n.rect
+	}
+	for _, e := range elements {
+		for _, blockerID := range e.BlockedBy {
+			from, ok := boxes[blockerID]
+			if !ok {
+				continue
+			}
+			to, ok := boxes[e.ID()]
+			if !ok {
+				continue
+			}
+			s.edges = append(s.edges, []point{from.clip(to.center()), to.clip(from.center())})
+		}
+	}
+	addSVGLinks(s, elements)
+
+	s.w = width + 2*margin
+	s.h = y - gapX + margin
+	if len(s.nodes) == 0 {
+		s.h = 2 * margin
+	}
+	addSVGExternal(s, elements)
+	return writeSVG(s, wf)
+}
+
+// writeSVG writes the scene with a legend of the workflow's statuses below.
+func writeSVG(s *scene, wf *board.Workflow) string {
+	statuses := wf.Statuses()
+	const swatchW, legendRow = 96.0, 24.0
+	width := math.Max(s.w, 2*margin+swatchW*4)
+	perRow := int((width - 2*margin - 60) / swatchW)
+	if perRow < 1 {
+		perRow = 1
+	}
+	legendRows := (len(statuses) + perRow - 1) / perRow
+	height := s.h + float64(legendRows)*legendRow + margin
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%.0f" height="%.0f" viewBox="0 0 %.0f %.0f" font-family="Helvetica, sans-serif" font-size="11">`+"\n",
+		width, height, width, height)
+	b.WriteString(`<defs><marker id="arrow" viewBox="0 0 10 10" refX="10" refY="5" markerWidth="8" markerHeight="8" orient="auto"><path d="M0,0 L10,5 L0,10 z" fill="#666666"/></marker></defs>` + "\n")
+	fmt.Fprintf(&b, `<rect width="%.0f" height="%.0f" fill="#ffffff"/>`+"\n", width, height)
+
+	clusters := append([]sceneCluster(nil), s.clusters...)
+	sort.SliceStable(clusters, func(i, j int) bool { return clusters[i].depth < clusters[j].depth })
+	for _, c := range clusters {
+		dash := ""
+		if c.dashed {
+			dash = ` stroke-dasharray="5 3"`
+		}
+		fmt.Fprintf(&b, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" rx="8" fill="none" stroke="%s"%s/>`+"\n",
+			c.x, c.y, c.w, c.h, c.color, dash)
+		fmt.Fprintf(&b, `<text x="%.1f" y="%.1f" font-weight="bold">%s</text>`+"\n",
+			c.x+padding, c.y+15, html.EscapeString(truncate(c.label, int((c.w-2*padding)/nameChar))))
+	}
+
+	for _, route := range s.edges {
+		coords := make([]string, len(route))
+		for i, p := range route {
+			coords[i] = fmt.Sprintf("%.1f,%.1f", p.x, p.y)
+		}
+		fmt.Fprintf(&b, `<polyline points="%s" fill="none" stroke="#666666" marker-end="url(#arrow)"/>`+"\n", strings.Join(coords, " "))
+	}
+	for _, l := range s.links {
+		writeSVGLink(&b, l)
+	}
+	writeSVGExternal(&b, s)
+
+	for _, n := range s.nodes {
+		writeSVGNode(&b, wf, n)
+	}
+
+	y := s.h
+	for i, st := range statuses {
+		if i%perRow == 0 {
+			if i > 0 {
+				y += legendRow
+			}
+			fmt.Fprintf(&b, `<text x="%.1f" y="%.1f" font-weight="bold">Legend</text>`+"\n", margin, y+13)
+		}
+		x := margin + 60 + float64(i%perRow)*swatchW
+		font := "#000000"
+		if IsDark(st.Color) {
+			font = "#ffffff"
+		}
+		fmt.Fprintf(&b, `<rect x="%.1f" y="%.1f" width="%.0f" height="18" fill="%s" stroke="#999999"/>`+"\n", x, y, swatchW-6, st.Color)
+		fmt.Fprintf(&b, `<text x="%.1f" y="%.1f" text-anchor="middle" fill="%s">%s</text>`+"\n", x+(swatchW-6)/2, y+13, font, st.Name)
+	}
+
+	b.WriteString("</svg>\n")
+	return b.String()
+}
+
+// writeSVGNode draws an element filled with its status colour: epics with
+// a heavier border, bugs as octagons.
+func writeSVGNode(b *strings.Builder, wf *board.Workflow, n sceneNode) {
+	fill := statusColor(wf, n.e)
+	font := "#000000"
+	if IsDark(fill) {
+		font = "#ffffff"
+	}
+	b.WriteString("<g>")
+	fmt.Fprintf(b, `<title>%s: %s (%s)</title>`+"\n", n.e.ID(), html.EscapeString(n.e.Name), n.e.Status)
+	switch n.e.Type {
+	case board.BugType:
+		const c = 8.0
+		x1, y1, x2, y2 := n.x, n.y, n.x+n.w, n.y+n.h
+		fmt.Fprintf(b, `<polygon points="%.1f,%.1f %.1f,%.1f %.1f,%.1f %.1f,%.1f %.1f,%.1f %.1f,%.1f %.1f,%.1f %.1f,%.1f" fill="%s" stroke="#666666"/>`+"\n",
+			x1+c, y1, x2-c, y1, x2, y1+c, x2, y2-c, x2-c, y2, x1+c, y2, x1, y2-c, x1, y1+c, fill)
+	case board.EpicType:
+		fmt.Fprintf(b, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" rx="3" fill="%s" stroke="#333333" stroke-width="2"/>`+"\n", n.x, n.y, n.w, n.h, fill)
+	default:
+		fmt.Fprintf(b, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" rx="3" fill="%s" stroke="#666666"/>`+"\n", n.x, n.y, n.w, n.h, fill)
+	}
+	fmt.Fprintf(b, `<text x="%.1f" y="%.1f" text-anchor="middle" fill="%s" font-weight="bold">%s</text>`+"\n", n.x+n.w/2, n.y+18, font, n.e.ID())
+	fmt.Fprintf(b, `<text x="%.1f" y="%.1f" text-anchor="middle" fill="%s">%s</text>`+"\n", n.x+n.w/2, n.y+34, font,
+		html.EscapeString(truncate(n.e.Name, int((n.w-12)/nameChar))))
+	b.WriteString("</g>\n")
+}
+
+// truncate shortens s to at most n runes, ending with an ellipsis.
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n || n < 1 {
+		return s
+	}
+	return string(r[:n-1]) + "…"
+}
@@ -0,0 +1,101 @@
+package plan
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/aagrigore/task-board/internal/board"
+)
+
+// checkXML fails the test when s isn't well-formed XML.
+func checkXML(t *testing.T, s string) {
+	t.Helper()
+	d := xml.NewDecoder(strings.NewReader(s))
+	for {
+		if _, err := d.Token(); err == io.EOF {
+			return
+		} else if err != nil {
+			t.Fatalf("invalid SVG: %v\n%s", err, s)
+		}
+	}
+}
+
+func TestGenerateSVGPhases(t *testing.T) {
+	a := makeElementWithStatus(board.TaskType, 1, "interface", board.StatusDone)
+	b := makeElementWithStatus(board.TaskType, 2, "<implementation>", board.StatusDevelopment, "TASK-01")
+	c := makeElementWithStatus(board.BugType, 3, "crash", board.StatusBlocked, "TASK-01")
+
+	elements := []*board.Element{a, b, c}
+	out := GenerateSVG(BuildPlan(elements), elements, nil)
+	checkXML(t, out)
+
+	for _, want := range []string{
+		">Phase 1</text>",
+		">Phase 2</text>",
+		">TASK-01</text>",
+		"&lt;implementation&gt;",
+		"<polygon", // the bug
+		`marker-end="url(#arrow)"`,
+		">Legend</text>",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q", want)
+		}
+	}
+	if got := strings.Count(out, "<polyline"); got != 2 {
+		t.Errorf("edges = %d, want 2", got)
+	}
+}
+
+func TestGenerateFullSVGHierarchy(t *testing.T) {
+	epic := &board.Element{Type: board.EpicType, Number: 1, Name: "recording", Status: board.StatusDevelopment}
+	story := &board.Element{Type: board.StoryType, Number: 1, Name: "capture", Status: board.StatusDevelopment, ParentID: "EPIC-01"}
+	t1 := &board.Element{Type: board.TaskType, Number: 1, Name: "mic", Status: board.StatusDone, ParentID: "STORY-01"}
+	t2 := &board.Element{Type: board.TaskType, Number: 2, Name: "encode", Status: board.StatusToDev, ParentID: "STORY-01", BlockedBy: []string{"TASK-01"}}
+
+	out := GenerateFullSVG(&board.Board{}, []*board.Element{epic, story, t1, t2})
+	checkXML(t, out)
+
+	for _, want := range []string{">EPIC-01: recording</text>", ">STORY-01: capture</text>", ">TASK-02</text>"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q", want)
+		}
+	}
+	// The epic cluster is solid and drawn before the dashed story cluster
+	epicAt := strings.Index(out, ">EPIC-01: recording<")
+	storyAt := strings.Index(out, ">STORY-01: capture<")
+	if epicAt > storyAt {
+		t.Error("story cluster drawn before its epic")
+	}
+	if got := strings.Count(out, "<polyline"); got != 1 {
+		t.Errorf("edges = %d, want 1", got)
+	}
+}
+
+func TestGenerateFullSVGEmpty(t *testing.T) {
+	checkXML(t, GenerateFullSVG(&board.Board{}, nil))
+}
+
+func TestRectClip(t *testing.T) {
+	r := rect{0, 0, 100, 40}
+	if got := r.clip(point{50, 200}); got != (point{50, 40}) {
+		t.Errorf("clip below = %v, want bottom centre", got)
+	}
+	if got := r.clip(point{500, 20}); got != (point{100, 20}) {
+		t.Errorf("clip right = %v, want right centre", got)
+	}
+}
+
+func TestGenerateSVGTypedLinks(t *testing.T) {
+	a := makeElementWithStatus(board.TaskType, 1, "first", board.StatusToDev)
+	b := makeElementWithStatus(board.TaskType, 2, "second", board.StatusToDev)
+	a.Links = []board.Link{{Type: board.LinkRelatesTo, Target: "TASK-02"}}
+
+	elements := []*board.Element{a, b}
+	svg := GenerateSVG(BuildPlan(elements), elements, nil)
+	if !strings.Contains(svg, `stroke="#1f77b4" stroke-dasharray="2 3"/>`) || !strings.Contains(svg, ">relates-to</text>") {
+		t.Errorf("missing styled relates-to link:\n%s", svg)
+	}
+}
@@ -0,0 +1,114 @@
+package plan
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aagrigore/task-board/internal/board"
+)
+
+// linkStyle is how a typed link is drawn, set apart from the solid grey
+// blocked-by arrows. Typed links never constrain the layout.
+type linkStyle struct {
+	color  string
+	dotted bool // else dashed
+}
+
+var linkStyles = map[board.LinkType]linkStyle{
+	board.LinkRelatesTo:  {"#1f77b4", true},
+	board.LinkDuplicates: {"#9467bd", false},
+	board.LinkCausedBy:   {"#d62728", false},
+	board.LinkFollowsUp:  {"#2ca02c", false},
+	board.LinkParentOf:   {"#8c564b", true},
+}
+
+// directed reports whether a link type gets an arrowhead; relates-to has no
+// direction.
+func directed(t board.LinkType) bool {
+	return t != board.LinkRelatesTo
+}
+
+// writeDOTLinks draws the typed links among elements, labelled with their type.
+func writeDOTLinks(b *strings.Builder, elements []*board.Element) {
+	for _, edge := range board.LinkEdges(elements) {
+		st := linkStyles[edge.Type]
+		style := "dashed"
+		if st.dotted {
+			style = "dotted"
+		}
+		dir := ""
+		if !directed(edge.Type) {
+			dir = ", dir=none"
+		}
+		fmt.Fprintf(b, "  %s -> %s [style=%s, color=\"%s\", fontcolor=\"%s\", fontsize=9, label=\"%s\", constraint=false%s];\n",
+			safeDOTID(edge.From), safeDOTID(edge.To), style, st.color, st.color, edge.Type, dir)
+	}
+}
+
+// writeMermaidLinks draws the typed links as labelled dotted edges.
+func writeMermaidLinks(b *strings.Builder, elements []*board.Element) {
+	for _, edge := range board.LinkEdges(elements) {
+		arrow := ".->"
+		if !directed(edge.Type) {
+			arrow = ".-"
+		}
+		fmt.Fprintf(b, "  %s -. %s %s %s\n", safeDOTID(edge.From), edge.Type, arrow, safeDOTID(edge.To))
+	}
+}
+
+// writePlantUMLLinks draws the typed links in their colour and line style.
+func writePlantUMLLinks(b *strings.Builder, elements []*board.Element) {
+	for _, edge := range board.LinkEdges(elements) {
+		st := linkStyles[edge.Type]
+		line := "dashed"
+		if st.dotted {
+			line = "dotted"
+		}
+		head := ">"
+		if !directed(edge.Type) {
+			head = ""
+		}
+		fmt.Fprintf(b, "%s -[%s,%s]-%s %s : %s\n", safeDOTID(edge.From), st.color, line, head, safeDOTID(edge.To), edge.Type)
+	}
+}
+
+// addSVGLinks routes the typed links straight between the boxes already in
+// the scene.
+func addSVGLinks(s *scene, elements []*board.Element) {
+	boxes := map[string]rect{}
+	for _, n := range s.nodes {
+		boxes[n.e.ID()] = n.rect
+	}
+	for _, edge := range board.LinkEdges(elements) {
+		from, ok := boxes[edge.From]
+		if !ok {
+			continue
+		}
+		to, ok := boxes[edge.To]
+		if !ok {
+			continue
+		}
+		s.links = append(s.links, sceneLink{
+			t:    edge.Type,
+			from: from.clip(to.center()),
+			to:   to.clip(from.center()),
+		})
+	}
+}
+
+// writeSVGLink draws a typed link with its type at the midpoint.
+func writeSVGLink(b *strings.Builder, l sceneLink) {
+	st := linkStyles[l.t]
+	dash := "6 3"
+	if st.dotted {
+		dash = "2 3"
+	}
+	marker := ""
+	if directed(l.t) {
+		marker = ` marker-end="url(#arrow)"`
+	}
+	fmt.Fprintf(b, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="%s" stroke-dasharray="%s"%s/>`+"\n",
+		l.from.x, l.from.y, l.to.x, l.to.y, st.color, dash, marker)
+	fmt.Fprintf(b, `<text x="%.1f" y="%.1f" text-anchor="middle" font-size="9" fill="%s">%s</text>`+"\n",
+		(l.from.x+l.to.x)/2, (l.from.y+l.to.y)/2-3, st.color, l.t)
+}
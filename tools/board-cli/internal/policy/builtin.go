@@ -0,0 +1,82 @@
+package policy
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/aagrigore/task-board/internal/board"
+)
+
+// builtin is a structure check validate has always made. Its ID is also
+// the code it reports, and the policy file may change its severity.
+type builtin struct {
+	id       string
+	severity Severity
+	check    func(b *board.Board, e *board.Element) []Violation
+}
+
+var builtins = []builtin{
+	{"MISSING_README", SeverityError, missingFile("README.md", (*board.Element).ReadmePath)},
+	{"MISSING_PROGRESS", SeverityError, missingFile("progress.md", (*board.Element).ProgressPath)},
+	{"NAMING_ERROR", SeverityWarn, namingError},
+	{"BROKEN_LINK", SeverityError, brokenLinks},
+	{"ORPHAN_ELEMENT", SeverityWarn, orphan},
+}
+
+func missingFile(name string, path func(*board.Element) string) func(*board.Board, *board.Element) []Violation {
+	return func(b *board.Board, e *board.Element) []Violation {
+		if _, err := os.Stat(path(e)); !os.IsNotExist(err) {
+			return nil
+		}
+		return []Violation{{Message: fmt.Sprintf("%s: %s missing", e.ID(), name), ElementID: e.ID()}}
+	}
+}
+
+func namingError(b *board.Board, e *board.Element) []Violation {
+	if _, _, _, err := board.ParseDirName(e.DirName()); err != nil {
+		return []Violation{{Message: fmt.Sprintf("%s: %v", e.ID(), err), ElementID: e.ID()}}
+	}
+	return nil
+}
+
+func brokenLinks(b *board.Board, e *board.Element) []Violation {
+	var violations []Violation
+	broken := func(kind, target string) {
+		violations = append(violations, Violation{
+			Message:    fmt.Sprintf("%s: %s %s (not found)", e.ID(), kind, target),
+			ElementID:  e.ID(),
+			ElementIDs: []string{e.ID(), target},
+		})
+	}
+	for _, blockerID := range e.BlockedBy {
+		if blockerID == "(none)" {
+			continue
+		}
+		if board.IsQualifiedID(blockerID) {
+			continue // cross-board link, resolved through --workspace
+		}
+		if b.FindByID(blockerID) == nil {
+			broken("blockedBy", blockerID)
+		}
+	}
+	for _, l := range e.Links {
+		if board.IsQualifiedID(l.Target) || b.FindByID(l.Target) != nil {
+			continue
+		}
+		broken(string(l.Type), l.Target)
+	}
+	return violations
+}
+
+func orphan(b *board.Board, e *board.Element) []Violation {
+	if e.ParentID != "" {
+		return nil
+	}
+	switch e.Type {
+	case board.StoryType:
+		return []Violation{{Message: fmt.Sprintf("%s: story without epic", e.ID()), ElementID: e.ID()}}
+	case board.TaskType, board.BugType:
+		return []Violation{{Message: fmt.Sprintf("%s: %s without story", e.ID(), e.Type), ElementID: e.ID()}}
+	}
+	return nil
+}
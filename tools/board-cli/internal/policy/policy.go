@@ -0,0 +1,349 @@
+// Package policy checks board elements against definition-of-done rules:
+// the built-in structure checks of validate and the rules of the board's
+// policy file.
+package policy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aagrigore/task-board/internal/board"
+	"gopkg.in/yaml.v3"
+)
+
+// File is the name of the policy file inside the board directory.
+const File = "policy.yaml"
+
+// Severity says what a violated rule does: an error fails validate and
+// refuses status changes, a warning is only reported.
+type Severity string
+
+const (
+	SeverityError Severity = "error"
+	SeverityWarn  Severity = "warn"
+	SeverityOff   Severity = "off"
+)
+
+// Checks a rule can make.
+const (
+	CheckChecklistDone = "checklist-done" // every checklist item checked
+	CheckSection       = "section"        // README section present and filled in
+	CheckChildren      = "children"       // at least min children, closed ones aside
+	CheckAssigned      = "assigned"       // someone assigned
+	CheckEstimate      = "estimate"       // an estimate recorded
+)
+
+var checks = []string{CheckChecklistDone, CheckSection, CheckChildren, CheckAssigned, CheckEstimate}
+
+// Rule is a named rule of the policy file. It holds for the elements of its
+// types (all when empty) while they are in one of its statuses (all when
+// empty), and is checked before a status change into one of them.
+type Rule struct {
+	ID         string              `yaml:"id"`
+	Types      []board.ElementType `yaml:"types,omitempty"`
+	On         []string            `yaml:"on,omitempty"`
+	Check      string              `yaml:"check,omitempty"`
+	Section    string              `yaml:"section,omitempty"`     // for section
+	Min        int                 `yaml:"min,omitempty"`         // for children, default 1
+	ChildTypes []board.ElementType `yaml:"child-types,omitempty"` // for children, default all
+	Severity   Severity            `yaml:"severity,omitempty"`    // default error
+	Message    string              `yaml:"message,omitempty"`
+}
+
+// Violation is a rule an element breaks.
+type Violation struct {
+	Rule       string   `json:"rule"`
+	Code       string   `json:"code"`
+	Severity   Severity `json:"severity"`
+	Message    string   `json:"message"`
+	ElementID  string   `json:"elementId,omitempty"`
+	ElementIDs []string `json:"elementIds,omitempty"`
+}
+
+// Policy is the built-in rules, with the severities the policy file gives
+// them, and the rules of the file.
+type Policy struct {
+	Path     string // empty without a policy file
+	Rules    []Rule
+	severity map[string]Severity // of the built-in rules
+}
+
+type policyDoc struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+// Path returns the path of the policy file for a board directory.
+func Path(boardDir string) string {
+	return filepath.Join(boardDir, File)
+}
+
+// Load reads the policy file of a board, checking its statuses against the
+// board's workflow. A missing file is not an error and yields the built-in
+// rules only.
+func Load(b *board.Board) (*Policy, error) {
+	p := &Policy{severity: map[string]Severity{}}
+	for _, r := range builtins {
+		p.severity[r.id] = r.severity
+	}
+	path := Path(b.Dir)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return p, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading policy: %w", err)
+	}
+	var doc policyDoc
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&doc); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("parsing %s: %w", File, err)
+	}
+	p.Path = path
+	seen := map[string]bool{}
+	for i := range doc.Rules {
+		r := &doc.Rules[i]
+		if err := p.add(b, r, seen); err != nil {
+			if r.ID != "" {
+				return nil, fmt.Errorf("%s: rule %s: %w", path, r.ID, err)
+			}
+			return nil, fmt.Errorf("%s: rule %d: %w", path, i+1, err)
+		}
+	}
+	return p, nil
+}
+
+// add checks a rule of the file and records it, or the severity it gives
+// a built-in rule.
+func (p *Policy) add(b *board.Board, r *Rule, seen map[string]bool) error {
+	if r.ID == "" {
+		return fmt.Errorf("id is required")
+	}
+	if seen[r.ID] {
+		return fmt.Errorf("duplicate id")
+	}
+	seen[r.ID] = true
+
+	switch r.Severity {
+	case "":
+		r.Severity = SeverityError
+	case SeverityError, SeverityWarn, SeverityOff:
+	default:
+		return fmt.Errorf("unknown severity %q (valid: error, warn, off)", r.Severity)
+	}
+	if _, ok := p.severity[r.ID]; ok {
+		if r.Check != "" || len(r.Types) > 0 || len(r.On) > 0 || r.Message != "" {
+			return fmt.Errorf("built-in rule: only severity can be set")
+		}
+		p.severity[r.ID] = r.Severity
+		return nil
+	}
+
+	for i, t := range r.Types {
+		parsed, err := board.ParseElementType(string(t))
+		if err != nil {
+			return err
+		}
+		r.Types[i] = parsed
+	}
+	for i, t := range r.ChildTypes {
+		parsed, err := board.ParseElementType(string(t))
+		if err != nil {
+			return fmt.Errorf("child-types: %w", err)
+		}
+		r.ChildTypes[i] = parsed
+	}
+	types := r.Types
+	if len(types) == 0 {
+		types = []board.ElementType{board.EpicType, board.StoryType, board.TaskType, board.BugType}
+	}
+	for i, s := range r.On {
+		var known board.Status
+		for _, t := range types {
+			if parsed, err := b.Flow(t).Parse(s); err == nil {
+				known = parsed
+				break
+			}
+		}
+		if known == "" {
+			return fmt.Errorf("on: unknown status %q", s)
+		}
+		r.On[i] = string(known)
+	}
+
+	switch r.Check {
+	case CheckSection:
+		if r.Section == "" {
+			return fmt.Errorf("check section needs a section name")
+		}
+	case CheckChildren:
+		if r.Min < 0 {
+			return fmt.Errorf("min must not be negative")
+		}
+		if r.Min == 0 {
+			r.Min = 1
+		}
+	case CheckChecklistDone, CheckAssigned, CheckEstimate:
+	case "":
+		return fmt.Errorf("check is required (valid: %s)", strings.Join(checks, ", "))
+	default:
+		return fmt.Errorf("unknown check %q (valid: %s)", r.Check, strings.Join(checks, ", "))
+	}
+	if r.Severity != SeverityOff {
+		p.Rules = append(p.Rules, *r)
+	}
+	return nil
+}
+
+// appliesTo reports whether the rule holds for elements of e's type in
+// status.
+func (r *Rule) appliesTo(b *board.Board, e *board.Element, status board.Status) bool {
+	if len(r.Types) > 0 && !containsType(r.Types, e.Type) {
+		return false
+	}
+	if len(r.On) == 0 {
+		return true
+	}
+	status = b.Flow(e.Type).Normalize(status)
+	for _, s := range r.On {
+		if board.Status(s) == status {
+			return true
+		}
+	}
+	return false
+}
+
+// Check returns the rules e would break in status; progress status runs
+// it before a change. Built-in rules are left to Validate.
+func (p *Policy) Check(b *board.Board, e *board.Element, status board.Status) []Violation {
+	var violations []Violation
+	for i := range p.Rules {
+		r := &p.Rules[i]
+		if !r.appliesTo(b, e, status) {
+			continue
+		}
+		if problem := r.problem(b, e); problem != "" {
+			if r.Message != "" {
+				problem = r.Message
+			}
+			violations = append(violations, Violation{
+				Rule:      r.ID,
+				Code:      "POLICY_VIOLATION",
+				Severity:  r.Severity,
+				Message:   fmt.Sprintf("%s: %s", e.ID(), problem),
+				ElementID: e.ID(),
+			})
+		}
+	}
+	return violations
+}
+
+// Validate returns every violation on the board: the built-in rules, then
+// the policy file's rules for each element in its current status.
+func (p *Policy) Validate(b *board.Board) []Violation {
+	var violations []Violation
+	for _, e := range b.Elements {
+		for _, r := range builtins {
+			severity := p.severity[r.id]
+			if severity == SeverityOff {
+				continue
+			}
+			for _, v := range r.check(b, e) {
+				v.Rule = r.id
+				v.Code = r.id
+				v.Severity = severity
+				violations = append(violations, v)
+			}
+		}
+		violations = append(violations, p.Check(b, e, e.Status)...)
+	}
+	return violations
+}
+
+// problem describes how e breaks the rule, or returns "".
+func (r *Rule) problem(b *board.Board, e *board.Element) string {
+	switch r.Check {
+	case CheckChecklistDone:
+		open := 0
+		for _, item := range e.Checklist {
+			if !item.Checked {
+				open++
+			}
+		}
+		if open > 0 {
+			return fmt.Sprintf("%d unchecked checklist item(s)", open)
+		}
+	case CheckSection:
+		if strings.TrimSpace(e.Section(r.Section)) == "" {
+			return fmt.Sprintf("README has no %s section", r.Section)
+		}
+	case CheckChildren:
+		count := 0
+		for _, c := range b.Children(e) {
+			if len(r.ChildTypes) > 0 && !containsType(r.ChildTypes, c.Type) {
+				continue
+			}
+			if b.Workflow.Category(c) == board.CategoryClosed {
+				continue
+			}
+			count++
+		}
+		if count < r.Min {
+			return fmt.Sprintf("has %d %s (needs at least %d)", count, childLabel(r.ChildTypes), r.Min)
+		}
+	case CheckAssigned:
+		if e.AssignedTo == "" {
+			return "not assigned"
+		}
+	case CheckEstimate:
+		if e.Estimate == 0 {
+			return "no estimate"
+		}
+	}
+	return ""
+}
+
+// Errors returns the violations of error severity.
+func Errors(violations []Violation) []Violation {
+	return bySeverity(violations, SeverityError)
+}
+
+// Warnings returns the violations of warn severity.
+func Warnings(violations []Violation) []Violation {
+	return bySeverity(violations, SeverityWarn)
+}
+
+func bySeverity(violations []Violation, s Severity) []Violation {
+	var result []Violation
+	for _, v := range violations {
+		if v.Severity == s {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func childLabel(types []board.ElementType) string {
+	if len(types) == 0 {
+		return "children"
+	}
+	names := make([]string, len(types))
+	for i, t := range types {
+		names[i] = string(t) + "s"
+	}
+	return strings.Join(names, "/")
+}
+
+func containsType(types []board.ElementType, t board.ElementType) bool {
+	for _, x := range types {
+		if x == t {
+			return true
+		}
+	}
+	return false
+}
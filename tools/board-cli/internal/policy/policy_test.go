@@ -0,0 +1,136 @@
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aagrigore/task-board/internal/board"
+)
+
+func testBoard(t *testing.T, policyYAML string) *board.Board {
+	t.Helper()
+	dir := t.TempDir()
+	if policyYAML != "" {
+		if err := os.WriteFile(filepath.Join(dir, File), []byte(policyYAML), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return &board.Board{Dir: dir, Workflow: board.DefaultWorkflow()}
+}
+
+func rules(violations []Violation) string {
+	ids := make([]string, len(violations))
+	for i, v := range violations {
+		ids[i] = v.Rule + ":" + v.ElementID
+	}
+	return strings.Join(ids, " ")
+}
+
+func TestLoadRejectsBadRules(t *testing.T) {
+	cases := map[string]string{
+		"rules: [{check: assigned}]":                                  "id is required",
+		"rules: [{id: a, check: assigned}, {id: a, check: estimate}]": "duplicate id",
+		"rules: [{id: a, check: nope}]":                               "unknown check",
+		"rules: [{id: a, check: section}]":                            "needs a section",
+		"rules: [{id: a, check: assigned, on: [nowhere]}]":            "unknown status",
+		"rules: [{id: a, check: assigned, severity: fatal}]":          "unknown severity",
+		"rules: [{id: a, check: assigned, types: [chore]}]":           "unknown element type",
+		"rules: [{id: ORPHAN_ELEMENT, check: assigned}]":              "only severity",
+		"rules: [{id: a, check: assigned, when: done}]":               "field when not found",
+	}
+	for doc, want := range cases {
+		_, err := Load(testBoard(t, doc))
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%s: err = %v, want %q", doc, err, want)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	b := testBoard(t, `rules:
+  - id: checklist-before-review
+    types: [task]
+    on: [toreview]
+    check: checklist-done
+  - id: bug-reproduction
+    types: [bug]
+    check: section
+    section: Reproduction
+    severity: warn
+  - id: story-has-tasks
+    types: [story]
+    on: [development]
+    check: children
+    child-types: [task]
+`)
+	story := &board.Element{Type: board.StoryType, RawID: "STORY-1", Status: board.StatusToDev}
+	task := &board.Element{Type: board.TaskType, RawID: "TASK-1", ParentID: "STORY-1", Status: board.StatusDevelopment,
+		Checklist: []board.ChecklistItem{{Text: "a", Checked: true}, {Text: "b"}}}
+	bug := &board.Element{Type: board.BugType, RawID: "BUG-1", ParentID: "STORY-1", Status: board.StatusDevelopment,
+		Extra: []board.ReadmeSection{{Heading: "Reproduction", Text: "(steps to reproduce)"}}}
+	b.Elements = []*board.Element{story, task, bug}
+	p, err := Load(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := rules(p.Check(b, task, board.StatusToReview)); got != "checklist-before-review:TASK-1" {
+		t.Errorf("task to to-review: %q", got)
+	}
+	if got := rules(p.Check(b, task, board.StatusDevelopment)); got != "" {
+		t.Errorf("task to development: %q", got)
+	}
+	// A placeholder does not fill a section in
+	v := p.Check(b, bug, board.StatusDone)
+	if rules(v) != "bug-reproduction:BUG-1" || len(Errors(v)) != 0 || len(Warnings(v)) != 1 {
+		t.Errorf("bug: %+v", v)
+	}
+	bug.Extra[0].Text = "1. open the agents screen"
+	if got := rules(p.Check(b, bug, board.StatusDone)); got != "" {
+		t.Errorf("bug with reproduction: %q", got)
+	}
+	// Only tasks count, and closed ones not at all
+	if got := rules(p.Check(b, story, board.StatusDevelopment)); got != "" {
+		t.Errorf("story with a task: %q", got)
+	}
+	task.Status = board.StatusClosed
+	if got := rules(p.Check(b, story, board.StatusDevelopment)); got != "story-has-tasks:STORY-1" {
+		t.Errorf("story without open tasks: %q", got)
+	}
+}
+
+func TestValidateBuiltins(t *testing.T) {
+	b := testBoard(t, "")
+	dir := filepath.Join(b.Dir, "TASK-1_a")
+	os.MkdirAll(dir, 0755)
+	os.WriteFile(filepath.Join(dir, "README.md"), []byte("# a\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "progress.md"), []byte("## Status\nbacklog\n"), 0644)
+	task := &board.Element{Type: board.TaskType, RawID: "TASK-1", Name: "a", Path: dir, Status: board.StatusBacklog,
+		BlockedBy: []string{"TASK-9"}}
+	b.Elements = []*board.Element{task}
+
+	p, err := Load(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := p.Validate(b)
+	if got := rules(v); got != "BROKEN_LINK:TASK-1 ORPHAN_ELEMENT:TASK-1" {
+		t.Fatalf("violations = %q", got)
+	}
+	if v[0].Code != "BROKEN_LINK" || v[0].Severity != SeverityError || v[1].Severity != SeverityWarn {
+		t.Errorf("built-in severities: %+v", v)
+	}
+
+	// The policy file can change a built-in rule's severity
+	os.WriteFile(Path(b.Dir), []byte("rules:\n  - {id: ORPHAN_ELEMENT, severity: off}\n  - {id: BROKEN_LINK, severity: warn}\n"), 0644)
+	p, err = Load(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v = p.Validate(b)
+	if rules(v) != "BROKEN_LINK:TASK-1" || v[0].Severity != SeverityWarn {
+		t.Errorf("overridden: %+v", v)
+	}
+}
@@ -0,0 +1,214 @@
+// Package recur parses the recurrence rules of repeating elements: fixed
+// intervals ("daily", "weekly", "monthly", "every 2w") and five-field cron
+// expressions ("0 9 * * 1"). Rules are evaluated in the location of the
+// times they are given.
+package recur
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Rule is a parsed recurrence rule.
+type Rule struct {
+	text   string
+	days   int // interval rules
+	months int
+	cron   *cron
+}
+
+// horizon bounds the search for the next cron match; four years cover
+// the 29th of February.
+const horizon = 5 * 366 * 24 * time.Hour
+
+// Parse reads a rule: daily, weekly, monthly, yearly, "every N days|weeks|
+// months" (or Nd, Nw, Nm), or a cron expression
+// "minute hour day-of-month month day-of-week".
+func Parse(s string) (Rule, error) {
+	text := strings.Join(strings.Fields(strings.ToLower(s)), " ")
+	r := Rule{text: text}
+	switch text {
+	case "daily":
+		r.days = 1
+		return r, nil
+	case "weekly":
+		r.days = 7
+		return r, nil
+	case "monthly":
+		r.months = 1
+		return r, nil
+	case "yearly":
+		r.months = 12
+		return r, nil
+	}
+
+	if rest, ok := strings.CutPrefix(text, "every "); ok {
+		n, unit := 1, strings.ReplaceAll(rest, " ", "")
+		if i := strings.IndexFunc(unit, func(c rune) bool { return c < '0' || c > '9' }); i > 0 {
+			n, _ = strconv.Atoi(unit[:i])
+			unit = unit[i:]
+		}
+		switch strings.TrimSuffix(unit, "s") {
+		case "d", "day":
+			r.days = n
+		case "w", "week":
+			r.days = 7 * n
+		case "m", "month":
+			r.months = n
+		default:
+			return Rule{}, fmt.Errorf("invalid rule %q: unknown unit %q (use days, weeks or months)", s, unit)
+		}
+		if n <= 0 {
+			return Rule{}, fmt.Errorf("invalid rule %q: the interval must be positive", s)
+		}
+		return r, nil
+	}
+
+	c, err := parseCron(text)
+	if err != nil {
+		return Rule{}, fmt.Errorf("invalid rule %q: %w", s, err)
+	}
+	r.cron = c
+	start := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	if r.Next(start).IsZero() {
+		return Rule{}, fmt.Errorf("invalid rule %q: never matches", s)
+	}
+	return r, nil
+}
+
+// String returns the rule as written, normalised.
+func (r Rule) String() string {
+	return r.text
+}
+
+// First returns the first occurrence at or after t: t itself for interval
+// rules, the first matching minute for cron rules.
+func (r Rule) First(t time.Time) time.Time {
+	if r.cron == nil {
+		return t
+	}
+	return r.Next(t.Add(-time.Nanosecond))
+}
+
+// Next returns the occurrence after one at t, or the zero time when a cron
+// rule doesn't match within a few years.
+func (r Rule) Next(t time.Time) time.Time {
+	if r.cron == nil {
+		return t.AddDate(0, r.months, r.days)
+	}
+	return r.cron.next(t)
+}
+
+// cron holds the allowed values of each field.
+type cron struct {
+	minute, hour, dom, month, dow map[int]bool
+	// A restricted day of month and day of week match when either does.
+	domAny, dowAny bool
+}
+
+func parseCron(s string) (*cron, error) {
+	fields := strings.Fields(s)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected daily, weekly, monthly, \"every 2w\" or five cron fields")
+	}
+	c := &cron{domAny: fields[2] == "*", dowAny: fields[4] == "*"}
+	for _, f := range []struct {
+		name     string
+		field    string
+		min, max int
+		set      *map[int]bool
+	}{
+		{"minute", fields[0], 0, 59, &c.minute},
+		{"hour", fields[1], 0, 23, &c.hour},
+		{"day of month", fields[2], 1, 31, &c.dom},
+		{"month", fields[3], 1, 12, &c.month},
+		{"day of week", fields[4], 0, 7, &c.dow},
+	} {
+		set, err := parseField(f.field, f.min, f.max)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.name, err)
+		}
+		*f.set = set
+	}
+	// Sunday is 0 or 7
+	if c.dow[7] {
+		c.dow[0] = true
+	}
+	return c, nil
+}
+
+// parseField reads a comma-separated list of *, N, N-M, with an optional
+// /step.
+func parseField(s string, min, max int) (map[int]bool, error) {
+	set := map[int]bool{}
+	for _, part := range strings.Split(s, ",") {
+		step := 1
+		if base, stepText, ok := strings.Cut(part, "/"); ok {
+			n, err := strconv.Atoi(stepText)
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("invalid step %q", stepText)
+			}
+			part, step = base, n
+		}
+		lo, hi := min, max
+		if part != "*" {
+			from, to, isRange := strings.Cut(part, "-")
+			var err error
+			if lo, err = strconv.Atoi(from); err != nil {
+				return nil, fmt.Errorf("invalid value %q", part)
+			}
+			hi = lo
+			if isRange {
+				if hi, err = strconv.Atoi(to); err != nil {
+					return nil, fmt.Errorf("invalid value %q", part)
+				}
+			} else if step > 1 {
+				hi = max
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return nil, fmt.Errorf("%q is out of range %d-%d", part, min, max)
+		}
+		for v := lo; v <= hi; v += step {
+			set[v] = true
+		}
+	}
+	return set, nil
+}
+
+func (c *cron) matchDay(t time.Time) bool {
+	dom, dow := c.dom[t.Day()], c.dow[int(t.Weekday())]
+	switch {
+	case c.domAny && c.dowAny:
+		return true
+	case c.domAny:
+		return dow
+	case c.dowAny:
+		return dom
+	}
+	return dom || dow
+}
+
+// next finds the first matching minute after t, skipping whole months,
+// days and hours that can't match.
+func (c *cron) next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	end := t.Add(horizon)
+	for t.Before(end) {
+		switch {
+		case !c.month[int(t.Month())]:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		case !c.matchDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case !c.hour[t.Hour()]:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+		case !c.minute[t.Minute()]:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,84 @@
+package recur
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestIntervalRules(t *testing.T) {
+	start := time.Date(2026, 1, 31, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		rule string
+		want time.Time
+	}{
+		{"daily", time.Date(2026, 2, 1, 9, 0, 0, 0, time.UTC)},
+		{"Weekly", time.Date(2026, 2, 7, 9, 0, 0, 0, time.UTC)},
+		{"every 2w", time.Date(2026, 2, 14, 9, 0, 0, 0, time.UTC)},
+		{"every 3 days", time.Date(2026, 2, 3, 9, 0, 0, 0, time.UTC)},
+		{"every month", time.Date(2026, 3, 3, 9, 0, 0, 0, time.UTC)}, // AddDate normalises Feb 31st
+		{"yearly", time.Date(2027, 1, 31, 9, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		r, err := Parse(tt.rule)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.rule, err)
+			continue
+		}
+		if got := r.Next(start); !got.Equal(tt.want) {
+			t.Errorf("%q: Next = %v, want %v", tt.rule, got, tt.want)
+		}
+		if got := r.First(start); !got.Equal(start) {
+			t.Errorf("%q: First = %v, want the start", tt.rule, got)
+		}
+	}
+}
+
+func TestCronRules(t *testing.T) {
+	// Friday
+	start := time.Date(2026, 10, 16, 10, 30, 0, 0, time.UTC)
+	tests := []struct {
+		rule string
+		want time.Time
+	}{
+		{"0 9 * * 1", time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2026, 10, 16, 10, 45, 0, 0, time.UTC)},
+		{"0 9 1 * *", time.Date(2026, 11, 1, 9, 0, 0, 0, time.UTC)},
+		{"30 8-17/3 * * 1-5", time.Date(2026, 10, 16, 11, 30, 0, 0, time.UTC)},
+		{"0 0 1 * 0", time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)}, // the 1st or a Sunday
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 12 * * 7", time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		r, err := Parse(tt.rule)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.rule, err)
+			continue
+		}
+		if got := r.Next(start); !got.Equal(tt.want) {
+			t.Errorf("%q: Next = %v, want %v", tt.rule, got, tt.want)
+		}
+	}
+
+	r, _ := Parse("30 10 * * *")
+	if got := r.First(start); !got.Equal(start) {
+		t.Errorf("First on a match = %v, want %v", got, start)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for rule, want := range map[string]string{
+		"fortnightly": "five cron fields",
+		"every 0d":    "must be positive",
+		"every 2y":    "unknown unit",
+		"60 * * * *":  "minute",
+		"0 9 * * mon": "day of week",
+		"0 0 31 2 *":  "never matches",
+		"*/0 * * * *": "invalid step",
+		"0 9 5-1 * *": "out of range",
+	} {
+		if _, err := Parse(rule); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Parse(%q) error = %v, want %q", rule, err, want)
+		}
+	}
+}
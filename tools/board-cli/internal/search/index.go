@@ -0,0 +1,278 @@
+// Package search maintains an on-disk full-text index of board elements
+// and answers ranked queries against it.
+package search
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/aagrigore/task-board/internal/board"
+)
+
+// IndexDir is the index directory name inside the board directory.
+const IndexDir = ".index"
+
+const indexFile = "index.json"
+
+// indexVersion is bumped whenever the on-disk format or tokenization changes;
+// an index with another version is discarded and rebuilt.
+const indexVersion = 1
+
+// Indexed fields. Title, description, scope and ac come from README.md;
+// checklist and notes from progress.md.
+const (
+	FieldTitle       = "title"
+	FieldDescription = "description"
+	FieldScope       = "scope"
+	FieldAC          = "ac"
+	FieldChecklist   = "checklist"
+	FieldNotes       = "notes"
+)
+
+// Fields lists every indexed field in display order.
+var Fields = []string{FieldTitle, FieldDescription, FieldScope, FieldAC, FieldChecklist, FieldNotes}
+
+// Index is an inverted index over board elements.
+type Index struct {
+	Version int `json:"version"`
+	// Docs holds per-element data keyed by element ID.
+	Docs map[string]*Doc `json:"docs"`
+	// Postings maps each term to the IDs of the elements containing it.
+	Postings map[string][]string `json:"postings"`
+}
+
+// Doc is the indexed form of one element.
+type Doc struct {
+	ID string `json:"id"`
+	// Signature of README.md and progress.md (size and mtime) at index time.
+	Signature string `json:"signature"`
+	// Text holds the raw text of each field, used for snippets.
+	Text map[string]string `json:"text"`
+	// Positions maps field → term → token positions within the field.
+	Positions map[string]map[string][]int `json:"positions"`
+	// Lengths is the token count of each field.
+	Lengths map[string]int `json:"lengths"`
+}
+
+// New returns an empty index.
+func New() *Index {
+	return &Index{
+		Version:  indexVersion,
+		Docs:     make(map[string]*Doc),
+		Postings: make(map[string][]string),
+	}
+}
+
+// Path returns the index file path for a board directory.
+func Path(boardDir string) string {
+	return filepath.Join(boardDir, IndexDir, indexFile)
+}
+
+// Load reads the index of a board directory. A missing, unreadable or
+// outdated index yields an empty one so callers can simply Sync and Save.
+func Load(boardDir string) *Index {
+	data, err := os.ReadFile(Path(boardDir))
+	if err != nil {
+		return New()
+	}
+	var ix Index
+	if err := json.Unmarshal(data, &ix); err != nil || ix.Version != indexVersion || ix.Docs == nil {
+		return New()
+	}
+	if ix.Postings == nil {
+		ix.Postings = make(map[string][]string)
+	}
+	return &ix
+}
+
+// Save writes the index atomically into the board's .index directory.
+// Searches save without the board lock, so each writes its own temporary
+// file and the last rename wins.
+func (ix *Index) Save(boardDir string) error {
+	dir := filepath.Join(boardDir, IndexDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("creating index directory: %w", err)
+	}
+	data, err := json.Marshal(ix)
+	if err != nil {
+		return fmt.Errorf("encoding index: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, filepath.Base(Path(boardDir))+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("writing index: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // fails harmlessly once renamed
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), 0644)
+	}
+	if err != nil {
+		return fmt.Errorf("writing index: %w", err)
+	}
+	return os.Rename(tmp.Name(), Path(boardDir))
+}
+
+// Sync brings the index up to date with the board: elements whose files
+// changed since they were indexed are re-read, new ones are added and
+// deleted ones removed. It reports whether anything changed.
+func (ix *Index) Sync(b *board.Board) bool {
+	changed := false
+	seen := make(map[string]bool, len(b.Elements))
+
+	for _, e := range b.Elements {
+		id := e.ID()
+		seen[id] = true
+		sig := signature(e)
+		if doc, ok := ix.Docs[id]; ok && doc.Signature == sig {
+			continue
+		}
+		ix.remove(id)
+		ix.add(buildDoc(e, sig))
+		changed = true
+	}
+
+	for id := range ix.Docs {
+		if !seen[id] {
+			ix.remove(id)
+			changed = true
+		}
+	}
+	return changed
+}
+
+// Len returns the number of indexed elements.
+func (ix *Index) Len() int {
+	return len(ix.Docs)
+}
+
+func (ix *Index) add(doc *Doc) {
+	ix.Docs[doc.ID] = doc
+	terms := make(map[string]bool)
+	for _, positions := range doc.Positions {
+		for term := range positions {
+			terms[term] = true
+		}
+	}
+	for term := range terms {
+		ix.Postings[term] = append(ix.Postings[term], doc.ID)
+	}
+}
+
+func (ix *Index) remove(id string) {
+	doc, ok := ix.Docs[id]
+	if !ok {
+		return
+	}
+	delete(ix.Docs, id)
+	for _, positions := range doc.Positions {
+		for term := range positions {
+			ix.Postings[term] = removeString(ix.Postings[term], id)
+			if len(ix.Postings[term]) == 0 {
+				delete(ix.Postings, term)
+			}
+		}
+	}
+}
+
+func removeString(list []string, s string) []string {
+	out := list[:0]
+	for _, v := range list {
+		if v != s {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// signature identifies the current on-disk state of an element's files.
+func signature(e *board.Element) string {
+	var parts []string
+	for _, p := range []string{e.ReadmePath(), e.ProgressPath()} {
+		info, err := os.Stat(p)
+		if err != nil {
+			parts = append(parts, "-")
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%d:%d", info.Size(), info.ModTime().UnixNano()))
+	}
+	return strings.Join(parts, "|")
+}
+
+// buildDoc reads an element's files and tokenizes every field.
+func buildDoc(e *board.Element, sig string) *Doc {
+	text := map[string]string{FieldTitle: e.Name}
+
+	if rd, err := board.ParseReadmeFile(e.ReadmePath()); err == nil {
+		if rd.Title != "" {
+			text[FieldTitle] = rd.Title
+		}
+		text[FieldDescription] = rd.Description
+		text[FieldScope] = rd.Scope
+		text[FieldAC] = rd.AC
+	}
+	if pd, err := board.ParseProgressFile(e.ProgressPath()); err == nil {
+		var items []string
+		for _, item := range pd.Checklist {
+			items = append(items, item.Text)
+		}
+		text[FieldChecklist] = strings.Join(items, "\n")
+		text[FieldNotes] = pd.Notes
+	}
+
+	doc := &Doc{
+		ID:        e.ID(),
+		Signature: sig,
+		Text:      make(map[string]string),
+		Positions: make(map[string]map[string][]int),
+		Lengths:   make(map[string]int),
+	}
+	for field, t := range text {
+		if strings.TrimSpace(t) == "" {
+			continue
+		}
+		tokens := Tokenize(t)
+		if len(tokens) == 0 {
+			continue
+		}
+		doc.Text[field] = t
+		doc.Lengths[field] = len(tokens)
+		positions := make(map[string][]int)
+		for i, tok := range tokens {
+			positions[tok.Term] = append(positions[tok.Term], i)
+		}
+		doc.Positions[field] = positions
+	}
+	return doc
+}
+
+// Token is a normalized term with its byte span in the source text.
+type Token struct {
+	Term       string
+	Start, End int
+}
+
+// Tokenize splits text into lowercase terms of letters and digits.
+func Tokenize(text string) []Token {
+	var tokens []Token
+	start := -1
+	for i, r := range text {
+		isWord := unicode.IsLetter(r) || unicode.IsDigit(r)
+		if isWord && start < 0 {
+			start = i
+		} else if !isWord && start >= 0 {
+			tokens = append(tokens, Token{Term: strings.ToLower(text[start:i]), Start: start, End: i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, Token{Term: strings.ToLower(text[start:]), Start: start, End: len(text)})
+	}
+	return tokens
+}
@@ -0,0 +1,154 @@
+package search
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aagrigore/task-board/internal/board"
+)
+
+func writeElement(t *testing.T, dir, title, ac, notes string) {
+	t.Helper()
+	os.MkdirAll(dir, 0755)
+	os.WriteFile(filepath.Join(dir, "README.md"),
+		[]byte("# "+title+"\n\n## Description\nSome description\n\n## Scope\nlib\n\n## Acceptance Criteria\n"+ac+"\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "progress.md"),
+		[]byte("## Status\nbacklog\n\n## Checklist\n- [ ] Write tests\n\n## Notes\n"+notes+"\n"), 0644)
+}
+
+// setupBoard creates an epic with a story and two tasks.
+func setupBoard(t *testing.T) string {
+	t.Helper()
+	bd := filepath.Join(t.TempDir(), ".task-board")
+	epic := filepath.Join(bd, "EPIC-260101-aaaaaa_recording")
+	story := filepath.Join(epic, "STORY-260101-bbbbbb_capture")
+	writeElement(t, epic, "Recording", "- Records audio", "")
+	writeElement(t, story, "Audio Capture", "- Captures audio from the microphone", "Microphone permission is flaky")
+	writeElement(t, filepath.Join(story, "TASK-260101-cccccc_interface"), "Recorder interface", "- Interface compiles", "Audio capture stub done")
+	writeElement(t, filepath.Join(story, "TASK-260101-dddddd_waveform"), "Waveform rendering", "- Renders waveform", "")
+	return bd
+}
+
+func TestTokenize(t *testing.T) {
+	tokens := Tokenize("Audio-Capture: TASK-12, naïve!")
+	want := []string{"audio", "capture", "task", "12", "naïve"}
+	if len(tokens) != len(want) {
+		t.Fatalf("got %d tokens, want %d: %+v", len(tokens), len(want), tokens)
+	}
+	for i, tok := range tokens {
+		if tok.Term != want[i] {
+			t.Errorf("token %d = %q, want %q", i, tok.Term, want[i])
+		}
+	}
+	if tokens[1].Start != 6 || tokens[1].End != 13 {
+		t.Errorf("capture span = %d..%d, want 6..13", tokens[1].Start, tokens[1].End)
+	}
+}
+
+func TestSyncSaveLoad(t *testing.T) {
+	bd := setupBoard(t)
+	b, err := board.Load(bd)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	ix := Load(bd)
+	if ix.Len() != 0 {
+		t.Fatalf("missing index should load empty, got %d docs", ix.Len())
+	}
+	if !ix.Sync(b) {
+		t.Fatal("first Sync should report changes")
+	}
+	if ix.Len() != 4 {
+		t.Fatalf("indexed %d docs, want 4", ix.Len())
+	}
+	if err := ix.Save(bd); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	reloaded := Load(bd)
+	if reloaded.Len() != 4 {
+		t.Fatalf("reloaded %d docs, want 4", reloaded.Len())
+	}
+	if reloaded.Sync(b) {
+		t.Error("Sync on unchanged board should report no changes")
+	}
+	if ids := reloaded.Postings["waveform"]; len(ids) != 1 || ids[0] != "TASK-260101-dddddd" {
+		t.Errorf("postings[waveform] = %v", ids)
+	}
+}
+
+func TestSyncPicksUpChanges(t *testing.T) {
+	bd := setupBoard(t)
+	b, _ := board.Load(bd)
+	ix := New()
+	ix.Sync(b)
+
+	// Edit one element and delete another
+	task := b.FindByID("TASK-260101-cccccc")
+	os.WriteFile(task.ProgressPath(), []byte("## Status\nbacklog\n\n## Notes\nSpectrogram added\n"), 0644)
+	later := time.Now().Add(2 * time.Second)
+	os.Chtimes(task.ProgressPath(), later, later)
+	os.RemoveAll(b.FindByID("TASK-260101-dddddd").Path)
+
+	b, _ = board.Load(bd)
+	if !ix.Sync(b) {
+		t.Fatal("Sync should detect modified and deleted elements")
+	}
+	if ix.Len() != 3 {
+		t.Errorf("expected 3 docs after delete, got %d", ix.Len())
+	}
+	if _, ok := ix.Postings["waveform"]; ok {
+		t.Error("terms of deleted elements must be removed")
+	}
+	if ids := ix.Postings["spectrogram"]; len(ids) != 1 {
+		t.Errorf("new note not indexed: %v", ids)
+	}
+	if _, ok := ix.Postings["stub"]; ok {
+		t.Error("old note terms must be removed on re-index")
+	}
+}
+
+func TestLoadDiscardsOtherVersion(t *testing.T) {
+	bd := setupBoard(t)
+	os.MkdirAll(filepath.Join(bd, IndexDir), 0755)
+	os.WriteFile(Path(bd), []byte(`{"version": 999, "docs": {"X": {}}}`), 0644)
+	if ix := Load(bd); ix.Len() != 0 {
+		t.Error("index with another version should be discarded")
+	}
+}
+
+func TestConcurrentSaves(t *testing.T) {
+	bd := setupBoard(t)
+	b, _ := board.Load(bd)
+	ix := Load(bd)
+	ix.Sync(b)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- ix.Save(bd)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Errorf("Save: %v", err)
+		}
+	}
+
+	if reloaded := Load(bd); reloaded.Len() != 4 {
+		t.Errorf("reloaded %d docs after concurrent saves, want 4", reloaded.Len())
+	}
+	entries, _ := os.ReadDir(filepath.Join(bd, IndexDir))
+	if len(entries) != 1 {
+		t.Errorf("only the index should be left, got %d files", len(entries))
+	}
+}
@@ -0,0 +1,355 @@
+package search
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// BM25 parameters.
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// fieldWeights boost matches in short, descriptive fields.
+var fieldWeights = map[string]float64{
+	FieldTitle:       3.0,
+	FieldAC:          1.5,
+	FieldDescription: 1.0,
+	FieldScope:       1.0,
+	FieldChecklist:   1.0,
+	FieldNotes:       1.0,
+}
+
+// fieldAliases maps query prefixes to indexed fields.
+var fieldAliases = map[string]string{
+	"title":       FieldTitle,
+	"name":        FieldTitle,
+	"description": FieldDescription,
+	"desc":        FieldDescription,
+	"scope":       FieldScope,
+	"ac":          FieldAC,
+	"checklist":   FieldChecklist,
+	"notes":       FieldNotes,
+}
+
+// Clause is one required query part: a single term or a phrase,
+// optionally restricted to one field.
+type Clause struct {
+	Field string   // "" means any field
+	Terms []string // consecutive terms; more than one means a phrase
+}
+
+// Query is a parsed search query. All clauses must match.
+type Query struct {
+	Raw     string
+	Clauses []Clause
+}
+
+// ParseQuery parses a search query.
+//
+//	audio capture             both terms, anywhere
+//	"audio capture"           exact phrase
+//	title:audio               term in the title only
+//	ac:"records audio"        phrase in the acceptance criteria
+//	notes:blocked             term in notes
+//
+// Words that tokenize into several terms (such as IDs) are treated as phrases.
+func ParseQuery(raw string) (*Query, error) {
+	q := &Query{Raw: raw}
+	i := 0
+	for i < len(raw) {
+		if raw[i] == ' ' || raw[i] == '\t' {
+			i++
+			continue
+		}
+		start := i
+		field := ""
+		// Optional field prefix
+		if colon := strings.IndexByte(raw[i:], ':'); colon > 0 {
+			prefix := raw[i : i+colon]
+			// Unknown prefixes ("TODO:", "http:") are searched as plain text
+			if f, ok := fieldAliases[strings.ToLower(prefix)]; ok && !strings.ContainsAny(prefix, " \t\"") {
+				field = f
+				i += colon + 1
+			}
+		}
+
+		var text string
+		if i < len(raw) && raw[i] == '"' {
+			end := strings.IndexByte(raw[i+1:], '"')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated quote at position %d", i+1)
+			}
+			text = raw[i+1 : i+1+end]
+			i += end + 2
+		} else {
+			for i < len(raw) && raw[i] != ' ' && raw[i] != '\t' {
+				i++
+			}
+			text = raw[start:i]
+			if field != "" {
+				text = text[strings.IndexByte(text, ':')+1:]
+			}
+		}
+
+		var terms []string
+		for _, tok := range Tokenize(text) {
+			terms = append(terms, tok.Term)
+		}
+		if len(terms) == 0 {
+			continue
+		}
+		q.Clauses = append(q.Clauses, Clause{Field: field, Terms: terms})
+	}
+	if len(q.Clauses) == 0 {
+		return nil, fmt.Errorf("empty search query")
+	}
+	return q, nil
+}
+
+// Hit is one ranked search result.
+type Hit struct {
+	ID      string
+	Score   float64
+	Field   string // field with the strongest match
+	Snippet string // excerpt of that field
+	Spans   [][2]int
+}
+
+// Highlight returns the snippet with every matched term wrapped in open/close.
+func (h Hit) Highlight(open, close string) string {
+	var b strings.Builder
+	last := 0
+	for _, s := range h.Spans {
+		b.WriteString(h.Snippet[last:s[0]])
+		b.WriteString(open)
+		b.WriteString(h.Snippet[s[0]:s[1]])
+		b.WriteString(close)
+		last = s[1]
+	}
+	b.WriteString(h.Snippet[last:])
+	return b.String()
+}
+
+// Search returns documents matching every clause, best first.
+// A limit of 0 returns all hits.
+func (ix *Index) Search(q *Query, limit int) []Hit {
+	candidates := ix.candidates(q)
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	n := float64(len(ix.Docs))
+	avgLen := ix.averageLengths()
+
+	var hits []Hit
+	for _, id := range candidates {
+		doc := ix.Docs[id]
+		score := 0.0
+		fieldScores := make(map[string]float64)
+		firstMatch := make(map[string]int)
+		matchedAll := true
+
+		for _, c := range q.Clauses {
+			df := float64(ix.docFrequency(c))
+			idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+			clauseScore := 0.0
+			for _, field := range Fields {
+				if c.Field != "" && c.Field != field {
+					continue
+				}
+				starts := phraseStarts(doc.Positions[field], c.Terms)
+				if len(starts) == 0 {
+					continue
+				}
+				tf := float64(len(starts))
+				norm := 1 - bm25B + bm25B*float64(doc.Lengths[field])/avgLen[field]
+				s := fieldWeights[field] * idf * tf * (bm25K1 + 1) / (tf + bm25K1*norm)
+				clauseScore += s
+				fieldScores[field] += s
+				if pos, ok := firstMatch[field]; !ok || starts[0] < pos {
+					firstMatch[field] = starts[0]
+				}
+			}
+			if clauseScore == 0 {
+				matchedAll = false
+				break
+			}
+			score += clauseScore
+		}
+		if !matchedAll {
+			continue
+		}
+
+		best := ""
+		for _, field := range Fields {
+			if fieldScores[field] > fieldScores[best] {
+				best = field
+			}
+		}
+		hit := Hit{ID: id, Score: score, Field: best}
+		hit.Snippet, hit.Spans = snippet(doc.Text[best], firstMatch[best], queryTerms(q))
+		hits = append(hits, hit)
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].ID < hits[j].ID
+	})
+	if limit > 0 && len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits
+}
+
+// candidates returns IDs of documents containing every query term.
+func (ix *Index) candidates(q *Query) []string {
+	var result map[string]bool
+	for _, c := range q.Clauses {
+		for _, term := range c.Terms {
+			next := make(map[string]bool)
+			for _, id := range ix.Postings[term] {
+				if result == nil || result[id] {
+					next[id] = true
+				}
+			}
+			result = next
+			if len(result) == 0 {
+				return nil
+			}
+		}
+	}
+	ids := make([]string, 0, len(result))
+	for id := range result {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// docFrequency approximates how many documents match a clause
+// by the rarest of its terms.
+func (ix *Index) docFrequency(c Clause) int {
+	df := -1
+	for _, term := range c.Terms {
+		if n := len(ix.Postings[term]); df < 0 || n < df {
+			df = n
+		}
+	}
+	return df
+}
+
+func (ix *Index) averageLengths() map[string]float64 {
+	totals := make(map[string]float64)
+	for _, doc := range ix.Docs {
+		for field, n := range doc.Lengths {
+			totals[field] += float64(n)
+		}
+	}
+	avg := make(map[string]float64)
+	for _, field := range Fields {
+		avg[field] = 1
+		if totals[field] > 0 {
+			avg[field] = totals[field] / float64(len(ix.Docs))
+		}
+	}
+	return avg
+}
+
+// phraseStarts returns the positions where terms occur consecutively.
+func phraseStarts(positions map[string][]int, terms []string) []int {
+	if positions == nil {
+		return nil
+	}
+	first := positions[terms[0]]
+	if len(terms) == 1 {
+		return first
+	}
+	var starts []int
+	for _, p := range first {
+		ok := true
+		for k := 1; k < len(terms); k++ {
+			if !containsInt(positions[terms[k]], p+k) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			starts = append(starts, p)
+		}
+	}
+	return starts
+}
+
+func containsInt(list []int, v int) bool {
+	for _, x := range list {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+func queryTerms(q *Query) map[string]bool {
+	terms := make(map[string]bool)
+	for _, c := range q.Clauses {
+		for _, t := range c.Terms {
+			terms[t] = true
+		}
+	}
+	return terms
+}
+
+// Snippet window, in tokens, around the first match.
+const (
+	snippetBefore = 6
+	snippetAfter  = 12
+)
+
+// snippet cuts a window of text around token position pos and returns it
+// with the byte spans of every query term inside the window.
+func snippet(text string, pos int, terms map[string]bool) (string, [][2]int) {
+	tokens := Tokenize(text)
+	if len(tokens) == 0 {
+		return "", nil
+	}
+	from := pos - snippetBefore
+	if from < 0 {
+		from = 0
+	}
+	to := pos + snippetAfter
+	if to >= len(tokens) {
+		to = len(tokens) - 1
+	}
+
+	start, end := tokens[from].Start, tokens[to].End
+	if from == 0 {
+		start = 0
+	}
+	if to == len(tokens)-1 {
+		end = len(text)
+	}
+
+	prefix, suffix := "", ""
+	if from > 0 {
+		prefix = "…"
+	}
+	if to < len(tokens)-1 {
+		suffix = "…"
+	}
+
+	body := strings.NewReplacer("\n", " ", "\t", " ", "\r", " ").Replace(text[start:end])
+	offset := len(prefix) - start
+	var spans [][2]int
+	for _, tok := range tokens[from : to+1] {
+		if terms[tok.Term] {
+			spans = append(spans, [2]int{tok.Start + offset, tok.End + offset})
+		}
+	}
+	return prefix + strings.TrimRight(body, " ") + suffix, spans
+}
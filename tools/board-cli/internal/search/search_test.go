@@ -0,0 +1,142 @@
+package search
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aagrigore/task-board/internal/board"
+)
+
+func indexBoard(t *testing.T) *Index {
+	t.Helper()
+	b, err := board.Load(setupBoard(t))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	ix := New()
+	ix.Sync(b)
+	return ix
+}
+
+func TestParseQuery(t *testing.T) {
+	q, err := ParseQuery(`audio title:recorder ac:"records audio" TASK-12 todo:`)
+	if err != nil {
+		t.Fatalf("ParseQuery: %v", err)
+	}
+	want := []Clause{
+		{Field: "", Terms: []string{"audio"}},
+		{Field: FieldTitle, Terms: []string{"recorder"}},
+		{Field: FieldAC, Terms: []string{"records", "audio"}},
+		{Field: "", Terms: []string{"task", "12"}},
+		{Field: "", Terms: []string{"todo"}},
+	}
+	if len(q.Clauses) != len(want) {
+		t.Fatalf("got %+v", q.Clauses)
+	}
+	for i, c := range q.Clauses {
+		if c.Field != want[i].Field || strings.Join(c.Terms, " ") != strings.Join(want[i].Terms, " ") {
+			t.Errorf("clause %d = %+v, want %+v", i, c, want[i])
+		}
+	}
+
+	for _, bad := range []string{"", "   ", `"open`, "!!"} {
+		if _, err := ParseQuery(bad); err == nil {
+			t.Errorf("ParseQuery(%q) should fail", bad)
+		}
+	}
+}
+
+func ids(hits []Hit) []string {
+	var out []string
+	for _, h := range hits {
+		out = append(out, h.ID)
+	}
+	return out
+}
+
+func TestSearchRanksTitleMatchesFirst(t *testing.T) {
+	ix := indexBoard(t)
+	q, _ := ParseQuery("capture")
+	hits := ix.Search(q, 0)
+	if len(hits) != 2 {
+		t.Fatalf("got %v, want story and task", ids(hits))
+	}
+	if hits[0].ID != "STORY-260101-bbbbbb" || hits[0].Field != FieldTitle {
+		t.Errorf("title match should rank first, got %+v", hits[0])
+	}
+	if hits[1].Field != FieldNotes {
+		t.Errorf("second hit should match in notes, got %s", hits[1].Field)
+	}
+}
+
+func TestSearchPhrase(t *testing.T) {
+	ix := indexBoard(t)
+
+	q, _ := ParseQuery(`"audio capture"`)
+	if got := ids(ix.Search(q, 0)); len(got) != 2 {
+		t.Errorf("phrase should match story title and task notes, got %v", got)
+	}
+
+	q, _ = ParseQuery(`"capture audio"`)
+	if got := ix.Search(q, 0); len(got) != 0 {
+		t.Errorf("reversed phrase should not match, got %v", ids(got))
+	}
+}
+
+func TestSearchFieldScoping(t *testing.T) {
+	ix := indexBoard(t)
+
+	q, _ := ParseQuery("notes:microphone")
+	if got := ids(ix.Search(q, 0)); len(got) != 1 || got[0] != "STORY-260101-bbbbbb" {
+		t.Errorf("notes:microphone = %v", got)
+	}
+
+	q, _ = ParseQuery("title:microphone")
+	if got := ix.Search(q, 0); len(got) != 0 {
+		t.Errorf("title:microphone should not match, got %v", ids(got))
+	}
+
+	q, _ = ParseQuery(`ac:"renders waveform"`)
+	if got := ids(ix.Search(q, 0)); len(got) != 1 || got[0] != "TASK-260101-dddddd" {
+		t.Errorf("ac phrase = %v", got)
+	}
+}
+
+func TestSearchRequiresAllClauses(t *testing.T) {
+	ix := indexBoard(t)
+	q, _ := ParseQuery("audio waveform")
+	if got := ix.Search(q, 0); len(got) != 0 {
+		t.Errorf("no element has both terms, got %v", ids(got))
+	}
+}
+
+func TestSearchLimit(t *testing.T) {
+	ix := indexBoard(t)
+	q, _ := ParseQuery("tests")
+	if got := ix.Search(q, 2); len(got) != 2 {
+		t.Errorf("limit 2 returned %d hits", len(got))
+	}
+}
+
+func TestSnippetHighlight(t *testing.T) {
+	ix := indexBoard(t)
+	q, _ := ParseQuery("notes:flaky")
+	hits := ix.Search(q, 0)
+	if len(hits) != 1 {
+		t.Fatalf("got %v", ids(hits))
+	}
+	if got := hits[0].Highlight("[", "]"); got != "Microphone permission is [flaky]" {
+		t.Errorf("highlight = %q", got)
+	}
+}
+
+func TestSnippetWindow(t *testing.T) {
+	text := "one two three four five six seven eight nine ten eleven twelve thirteen fourteen fifteen sixteen seventeen eighteen nineteen twenty twentyone twentytwo twentythree twentyfour"
+	snip, spans := snippet(text, 10, map[string]bool{"eleven": true})
+	if !strings.HasPrefix(snip, "…five") || !strings.HasSuffix(snip, "…") {
+		t.Errorf("unexpected window %q", snip)
+	}
+	if len(spans) != 1 || snip[spans[0][0]:spans[0][1]] != "eleven" {
+		t.Errorf("span does not point at the term: %v in %q", spans, snip)
+	}
+}
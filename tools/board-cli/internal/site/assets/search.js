@@ -0,0 +1,78 @@
+// Client-side search over BOARD_INDEX (index.js): every word typed must
+// appear in an element's ID, name, status or text.
+(function () {
+  var input = document.getElementById("search");
+  var results = document.getElementById("results");
+  var root = document.body.getAttribute("data-root") || "";
+  var index = (window.BOARD_INDEX || []).map(function (e) {
+    return { entry: e, haystack: [e.id, e.name, e.status, e.text].join(" ").toLowerCase() };
+  });
+  var active = -1;
+
+  function search(query) {
+    var words = query.toLowerCase().split(/\s+/).filter(Boolean);
+    if (words.length === 0) {
+      return null;
+    }
+    return index.filter(function (item) {
+      return words.every(function (w) { return item.haystack.indexOf(w) >= 0; });
+    }).slice(0, 30);
+  }
+
+  function show(matches) {
+    results.innerHTML = "";
+    active = -1;
+    if (matches === null) {
+      results.hidden = true;
+      return;
+    }
+    if (matches.length === 0) {
+      var none = document.createElement("li");
+      none.className = "empty";
+      none.textContent = "No matches";
+      results.appendChild(none);
+    }
+    matches.forEach(function (item) {
+      var e = item.entry;
+      var li = document.createElement("li");
+      var a = document.createElement("a");
+      a.href = root + e.url;
+      var id = document.createElement("span");
+      id.className = "rid";
+      id.textContent = e.id;
+      a.appendChild(id);
+      a.appendChild(document.createTextNode(e.name + " · " + e.status));
+      li.appendChild(a);
+      results.appendChild(li);
+    });
+    results.hidden = false;
+  }
+
+  function move(delta) {
+    var items = results.querySelectorAll("li a");
+    if (items.length === 0) {
+      return;
+    }
+    if (active >= 0) {
+      items[active].parentNode.classList.remove("active");
+    }
+    active = (active + delta + items.length) % items.length;
+    items[active].parentNode.classList.add("active");
+    items[active].scrollIntoView({ block: "nearest" });
+  }
+
+  input.addEventListener("input", function () { show(search(input.value)); });
+  input.addEventListener("keydown", function (ev) {
+    if (ev.key === "ArrowDown") { move(1); ev.preventDefault(); }
+    if (ev.key === "ArrowUp") { move(-1); ev.preventDefault(); }
+    if (ev.key === "Enter") {
+      var items = results.querySelectorAll("li a");
+      var target = items[active >= 0 ? active : 0];
+      if (target) { window.location.href = target.href; }
+    }
+    if (ev.key === "Escape") { input.value = ""; show(null); }
+  });
+  document.addEventListener("click", function (ev) {
+    if (!results.contains(ev.target) && ev.target !== input) { results.hidden = true; }
+  });
+})();
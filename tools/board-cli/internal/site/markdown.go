@@ -0,0 +1,22 @@
+package site
+
+import (
+	"bytes"
+	"html/template"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+// md renders GitHub-flavoured markdown. Raw HTML in the source is left
+// out, so board content can't inject scripts into the report.
+var md = goldmark.New(goldmark.WithExtensions(extension.GFM))
+
+// Markdown renders markdown as HTML, falling back to escaped text.
+func Markdown(src string) template.HTML {
+	var b bytes.Buffer
+	if err := md.Convert([]byte(src), &b); err != nil {
+		return template.HTML("<pre>" + template.HTMLEscapeString(src) + "</pre>")
+	}
+	return template.HTML(b.String())
+}
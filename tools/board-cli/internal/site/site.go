@@ -0,0 +1,242 @@
+// Package site writes the board as a static HTML site that works offline:
+// an index of epics, a page per element, the plan graphs and a client-side
+// search over a generated JSON index. The data is gathered by the report
+// html command; this package only lays it out.
+package site
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/aagrigore/task-board/internal/board"
+)
+
+//go:embed assets
+var assets embed.FS
+
+// Site is everything the report shows.
+type Site struct {
+	Title     string
+	Generated time.Time
+	// Progress counts the tasks and bugs of the whole board.
+	Progress Progress
+	Epics    []Summary
+	// Plan is the SVG of the project plan, "" for none.
+	Plan  string
+	Pages []*Page
+	// Tree is the tree --json response, written to tree.json.
+	Tree any
+}
+
+// Progress is finished work out of the total.
+type Progress struct {
+	Done  int `json:"done"`
+	Total int `json:"total"`
+}
+
+// Percent returns Done as a whole percentage of Total.
+func (p Progress) Percent() int {
+	if p.Total == 0 {
+		return 0
+	}
+	return p.Done * 100 / p.Total
+}
+
+// Status is a status with its workflow colour.
+type Status struct {
+	Name  string
+	Color string
+	Dark  bool // the colour needs light text
+}
+
+// Link points to an element's page.
+type Link struct {
+	ID     string
+	Name   string
+	Status Status
+}
+
+// Summary is a row in a list of elements.
+type Summary struct {
+	Link
+	Type     string
+	Assignee string
+	Progress Progress
+}
+
+// ChecklistItem is one checklist line.
+type ChecklistItem struct {
+	Text string
+	Done bool
+}
+
+// Page is one element's page.
+type Page struct {
+	ID       string
+	Type     string
+	Name     string
+	Status   Status
+	Assignee string
+	Created  string
+	Updated  string
+	// Crumbs are the ancestors, outermost first.
+	Crumbs    []Link
+	Progress  Progress
+	Readme    string // markdown, without the title line
+	Checklist []ChecklistItem
+	BlockedBy []Link
+	Blocks    []Link
+	Children  []Summary
+	History   []board.StatusChange
+	Notes     string // markdown
+	Plan      string // SVG of the element's plan, "" for none
+	// Data is the show --json element, written to data/<ID>.json.
+	Data any
+}
+
+// frame is what the header and footer of every page need: the path back to
+// the site root and the page title.
+type frame struct {
+	Root  string
+	Title string
+	Site  *Site
+}
+
+// indexEntry is one element in the search index.
+type indexEntry struct {
+	ID     string `json:"id"`
+	Type   string `json:"type"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	URL    string `json:"url"` // relative to the site root
+	Text   string `json:"text"`
+}
+
+var unsafeName = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+
+// fileName turns an element ID into a file name; workspace IDs carry a
+// board prefix with a colon.
+func fileName(id string) string {
+	return unsafeName.ReplaceAllString(id, "_")
+}
+
+// Write generates the site into dir, creating it if needed. Files from an
+// earlier run are overwritten; others are left alone.
+func Write(dir string, s *Site) error {
+	tmpl, err := template.New("site").Funcs(template.FuncMap{
+		"markdown": Markdown,
+		"frame": func(root, title string, s *Site) frame {
+			return frame{Root: root, Title: title, Site: s}
+		},
+		"page": func(id string) string { return "e/" + fileName(id) + ".html" },
+		"file": fileName,
+		"date": func(t time.Time) string {
+			return t.UTC().Format("2006-01-02 15:04")
+		},
+	}).ParseFS(assets, "assets/*.html")
+	if err != nil {
+		return fmt.Errorf("parsing templates: %w", err)
+	}
+	for _, sub := range []string{"e", "data", "plans"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0755); err != nil {
+			return fmt.Errorf("creating %s: %w", sub, err)
+		}
+	}
+
+	write := func(name string, data []byte) error {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0644); err != nil {
+			return fmt.Errorf("writing %s: %w", name, err)
+		}
+		return nil
+	}
+	render := func(name, tmplName string, data any) error {
+		var b strings.Builder
+		if err := tmpl.ExecuteTemplate(&b, tmplName, data); err != nil {
+			return fmt.Errorf("rendering %s: %w", name, err)
+		}
+		return write(name, []byte(b.String()))
+	}
+	writeJSON := func(name string, v any) error {
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return fmt.Errorf("encoding %s: %w", name, err)
+		}
+		return write(name, append(data, '\n'))
+	}
+
+	for _, asset := range []string{"style.css", "search.js"} {
+		data, err := assets.ReadFile("assets/" + asset)
+		if err != nil {
+			return err
+		}
+		if err := write(asset, data); err != nil {
+			return err
+		}
+	}
+
+	if s.Plan != "" {
+		if err := write("plans/board.svg", []byte(s.Plan)); err != nil {
+			return err
+		}
+	}
+	if err := render("index.html", "index.html", s); err != nil {
+		return err
+	}
+	if err := writeJSON("tree.json", s.Tree); err != nil {
+		return err
+	}
+
+	index := make([]indexEntry, 0, len(s.Pages))
+	for _, p := range s.Pages {
+		name := fileName(p.ID)
+		if p.Plan != "" {
+			if err := write("plans/"+name+".svg", []byte(p.Plan)); err != nil {
+				return err
+			}
+		}
+		if err := render("e/"+name+".html", "element.html", struct {
+			*Page
+			Site *Site
+		}{p, s}); err != nil {
+			return err
+		}
+		if err := writeJSON("data/"+name+".json", p.Data); err != nil {
+			return err
+		}
+		index = append(index, indexEntry{
+			ID:     p.ID,
+			Type:   p.Type,
+			Name:   p.Name,
+			Status: p.Status.Name,
+			URL:    "e/" + name + ".html",
+			Text:   searchText(p),
+		})
+	}
+
+	// index.json is for tools; index.js loads the same data from a page
+	// opened from disk, where browsers refuse to fetch local files
+	if err := writeJSON("index.json", index); err != nil {
+		return err
+	}
+	data, err := json.Marshal(index)
+	if err != nil {
+		return fmt.Errorf("encoding index: %w", err)
+	}
+	return write("index.js", []byte("var BOARD_INDEX = "+string(data)+";\n"))
+}
+
+// searchText is what the search matches besides ID, name and status.
+func searchText(p *Page) string {
+	parts := []string{p.Type, p.Assignee, p.Readme, p.Notes}
+	for _, item := range p.Checklist {
+		parts = append(parts, item.Text)
+	}
+	return strings.Join(strings.Fields(strings.Join(parts, " ")), " ")
+}
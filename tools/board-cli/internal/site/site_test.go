@@ -0,0 +1,76 @@
+package site
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMarkdownDropsRawHTML(t *testing.T) {
+	got := string(Markdown("## Scope\n\n- [x] **done**\n\n<script>alert(1)</script>\n"))
+	if !strings.Contains(got, "<h2>Scope</h2>") || !strings.Contains(got, "<strong>done</strong>") {
+		t.Errorf("markdown not rendered: %s", got)
+	}
+	if strings.Contains(got, "<script>") {
+		t.Errorf("raw HTML should be dropped: %s", got)
+	}
+}
+
+func TestFileName(t *testing.T) {
+	if got := fileName("api:TASK-260101-aaaaaa"); got != "api_TASK-260101-aaaaaa" {
+		t.Errorf("fileName = %q", got)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	dir := t.TempDir()
+	s := &Site{
+		Title:     "Demo <board>",
+		Generated: time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC),
+		Progress:  Progress{Done: 1, Total: 4},
+		Pages: []*Page{{
+			ID:        "TASK-01",
+			Type:      "task",
+			Name:      "Write the parser",
+			Status:    Status{Name: "closed", Color: "#6c757d", Dark: true},
+			Crumbs:    []Link{{ID: "EPIC-01"}, {ID: "STORY-01"}},
+			Readme:    "## Description\nParse `progress.md`.",
+			Checklist: []ChecklistItem{{Text: "lexer", Done: true}, {Text: "parser"}},
+			Data:      map[string]string{"id": "TASK-01"},
+		}},
+	}
+	if err := Write(dir, s); err != nil {
+		t.Fatal(err)
+	}
+
+	index, _ := os.ReadFile(filepath.Join(dir, "index.html"))
+	if !strings.Contains(string(index), "<h1>Demo &lt;board&gt;</h1>") || !strings.Contains(string(index), "width:25%") {
+		t.Errorf("index.html:\n%s", index)
+	}
+	page, err := os.ReadFile(filepath.Join(dir, "e", "TASK-01.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`<a href="EPIC-01.html">EPIC-01</a> › <a href="STORY-01.html">STORY-01</a> › TASK-01`,
+		`<code>progress.md</code>`,
+		`<input type="checkbox" disabled checked> lexer`,
+		`class="status dark" style="background:#6c757d"`,
+		`<script src="../index.js">`,
+	} {
+		if !strings.Contains(string(page), want) {
+			t.Errorf("page missing %q:\n%s", want, page)
+		}
+	}
+	for _, name := range []string{"style.css", "search.js", "index.json", "data/TASK-01.json"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+	index, _ = os.ReadFile(filepath.Join(dir, "index.js"))
+	if !strings.Contains(string(index), `"text":"task ## Description Parse `) {
+		t.Errorf("index.js = %s", index)
+	}
+}
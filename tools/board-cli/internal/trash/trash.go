@@ -0,0 +1,139 @@
+// Package trash keeps deleted element directories in .task-board/.trash
+// until they are restored or purged.
+package trash
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Dir is the trash directory inside the board directory.
+const Dir = ".trash"
+
+// metaFile describes a trashed element next to its directory.
+const metaFile = "trash.json"
+
+// Ref is a dependency reference that delete removed from another element.
+type Ref struct {
+	Element   string `json:"element"`   // element the reference was removed from
+	Target    string `json:"target"`    // deleted element it pointed to
+	BlockedBy bool   `json:"blockedBy"` // from its Blocked By list, else Blocks
+	// LinkType is set for a typed link, from the element's Links
+	LinkType string `json:"linkType,omitempty"`
+}
+
+// Item is one trashed element with its descendants.
+type Item struct {
+	// Key names the item's directory in the trash.
+	Key   string `json:"key"`
+	ID    string `json:"id"`
+	Type  string `json:"type"`
+	Title string `json:"title"`
+	// OriginalPath is the element directory relative to the board directory.
+	OriginalPath string    `json:"originalPath"`
+	DeletedAt    time.Time `json:"deletedAt"`
+	// Contains lists the IDs of the element and its descendants.
+	Contains []string `json:"contains"`
+	// Stripped lists the references delete removed from other elements.
+	Stripped []Ref `json:"stripped"`
+}
+
+// Put moves the element directory at elemPath into the trash and records
+// item next to it. Key and DeletedAt are filled in.
+func Put(boardDir, elemPath string, item *Item) error {
+	rel, err := filepath.Rel(boardDir, elemPath)
+	if err != nil {
+		return err
+	}
+	item.OriginalPath = rel
+	item.DeletedAt = time.Now().UTC()
+	item.Key = fmt.Sprintf("%s_%s", item.DeletedAt.Format("20060102-150405.000"), item.ID)
+
+	dir := filepath.Join(boardDir, Dir, item.Key)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("creating trash entry: %w", err)
+	}
+	data, err := json.MarshalIndent(item, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, metaFile), data, 0644); err != nil {
+		return fmt.Errorf("writing trash entry: %w", err)
+	}
+	if err := os.Rename(elemPath, filepath.Join(dir, filepath.Base(elemPath))); err != nil {
+		os.RemoveAll(dir)
+		return fmt.Errorf("moving %s to trash: %w", item.ID, err)
+	}
+	return nil
+}
+
+// List returns the trashed items, most recently deleted first.
+func List(boardDir string) ([]*Item, error) {
+	entries, err := os.ReadDir(filepath.Join(boardDir, Dir))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading trash: %w", err)
+	}
+	var items []*Item
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(boardDir, Dir, entry.Name(), metaFile))
+		if err != nil {
+			continue // not a trash entry
+		}
+		var item Item
+		if err := json.Unmarshal(data, &item); err != nil {
+			return nil, fmt.Errorf("reading trash entry %s: %w", entry.Name(), err)
+		}
+		item.Key = entry.Name()
+		items = append(items, &item)
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].DeletedAt.After(items[j].DeletedAt) })
+	return items, nil
+}
+
+// Find returns the most recently trashed item for an element ID or trash
+// key, or nil.
+func Find(boardDir, ref string) (*Item, error) {
+	items, err := List(boardDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		if strings.EqualFold(item.ID, ref) || item.Key == ref {
+			return item, nil
+		}
+	}
+	return nil, nil
+}
+
+// Restore moves an item's element directory back to its original place
+// and removes it from the trash. The parent directory must exist.
+func Restore(boardDir string, item *Item) error {
+	dest := filepath.Join(boardDir, item.OriginalPath)
+	if _, err := os.Stat(filepath.Dir(dest)); err != nil {
+		return fmt.Errorf("parent of %s no longer exists (%s)", item.ID, filepath.Dir(item.OriginalPath))
+	}
+	if _, err := os.Stat(dest); err == nil {
+		return fmt.Errorf("%s already exists", item.OriginalPath)
+	}
+	dir := filepath.Join(boardDir, Dir, item.Key)
+	if err := os.Rename(filepath.Join(dir, filepath.Base(dest)), dest); err != nil {
+		return fmt.Errorf("restoring %s: %w", item.ID, err)
+	}
+	return os.RemoveAll(dir)
+}
+
+// Purge deletes an item from the trash for good.
+func Purge(boardDir string, item *Item) error {
+	return os.RemoveAll(filepath.Join(boardDir, Dir, item.Key))
+}
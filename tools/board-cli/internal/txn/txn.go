@@ -0,0 +1,323 @@
+// Package txn runs a series of board writes as one unit: it takes the board
+// lock, journals the board files, and restores them if the writes fail.
+// Every board-changing command holds the same lock, so snapshots taken
+// under it also tell what that command changed.
+package txn
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// LockFile is the lock file name inside the board directory.
+const LockFile = ".lock"
+
+// staleAfter is how old a lock may get before it is assumed abandoned
+// by a crashed process and taken over.
+const staleAfter = time.Hour
+
+// ErrLocked is returned by Acquire and Begin when another process holds the
+// lock.
+var ErrLocked = errors.New("board is locked by another command")
+
+// retryEvery is how often Acquire tries again while it waits.
+const retryEvery = 50 * time.Millisecond
+
+// skipDirs are board subdirectories never journaled: derived data and
+// renders, the trash, which only delete, trash restore and trash purge
+// change, and the undo history itself.
+var skipDirs = map[string]bool{".index": true, ".temp": true, ".trash": true, ".history": true}
+
+// Lock is the board lock held by this process.
+type Lock struct {
+	path string // empty when the board did not exist yet
+}
+
+// Acquire locks a board directory, waiting up to wait for another process
+// to release it. A board directory that does not exist yet has nothing to
+// protect and is not locked.
+func Acquire(boardDir string, wait time.Duration) (*Lock, error) {
+	if _, err := os.Stat(boardDir); os.IsNotExist(err) {
+		return &Lock{}, nil
+	}
+	lockPath := filepath.Join(boardDir, LockFile)
+	deadline := time.Now().Add(wait)
+	for {
+		err := acquire(lockPath)
+		if err == nil {
+			return &Lock{path: lockPath}, nil
+		}
+		if !errors.Is(err, ErrLocked) || time.Now().After(deadline) {
+			return nil, err
+		}
+		time.Sleep(retryEvery)
+	}
+}
+
+// Release unlocks the board.
+func (l *Lock) Release() error {
+	if l == nil || l.path == "" {
+		return nil
+	}
+	path := l.path
+	l.path = ""
+	return os.Remove(path)
+}
+
+// Tx is an open transaction on a board directory.
+type Tx struct {
+	snap *Snapshot
+	lock *Lock // nil when the caller holds the lock
+	done bool
+}
+
+// Begin locks the board directory and journals its current content.
+func Begin(boardDir string) (*Tx, error) {
+	lock, err := Acquire(boardDir, 0)
+	if err != nil {
+		return nil, err
+	}
+	snap, err := TakeSnapshot(boardDir)
+	if err != nil {
+		lock.Release()
+		return nil, err
+	}
+	return &Tx{snap: snap, lock: lock}, nil
+}
+
+// Journal starts a transaction for a caller that already holds the board
+// lock; Commit and Rollback leave the lock alone.
+func Journal(boardDir string) (*Tx, error) {
+	snap, err := TakeSnapshot(boardDir)
+	if err != nil {
+		return nil, err
+	}
+	return &Tx{snap: snap}, nil
+}
+
+// Commit keeps all writes and releases the lock.
+func (tx *Tx) Commit() error {
+	if tx.done {
+		return nil
+	}
+	tx.done = true
+	return tx.lock.Release()
+}
+
+// Rollback restores the board to its state at Begin and releases the lock.
+func (tx *Tx) Rollback() error {
+	if tx.done {
+		return nil
+	}
+	tx.done = true
+	defer tx.lock.Release()
+	return tx.snap.Restore()
+}
+
+// Snapshot holds the content of a board directory at one point in time.
+type Snapshot struct {
+	boardDir string
+	// files holds the content of every journaled file, by path.
+	files map[string][]byte
+	// dirs holds every journaled directory.
+	dirs map[string]bool
+}
+
+// TakeSnapshot journals the current content of a board directory without
+// locking it.
+func TakeSnapshot(boardDir string) (*Snapshot, error) {
+	s := &Snapshot{
+		boardDir: boardDir,
+		files:    make(map[string][]byte),
+		dirs:     make(map[string]bool),
+	}
+	err := walk(boardDir, func(path string, d fs.DirEntry) error {
+		if d.IsDir() {
+			s.dirs[path] = true
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		s.files[path] = data
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("journaling board: %w", err)
+	}
+	return s, nil
+}
+
+// Changes lists what happened to a board directory since a snapshot.
+// Paths are relative to the board directory.
+type Changes struct {
+	// Before holds the earlier content of modified and deleted files.
+	Before map[string][]byte
+	// Created lists files that did not exist at snapshot time.
+	Created []string
+	// CreatedDirs and DeletedDirs list directories added and removed.
+	CreatedDirs []string
+	DeletedDirs []string
+}
+
+// Empty reports whether nothing changed.
+func (c *Changes) Empty() bool {
+	return len(c.Before) == 0 && len(c.Created) == 0 && len(c.CreatedDirs) == 0 && len(c.DeletedDirs) == 0
+}
+
+// Changes compares the board directory with the snapshot.
+func (s *Snapshot) Changes() (*Changes, error) {
+	c := &Changes{Before: make(map[string][]byte)}
+	seenFiles := make(map[string]bool)
+	seenDirs := make(map[string]bool)
+	err := walk(s.boardDir, func(path string, d fs.DirEntry) error {
+		rel, err := filepath.Rel(s.boardDir, path)
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			seenDirs[path] = true
+			if !s.dirs[path] {
+				c.CreatedDirs = append(c.CreatedDirs, rel)
+			}
+			return nil
+		}
+		seenFiles[path] = true
+		before, existed := s.files[path]
+		if !existed {
+			c.Created = append(c.Created, rel)
+			return nil
+		}
+		current, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(current, before) {
+			c.Before[rel] = before
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("comparing board: %w", err)
+	}
+	for path, data := range s.files {
+		if !seenFiles[path] {
+			rel, _ := filepath.Rel(s.boardDir, path)
+			c.Before[rel] = data
+		}
+	}
+	for path := range s.dirs {
+		if !seenDirs[path] {
+			rel, _ := filepath.Rel(s.boardDir, path)
+			c.DeletedDirs = append(c.DeletedDirs, rel)
+		}
+	}
+	sort.Strings(c.Created)
+	sort.Strings(c.CreatedDirs)
+	sort.Strings(c.DeletedDirs)
+	return c, nil
+}
+
+// Restore returns the board directory to the snapshot: files created since
+// are removed, modified or deleted ones are rewritten.
+func (s *Snapshot) Restore() error {
+	var newDirs []string
+	err := walk(s.boardDir, func(path string, d fs.DirEntry) error {
+		if d.IsDir() {
+			if !s.dirs[path] {
+				newDirs = append(newDirs, path)
+			}
+			return nil
+		}
+		if _, ok := s.files[path]; !ok {
+			return os.Remove(path)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("rolling back: %w", err)
+	}
+
+	// Deepest directories first so parents are empty when removed
+	sort.Slice(newDirs, func(i, j int) bool { return len(newDirs[i]) > len(newDirs[j]) })
+	for _, dir := range newDirs {
+		if err := os.Remove(dir); err != nil {
+			return fmt.Errorf("rolling back: %w", err)
+		}
+	}
+
+	for dir := range s.dirs {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("rolling back: %w", err)
+		}
+	}
+	for path, data := range s.files {
+		current, err := os.ReadFile(path)
+		if err == nil && bytes.Equal(current, data) {
+			continue
+		}
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			return fmt.Errorf("rolling back: %w", err)
+		}
+	}
+	return nil
+}
+
+// walk visits every journaled path below the board directory, excluding
+// the board directory itself, the lock file and skipped directories.
+// A board directory that does not exist yet is empty.
+func walk(boardDir string, fn func(path string, d fs.DirEntry) error) error {
+	if _, err := os.Stat(boardDir); os.IsNotExist(err) {
+		return nil
+	}
+	lockPath := filepath.Join(boardDir, LockFile)
+	return filepath.WalkDir(boardDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == boardDir || path == lockPath {
+			return nil
+		}
+		if d.IsDir() && skipDirs[d.Name()] {
+			return filepath.SkipDir
+		}
+		return fn(path, d)
+	})
+}
+
+// acquire creates the lock file, taking over locks older than staleAfter.
+func acquire(lockPath string) error {
+	for attempt := 0; attempt < 2; attempt++ {
+		f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			fmt.Fprintf(f, "pid %d\nsince %s\n", os.Getpid(), time.Now().UTC().Format(time.RFC3339))
+			return f.Close()
+		}
+		if !os.IsExist(err) {
+			return fmt.Errorf("creating lock: %w", err)
+		}
+		info, statErr := os.Stat(lockPath)
+		if statErr != nil || time.Since(info.ModTime()) < staleAfter {
+			return fmt.Errorf("%w (%s)", ErrLocked, holder(lockPath))
+		}
+		os.Remove(lockPath)
+	}
+	return fmt.Errorf("%w (%s)", ErrLocked, holder(lockPath))
+}
+
+// holder describes the lock owner for error messages.
+func holder(lockPath string) string {
+	data, err := os.ReadFile(lockPath)
+	if err != nil {
+		return lockPath
+	}
+	return fmt.Sprintf("%s: %s", lockPath, strings.Join(strings.Fields(string(data)), " "))
+}
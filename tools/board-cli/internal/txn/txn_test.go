@@ -0,0 +1,175 @@
+package txn
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func setupDir(t *testing.T) string {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), ".task-board")
+	os.MkdirAll(filepath.Join(dir, "EPIC-1_a"), 0755)
+	os.WriteFile(filepath.Join(dir, "EPIC-1_a", "README.md"), []byte("readme"), 0644)
+	os.WriteFile(filepath.Join(dir, "EPIC-1_a", "progress.md"), []byte("progress"), 0644)
+	return dir
+}
+
+func TestRollbackRestoresBoard(t *testing.T) {
+	dir := setupDir(t)
+	tx, err := Begin(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Modify, delete and create
+	os.WriteFile(filepath.Join(dir, "EPIC-1_a", "README.md"), []byte("changed"), 0644)
+	os.Remove(filepath.Join(dir, "EPIC-1_a", "progress.md"))
+	os.MkdirAll(filepath.Join(dir, "EPIC-1_a", "STORY-2_b"), 0755)
+	os.WriteFile(filepath.Join(dir, "EPIC-1_a", "STORY-2_b", "README.md"), []byte("new"), 0644)
+
+	if err := tx.Rollback(); err != nil {
+		t.Fatalf("Rollback: %v", err)
+	}
+
+	if data, _ := os.ReadFile(filepath.Join(dir, "EPIC-1_a", "README.md")); string(data) != "readme" {
+		t.Errorf("modified file not restored: %q", data)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "EPIC-1_a", "progress.md")); string(data) != "progress" {
+		t.Errorf("deleted file not restored: %q", data)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "EPIC-1_a", "STORY-2_b")); !os.IsNotExist(err) {
+		t.Error("created directory should be removed")
+	}
+	if _, err := os.Stat(filepath.Join(dir, LockFile)); !os.IsNotExist(err) {
+		t.Error("lock should be released")
+	}
+}
+
+func TestCommitKeepsChanges(t *testing.T) {
+	dir := setupDir(t)
+	tx, err := Begin(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(filepath.Join(dir, "EPIC-1_a", "README.md"), []byte("changed"), 0644)
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	// Rollback after commit is a no-op
+	tx.Rollback()
+	if data, _ := os.ReadFile(filepath.Join(dir, "EPIC-1_a", "README.md")); string(data) != "changed" {
+		t.Errorf("committed change lost: %q", data)
+	}
+}
+
+func TestLockExcludesSecondTransaction(t *testing.T) {
+	dir := setupDir(t)
+	tx, err := Begin(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Begin(dir); !errors.Is(err, ErrLocked) {
+		t.Errorf("second Begin should fail with ErrLocked, got %v", err)
+	}
+	tx.Commit()
+	tx2, err := Begin(dir)
+	if err != nil {
+		t.Fatalf("Begin after Commit: %v", err)
+	}
+	tx2.Commit()
+}
+
+func TestAcquireWaitsForRelease(t *testing.T) {
+	dir := setupDir(t)
+	held, err := Acquire(dir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Acquire(dir, 0); !errors.Is(err, ErrLocked) {
+		t.Fatalf("second Acquire should fail with ErrLocked, got %v", err)
+	}
+	time.AfterFunc(2*retryEvery, func() { held.Release() })
+	lock, err := Acquire(dir, time.Second)
+	if err != nil {
+		t.Fatalf("Acquire should get the lock once released: %v", err)
+	}
+	lock.Release()
+}
+
+func TestStaleLockTakenOver(t *testing.T) {
+	dir := setupDir(t)
+	lock := filepath.Join(dir, LockFile)
+	os.WriteFile(lock, []byte("pid 1\n"), 0644)
+	old := time.Now().Add(-2 * staleAfter)
+	os.Chtimes(lock, old, old)
+
+	tx, err := Begin(dir)
+	if err != nil {
+		t.Fatalf("stale lock should be taken over: %v", err)
+	}
+	tx.Commit()
+}
+
+func TestIndexNotJournaled(t *testing.T) {
+	dir := setupDir(t)
+	tx, err := Begin(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.MkdirAll(filepath.Join(dir, ".index"), 0755)
+	os.WriteFile(filepath.Join(dir, ".index", "index.json"), []byte("{}"), 0644)
+	tx.Rollback()
+	if _, err := os.Stat(filepath.Join(dir, ".index", "index.json")); err != nil {
+		t.Error("derived index should be left alone")
+	}
+}
+
+func TestSnapshotChanges(t *testing.T) {
+	dir := setupDir(t)
+	snap, err := TakeSnapshot(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c, _ := snap.Changes(); !c.Empty() {
+		t.Fatalf("nothing changed yet, got %+v", c)
+	}
+
+	os.WriteFile(filepath.Join(dir, "EPIC-1_a", "README.md"), []byte("changed"), 0644)
+	os.MkdirAll(filepath.Join(dir, "EPIC-1_a", "STORY-2_b"), 0755)
+	os.WriteFile(filepath.Join(dir, "EPIC-1_a", "STORY-2_b", "README.md"), []byte("new"), 0644)
+	for _, skipped := range []string{".history", ".trash", ".temp"} {
+		os.MkdirAll(filepath.Join(dir, skipped), 0755)
+		os.WriteFile(filepath.Join(dir, skipped, "file"), []byte("{}"), 0644)
+	}
+
+	c, err := snap.Changes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(c.Before[filepath.Join("EPIC-1_a", "README.md")]) != "readme" || len(c.Before) != 1 {
+		t.Errorf("Before = %v", c.Before)
+	}
+	if len(c.Created) != 1 || c.Created[0] != filepath.Join("EPIC-1_a", "STORY-2_b", "README.md") {
+		t.Errorf("Created = %v", c.Created)
+	}
+	if len(c.CreatedDirs) != 1 || len(c.DeletedDirs) != 0 {
+		t.Errorf("dirs = %v / %v", c.CreatedDirs, c.DeletedDirs)
+	}
+}
+
+func TestSnapshotOfMissingBoard(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), ".task-board")
+	snap, err := TakeSnapshot(dir)
+	if err != nil {
+		t.Fatalf("missing board should snapshot as empty: %v", err)
+	}
+	os.MkdirAll(filepath.Join(dir, "EPIC-1_a"), 0755)
+	os.WriteFile(filepath.Join(dir, "EPIC-1_a", "README.md"), []byte("readme"), 0644)
+	c, _ := snap.Changes()
+	if len(c.Created) != 1 || len(c.CreatedDirs) != 1 {
+		t.Errorf("changes = %+v", c)
+	}
+}
@@ -0,0 +1,170 @@
+// Package wip measures work in progress against the limits set in the
+// board's workflow.
+package wip
+
+import (
+	"sort"
+
+	"github.com/aagrigore/task-board/internal/board"
+)
+
+// Scope is what a limit applies to.
+type Scope string
+
+const (
+	ScopeStatus   Scope = "status"
+	ScopeStory    Scope = "story"
+	ScopeAssignee Scope = "assignee"
+)
+
+// Usage is the current load of one limited status, story or assignee.
+type Usage struct {
+	Scope Scope    `json:"scope"`
+	Key   string   `json:"key"` // status, story ID or agent name
+	Count int      `json:"count"`
+	Limit int      `json:"limit"`
+	IDs   []string `json:"ids"`
+}
+
+// Over reports whether the load exceeds the limit.
+func (u Usage) Over() bool {
+	return u.Count > u.Limit
+}
+
+type bucket struct {
+	scope Scope
+	key   string
+}
+
+// buckets returns the limited buckets e counts towards. Status and story
+// limits count tasks and bugs only, so parents moved by auto-promotion
+// don't use up a column.
+func buckets(b *board.Board, e *board.Element) []bucket {
+	limits := b.Workflow.Limits()
+	var result []bucket
+	work := e.Type == board.TaskType || e.Type == board.BugType
+	if work && limits.Status[e.Status] > 0 {
+		result = append(result, bucket{ScopeStatus, string(e.Status)})
+	}
+	if work && limits.Story > 0 && b.Workflow.Category(e) == board.CategoryActive {
+		if parent := b.FindByID(e.ParentID); parent != nil && parent.Type == board.StoryType {
+			result = append(result, bucket{ScopeStory, parent.ID()})
+		}
+	}
+	if e.AssignedTo != "" && !b.Workflow.Finished(e) && limits.AssigneeLimit(e.AssignedTo) > 0 {
+		result = append(result, bucket{ScopeAssignee, e.AssignedTo})
+	}
+	return result
+}
+
+func limitOf(b *board.Board, k bucket) int {
+	limits := b.Workflow.Limits()
+	switch k.scope {
+	case ScopeStatus:
+		return limits.Status[board.Status(k.key)]
+	case ScopeStory:
+		return limits.Story
+	default:
+		return limits.AssigneeLimit(k.key)
+	}
+}
+
+// Usages returns the load of every limited status (in workflow order), then
+// of the stories and assignees that have any load or their own limit.
+func Usages(b *board.Board) []Usage {
+	byBucket := map[bucket]*Usage{}
+	var statuses, others []*Usage
+	add := func(k bucket) *Usage {
+		u, ok := byBucket[k]
+		if !ok {
+			u = &Usage{Scope: k.scope, Key: k.key, Limit: limitOf(b, k), IDs: []string{}}
+			byBucket[k] = u
+			if k.scope == ScopeStatus {
+				statuses = append(statuses, u)
+			} else {
+				others = append(others, u)
+			}
+		}
+		return u
+	}
+
+	for _, s := range b.Workflow.Statuses() {
+		if b.Workflow.Limits().Status[s.Name] > 0 {
+			add(bucket{ScopeStatus, string(s.Name)})
+		}
+	}
+	for agent, n := range b.Workflow.Limits().Assignees {
+		if n > 0 {
+			add(bucket{ScopeAssignee, agent})
+		}
+	}
+	for _, e := range b.Elements {
+		for _, k := range buckets(b, e) {
+			u := add(k)
+			u.Count++
+			u.IDs = append(u.IDs, e.ID())
+		}
+	}
+
+	sort.Slice(others, func(i, j int) bool {
+		if others[i].Scope != others[j].Scope {
+			return others[i].Scope == ScopeStory
+		}
+		return others[i].Key < others[j].Key
+	})
+	result := make([]Usage, 0, len(statuses)+len(others))
+	for _, u := range append(statuses, others...) {
+		result = append(result, *u)
+	}
+	return result
+}
+
+// Check returns the limits e would exceed if its status and assignee were
+// changed to the ones given. Limits e already counts towards are not
+// reported, so moving within a full column is allowed.
+func Check(b *board.Board, e *board.Element, status board.Status, assignee string) []Usage {
+	changed := *e
+	changed.Status = status
+	changed.AssignedTo = assignee
+
+	before := map[bucket]bool{}
+	for _, k := range buckets(b, e) {
+		before[k] = true
+	}
+	var violations []Usage
+	for _, k := range buckets(b, &changed) {
+		if before[k] {
+			continue
+		}
+		u := Usage{Scope: k.scope, Key: k.key, Limit: limitOf(b, k), IDs: []string{}}
+		for _, other := range b.Elements {
+			if other.ID() == e.ID() {
+				continue
+			}
+			for _, ok := range buckets(b, other) {
+				if ok == k {
+					u.Count++
+					u.IDs = append(u.IDs, other.ID())
+				}
+			}
+		}
+		u.Count++
+		u.IDs = append(u.IDs, e.ID())
+		if u.Over() {
+			violations = append(violations, u)
+		}
+	}
+	return violations
+}
+
+// AssigneeLoad counts the unfinished elements assigned to an agent, whether
+// or not the agent has a limit.
+func AssigneeLoad(b *board.Board, agent string) int {
+	n := 0
+	for _, e := range b.Elements {
+		if e.AssignedTo == agent && !b.Workflow.Finished(e) {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,96 @@
+package wip
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aagrigore/task-board/internal/board"
+)
+
+func testBoard(limits board.WIPLimits, elems ...*board.Element) *board.Board {
+	wf := board.DefaultWorkflow()
+	wf.WIP = limits
+	return &board.Board{Workflow: wf, Elements: elems}
+}
+
+func task(id, parent string, status board.Status, assignee string) *board.Element {
+	return &board.Element{Type: board.TaskType, RawID: id, ParentID: parent, Status: status, AssignedTo: assignee}
+}
+
+func describe(violations []Usage) string {
+	parts := make([]string, len(violations))
+	for i, u := range violations {
+		parts[i] = string(u.Scope) + ":" + u.Key + ":" + strings.Join(u.IDs, ",")
+	}
+	return strings.Join(parts, " ")
+}
+
+func TestCheckStatusLimit(t *testing.T) {
+	story := &board.Element{Type: board.StoryType, RawID: "STORY-1", Status: board.StatusDevelopment}
+	busy := task("TASK-1", "STORY-1", board.StatusDevelopment, "")
+	next := task("TASK-2", "STORY-1", board.StatusToDev, "")
+	b := testBoard(board.WIPLimits{Status: map[board.Status]int{board.StatusDevelopment: 1}}, story, busy, next)
+
+	if got := describe(Check(b, next, board.StatusDevelopment, "")); got != "status:development:TASK-1,TASK-2" {
+		t.Errorf("moving into a full status = %q", got)
+	}
+	if got := Check(b, next, board.StatusToReview, ""); len(got) != 0 {
+		t.Errorf("a status without a limit = %+v", got)
+	}
+	// Stories don't use up a column
+	if got := Check(b, &board.Element{Type: board.StoryType, RawID: "STORY-2", Status: board.StatusToDev}, board.StatusDevelopment, ""); len(got) != 0 {
+		t.Errorf("a story moving into a full status = %+v", got)
+	}
+}
+
+func TestCheckStoryLimit(t *testing.T) {
+	busy := task("TASK-1", "STORY-1", board.StatusDevelopment, "")
+	next := task("TASK-2", "STORY-1", board.StatusToDev, "")
+	elsewhere := task("TASK-3", "STORY-2", board.StatusToDev, "")
+	b := testBoard(board.WIPLimits{Story: 1},
+		&board.Element{Type: board.StoryType, RawID: "STORY-1"},
+		&board.Element{Type: board.StoryType, RawID: "STORY-2"},
+		busy, next, elsewhere)
+
+	if got := describe(Check(b, next, board.StatusToReview, "")); got != "story:STORY-1:TASK-1,TASK-2" {
+		t.Errorf("starting a second task in a story = %q", got)
+	}
+	if got := Check(b, elsewhere, board.StatusDevelopment, ""); len(got) != 0 {
+		t.Errorf("starting a task in another story = %+v", got)
+	}
+}
+
+func TestCheckAssigneeLimit(t *testing.T) {
+	held := task("TASK-1", "", board.StatusDevelopment, "agent-1")
+	done := task("TASK-2", "", board.StatusDone, "agent-1")
+	next := task("TASK-3", "", board.StatusToDev, "")
+	b := testBoard(board.WIPLimits{Assignee: 1, Assignees: map[string]int{"agent-2": 2}}, held, done, next)
+
+	if got := describe(Check(b, next, next.Status, "agent-1")); got != "assignee:agent-1:TASK-1,TASK-3" {
+		t.Errorf("assigning past the limit = %q", got)
+	}
+	// agent-2 has a limit of its own
+	if got := Check(b, next, next.Status, "agent-2"); len(got) != 0 {
+		t.Errorf("assigning within a per-agent limit = %+v", got)
+	}
+	// Finished elements don't count, in the load or the change
+	if got := Check(b, held, board.StatusDone, "agent-1"); len(got) != 0 {
+		t.Errorf("finishing an assigned element = %+v", got)
+	}
+}
+
+func TestCheckAllowsMovesWithinLimits(t *testing.T) {
+	first := task("TASK-1", "STORY-1", board.StatusDevelopment, "agent-1")
+	second := task("TASK-2", "STORY-1", board.StatusDevelopment, "agent-1")
+	b := testBoard(board.WIPLimits{Status: map[board.Status]int{board.StatusDevelopment: 1}, Story: 1, Assignee: 1},
+		&board.Element{Type: board.StoryType, RawID: "STORY-1"}, first, second)
+
+	// Already over every limit: staying put, or moving within the same
+	// story and assignee, is not refused
+	if got := Check(b, first, board.StatusDevelopment, "agent-1"); len(got) != 0 {
+		t.Errorf("staying in the same status = %+v", got)
+	}
+	if got := Check(b, first, board.StatusToReview, "agent-1"); len(got) != 0 {
+		t.Errorf("moving to another active status = %+v", got)
+	}
+}
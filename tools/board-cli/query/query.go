@@ -0,0 +1,511 @@
+// Package query implements the board query language shared by the CLI
+// (`list --query`, saved views) and the TUI (`/filter`, `/view`).
+//
+// Grammar:
+//
+//	query   = or
+//	or      = and { ("OR" | "||") and }
+//	and     = unary { ["AND" | "&&"] unary }      // juxtaposition means AND
+//	unary   = ("NOT" | "!" | "-") unary | "(" or ")" | term
+//	term    = field ":" value | text
+//
+// Fields:
+//
+//	status:development        exact status; commas mean any-of (status:done,closed)
+//	type:task                 element type (epic, story, task, bug)
+//	assignee:alice            assignee; "none" matches unassigned
+//	parent:STORY-260101-abc   direct parent or any ancestor
+//	id:TASK-260101-abc        element ID
+//	name:"audio capture"      substring of the element name
+//	updated:<2h               updated within the last 2h (m, h, d, w units)
+//	updated:>7d               not updated for more than 7 days
+//	updated:>2026-01-01       updated after a date (also <, <=, >=)
+//	blocked:true              has unfinished blockers
+//
+// Bare text matches case-insensitively against ID, name, status, type and
+// assignee. Quote text that contains spaces or colons.
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Fields are the element attributes a query can match against.
+type Fields struct {
+	ID        string
+	Type      string
+	Name      string
+	Status    string
+	Assignee  string
+	Parent    string
+	Ancestors []string // every ancestor ID, nearest first
+	UpdatedAt time.Time
+	Blocked   bool
+}
+
+// Query is a parsed query. The zero value and a nil *Query match everything.
+type Query struct {
+	source string
+	root   node
+}
+
+// now is replaced in tests.
+var now = time.Now
+
+// knownFields lists the supported field names, in documentation order.
+var knownFields = []string{"status", "type", "assignee", "parent", "id", "name", "updated", "blocked"}
+
+// Parse parses a query string. An empty string yields a query matching everything.
+func Parse(input string) (*Query, error) {
+	tokens, err := lex(input)
+	if err != nil {
+		return nil, err
+	}
+	q := &Query{source: strings.TrimSpace(input)}
+	if len(tokens) == 0 {
+		return q, nil
+	}
+	p := &parser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q at position %d", p.tokens[p.pos].text, p.tokens[p.pos].offset+1)
+	}
+	q.root = root
+	return q, nil
+}
+
+// Match reports whether the element described by f satisfies the query.
+func (q *Query) Match(f Fields) bool {
+	if q == nil || q.root == nil {
+		return true
+	}
+	return q.root.match(f)
+}
+
+// String returns the original query text.
+func (q *Query) String() string {
+	if q == nil {
+		return ""
+	}
+	return q.source
+}
+
+// IsEmpty reports whether the query matches everything.
+func (q *Query) IsEmpty() bool {
+	return q == nil || q.root == nil
+}
+
+// --- AST ---
+
+type node interface {
+	match(f Fields) bool
+}
+
+type andNode struct{ left, right node }
+
+func (n andNode) match(f Fields) bool { return n.left.match(f) && n.right.match(f) }
+
+type orNode struct{ left, right node }
+
+func (n orNode) match(f Fields) bool { return n.left.match(f) || n.right.match(f) }
+
+type notNode struct{ inner node }
+
+func (n notNode) match(f Fields) bool { return !n.inner.match(f) }
+
+type textNode struct{ text string }
+
+func (n textNode) match(f Fields) bool {
+	haystack := strings.ToLower(strings.Join([]string{f.ID, f.Name, f.Status, f.Type, f.Assignee}, " "))
+	return strings.Contains(haystack, n.text)
+}
+
+// anyOfNode matches a string field against a set of lowercase values.
+type anyOfNode struct {
+	get    func(Fields) string
+	values []string
+}
+
+func (n anyOfNode) match(f Fields) bool {
+	v := strings.ToLower(n.get(f))
+	for _, want := range n.values {
+		if v == want {
+			return true
+		}
+	}
+	return false
+}
+
+type nameNode struct{ text string }
+
+func (n nameNode) match(f Fields) bool {
+	return strings.Contains(strings.ToLower(f.Name), n.text)
+}
+
+type parentNode struct{ values []string }
+
+func (n parentNode) match(f Fields) bool {
+	candidates := append([]string{f.Parent}, f.Ancestors...)
+	for _, c := range candidates {
+		for _, want := range n.values {
+			if c != "" && strings.EqualFold(c, want) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type blockedNode struct{ want bool }
+
+func (n blockedNode) match(f Fields) bool { return f.Blocked == n.want }
+
+// updatedNode compares UpdatedAt against an absolute time.
+// Elements without a known update time never match.
+type updatedNode struct {
+	op  string
+	at  time.Time // absolute threshold, or zero when age is used
+	age time.Duration
+}
+
+func (n updatedNode) match(f Fields) bool {
+	if f.UpdatedAt.IsZero() {
+		return false
+	}
+	if n.at.IsZero() {
+		// Relative form: compare ages. "<2h" means younger than 2h.
+		age := now().Sub(f.UpdatedAt)
+		return compare(n.op, float64(age), float64(n.age))
+	}
+	return compare(n.op, float64(f.UpdatedAt.Unix()), float64(n.at.Unix()))
+}
+
+func compare(op string, a, b float64) bool {
+	switch op {
+	case "<":
+		return a < b
+	case "<=":
+		return a <= b
+	case ">":
+		return a > b
+	case ">=":
+		return a >= b
+	default:
+		return a == b
+	}
+}
+
+// --- Lexer ---
+
+type tokenKind int
+
+const (
+	tokWord tokenKind = iota
+	tokAnd
+	tokOr
+	tokNot
+	tokLParen
+	tokRParen
+)
+
+type token struct {
+	kind   tokenKind
+	text   string // unquoted word text
+	quoted bool   // the whole word was a quoted string
+	offset int
+}
+
+func lex(input string) ([]token, error) {
+	var tokens []token
+	i := 0
+	for i < len(input) {
+		c := input[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+		case c == '(':
+			tokens = append(tokens, token{kind: tokLParen, text: "(", offset: i})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{kind: tokRParen, text: ")", offset: i})
+			i++
+		case strings.HasPrefix(input[i:], "&&"):
+			tokens = append(tokens, token{kind: tokAnd, text: "&&", offset: i})
+			i += 2
+		case strings.HasPrefix(input[i:], "||"):
+			tokens = append(tokens, token{kind: tokOr, text: "||", offset: i})
+			i += 2
+		case c == '!':
+			tokens = append(tokens, token{kind: tokNot, text: "!", offset: i})
+			i++
+		case c == '-' && (i == 0 || isBoundary(input[i-1])) && i+1 < len(input) && !isBoundary(input[i+1]):
+			tokens = append(tokens, token{kind: tokNot, text: "-", offset: i})
+			i++
+		default:
+			start := i
+			var b strings.Builder
+			quoted := c == '"'
+			for i < len(input) && !isBoundary(input[i]) {
+				if input[i] == '"' {
+					end := strings.IndexByte(input[i+1:], '"')
+					if end < 0 {
+						return nil, fmt.Errorf("unterminated quote at position %d", i+1)
+					}
+					b.WriteString(input[i+1 : i+1+end])
+					i += end + 2
+					continue
+				}
+				b.WriteByte(input[i])
+				i++
+			}
+			word := input[start:i]
+			tok := token{kind: tokWord, text: b.String(), quoted: quoted && strings.HasSuffix(word, `"`), offset: start}
+			if !quoted {
+				switch word {
+				case "AND":
+					tok.kind = tokAnd
+				case "OR":
+					tok.kind = tokOr
+				case "NOT":
+					tok.kind = tokNot
+				}
+			}
+			tokens = append(tokens, tok)
+		}
+	}
+	return tokens, nil
+}
+
+func isBoundary(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '(' || c == ')'
+}
+
+// --- Parser ---
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() *token {
+	if p.pos >= len(p.tokens) {
+		return nil
+	}
+	return &p.tokens[p.pos]
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		if t == nil || t.kind != tokOr {
+			return left, nil
+		}
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left, right}
+	}
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		if t == nil || t.kind == tokOr || t.kind == tokRParen {
+			return left, nil
+		}
+		if t.kind == tokAnd {
+			p.pos++
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left, right}
+	}
+}
+
+func (p *parser) parseUnary() (node, error) {
+	t := p.peek()
+	if t == nil {
+		return nil, fmt.Errorf("unexpected end of query")
+	}
+	switch t.kind {
+	case tokNot:
+		p.pos++
+		inner, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{inner}, nil
+	case tokLParen:
+		p.pos++
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if c := p.peek(); c == nil || c.kind != tokRParen {
+			return nil, fmt.Errorf("missing closing parenthesis for '(' at position %d", t.offset+1)
+		}
+		p.pos++
+		return inner, nil
+	case tokWord:
+		p.pos++
+		return parseTerm(*t)
+	default:
+		return nil, fmt.Errorf("unexpected %q at position %d", t.text, t.offset+1)
+	}
+}
+
+func parseTerm(t token) (node, error) {
+	if t.quoted {
+		return textNode{strings.ToLower(t.text)}, nil
+	}
+	field, value, ok := strings.Cut(t.text, ":")
+	if !ok {
+		return textNode{strings.ToLower(t.text)}, nil
+	}
+	field = strings.ToLower(field)
+	if value == "" {
+		return nil, fmt.Errorf("missing value for %s: at position %d", field, t.offset+1)
+	}
+
+	switch field {
+	case "status":
+		values := splitValues(value)
+		for i, v := range values {
+			if full, ok := statusAliases[v]; ok {
+				values[i] = full
+			}
+		}
+		return anyOfNode{func(f Fields) string { return f.Status }, values}, nil
+	case "type":
+		values := splitValues(value)
+		for i, v := range values {
+			values[i] = normalizeType(v)
+		}
+		return anyOfNode{func(f Fields) string { return f.Type }, values}, nil
+	case "assignee":
+		values := splitValues(value)
+		for i, v := range values {
+			values[i] = strings.TrimPrefix(v, "@")
+			if values[i] == "none" {
+				values[i] = ""
+			}
+		}
+		return anyOfNode{func(f Fields) string { return f.Assignee }, values}, nil
+	case "id":
+		return anyOfNode{func(f Fields) string { return f.ID }, splitValues(value)}, nil
+	case "parent":
+		return parentNode{splitValues(value)}, nil
+	case "name":
+		return nameNode{strings.ToLower(value)}, nil
+	case "blocked":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("blocked: expects true or false, got %q", value)
+		}
+		return blockedNode{b}, nil
+	case "updated":
+		return parseUpdated(value)
+	default:
+		return nil, fmt.Errorf("unknown field %q (known: %s); quote the term to search for it as text",
+			field, strings.Join(knownFields, ", "))
+	}
+}
+
+func splitValues(value string) []string {
+	parts := strings.Split(strings.ToLower(value), ",")
+	out := parts[:0]
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// statusAliases mirrors the short forms accepted by `progress status`.
+var statusAliases = map[string]string{
+	"dev":      "development",
+	"todev":    "to-dev",
+	"review":   "reviewing",
+	"toreview": "to-review",
+}
+
+// normalizeType accepts plural forms such as "tasks" or "stories".
+func normalizeType(v string) string {
+	switch v {
+	case "epics":
+		return "epic"
+	case "stories":
+		return "story"
+	case "tasks":
+		return "task"
+	case "bugs":
+		return "bug"
+	}
+	return v
+}
+
+func parseUpdated(value string) (node, error) {
+	op := "="
+	for _, candidate := range []string{"<=", ">=", "<", ">", "="} {
+		if strings.HasPrefix(value, candidate) {
+			op = candidate
+			value = value[len(candidate):]
+			break
+		}
+	}
+	if value == "" {
+		return nil, fmt.Errorf("updated: needs a duration (2h, 3d) or a date (2006-01-02)")
+	}
+	if d, err := ParseAge(value); err == nil {
+		return updatedNode{op: op, age: d}, nil
+	}
+	if at, err := time.Parse("2006-01-02", value); err == nil {
+		if op == "=" {
+			// A bare date means "on that day"
+			return andNode{updatedNode{op: ">=", at: at}, updatedNode{op: "<", at: at.AddDate(0, 0, 1)}}, nil
+		}
+		return updatedNode{op: op, at: at}, nil
+	}
+	return nil, fmt.Errorf("updated: cannot parse %q as a duration (2h, 3d) or a date (2006-01-02)", value)
+}
+
+// ParseAge parses durations like "30m", "2h", "3d" or "1w".
+func ParseAge(s string) (time.Duration, error) {
+	if len(s) < 2 {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	n, err := strconv.Atoi(s[:len(s)-1])
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	switch s[len(s)-1] {
+	case 'm':
+		return time.Duration(n) * time.Minute, nil
+	case 'h':
+		return time.Duration(n) * time.Hour, nil
+	case 'd':
+		return time.Duration(n) * 24 * time.Hour, nil
+	case 'w':
+		return time.Duration(n) * 7 * 24 * time.Hour, nil
+	}
+	return 0, fmt.Errorf("invalid duration %q (use m, h, d or w)", s)
+}
@@ -0,0 +1,146 @@
+package query
+
+import (
+	"testing"
+	"time"
+)
+
+var testNow = time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+func sample() Fields {
+	return Fields{
+		ID:        "TASK-260101-ffffff",
+		Type:      "task",
+		Name:      "audio interface",
+		Status:    "development",
+		Assignee:  "alice",
+		Parent:    "STORY-260101-cccccc",
+		Ancestors: []string{"STORY-260101-cccccc", "EPIC-260101-aaaaaa"},
+		UpdatedAt: testNow.Add(-90 * time.Minute),
+		Blocked:   true,
+	}
+}
+
+func TestMatch(t *testing.T) {
+	now = func() time.Time { return testNow }
+	defer func() { now = time.Now }()
+
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{"", true},
+		{"audio", true},
+		{"AUDIO", true},
+		{"video", false},
+		{"status:development", true},
+		{"status:done,closed", false},
+		{"status:dev", true},
+		{"status:done,development", true},
+		{"type:task", true},
+		{"type:tasks", true},
+		{"type:story", false},
+		{"assignee:alice", true},
+		{"assignee:@alice", true},
+		{"assignee:none", false},
+		{"parent:STORY-260101-cccccc", true},
+		{"parent:epic-260101-aaaaaa", true},
+		{"parent:EPIC-260101-bbbbbb", false},
+		{"id:TASK-260101-ffffff", true},
+		{`name:"audio inter"`, true},
+		{"updated:<2h", true},
+		{"updated:<1h", false},
+		{"updated:>1h", true},
+		{"updated:>=2026-03-10", true},
+		{"updated:<2026-03-10", false},
+		{"updated:2026-03-10", true},
+		{"blocked:true", true},
+		{"blocked:false", false},
+		{"type:task status:development", true},
+		{"type:task AND status:done", false},
+		{"type:task && status:done", false},
+		{"status:done OR assignee:alice", true},
+		{"status:done || assignee:bob", false},
+		{"NOT status:done", true},
+		{"!status:development", false},
+		{"-status:development", false},
+		{"(status:done OR status:development) AND type:task", true},
+		{"type:story OR (assignee:alice AND -blocked:false)", true},
+		{"status:to-dev", false},
+		{`"audio interface"`, true},
+	}
+
+	f := sample()
+	for _, tt := range tests {
+		q, err := Parse(tt.query)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.query, err)
+			continue
+		}
+		if got := q.Match(f); got != tt.want {
+			t.Errorf("Match(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, input := range []string{
+		"(status:done",
+		"status:done)",
+		"unknown:value",
+		"status:",
+		"blocked:maybe",
+		"updated:<soon",
+		`name:"open`,
+		"type:task AND",
+		"OR type:task",
+	} {
+		if _, err := Parse(input); err == nil {
+			t.Errorf("Parse(%q) should fail", input)
+		}
+	}
+}
+
+func TestAssigneeNone(t *testing.T) {
+	q, err := Parse("assignee:none")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !q.Match(Fields{}) {
+		t.Error("assignee:none should match unassigned elements")
+	}
+}
+
+func TestUpdatedIgnoresUnknownTime(t *testing.T) {
+	q, _ := Parse("updated:>1d")
+	if q.Match(Fields{}) {
+		t.Error("elements without update time should not match updated: predicates")
+	}
+}
+
+func TestNilQueryMatchesAll(t *testing.T) {
+	var q *Query
+	if !q.Match(sample()) || !q.IsEmpty() {
+		t.Error("nil query should match everything")
+	}
+}
+
+func TestParseAge(t *testing.T) {
+	cases := map[string]time.Duration{
+		"30m": 30 * time.Minute,
+		"2h":  2 * time.Hour,
+		"3d":  72 * time.Hour,
+		"1w":  168 * time.Hour,
+	}
+	for in, want := range cases {
+		got, err := ParseAge(in)
+		if err != nil || got != want {
+			t.Errorf("ParseAge(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "h", "2y", "-1h"} {
+		if _, err := ParseAge(in); err == nil {
+			t.Errorf("ParseAge(%q) should fail", in)
+		}
+	}
+}
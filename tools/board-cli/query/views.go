@@ -0,0 +1,79 @@
+package query
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ViewsFile is the name of the saved views file inside the board directory.
+const ViewsFile = "views.yaml"
+
+// View is a named, saved query.
+type View struct {
+	Name        string `yaml:"name" json:"name"`
+	Query       string `yaml:"query" json:"query"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+}
+
+type viewsDoc struct {
+	Views []View `yaml:"views"`
+}
+
+// ViewsPath returns the path of the views file for a board directory.
+func ViewsPath(boardDir string) string {
+	return filepath.Join(boardDir, ViewsFile)
+}
+
+// LoadViews reads saved views from the board directory.
+// A missing file is not an error and yields no views.
+func LoadViews(boardDir string) ([]View, error) {
+	data, err := os.ReadFile(ViewsPath(boardDir))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading views: %w", err)
+	}
+	var doc viewsDoc
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", ViewsFile, err)
+	}
+	return doc.Views, nil
+}
+
+// SaveViews writes views to the board directory, replacing the file.
+func SaveViews(boardDir string, views []View) error {
+	data, err := yaml.Marshal(viewsDoc{Views: views})
+	if err != nil {
+		return fmt.Errorf("encoding views: %w", err)
+	}
+	return os.WriteFile(ViewsPath(boardDir), data, 0644)
+}
+
+// FindView returns the view with the given name (case-insensitive), or nil.
+func FindView(views []View, name string) *View {
+	for i := range views {
+		if strings.EqualFold(views[i].Name, name) {
+			return &views[i]
+		}
+	}
+	return nil
+}
+
+// ValidViewName reports whether name can be used for a view.
+func ValidViewName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, c := range name {
+		if !(c == '-' || c == '_' || c == '.' ||
+			(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')) {
+			return false
+		}
+	}
+	return true
+}
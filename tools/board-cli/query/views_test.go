@@ -0,0 +1,48 @@
+package query
+
+import "testing"
+
+func TestViewsRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+
+	views, err := LoadViews(dir)
+	if err != nil || len(views) != 0 {
+		t.Fatalf("LoadViews on empty dir = %v, %v", views, err)
+	}
+
+	want := []View{
+		{Name: "mine", Query: "assignee:alice -status:done,closed"},
+		{Name: "stale", Query: "updated:>7d", Description: "Untouched for a week"},
+	}
+	if err := SaveViews(dir, want); err != nil {
+		t.Fatalf("SaveViews: %v", err)
+	}
+
+	got, err := LoadViews(dir)
+	if err != nil {
+		t.Fatalf("LoadViews: %v", err)
+	}
+	if len(got) != 2 || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("round trip = %+v, want %+v", got, want)
+	}
+
+	if v := FindView(got, "STALE"); v == nil || v.Query != "updated:>7d" {
+		t.Errorf("FindView is case-insensitive, got %+v", v)
+	}
+	if FindView(got, "missing") != nil {
+		t.Error("FindView should return nil for unknown views")
+	}
+}
+
+func TestValidViewName(t *testing.T) {
+	for _, name := range []string{"mine", "my-view", "sprint_3", "v1.2"} {
+		if !ValidViewName(name) {
+			t.Errorf("%q should be valid", name)
+		}
+	}
+	for _, name := range []string{"", "has space", "a:b", "x/y"} {
+		if ValidViewName(name) {
+			t.Errorf("%q should be invalid", name)
+		}
+	}
+}
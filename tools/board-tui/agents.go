@@ -3,7 +3,6 @@ package main
 import (
 	"encoding/json"
 	"fmt"
-	"os/exec"
 	"sort"
 	"strings"
 	"time"
@@ -18,6 +17,8 @@ type AgentInfo struct {
 	AssignedElements []AssignedElement `json:"assignedElements"`
 	TotalAssigned    int               `json:"totalAssigned"`
 	StaleCount       int               `json:"staleCount"`
+	Load             int               `json:"load"`
+	Limit            int               `json:"limit"`
 }
 
 // AssignedElement represents an element assigned to an agent
@@ -80,7 +81,7 @@ func loadAgentsWithStale(staleMinutes int) tea.Msg {
 	} else {
 		args = append(args, "--all")
 	}
-	cmd := exec.Command("task-board", args...)
+	cmd := taskBoardCommand(args...)
 	output, err := cmd.Output()
 	if err != nil {
 		return AgentsLoadedMsg{Err: err}
@@ -109,7 +110,7 @@ func loadAgentsWithStale(staleMinutes int) tea.Msg {
 func loadChildrenMap() map[string][]ChildElement {
 	result := make(map[string][]ChildElement)
 
-	cmd := exec.Command("task-board", "tree", "--json")
+	cmd := taskBoardCommand(snoozedArgs("tree", "--json")...)
 	output, err := cmd.Output()
 	if err != nil {
 		return result
@@ -242,9 +243,16 @@ func (m *AgentsModel) buildRows() {
 		if agent.StaleCount > 0 {
 			staleInfo = staleStyle.Render(fmt.Sprintf(" (%d stale)", agent.StaleCount))
 		}
+		loadInfo := ""
+		if agent.Limit > 0 {
+			loadInfo = fmt.Sprintf(" · load %d/%d", agent.Load, agent.Limit)
+			if agent.Load > agent.Limit {
+				loadInfo = wipOverStyle.Render(loadInfo)
+			}
+		}
 		m.rows = append(m.rows, agentRow{
 			kind: rowHeader,
-			text: agentNameStyle.Render("@"+agent.Name) + fmt.Sprintf(" — %d tasks", agent.TotalAssigned) + staleInfo,
+			text: agentNameStyle.Render("@"+agent.Name) + fmt.Sprintf(" — %d tasks", agent.TotalAssigned) + loadInfo + staleInfo,
 		})
 
 		// Elements
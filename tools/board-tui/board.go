@@ -117,7 +117,7 @@ func (m *model) boardRebuildRows() {
 		}
 	}
 
-	m.boardRows = buildBoardRows(m.tree)
+	m.boardRows = buildBoardRows(m.displayTree())
 
 	// Restore selection by ID
 	if selectedID != "" {
@@ -210,6 +210,22 @@ func (m *model) boardSelectedNode() *TreeNode {
 	return nil
 }
 
+// boardJumpTo reveals an element on the board and selects it.
+// A filter hiding the element is cleared first.
+func (m *model) boardJumpTo(id string) {
+	node := FindNodeByID(m.displayTree(), id)
+	if node == nil && m.filter != nil {
+		m.setFilter(nil, "")
+		node = FindNodeByID(m.tree, id)
+	}
+	if node == nil {
+		return
+	}
+	ExpandToNode(node)
+	m.boardRebuildRows()
+	m.boardSelectNodeByID(id)
+}
+
 func (m *model) boardSelectNodeByID(id string) {
 	for i, row := range m.boardRows {
 		if row.node != nil && row.node.ID == id {
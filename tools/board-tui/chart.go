@@ -0,0 +1,56 @@
+package main
+
+import (
+	"encoding/json"
+
+	"github.com/aagrigore/task-board/chart"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ChartResponse is the JSON response from `task-board chart burndown --json`
+type ChartResponse struct {
+	Chart *chart.Series `json:"chart"`
+}
+
+// chartLoadedMsg carries a loaded burndown or burnup series
+type chartLoadedMsg struct {
+	series *chart.Series
+	err    error
+}
+
+// loadChartFromCLI calls task-board chart <kind> [ID] --json. An empty ID
+// charts the whole board.
+func loadChartFromCLI(kind chart.Kind, id string) (*chart.Series, error) {
+	args := []string{"chart", string(kind)}
+	if id != "" {
+		args = append(args, id)
+	}
+	output, err := taskBoardCommand(append(args, "--json")...).Output()
+	if err != nil {
+		return nil, err
+	}
+	var response ChartResponse
+	if err := json.Unmarshal(output, &response); err != nil {
+		return nil, err
+	}
+	return response.Chart, nil
+}
+
+// LoadChart starts loading a chart into the detail view
+func (m *DetailModel) LoadChart(kind chart.Kind, id string) tea.Cmd {
+	m.loading = true
+	m.err = nil
+	return func() tea.Msg {
+		series, err := loadChartFromCLI(kind, id)
+		return chartLoadedMsg{series: series, err: err}
+	}
+}
+
+// renderChart draws the loaded chart to fit the viewport
+func (m *DetailModel) renderChart() string {
+	height := m.viewport.Height - 8
+	if height < 4 {
+		height = 4
+	}
+	return chart.Text(m.chart, m.viewport.Width-12, height)
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aagrigore/task-board/chart"
+)
+
+func TestDetailShowsChart(t *testing.T) {
+	m := NewDetailModel()
+	m.SetSize(80, 30)
+	m, _ = m.Update(chartLoadedMsg{series: &chart.Series{
+		Kind:  chart.Burndown,
+		Title: "Burndown — EPIC-1",
+		Unit:  "elements",
+		Points: []chart.Point{
+			{Date: "2026-03-01", Total: 2, Remaining: 2},
+			{Date: "2026-03-02", Total: 2, Done: 1, Remaining: 1},
+		},
+	}})
+
+	view := m.View()
+	if !strings.Contains(view, "Burndown — EPIC-1") {
+		t.Errorf("title missing:\n%s", view)
+	}
+	if !strings.Contains(view, "1 remaining, 1 done of 2 elements") || !strings.Contains(view, "┤█") {
+		t.Errorf("chart missing:\n%s", view)
+	}
+}
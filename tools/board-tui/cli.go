@@ -0,0 +1,42 @@
+package main
+
+import (
+	"os/exec"
+	"strings"
+)
+
+// cliGlobalArgs are forwarded to every task-board invocation so the TUI
+// looks at the same board (or workspace) it was launched for.
+var cliGlobalArgs []string
+
+// forwardedFlags are the task-board persistent flags the TUI passes through.
+var forwardedFlags = map[string]bool{
+	"--board-dir": true,
+	"--workspace": true,
+}
+
+// parseCLIArgs extracts forwarded flags from the TUI's own command line.
+// Both "--flag value" and "--flag=value" forms are accepted.
+func parseCLIArgs(args []string) []string {
+	var result []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if name, value, ok := strings.Cut(arg, "="); ok {
+			if forwardedFlags[name] {
+				result = append(result, name, value)
+			}
+			continue
+		}
+		if forwardedFlags[arg] && i+1 < len(args) {
+			result = append(result, arg, args[i+1])
+			i++
+		}
+	}
+	return result
+}
+
+// taskBoardCommand builds a task-board invocation with the global flags appended.
+func taskBoardCommand(args ...string) *exec.Cmd {
+	full := append(append([]string{}, args...), cliGlobalArgs...)
+	return exec.Command("task-board", full...)
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCLIArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{"empty", nil, nil},
+		{"separate value", []string{"--board-dir", "/tmp/b"}, []string{"--board-dir", "/tmp/b"}},
+		{"equals form", []string{"--workspace=ws.yaml"}, []string{"--workspace", "ws.yaml"}},
+		{"unknown flags dropped", []string{"--verbose", "--workspace", "ws.yaml", "x"}, []string{"--workspace", "ws.yaml"}},
+		{"dangling flag ignored", []string{"--board-dir"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseCLIArgs(tt.args)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseCLIArgs(%v) = %v, want %v", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTaskBoardCommandAppendsGlobalArgs(t *testing.T) {
+	old := cliGlobalArgs
+	defer func() { cliGlobalArgs = old }()
+
+	cliGlobalArgs = []string{"--workspace", "ws.yaml"}
+	cmd := taskBoardCommand("tree", "--json")
+	want := []string{"task-board", "tree", "--json", "--workspace", "ws.yaml"}
+	if !reflect.DeepEqual(cmd.Args, want) {
+		t.Errorf("Args = %v, want %v", cmd.Args, want)
+	}
+}
+
+func TestSnoozedArgs(t *testing.T) {
+	old := includeSnoozed
+	defer func() { includeSnoozed = old }()
+
+	includeSnoozed = false
+	if got := snoozedArgs("tree", "--json"); !reflect.DeepEqual(got, []string{"tree", "--json"}) {
+		t.Errorf("hidden: got %v", got)
+	}
+	includeSnoozed = true
+	if got := snoozedArgs("tree", "--json"); !reflect.DeepEqual(got, []string{"tree", "--json", "--include-snoozed"}) {
+		t.Errorf("shown: got %v", got)
+	}
+}
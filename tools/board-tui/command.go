@@ -43,10 +43,16 @@ func NewCommandModel() CommandModel {
 		input:  ti,
 		active: false,
 		commands: []Command{
-			{Name: "filter", Description: "Filter items (e.g., /filter done)"},
+			{Name: "filter", Description: "Filter by query (e.g., /filter status:dev assignee:alice); empty clears"},
+			{Name: "view", Description: "Apply a saved view (e.g., /view mine); empty clears"},
+			{Name: "search", Description: "Full-text search (e.g., /search title:recorder)"},
 			{Name: "agents", Description: "Show agent assignments"},
+			{Name: "review", Description: "Review acceptance criteria of the selected element (or /review ID)"},
+			{Name: "burndown", Description: "Burndown chart of the selected element (or /burndown ID)"},
+			{Name: "burnup", Description: "Burnup chart of the selected element (or /burnup ID)"},
 			{Name: "arkanoid", Description: "Open Arkanoid mini-game"},
 			{Name: "settings", Description: "Open settings screen"},
+			{Name: "snoozed", Description: "Show or hide snoozed elements"},
 			{Name: "refresh", Description: "Force refresh data"},
 			{Name: "expand", Description: "Expand all nodes"},
 			{Name: "collapse", Description: "Collapse all nodes"},
@@ -3,10 +3,10 @@ package main
 import (
 	"encoding/json"
 	"fmt"
-	"os/exec"
 	"strings"
 	"sync"
 
+	"github.com/aagrigore/task-board/chart"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/glamour"
@@ -51,8 +51,10 @@ type ElementDetail struct {
 	UpdatedAt          string          `json:"updatedAt"`
 	BlockedBy          []string        `json:"blockedBy"`
 	Blocks             []string        `json:"blocks"`
+	Links              []LinkItem      `json:"links"`
 	Description        string          `json:"description"`
 	AcceptanceCriteria string          `json:"acceptanceCriteria"`
+	Criteria           []CriterionItem `json:"criteria"`
 	Checklist          []ChecklistItem `json:"checklist"`
 	Notes              []NoteItem      `json:"notes"`
 }
@@ -62,6 +64,19 @@ type ChecklistItem struct {
 	Done bool   `json:"done"`
 }
 
+// CriterionItem is an acceptance criterion with its review verdict.
+type CriterionItem struct {
+	Number  int    `json:"number"`
+	Text    string `json:"text"`
+	Verdict string `json:"verdict"`
+}
+
+// LinkItem is a typed link such as "caused-by BUG-…".
+type LinkItem struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
 type NoteItem struct {
 	Timestamp string `json:"timestamp"`
 	Text      string `json:"text"`
@@ -81,8 +96,9 @@ type DetailModel struct {
 	width       int
 	height      int
 	ready       bool
-	title       string // Custom title (for help screen)
-	helpContent string // Static help content (bypasses element loading)
+	title       string        // Custom title (for help screen)
+	helpContent string        // Static help content (bypasses element loading)
+	chart       *chart.Series // Burndown/burnup chart (bypasses element loading)
 }
 
 // DetailCloseMsg signals returning to the board
@@ -143,7 +159,7 @@ func (m *DetailModel) SetHelpContent(commands []Command) {
 
 // loadElementFromCLI calls task-board show ID --json
 func loadElementFromCLI(id string) (*ElementDetail, error) {
-	cmd := exec.Command("task-board", "show", id, "--json")
+	cmd := taskBoardCommand("show", id, "--json")
 	output, err := cmd.Output()
 	if err != nil {
 		return nil, err
@@ -186,6 +202,11 @@ func (m *DetailModel) SetSize(width, height int) {
 		return
 	}
 
+	if m.chart != nil {
+		m.viewport.SetContent(m.renderChart())
+		return
+	}
+
 	// Re-render content if we have element
 	if m.element != nil {
 		m.viewport.SetContent(m.renderContent())
@@ -209,6 +230,18 @@ func (m DetailModel) Update(msg tea.Msg) (DetailModel, tea.Cmd) {
 			m.viewport.SetContent(m.renderContent())
 		}
 		return m, nil
+
+	case chartLoadedMsg:
+		m.loading = false
+		m.err = msg.err
+		m.chart = msg.series
+		if m.chart != nil {
+			m.title = m.chart.Title
+			if m.ready {
+				m.viewport.SetContent(m.renderChart())
+			}
+		}
+		return m, nil
 	}
 
 	// Handle viewport scrolling
@@ -250,6 +283,9 @@ func (m *DetailModel) renderContent() string {
 	if len(e.Blocks) > 0 {
 		sb.WriteString(fmt.Sprintf("**Blocks:** %s\n\n", strings.Join(e.Blocks, ", ")))
 	}
+	for _, l := range e.Links {
+		sb.WriteString(fmt.Sprintf("**%s:** %s\n\n", l.Type, l.ID))
+	}
 
 	sb.WriteString("---\n\n")
 
@@ -260,8 +296,14 @@ func (m *DetailModel) renderContent() string {
 		sb.WriteString("\n\n")
 	}
 
-	// Acceptance Criteria
-	if e.AcceptanceCriteria != "" && e.AcceptanceCriteria != "(define acceptance criteria)" {
+	// Acceptance Criteria, with the review verdicts when there are criteria
+	if len(e.Criteria) > 0 {
+		sb.WriteString("## Acceptance Criteria\n\n")
+		for _, c := range e.Criteria {
+			sb.WriteString(fmt.Sprintf("%d. **[%s]** %s\n", c.Number, c.Verdict, c.Text))
+		}
+		sb.WriteString("\n")
+	} else if e.AcceptanceCriteria != "" && e.AcceptanceCriteria != "(define acceptance criteria)" {
 		sb.WriteString("## Acceptance Criteria\n\n")
 		sb.WriteString(e.AcceptanceCriteria)
 		sb.WriteString("\n\n")
@@ -315,8 +357,8 @@ func (m DetailModel) View() string {
 		return fmt.Sprintf("\n  Error: %v\n\n  Press q or esc to go back", m.err)
 	}
 
-	// Check for help content first
-	if m.helpContent != "" {
+	// Check for help content or a chart first
+	if m.helpContent != "" || m.chart != nil {
 		// Render help content if not already done
 		if !m.ready {
 			return "\n  Preparing help..."
@@ -1,202 +1,138 @@
 package main
 
 import (
+	"encoding/json"
+	"fmt"
 	"regexp"
-	"strings"
-)
-
-// FilterExpression represents a parsed filter with logical operators
-type FilterExpression interface {
-	Match(node *TreeNode) bool
-}
-
-// TermExpr matches a simple term against node fields
-type TermExpr struct {
-	term    string
-	negate  bool
-}
-
-func (e *TermExpr) Match(node *TreeNode) bool {
-	term := strings.ToLower(e.term)
-	searchable := strings.ToLower(node.ID + " " + node.Name + " " + node.Status + " " + node.Type + " " + node.GetAssignee())
-	result := strings.Contains(searchable, term)
-	if e.negate {
-		return !result
-	}
-	return result
-}
 
-// AndExpr matches if all sub-expressions match
-type AndExpr struct {
-	exprs []FilterExpression
-}
+	"github.com/aagrigore/task-board/query"
+	tea "github.com/charmbracelet/bubbletea"
+)
 
-func (e *AndExpr) Match(node *TreeNode) bool {
-	for _, expr := range e.exprs {
-		if !expr.Match(node) {
-			return false
+// ParseFilter parses a filter string using the board query language shared
+// with the CLI (`task-board list --query`).
+// Examples:
+//   - "auth" - simple text term
+//   - "!status:done" - negation
+//   - "type:task && assignee:alice" - AND
+//   - "status:development || status:to-review" - OR
+//   - "(auth || login) updated:<2h" - grouped, implicit AND
+func ParseFilter(input string) (*query.Query, error) {
+	return query.Parse(input)
+}
+
+// nodeFields describes a tree node for the query language.
+// statusByID resolves blockers so blocked:true means "has unfinished blockers".
+func nodeFields(node *TreeNode, statusByID map[string]string) query.Fields {
+	f := query.Fields{
+		ID:       node.ID,
+		Type:     node.Type,
+		Name:     node.Name,
+		Status:   node.Status,
+		Assignee: node.GetAssignee(),
+	}
+	if node.Parent != nil {
+		f.Parent = node.Parent.ID
+	}
+	for p := node.Parent; p != nil; p = p.Parent {
+		f.Ancestors = append(f.Ancestors, p.ID)
+	}
+	if t, err := node.GetParsedUpdatedAt(); err == nil {
+		f.UpdatedAt = t
+	}
+	for _, id := range node.BlockedBy {
+		if status, ok := statusByID[id]; ok && !isFinishedStatus(status) {
+			f.Blocked = true
+			break
 		}
 	}
-	return true
+	return f
 }
 
-// OrExpr matches if any sub-expression matches
-type OrExpr struct {
-	exprs []FilterExpression
-}
-
-func (e *OrExpr) Match(node *TreeNode) bool {
-	for _, expr := range e.exprs {
-		if expr.Match(node) {
-			return true
-		}
+// collectStatuses maps every node ID to its status
+func collectStatuses(node *TreeNode, statuses map[string]string) {
+	statuses[node.ID] = node.Status
+	for _, child := range node.Children {
+		collectStatuses(child, statuses)
 	}
-	return false
 }
 
-// ParseFilter parses a filter string with && || ! and parentheses
-// Examples:
-//   - "auth" - simple term
-//   - "!done" - negation
-//   - "auth && done" - AND
-//   - "auth || login" - OR
-//   - "(auth || login) && done" - grouped
-func ParseFilter(input string) FilterExpression {
-	input = strings.TrimSpace(input)
-	if input == "" {
-		return nil
+// displayTree returns the tree shown on the board: filtered when a filter is active
+func (m *model) displayTree() []*TreeNode {
+	if m.filter != nil {
+		return m.filteredTree
 	}
-	return parseOr(input)
+	return m.tree
 }
 
-// parseOr handles || operator (lowest precedence)
-func parseOr(input string) FilterExpression {
-	parts := splitByOperator(input, "||")
-	if len(parts) == 1 {
-		return parseAnd(parts[0])
-	}
-
-	exprs := make([]FilterExpression, 0, len(parts))
-	for _, part := range parts {
-		if expr := parseAnd(part); expr != nil {
-			exprs = append(exprs, expr)
-		}
-	}
-	if len(exprs) == 0 {
-		return nil
-	}
-	if len(exprs) == 1 {
-		return exprs[0]
-	}
-	return &OrExpr{exprs: exprs}
+// setFilter activates q (nil clears the filter) and rebuilds the board
+func (m *model) setFilter(q *query.Query, label string) {
+	m.filter = q
+	m.filterLabel = label
+	m.filterError = nil
+	m.boardSelectedIdx = 0
+	m.boardScrollOff = 0
+	m.applyFilter()
+	m.boardRebuildRows()
 }
 
-// parseAnd handles && operator (higher precedence than ||)
-func parseAnd(input string) FilterExpression {
-	parts := splitByOperator(input, "&&")
-	if len(parts) == 1 {
-		return parseTerm(parts[0])
-	}
-
-	exprs := make([]FilterExpression, 0, len(parts))
-	for _, part := range parts {
-		if expr := parseTerm(part); expr != nil {
-			exprs = append(exprs, expr)
-		}
-	}
-	if len(exprs) == 0 {
-		return nil
+// applyFilter recomputes the filtered tree from the current tree
+func (m *model) applyFilter() {
+	if m.filter == nil {
+		m.filteredTree = nil
+		return
 	}
-	if len(exprs) == 1 {
-		return exprs[0]
-	}
-	return &AndExpr{exprs: exprs}
+	m.filteredTree = FilterTree(m.tree, m.filter)
 }
 
-// parseTerm handles individual terms, negation, and parentheses
-func parseTerm(input string) FilterExpression {
-	input = strings.TrimSpace(input)
-	if input == "" {
-		return nil
-	}
-
-	// Handle negation
-	if strings.HasPrefix(input, "!") {
-		inner := parseTerm(input[1:])
-		if inner == nil {
-			return nil
-		}
-		// If it's already a TermExpr, just flip negate
-		if term, ok := inner.(*TermExpr); ok {
-			term.negate = !term.negate
-			return term
-		}
-		// Otherwise wrap in a negating term (simplified)
-		return &TermExpr{term: input[1:], negate: true}
-	}
-
-	// Handle parentheses
-	if strings.HasPrefix(input, "(") && strings.HasSuffix(input, ")") {
-		// Find matching closing paren
-		depth := 0
-		for i, c := range input {
-			if c == '(' {
-				depth++
-			} else if c == ')' {
-				depth--
-				if depth == 0 && i == len(input)-1 {
-					return parseOr(input[1 : len(input)-1])
-				}
-			}
-		}
-	}
+// ViewLoadedMsg carries a saved view fetched from the CLI
+type ViewLoadedMsg struct {
+	Name  string
+	Query string
+	Err   error
+}
 
-	// Simple term
-	return &TermExpr{term: input, negate: false}
+// viewShowResponse is the JSON response from `task-board view show NAME --json`
+type viewShowResponse struct {
+	View struct {
+		Name  string `json:"name"`
+		Query string `json:"query"`
+	} `json:"view"`
 }
 
-// splitByOperator splits input by operator, respecting parentheses
-func splitByOperator(input string, op string) []string {
-	var parts []string
-	var current strings.Builder
-	depth := 0
-
-	for i := 0; i < len(input); i++ {
-		c := input[i]
-
-		if c == '(' {
-			depth++
-			current.WriteByte(c)
-		} else if c == ')' {
-			depth--
-			current.WriteByte(c)
-		} else if depth == 0 && i+len(op) <= len(input) && input[i:i+len(op)] == op {
-			parts = append(parts, strings.TrimSpace(current.String()))
-			current.Reset()
-			i += len(op) - 1
-		} else {
-			current.WriteByte(c)
+// LoadView returns a command that fetches a saved view by name
+func LoadView(name string) tea.Cmd {
+	return func() tea.Msg {
+		cmd := taskBoardCommand("view", "show", name, "--json")
+		output, err := cmd.Output()
+		// In JSON mode a missing view is reported on stderr with empty stdout
+		if err != nil || len(output) == 0 {
+			return ViewLoadedMsg{Name: name, Err: fmt.Errorf("view %s not found", name)}
 		}
+		var resp viewShowResponse
+		if err := json.Unmarshal(output, &resp); err != nil {
+			return ViewLoadedMsg{Name: name, Err: fmt.Errorf("view %s: %w", name, err)}
+		}
+		return ViewLoadedMsg{Name: resp.View.Name, Query: resp.View.Query}
 	}
-
-	if current.Len() > 0 {
-		parts = append(parts, strings.TrimSpace(current.String()))
-	}
-
-	return parts
 }
 
 // FilterTree filters the tree and returns matching nodes with their ancestors expanded
 // Returns a new tree with only matching nodes visible
-func FilterTree(roots []*TreeNode, filter FilterExpression) []*TreeNode {
-	if filter == nil {
+func FilterTree(roots []*TreeNode, filter *query.Query) []*TreeNode {
+	if filter.IsEmpty() {
 		return roots
 	}
 
+	statusByID := make(map[string]string)
+	for _, root := range roots {
+		collectStatuses(root, statusByID)
+	}
+
 	// Find all matching nodes
 	matchingIDs := make(map[string]bool)
 	for _, root := range roots {
-		findMatches(root, filter, matchingIDs)
+		findMatches(root, filter, statusByID, matchingIDs)
 	}
 
 	if len(matchingIDs) == 0 {
@@ -214,12 +150,12 @@ func FilterTree(roots []*TreeNode, filter FilterExpression) []*TreeNode {
 }
 
 // findMatches recursively finds all nodes matching the filter
-func findMatches(node *TreeNode, filter FilterExpression, matches map[string]bool) {
-	if filter.Match(node) {
+func findMatches(node *TreeNode, filter *query.Query, statusByID map[string]string, matches map[string]bool) {
+	if filter.Match(nodeFields(node, statusByID)) {
 		matches[node.ID] = true
 	}
 	for _, child := range node.Children {
-		findMatches(child, filter, matches)
+		findMatches(child, filter, statusByID, matches)
 	}
 }
 
@@ -273,6 +209,8 @@ func filterNode(node *TreeNode, matchingIDs, ancestorIDs map[string]bool) *TreeN
 		Expanded:  true, // Auto-expand to show matches
 		Depth:     node.Depth,
 		Parent:    node.Parent,
+		BlockedBy: node.BlockedBy,
+		Blocks:    node.Blocks,
 	}
 
 	// Filter children
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"testing"
+)
+
+func filterTestTree(t *testing.T) []*TreeNode {
+	t.Helper()
+	tree, err := ParseTreeJSON([]byte(`{
+		"tree": [
+			{"id": "EPIC-01", "type": "epic", "name": "recording", "status": "development", "children": [
+				{"id": "STORY-01", "type": "story", "name": "capture", "status": "development", "children": [
+					{"id": "TASK-01", "type": "task", "name": "interface", "status": "development", "assignee": "alice", "children": []},
+					{"id": "TASK-02", "type": "task", "name": "impl", "status": "backlog", "children": []}
+				]}
+			]},
+			{"id": "EPIC-02", "type": "epic", "name": "storage", "status": "backlog", "children": [
+				{"id": "STORY-02", "type": "story", "name": "migration", "status": "done", "children": []}
+			]}
+		]
+	}`))
+	if err != nil {
+		t.Fatalf("ParseTreeJSON: %v", err)
+	}
+	// TASK-02 is blocked by TASK-01, which is still in development
+	tree[0].Children[0].Children[1].BlockedBy = []string{"TASK-01"}
+	return tree
+}
+
+func collectIDs(nodes []*TreeNode) []string {
+	var ids []string
+	for _, n := range nodes {
+		ids = append(ids, n.ID)
+		ids = append(ids, collectIDs(n.Children)...)
+	}
+	return ids
+}
+
+func TestFilterTreeWithQuery(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"assignee:alice", []string{"EPIC-01", "STORY-01", "TASK-01"}},
+		{"blocked:true", []string{"EPIC-01", "STORY-01", "TASK-02"}},
+		{"type:story status:done", []string{"EPIC-02", "STORY-02"}},
+		{"parent:EPIC-01 type:task -status:backlog", []string{"EPIC-01", "STORY-01", "TASK-01"}},
+		{"storage || impl", []string{"EPIC-01", "STORY-01", "TASK-02", "EPIC-02"}},
+	}
+
+	for _, tt := range tests {
+		q, err := ParseFilter(tt.query)
+		if err != nil {
+			t.Fatalf("ParseFilter(%q): %v", tt.query, err)
+		}
+		got := collectIDs(FilterTree(filterTestTree(t), q))
+		if len(got) != len(tt.want) {
+			t.Errorf("%q: got %v, want %v", tt.query, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("%q: got %v, want %v", tt.query, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestFilterTreeNoMatches(t *testing.T) {
+	q, _ := ParseFilter("status:reviewing")
+	if got := FilterTree(filterTestTree(t), q); got != nil {
+		t.Errorf("expected no nodes, got %v", collectIDs(got))
+	}
+}
+
+func TestParseFilterInvalid(t *testing.T) {
+	if _, err := ParseFilter("(status:done"); err == nil {
+		t.Error("expected error for unbalanced parentheses")
+	}
+}
+
+func TestFilterCommand(t *testing.T) {
+	m := &model{tree: filterTestTree(t)}
+
+	m.executeCommand("filter", "assignee:alice")
+	if m.filter == nil || m.filterLabel != "assignee:alice" {
+		t.Fatalf("filter not applied: %+v", m.filter)
+	}
+	if got := collectIDs(m.displayTree()); len(got) != 3 {
+		t.Errorf("display tree = %v, want 3 nodes", got)
+	}
+
+	m.executeCommand("filter", "(broken")
+	if m.filterError == nil {
+		t.Error("invalid filter should set filterError")
+	}
+	if m.filterLabel != "assignee:alice" {
+		t.Error("invalid filter should keep the previous filter")
+	}
+
+	m.executeCommand("filter", "")
+	if m.filter != nil || m.filterError != nil {
+		t.Error("empty /filter should clear the filter")
+	}
+	if got := collectIDs(m.displayTree()); len(got) != 6 {
+		t.Errorf("display tree after clear = %v, want full tree", got)
+	}
+}
+
+func TestViewLoadedMsgAppliesFilter(t *testing.T) {
+	m := model{tree: filterTestTree(t)}
+
+	updated, _ := m.Update(ViewLoadedMsg{Name: "mine", Query: "assignee:alice"})
+	m = updated.(model)
+	if m.filter == nil || m.filterLabel != "view:mine" {
+		t.Fatalf("view not applied, label %q", m.filterLabel)
+	}
+}
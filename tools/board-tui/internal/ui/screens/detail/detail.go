@@ -52,8 +52,10 @@ type Element struct {
 	UpdatedAt          string          `json:"updatedAt"`
 	BlockedBy          []string        `json:"blockedBy"`
 	Blocks             []string        `json:"blocks"`
+	Links              []LinkItem      `json:"links"`
 	Description        string          `json:"description"`
 	AcceptanceCriteria string          `json:"acceptanceCriteria"`
+	Criteria           []CriterionItem `json:"criteria"`
 	Checklist          []ChecklistItem `json:"checklist"`
 	Notes              []NoteItem      `json:"notes"`
 }
@@ -63,6 +65,19 @@ type ChecklistItem struct {
 	Done bool   `json:"done"`
 }
 
+// CriterionItem is an acceptance criterion with its review verdict.
+type CriterionItem struct {
+	Number  int    `json:"number"`
+	Text    string `json:"text"`
+	Verdict string `json:"verdict"`
+}
+
+// LinkItem is a typed link such as "caused-by BUG-…".
+type LinkItem struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
 type NoteItem struct {
 	Timestamp string `json:"timestamp"`
 	Text      string `json:"text"`
@@ -249,6 +264,9 @@ func (m *Model) renderContent() string {
 	if len(e.Blocks) > 0 {
 		sb.WriteString(fmt.Sprintf("**Blocks:** %s\n\n", strings.Join(e.Blocks, ", ")))
 	}
+	for _, l := range e.Links {
+		sb.WriteString(fmt.Sprintf("**%s:** %s\n\n", l.Type, l.ID))
+	}
 
 	sb.WriteString("---\n\n")
 
@@ -258,7 +276,13 @@ func (m *Model) renderContent() string {
 		sb.WriteString("\n\n")
 	}
 
-	if e.AcceptanceCriteria != "" && e.AcceptanceCriteria != "(define acceptance criteria)" {
+	if len(e.Criteria) > 0 {
+		sb.WriteString("## Acceptance Criteria\n\n")
+		for _, c := range e.Criteria {
+			sb.WriteString(fmt.Sprintf("%d. **[%s]** %s\n", c.Number, c.Verdict, c.Text))
+		}
+		sb.WriteString("\n")
+	} else if e.AcceptanceCriteria != "" && e.AcceptanceCriteria != "(define acceptance criteria)" {
 		sb.WriteString("## Acceptance Criteria\n\n")
 		sb.WriteString(e.AcceptanceCriteria)
 		sb.WriteString("\n\n")
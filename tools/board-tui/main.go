@@ -6,6 +6,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/aagrigore/task-board/chart"
+	"github.com/aagrigore/task-board/query"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -22,6 +24,8 @@ const (
 	DetailScreen
 	AgentsScreen
 	ArkanoidScreen
+	SearchScreen
+	ReviewScreen
 )
 
 // Styles
@@ -40,14 +44,20 @@ var (
 	helpStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#626262"))
 
-	// Status colors for visual feedback
+	// Status colors for visual feedback; replaced by the board's workflow
+	// colours at startup
 	statusStyles = map[string]lipgloss.Style{
 		"backlog":     lipgloss.NewStyle().Foreground(lipgloss.Color("#808080")),
+		"analysis":    lipgloss.NewStyle().Foreground(lipgloss.Color("#00BFFF")),
+		"to-dev":      lipgloss.NewStyle().Foreground(lipgloss.Color("#00BFFF")),
 		"ready":       lipgloss.NewStyle().Foreground(lipgloss.Color("#00BFFF")),
 		"development": lipgloss.NewStyle().Foreground(lipgloss.Color("#FFA500")),
 		"progress":    lipgloss.NewStyle().Foreground(lipgloss.Color("#FFA500")),
+		"to-review":   lipgloss.NewStyle().Foreground(lipgloss.Color("#9370DB")),
+		"reviewing":   lipgloss.NewStyle().Foreground(lipgloss.Color("#9370DB")),
 		"review":      lipgloss.NewStyle().Foreground(lipgloss.Color("#9370DB")),
 		"done":        lipgloss.NewStyle().Foreground(lipgloss.Color("#32CD32")),
+		"closed":      lipgloss.NewStyle().Foreground(lipgloss.Color("#32CD32")),
 		"blocked":     lipgloss.NewStyle().Foreground(lipgloss.Color("#FF4500")),
 	}
 
@@ -71,6 +81,10 @@ var (
 // Model is the bubbletea model
 type model struct {
 	tree                  []*TreeNode // The tree data
+	filter                *query.Query // Active /filter or /view query (nil = none)
+	filterLabel           string       // Filter text or view name shown in the status bar
+	filteredTree          []*TreeNode  // Tree restricted to filter matches and their ancestors
+	filterError           error        // Last /filter or /view error, shown in the status bar
 	boardRows             []boardRow
 	boardSelectedIdx      int
 	boardScrollOff        int
@@ -90,6 +104,8 @@ type model struct {
 	detailModel           DetailModel   // Detail view model
 	agentsModel           AgentsModel   // Agents dashboard model
 	arkanoidModel         ArkanoidModel // Arkanoid mini-game model
+	searchModel           SearchModel   // Full-text search screen model
+	reviewModel           ReviewModel   // Acceptance review screen model
 	commandModel          CommandModel  // Command palette model
 	logger                *Logger       // Session logger
 	confirmQuit           bool          // Show quit confirmation dialog
@@ -229,14 +245,14 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 
 			case "e":
-				for _, root := range m.tree {
+				for _, root := range m.displayTree() {
 					root.ExpandAll()
 				}
 				m.boardRebuildRows()
 				return m, nil
 
 			case "c":
-				for _, root := range m.tree {
+				for _, root := range m.displayTree() {
 					root.CollapseAll()
 				}
 				m.boardRebuildRows()
@@ -315,6 +331,20 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, cmd
 		}
 
+		// Search-specific key handlers
+		if m.currentScreen == SearchScreen {
+			var cmd tea.Cmd
+			m.searchModel, cmd = m.searchModel.Update(msg)
+			return m, cmd
+		}
+
+		// Review-specific key handlers
+		if m.currentScreen == ReviewScreen {
+			var cmd tea.Cmd
+			m.reviewModel, cmd = m.reviewModel.Update(msg)
+			return m, cmd
+		}
+
 		// Arkanoid-specific key handlers
 		if m.currentScreen == ArkanoidScreen {
 			var cmd tea.Cmd
@@ -372,6 +402,8 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.detailModel.SetSize(msg.Width, msg.Height)
 		m.agentsModel.SetSize(msg.Width, msg.Height)
 		m.arkanoidModel.SetSize(msg.Width, msg.Height)
+		m.searchModel.SetSize(msg.Width, msg.Height)
+		m.reviewModel.SetSize(msg.Width, msg.Height)
 		m.commandModel.SetWidth(msg.Width)
 
 	case treeLoadedMsg:
@@ -398,6 +430,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if len(expandedIDs) > 0 {
 			ApplyExpandedNodes(m.tree, expandedIDs)
 		}
+		m.applyFilter()
 		m.boardRebuildRows()
 
 	case tickMsg:
@@ -434,7 +467,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.currentScreen = m.previousScreen
 		return m, nil
 
-	case elementLoadedMsg:
+	case elementLoadedMsg, chartLoadedMsg:
 		// Forward to detail model
 		var cmd tea.Cmd
 		m.detailModel, cmd = m.detailModel.Update(msg)
@@ -506,6 +539,53 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case SearchResultsMsg:
+		var cmd tea.Cmd
+		m.searchModel, cmd = m.searchModel.Update(msg)
+		return m, cmd
+
+	case SearchCloseMsg:
+		m.currentScreen = BoardScreen
+		return m, nil
+
+	case ReviewLoadedMsg:
+		var cmd tea.Cmd
+		m.reviewModel, cmd = m.reviewModel.Update(msg)
+		if msg.Changed {
+			// Verdicts gate done, so the board may look different now
+			m.refreshing = true
+			return m, tea.Batch(cmd, loadTree)
+		}
+		return m, cmd
+
+	case ReviewCloseMsg:
+		m.currentScreen = BoardScreen
+		return m, nil
+
+	case SearchSelectMsg:
+		if m.logger != nil {
+			m.logger.Action("search", "jump to "+msg.ID)
+		}
+		m.currentScreen = BoardScreen
+		m.boardJumpTo(msg.ID)
+		return m, nil
+
+	case ViewLoadedMsg:
+		if msg.Err != nil {
+			if m.logger != nil {
+				m.logger.Error("view %s: %v", msg.Name, msg.Err)
+			}
+			m.filterError = msg.Err
+			return m, nil
+		}
+		q, err := ParseFilter(msg.Query)
+		if err != nil {
+			m.filterError = fmt.Errorf("view %s: %w", msg.Name, err)
+			return m, nil
+		}
+		m.setFilter(q, "view:"+msg.Name)
+		return m, nil
+
 	case CommandExecuteMsg:
 		// Execute the command
 		if m.logger != nil {
@@ -525,12 +605,51 @@ func (m *model) executeCommand(cmd, args string) (tea.Model, tea.Cmd) {
 
 	switch cmd {
 	case "filter":
+		args = strings.TrimSpace(args)
+		if args == "" {
+			if m.logger != nil {
+				m.logger.Command("filter", "", "clearing filter")
+			}
+			m.setFilter(nil, "")
+			return m, nil
+		}
+		q, err := ParseFilter(args)
+		if err != nil {
+			if m.logger != nil {
+				m.logger.Command("filter", args, fmt.Sprintf("invalid filter: %v", err))
+			}
+			m.filterError = err
+			return m, nil
+		}
 		if m.logger != nil {
-			m.logger.Command("filter", args, "filter not yet implemented")
+			m.logger.Command("filter", args, "applying filter")
 		}
-		// TODO: implement custom filter (TASK-260206-2tovz9)
+		m.setFilter(q, args)
 		return m, nil
 
+	case "view":
+		name := strings.TrimSpace(args)
+		if name == "" {
+			if m.logger != nil {
+				m.logger.Command("view", "", "clearing view")
+			}
+			m.setFilter(nil, "")
+			return m, nil
+		}
+		if m.logger != nil {
+			m.logger.Command("view", name, "loading view")
+		}
+		return m, LoadView(name)
+
+	case "search":
+		if m.logger != nil {
+			m.logger.Command("search", args, "opening search screen")
+		}
+		m.searchModel = NewSearchModel(strings.TrimSpace(args))
+		m.searchModel.SetSize(m.width, m.height)
+		m.currentScreen = SearchScreen
+		return m, m.searchModel.Start()
+
 	case "agents":
 		if m.logger != nil {
 			m.logger.Command("agents", "", "opening agents screen")
@@ -540,6 +659,42 @@ func (m *model) executeCommand(cmd, args string) (tea.Model, tea.Cmd) {
 		m.currentScreen = AgentsScreen
 		return m, LoadAgentsWithFilter(m.getStaleMinutes())
 
+	case "burndown", "burnup":
+		// Chart the given ID, else the selected element, else the whole board
+		id := strings.TrimSpace(args)
+		if id == "" {
+			if node := m.boardSelectedNode(); node != nil {
+				id = node.ID
+			}
+		}
+		if m.logger != nil {
+			m.logger.Command(cmd, id, "opening chart")
+		}
+		m.previousScreen = m.currentScreen
+		m.detailModel = NewDetailModel()
+		m.detailModel.SetSize(m.width, m.height)
+		m.currentScreen = DetailScreen
+		return m, m.detailModel.LoadChart(chart.Kind(cmd), id)
+
+	case "review":
+		// Review the given ID, else the selected element
+		id := strings.TrimSpace(args)
+		if id == "" {
+			if node := m.boardSelectedNode(); node != nil {
+				id = node.ID
+			}
+		}
+		if id == "" {
+			return m, nil
+		}
+		if m.logger != nil {
+			m.logger.Command("review", id, "opening review screen")
+		}
+		m.reviewModel = NewReviewModel(id)
+		m.reviewModel.SetSize(m.width, m.height)
+		m.currentScreen = ReviewScreen
+		return m, m.reviewModel.Start()
+
 	case "help":
 		if m.logger != nil {
 			m.logger.Command("help", "", "showing help")
@@ -562,6 +717,14 @@ func (m *model) executeCommand(cmd, args string) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case "snoozed":
+		includeSnoozed = !includeSnoozed
+		if m.logger != nil {
+			m.logger.Command("snoozed", "", fmt.Sprintf("include snoozed: %v", includeSnoozed))
+		}
+		m.refreshing = true
+		return m, loadTree
+
 	case "settings":
 		if m.logger != nil {
 			m.logger.Command("settings", "", "opening settings screen")
@@ -575,7 +738,7 @@ func (m *model) executeCommand(cmd, args string) (tea.Model, tea.Cmd) {
 		if m.logger != nil {
 			m.logger.Command("expand", "", "expanding all")
 		}
-		for _, root := range m.tree {
+		for _, root := range m.displayTree() {
 			root.ExpandAll()
 		}
 		m.boardRebuildRows()
@@ -585,7 +748,7 @@ func (m *model) executeCommand(cmd, args string) (tea.Model, tea.Cmd) {
 		if m.logger != nil {
 			m.logger.Command("collapse", "", "collapsing all")
 		}
-		for _, root := range m.tree {
+		for _, root := range m.displayTree() {
 			root.CollapseAll()
 		}
 		m.boardRebuildRows()
@@ -680,6 +843,10 @@ func (m model) View() string {
 		return m.agentsModel.View()
 	case ArkanoidScreen:
 		return m.arkanoidModel.View()
+	case SearchScreen:
+		return m.searchModel.View()
+	case ReviewScreen:
+		return m.reviewModel.View()
 	default:
 		return m.viewBoard()
 	}
@@ -701,13 +868,28 @@ func (m model) viewBoard() string {
 	} else if !m.lastUpdate.IsZero() {
 		statusInfo = statusBarStyle.Render(fmt.Sprintf(" Updated %s ", m.formatTimeSince()))
 	}
+	if m.filterError != nil {
+		statusInfo += lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FF4500")).
+			Render(fmt.Sprintf(" %v ", m.filterError))
+	} else if m.filter != nil {
+		statusInfo += statusBarStyle.Render(fmt.Sprintf(" Filter: %s ", m.filterLabel))
+	}
+	if includeSnoozed {
+		statusInfo += statusBarStyle.Render(" +snoozed ")
+	}
+	statusInfo += renderWIP(m.tree)
 
 	// Content rows
 	vh := m.boardVisibleHeight()
 	var content strings.Builder
 
 	if len(m.boardRows) == 0 {
-		content.WriteString("  Loading board...\n")
+		if m.filter != nil && len(m.tree) > 0 {
+			content.WriteString("  No elements match the filter (/filter to clear)\n")
+		} else {
+			content.WriteString("  Loading board...\n")
+		}
 		for i := 1; i < vh; i++ {
 			content.WriteByte('\n')
 		}
@@ -831,15 +1013,13 @@ func loadTree() tea.Msg {
 
 // getStatusSymbol returns a visual symbol for status (used in compact views)
 func getStatusSymbol(status string) string {
-	switch status {
-	case "done":
+	switch statusCategories[status] {
+	case "done", "closed":
 		return "[x]"
-	case "development", "progress":
+	case "active":
 		return "[~]"
 	case "blocked":
 		return "[!]"
-	case "review":
-		return "[?]"
 	default:
 		return "[ ]"
 	}
@@ -907,6 +1087,9 @@ func buildStatusBar(roots []*TreeNode) string {
 }
 
 func main() {
+	// Forward --board-dir / --workspace to every task-board call
+	cliGlobalArgs = parseCLIArgs(os.Args[1:])
+
 	// Warm up markdown renderer in background
 	InitMarkdownRenderer()
 
@@ -916,6 +1099,11 @@ func main() {
 		fmt.Fprintf(os.Stderr, "Warning: failed to open logger: %v\n", err)
 	}
 
+	// Status colours and categories come from the board's workflow
+	if err := LoadWorkflowFromCLI(); err != nil {
+		logger.Warn("Failed to load workflow, using defaults: %v", err)
+	}
+
 	// Load configuration (uses defaults if file doesn't exist)
 	cfg, err := LoadConfig()
 	if err != nil {
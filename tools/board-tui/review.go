@@ -0,0 +1,274 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ReviewCriterion is an acceptance criterion with its verdict
+type ReviewCriterion struct {
+	Number  int    `json:"number"`
+	Text    string `json:"text"`
+	Verdict string `json:"verdict"` // pass, fail or pending
+}
+
+// ReviewComment is a reviewer's comment
+type ReviewComment struct {
+	At   string `json:"at"`
+	Text string `json:"text"`
+}
+
+// ReviewResponse is the JSON response from task-board review --json
+type ReviewResponse struct {
+	ID       string            `json:"id"`
+	Criteria []ReviewCriterion `json:"criteria"`
+	Passed   int               `json:"passed"`
+	Failed   int               `json:"failed"`
+	Pending  int               `json:"pending"`
+	Comments []ReviewComment   `json:"comments"`
+	Message  string            `json:"message"`
+}
+
+// cliErrorResponse is the error task-board --json writes to stderr
+type cliErrorResponse struct {
+	Error struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// ReviewLoadedMsg carries the review of an element after loading or a change
+type ReviewLoadedMsg struct {
+	Review  *ReviewResponse
+	Changed bool // verdicts or comments were recorded
+	Err     error
+}
+
+// ReviewCloseMsg signals returning to the board
+type ReviewCloseMsg struct{}
+
+// RunReview calls task-board review ID with extra flags (none just loads)
+func RunReview(id string, flags ...string) tea.Cmd {
+	return func() tea.Msg {
+		args := append([]string{"review", id, "--json"}, flags...)
+		cmd := taskBoardCommand(args...)
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		output, err := cmd.Output()
+		if err != nil {
+			return ReviewLoadedMsg{Err: err}
+		}
+		// Refusals are reported on stderr with empty stdout
+		if len(output) == 0 {
+			var cliErr cliErrorResponse
+			if json.Unmarshal(stderr.Bytes(), &cliErr) == nil && cliErr.Error.Message != "" {
+				return ReviewLoadedMsg{Err: fmt.Errorf("%s", cliErr.Error.Message)}
+			}
+			return ReviewLoadedMsg{Err: fmt.Errorf("no review for %s", id)}
+		}
+		var response ReviewResponse
+		if err := json.Unmarshal(output, &response); err != nil {
+			return ReviewLoadedMsg{Err: err}
+		}
+		return ReviewLoadedMsg{Review: &response, Changed: len(flags) > 0}
+	}
+}
+
+var (
+	verdictStyles = map[string]lipgloss.Style{
+		"pass":    lipgloss.NewStyle().Foreground(lipgloss.Color("#32CD32")),
+		"fail":    lipgloss.NewStyle().Foreground(lipgloss.Color("#FF4500")),
+		"pending": lipgloss.NewStyle().Foreground(lipgloss.Color("#626262")),
+	}
+	reviewCommentStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#AAAAAA"))
+)
+
+// ReviewModel is the acceptance review screen: the orchestrator marks each
+// criterion of an element as passed or failed and leaves comments
+type ReviewModel struct {
+	id          string
+	review      *ReviewResponse
+	selectedIdx int
+	commenting  bool
+	input       textinput.Model
+	busy        bool
+	err         error
+	width       int
+	height      int
+}
+
+// NewReviewModel creates the review screen for an element
+func NewReviewModel(id string) ReviewModel {
+	ti := textinput.New()
+	ti.Placeholder = "what the reviewer found"
+	ti.Prompt = "Comment: "
+	ti.CharLimit = 500
+	return ReviewModel{id: id, input: ti, busy: true}
+}
+
+// SetSize sets the available area
+func (m *ReviewModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.input.Width = width - 16
+}
+
+// Start loads the review
+func (m *ReviewModel) Start() tea.Cmd {
+	return RunReview(m.id)
+}
+
+// selected returns the number of the criterion under the cursor, or 0
+func (m *ReviewModel) selected() int {
+	if m.review == nil || m.selectedIdx >= len(m.review.Criteria) {
+		return 0
+	}
+	return m.review.Criteria[m.selectedIdx].Number
+}
+
+// change records a verdict or comment through the CLI
+func (m ReviewModel) change(flags ...string) (ReviewModel, tea.Cmd) {
+	m.busy = true
+	m.err = nil
+	return m, RunReview(m.id, flags...)
+}
+
+// Update handles messages
+func (m ReviewModel) Update(msg tea.Msg) (ReviewModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if m.commenting {
+			switch msg.String() {
+			case "esc":
+				m.commenting = false
+				m.input.Blur()
+				return m, nil
+			case "enter":
+				text := strings.TrimSpace(m.input.Value())
+				m.commenting = false
+				m.input.Blur()
+				if text == "" {
+					return m, nil
+				}
+				return m.change("--comment", text)
+			}
+			var cmd tea.Cmd
+			m.input, cmd = m.input.Update(msg)
+			return m, cmd
+		}
+
+		switch msg.String() {
+		case "esc", "q":
+			return m, func() tea.Msg { return ReviewCloseMsg{} }
+		case "down", "j":
+			if m.review != nil && m.selectedIdx < len(m.review.Criteria)-1 {
+				m.selectedIdx++
+			}
+			return m, nil
+		case "up", "k":
+			if m.selectedIdx > 0 {
+				m.selectedIdx--
+			}
+			return m, nil
+		case "p":
+			if n := m.selected(); n > 0 && !m.busy {
+				return m.change("--pass", strconv.Itoa(n))
+			}
+			return m, nil
+		case "f":
+			if n := m.selected(); n > 0 && !m.busy {
+				return m.change("--fail", strconv.Itoa(n))
+			}
+			return m, nil
+		case "a":
+			if m.selected() > 0 && !m.busy {
+				return m.change("--pass", "all")
+			}
+			return m, nil
+		case "c":
+			if !m.busy {
+				m.commenting = true
+				m.input.SetValue("")
+				return m, m.input.Focus()
+			}
+			return m, nil
+		case "r":
+			m.busy = true
+			return m, RunReview(m.id)
+		}
+
+	case ReviewLoadedMsg:
+		m.busy = false
+		m.err = msg.Err
+		if msg.Review != nil {
+			m.review = msg.Review
+			if m.selectedIdx >= len(m.review.Criteria) {
+				m.selectedIdx = 0
+			}
+		}
+		return m, nil
+	}
+
+	return m, nil
+}
+
+// View renders the review screen
+func (m ReviewModel) View() string {
+	title := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FFFDF5")).
+		Background(lipgloss.Color("#6C5CE7")).
+		Padding(0, 1).
+		Render(fmt.Sprintf(" Review %s ", m.id))
+
+	var status string
+	switch {
+	case m.busy:
+		status = statusBarStyle.Render(" Saving... ")
+	case m.review != nil:
+		status = statusBarStyle.Render(fmt.Sprintf(" %d/%d passed ", m.review.Passed, len(m.review.Criteria)))
+	}
+
+	var content strings.Builder
+	if m.err != nil {
+		content.WriteString(verdictStyles["fail"].Render(fmt.Sprintf("  Error: %v", m.err)) + "\n\n")
+	}
+	switch {
+	case m.review == nil:
+		if m.err == nil {
+			content.WriteString("  Loading review...\n")
+		}
+	case len(m.review.Criteria) == 0:
+		content.WriteString("  No acceptance criteria to review.\n")
+	default:
+		for i, c := range m.review.Criteria {
+			style, ok := verdictStyles[c.Verdict]
+			if !ok {
+				style = verdictStyles["pending"]
+			}
+			line := fmt.Sprintf("%2d. %s %s", c.Number, style.Render(fmt.Sprintf("%-9s", "["+c.Verdict+"]")), c.Text)
+			if i == m.selectedIdx {
+				line = cursorStyle.Render(lipgloss.NewStyle().Width(m.width - 4).Render(line))
+			}
+			content.WriteString(line + "\n")
+		}
+	}
+	if m.review != nil && len(m.review.Comments) > 0 {
+		content.WriteString("\n  Comments:\n")
+		for _, c := range m.review.Comments {
+			content.WriteString(reviewCommentStyle.Render(fmt.Sprintf("    %s  %s", c.At, c.Text)) + "\n")
+		}
+	}
+
+	help := helpStyle.Render("  ↑↓: select | p: pass | f: fail | a: pass all | c: comment | esc: back")
+	if m.commenting {
+		help = m.input.View() + "\n" + helpStyle.Render("  enter: save comment | esc: cancel")
+	}
+
+	return appStyle.Render(fmt.Sprintf("%s%s\n\n%s\n%s", title, status, content.String(), help))
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestReviewScreen(t *testing.T) {
+	m := NewReviewModel("TASK-1")
+	m.SetSize(100, 30)
+	m, _ = m.Update(ReviewLoadedMsg{Review: &ReviewResponse{
+		ID: "TASK-1",
+		Criteria: []ReviewCriterion{
+			{Number: 1, Text: "Records audio", Verdict: "pass"},
+			{Number: 2, Text: "Handles silence", Verdict: "pending"},
+		},
+		Passed:   1,
+		Pending:  1,
+		Comments: []ReviewComment{{At: "2026-10-18T10:00:00Z", Text: "clipping at the start"}},
+	}})
+
+	view := m.View()
+	for _, want := range []string{"Review TASK-1", "1/2 passed", "[pass]", "Handles silence", "clipping at the start"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q:\n%s", want, view)
+		}
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if m.selected() != 2 {
+		t.Errorf("selected = %d, want 2", m.selected())
+	}
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	if cmd == nil || !m.busy {
+		t.Error("f should record a verdict through the CLI")
+	}
+
+	m.busy = false
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	if !m.commenting || !strings.Contains(m.View(), "Comment:") {
+		t.Error("c should open the comment input")
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.commenting {
+		t.Error("esc should cancel the comment")
+	}
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc}); cmd == nil {
+		t.Fatal("esc should close the review")
+	} else if _, ok := cmd().(ReviewCloseMsg); !ok {
+		t.Error("esc should send ReviewCloseMsg")
+	}
+}
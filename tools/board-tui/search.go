@@ -0,0 +1,248 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// SearchHit is one result from task-board search --json
+type SearchHit struct {
+	ID           string  `json:"id"`
+	Type         string  `json:"type"`
+	Name         string  `json:"name"`
+	Status       string  `json:"status"`
+	MatchField   string  `json:"matchField"`
+	MatchContext string  `json:"matchContext"`
+	Score        float64 `json:"score"`
+}
+
+// SearchResponse is the JSON response from task-board search --json
+type SearchResponse struct {
+	Results []SearchHit `json:"results"`
+	Count   int         `json:"count"`
+	Query   string      `json:"query"`
+}
+
+// SearchResultsMsg carries search results for a query
+type SearchResultsMsg struct {
+	Query   string
+	Results []SearchHit
+	Err     error
+}
+
+// SearchCloseMsg signals returning to board without a selection
+type SearchCloseMsg struct{}
+
+// SearchSelectMsg asks the board to jump to an element
+type SearchSelectMsg struct {
+	ID string
+}
+
+// RunSearch returns a command that runs a full-text search via the CLI
+func RunSearch(query string) tea.Cmd {
+	return func() tea.Msg {
+		cmd := taskBoardCommand("search", query, "--json")
+		output, err := cmd.Output()
+		if err != nil {
+			return SearchResultsMsg{Query: query, Err: err}
+		}
+		// Invalid queries are reported on stderr with empty stdout
+		if len(output) == 0 {
+			return SearchResultsMsg{Query: query, Err: fmt.Errorf("invalid query")}
+		}
+		var response SearchResponse
+		if err := json.Unmarshal(output, &response); err != nil {
+			return SearchResultsMsg{Query: query, Err: err}
+		}
+		return SearchResultsMsg{Query: query, Results: response.Results}
+	}
+}
+
+var (
+	searchFieldStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#626262"))
+	searchMatchStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#000000")).Background(lipgloss.Color("#FFD700"))
+)
+
+// SearchModel is the interactive full-text search screen
+type SearchModel struct {
+	input       textinput.Model
+	results     []SearchHit
+	lastQuery   string // query the current results belong to
+	selectedIdx int
+	scrollOff   int
+	searching   bool
+	err         error
+	width       int
+	height      int
+}
+
+// NewSearchModel creates a search screen, optionally pre-filled with a query
+func NewSearchModel(query string) SearchModel {
+	ti := textinput.New()
+	ti.Placeholder = `e.g. audio capture, "exact phrase", title:recorder, ac:tests`
+	ti.Prompt = "Search: "
+	ti.CharLimit = 200
+	ti.SetValue(query)
+	ti.Focus()
+	return SearchModel{input: ti}
+}
+
+// SetSize sets the available area
+func (m *SearchModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.input.Width = width - 14
+}
+
+// visibleResults returns how many results fit (each result takes 3 lines)
+func (m *SearchModel) visibleResults() int {
+	// appPadTop(1) + title(1) + blank(1) + input(1) + blank(1) + footerBlank(1) + footer(1) + appPadBottom(1) = 8
+	n := (m.height - 8) / 3
+	if n < 1 {
+		n = 1
+	}
+	return n
+}
+
+func (m *SearchModel) ensureVisible() {
+	vr := m.visibleResults()
+	if m.selectedIdx < m.scrollOff {
+		m.scrollOff = m.selectedIdx
+	}
+	if m.selectedIdx >= m.scrollOff+vr {
+		m.scrollOff = m.selectedIdx - vr + 1
+	}
+}
+
+// Start runs the pre-filled query, if any
+func (m *SearchModel) Start() tea.Cmd {
+	query := strings.TrimSpace(m.input.Value())
+	if query == "" {
+		return textinput.Blink
+	}
+	m.searching = true
+	return RunSearch(query)
+}
+
+// Update handles messages
+func (m SearchModel) Update(msg tea.Msg) (SearchModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc":
+			return m, func() tea.Msg { return SearchCloseMsg{} }
+
+		case "enter":
+			query := strings.TrimSpace(m.input.Value())
+			// Enter searches when the query changed, otherwise jumps to the selection
+			if query != "" && query != m.lastQuery {
+				m.searching = true
+				return m, RunSearch(query)
+			}
+			if m.selectedIdx < len(m.results) {
+				id := m.results[m.selectedIdx].ID
+				return m, func() tea.Msg { return SearchSelectMsg{ID: id} }
+			}
+			return m, nil
+
+		case "down", "ctrl+n":
+			if m.selectedIdx < len(m.results)-1 {
+				m.selectedIdx++
+				m.ensureVisible()
+			}
+			return m, nil
+
+		case "up", "ctrl+p":
+			if m.selectedIdx > 0 {
+				m.selectedIdx--
+				m.ensureVisible()
+			}
+			return m, nil
+		}
+
+		var cmd tea.Cmd
+		m.input, cmd = m.input.Update(msg)
+		return m, cmd
+
+	case SearchResultsMsg:
+		m.searching = false
+		m.lastQuery = msg.Query
+		m.err = msg.Err
+		m.results = msg.Results
+		m.selectedIdx = 0
+		m.scrollOff = 0
+		return m, nil
+	}
+
+	return m, nil
+}
+
+// renderMatchContext replaces **bold** markers from the CLI with highlight styling
+func renderMatchContext(context string) string {
+	parts := strings.Split(context, "**")
+	var b strings.Builder
+	for i, part := range parts {
+		if i%2 == 1 {
+			b.WriteString(searchMatchStyle.Render(part))
+		} else {
+			b.WriteString(part)
+		}
+	}
+	return b.String()
+}
+
+// View renders the search screen
+func (m SearchModel) View() string {
+	title := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FFFDF5")).
+		Background(lipgloss.Color("#6C5CE7")).
+		Padding(0, 1).
+		Render(" Search ")
+
+	var status string
+	switch {
+	case m.searching:
+		status = statusBarStyle.Render(" Searching... ")
+	case m.lastQuery != "" && m.err == nil:
+		status = statusBarStyle.Render(fmt.Sprintf(" %d result(s) ", len(m.results)))
+	}
+
+	help := helpStyle.Render("  enter: search / jump to element | ↑↓: select | esc: back")
+
+	var content strings.Builder
+	switch {
+	case m.err != nil:
+		content.WriteString(fmt.Sprintf("  Error: %v\n", m.err))
+	case m.lastQuery != "" && len(m.results) == 0:
+		content.WriteString("  No matches found.\n")
+	default:
+		end := m.scrollOff + m.visibleResults()
+		if end > len(m.results) {
+			end = len(m.results)
+		}
+		for i := m.scrollOff; i < end; i++ {
+			hit := m.results[i]
+			typeInd := typeIndicators[hit.Type]
+			if typeInd == "" {
+				typeInd = "?"
+			}
+			statusSt, ok := statusStyles[hit.Status]
+			if !ok {
+				statusSt = lipgloss.NewStyle()
+			}
+			header := fmt.Sprintf("%s %s %s %s", typeInd, hit.ID, hit.Name, statusSt.Render("["+hit.Status+"]"))
+			if i == m.selectedIdx {
+				header = cursorStyle.Render(lipgloss.NewStyle().Width(m.width - 4).Render(header))
+			}
+			content.WriteString(header + "\n")
+			content.WriteString("    " + searchFieldStyle.Render(hit.MatchField+": ") + renderMatchContext(hit.MatchContext) + "\n\n")
+		}
+	}
+
+	return appStyle.Render(fmt.Sprintf("%s%s\n\n%s\n\n%s\n%s", title, status, m.input.View(), content.String(), help))
+}
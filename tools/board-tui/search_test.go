@@ -0,0 +1,73 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestRenderMatchContextStripsMarkers(t *testing.T) {
+	got := renderMatchContext("records **audio** and **video**")
+	if strings.Contains(got, "**") {
+		t.Errorf("markers should be replaced: %q", got)
+	}
+	if !strings.Contains(got, "audio") || !strings.Contains(got, "video") {
+		t.Errorf("matched text lost: %q", got)
+	}
+}
+
+func TestSearchModelEnterSearchesThenSelects(t *testing.T) {
+	m := NewSearchModel("audio")
+
+	// Query not yet run: enter triggers a search
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil || !m.searching {
+		t.Fatal("enter on a new query should start a search")
+	}
+
+	m, _ = m.Update(SearchResultsMsg{Query: "audio", Results: []SearchHit{
+		{ID: "STORY-01", Type: "story", Name: "capture"},
+		{ID: "TASK-01", Type: "task", Name: "interface"},
+	}})
+	if m.searching || len(m.results) != 2 {
+		t.Fatalf("results not stored: %+v", m.results)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("enter on unchanged query should select the result")
+	}
+	sel, ok := cmd().(SearchSelectMsg)
+	if !ok || sel.ID != "TASK-01" {
+		t.Errorf("expected SearchSelectMsg for TASK-01, got %#v", cmd())
+	}
+}
+
+func TestBoardJumpToRevealsNode(t *testing.T) {
+	m := &model{tree: filterTestTree(t)}
+	m.boardRebuildRows()
+
+	m.boardJumpTo("TASK-02")
+	node := m.boardSelectedNode()
+	if node == nil || node.ID != "TASK-02" {
+		t.Fatalf("selected %v, want TASK-02", node)
+	}
+	if !node.Parent.Expanded || !node.Parent.Parent.Expanded {
+		t.Error("ancestors should be expanded")
+	}
+}
+
+func TestBoardJumpToClearsHidingFilter(t *testing.T) {
+	m := &model{tree: filterTestTree(t)}
+	m.executeCommand("filter", "type:epic")
+
+	m.boardJumpTo("TASK-01")
+	if m.filter != nil {
+		t.Error("filter hiding the target should be cleared")
+	}
+	if node := m.boardSelectedNode(); node == nil || node.ID != "TASK-01" {
+		t.Errorf("selected %v, want TASK-01", node)
+	}
+}
@@ -2,7 +2,6 @@ package main
 
 import (
 	"encoding/json"
-	"os/exec"
 	"time"
 )
 
@@ -104,9 +103,21 @@ type ListResponse struct {
 	Elements []ListElement `json:"elements"`
 }
 
+// includeSnoozed makes the tree and list calls pass --include-snoozed, so
+// snoozed elements are shown (toggled by /snoozed)
+var includeSnoozed bool
+
+// snoozedArgs appends --include-snoozed to args when snoozed elements are shown
+func snoozedArgs(args ...string) []string {
+	if includeSnoozed {
+		return append(args, "--include-snoozed")
+	}
+	return args
+}
+
 // LoadTreeFromCLI calls `task-board tree --json` and parses the response
 func LoadTreeFromCLI() ([]*TreeNode, error) {
-	cmd := exec.Command("task-board", "tree", "--json")
+	cmd := taskBoardCommand(snoozedArgs("tree", "--json")...)
 	output, err := cmd.Output()
 	if err != nil {
 		return nil, err
@@ -126,7 +137,7 @@ func LoadTreeFromCLI() ([]*TreeNode, error) {
 // LoadDependencies loads blockedBy/blocks from list command and applies to tree nodes
 func LoadDependencies(roots []*TreeNode) {
 	// Get all elements with dependencies
-	cmd := exec.Command("task-board", "list", "tasks", "--json")
+	cmd := taskBoardCommand(snoozedArgs("list", "tasks", "--json")...)
 	output, err := cmd.Output()
 	if err != nil {
 		return // Silently fail - dependencies are optional
@@ -144,7 +155,7 @@ func LoadDependencies(roots []*TreeNode) {
 	}
 
 	// Also load stories
-	cmd = exec.Command("task-board", "list", "stories", "--json")
+	cmd = taskBoardCommand(snoozedArgs("list", "stories", "--json")...)
 	output, err = cmd.Output()
 	if err == nil {
 		var storyResponse ListResponse
@@ -174,7 +185,7 @@ func applyDependencies(node *TreeNode, depsMap map[string]ListElement) {
 
 // LoadTreeFromCLIWithEpic calls `task-board tree --json --epic EPIC-XX` for a specific epic
 func LoadTreeFromCLIWithEpic(epicID string) ([]*TreeNode, error) {
-	cmd := exec.Command("task-board", "tree", "--json", "--epic", epicID)
+	cmd := taskBoardCommand(snoozedArgs("tree", "--json", "--epic", epicID)...)
 	output, err := cmd.Output()
 	if err != nil {
 		return nil, err
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// WorkflowResponse is the JSON response from `task-board workflow show --json`
+type WorkflowResponse struct {
+	Source    string                  `json:"source"`
+	Types     map[string]WorkflowFlow `json:"types"`
+	WIPLimits WIPLimits               `json:"wipLimits"`
+}
+
+// WIPLimits holds the board's work-in-progress limits
+type WIPLimits struct {
+	Status map[string]int `json:"status"`
+}
+
+// WorkflowFlow is the workflow of one element type
+type WorkflowFlow struct {
+	Statuses []WorkflowStatus `json:"statuses"`
+}
+
+// WorkflowStatus is one status with its category and terminal colour
+type WorkflowStatus struct {
+	Name      string `json:"name"`
+	Category  string `json:"category"`
+	TextColor string `json:"textColor"`
+}
+
+// statusCategories maps status names to their workflow category
+// (todo, active, done, closed, blocked). Filled from the board's workflow.
+var statusCategories = map[string]string{
+	"backlog":     "todo",
+	"analysis":    "active",
+	"to-dev":      "todo",
+	"development": "active",
+	"to-review":   "active",
+	"reviewing":   "active",
+	"done":        "done",
+	"closed":      "closed",
+	"blocked":     "blocked",
+}
+
+// statusLimits caps the tasks and bugs in each status, in workflow order
+var statusLimits []statusLimit
+
+type statusLimit struct {
+	Status string
+	Limit  int
+}
+
+// LoadWorkflowFromCLI reads the board's workflow and applies its status
+// colours and categories. The built-in defaults stay when the CLI fails.
+func LoadWorkflowFromCLI() error {
+	output, err := taskBoardCommand("workflow", "show", "--json").Output()
+	if err != nil {
+		return err
+	}
+	var response WorkflowResponse
+	if err := json.Unmarshal(output, &response); err != nil {
+		return err
+	}
+	applyWorkflow(response)
+	return nil
+}
+
+// applyWorkflow installs the styles and categories of every status used by
+// any element type. Where types share a status name the first one wins.
+func applyWorkflow(response WorkflowResponse) {
+	seen := map[string]bool{}
+	statusLimits = nil
+	for _, typ := range []string{"epic", "story", "task", "bug"} {
+		for _, s := range response.Types[typ].Statuses {
+			if seen[s.Name] {
+				continue
+			}
+			seen[s.Name] = true
+			if limit := response.WIPLimits.Status[s.Name]; limit > 0 {
+				statusLimits = append(statusLimits, statusLimit{Status: s.Name, Limit: limit})
+			}
+			statusCategories[s.Name] = s.Category
+			if s.TextColor != "" {
+				statusStyles[s.Name] = lipgloss.NewStyle().Foreground(lipgloss.Color(s.TextColor))
+			}
+		}
+	}
+}
+
+// isFinishedStatus reports whether a status no longer blocks others
+func isFinishedStatus(status string) bool {
+	category := statusCategories[status]
+	return category == "done" || category == "closed"
+}
+
+// countStatuses counts the tasks and bugs in each status
+func countStatuses(nodes []*TreeNode, counts map[string]int) {
+	for _, node := range nodes {
+		if node.Type == "task" || node.Type == "bug" {
+			counts[node.Status]++
+		}
+		countStatuses(node.Children, counts)
+	}
+}
+
+// renderWIP shows the load of each limited status, red when over its limit
+func renderWIP(tree []*TreeNode) string {
+	if len(statusLimits) == 0 {
+		return ""
+	}
+	counts := map[string]int{}
+	countStatuses(tree, counts)
+	parts := make([]string, 0, len(statusLimits))
+	for _, l := range statusLimits {
+		part := fmt.Sprintf("%s %d/%d", l.Status, counts[l.Status], l.Limit)
+		if counts[l.Status] > l.Limit {
+			part = wipOverStyle.Render(part)
+		}
+		parts = append(parts, part)
+	}
+	return statusBarStyle.Render(" WIP: ") + strings.Join(parts, statusBarStyle.Render(" · ")) + " "
+}
+
+var wipOverStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF4500")).Bold(true)
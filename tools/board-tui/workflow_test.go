@@ -0,0 +1,91 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestApplyWorkflow(t *testing.T) {
+	oldStyles, oldCategories := statusStyles, statusCategories
+	defer func() { statusStyles, statusCategories = oldStyles, oldCategories }()
+	statusStyles = map[string]lipgloss.Style{}
+	statusCategories = map[string]string{}
+
+	applyWorkflow(WorkflowResponse{Types: map[string]WorkflowFlow{
+		"task": {Statuses: []WorkflowStatus{
+			{Name: "backlog", Category: "todo", TextColor: "#808080"},
+			{Name: "done", Category: "done", TextColor: "#32CD32"},
+		}},
+		"bug": {Statuses: []WorkflowStatus{
+			{Name: "triage", Category: "todo", TextColor: "#FF00FF"},
+			{Name: "fixing", Category: "active"},
+			{Name: "verified", Category: "done", TextColor: "#00FF00"},
+			{Name: "wontfix", Category: "closed"},
+		}},
+	}})
+
+	if _, ok := statusStyles["triage"]; !ok {
+		t.Error("triage should get a style from its text colour")
+	}
+	if _, ok := statusStyles["fixing"]; ok {
+		t.Error("statuses without a colour should not get a style")
+	}
+	tests := []struct {
+		status   string
+		symbol   string
+		finished bool
+	}{
+		{"triage", "[ ]", false},
+		{"fixing", "[~]", false},
+		{"verified", "[x]", true},
+		{"wontfix", "[x]", true},
+		{"unknown", "[ ]", false},
+	}
+	for _, tt := range tests {
+		if got := getStatusSymbol(tt.status); got != tt.symbol {
+			t.Errorf("getStatusSymbol(%q) = %q, want %q", tt.status, got, tt.symbol)
+		}
+		if got := isFinishedStatus(tt.status); got != tt.finished {
+			t.Errorf("isFinishedStatus(%q) = %v, want %v", tt.status, got, tt.finished)
+		}
+	}
+}
+
+func TestRenderWIP(t *testing.T) {
+	oldStyles, oldCategories, oldLimits := statusStyles, statusCategories, statusLimits
+	defer func() { statusStyles, statusCategories, statusLimits = oldStyles, oldCategories, oldLimits }()
+	statusStyles = map[string]lipgloss.Style{}
+	statusCategories = map[string]string{}
+
+	applyWorkflow(WorkflowResponse{
+		Types: map[string]WorkflowFlow{
+			"task": {Statuses: []WorkflowStatus{
+				{Name: "backlog", Category: "todo"},
+				{Name: "development", Category: "active"},
+				{Name: "done", Category: "done"},
+			}},
+		},
+		WIPLimits: WIPLimits{Status: map[string]int{"development": 1}},
+	})
+	if len(statusLimits) != 1 || statusLimits[0].Status != "development" {
+		t.Fatalf("statusLimits = %+v, want development only", statusLimits)
+	}
+
+	tree := []*TreeNode{{ID: "E1", Type: "epic", Status: "development", Children: []*TreeNode{
+		{ID: "S1", Type: "story", Status: "development", Children: []*TreeNode{
+			{ID: "T1", Type: "task", Status: "development"},
+			{ID: "T2", Type: "task", Status: "development"},
+			{ID: "T3", Type: "task", Status: "done"},
+		}},
+	}}}
+	if got := renderWIP(tree); !strings.Contains(got, "development 2/1") {
+		t.Errorf("renderWIP() = %q, want it to count only tasks and bugs", got)
+	}
+
+	statusLimits = nil
+	if got := renderWIP(tree); got != "" {
+		t.Errorf("renderWIP() without limits = %q, want empty", got)
+	}
+}